@@ -0,0 +1,39 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/namikmesic/go-mcp/internal/browse"
+)
+
+// runBrowse implements the "browse" subcommand: explore an analysis interactively in
+// the terminal, read from a file written by the JSON renderer or produced by
+// analyzing a project.
+func runBrowse(args []string) {
+	fs := flag.NewFlagSet("browse", flag.ExitOnError)
+	analysisOpts := registerAnalysisFlags(fs)
+	logOpts := registerLogFlags(fs)
+	fs.Usage = func() {
+		fmt.Println("Usage: go run main.go browse [flags] [analysis.json | path-to-go-project]")
+		fmt.Println("  Example: go run main.go browse .")
+		fmt.Println("  Example: go run main.go browse analysis.json")
+		fmt.Println("Flags (the analysis flags apply when analyzing a project):")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	logOpts.install()
+	if fs.NArg() > 1 {
+		fs.Usage()
+		os.Exit(1)
+	}
+	target := "."
+	if fs.NArg() == 1 {
+		target = fs.Arg(0)
+	}
+
+	if err := browse.NewBrowser(analysisOpts.loadOrAnalyze(target)).Run(os.Stdin, os.Stdout); err != nil {
+		fatalf("%v", err)
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/namikmesic/go-mcp/internal/analyzer/reach"
+)
+
+// runCallPath implements the "callpath" subcommand: analyze the project and print the
+// call paths from one function to another as JSON. Functions are given by ID or by an
+// unambiguous ID suffix. It exits with status 1 if there is no path.
+func runCallPath(args []string) {
+	fs := flag.NewFlagSet("callpath", flag.ExitOnError)
+	all := fs.Bool("all", false, "Print all paths that visit no function twice instead of a shortest one")
+	maxPaths := fs.Int("max-paths", reach.DefaultMaxPaths, "Maximum number of paths printed with --all")
+	analysisOpts := registerAnalysisFlags(fs)
+	logOpts := registerLogFlags(fs)
+	fs.Usage = func() {
+		fmt.Println("Usage: go run main.go callpath [flags] <from> <to> [path-to-go-project]")
+		fmt.Println("  Example: go run main.go callpath Server.handleCalls store.DB.Query .")
+		fmt.Println("Flags:")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	logOpts.install()
+	if fs.NArg() < 2 {
+		fs.Usage()
+		os.Exit(1)
+	}
+	if analysisOpts.noCallGraph {
+		fatalf("callpath needs the call graph and cannot be used with --no-callgraph.")
+	}
+	targetPathArg := "."
+	if fs.NArg() > 2 {
+		targetPathArg = fs.Arg(2)
+	}
+
+	analysisPattern := resolveAnalysisPattern(targetPathArg)
+	analysisOpts.checkSandbox(patternDir(analysisPattern))
+	analyzer := newAnalyzer(analysisOpts)
+	slog.Info("Starting analysis", "pattern", analysisPattern)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	analysisCtx, cancel := analysisOpts.analysisContext(ctx)
+	projectAnalysis, err := analyzer.Analyze(analysisCtx, analysisPattern)
+	cancel()
+	if err != nil {
+		fatalf("Analysis failed: %v", err)
+	}
+
+	paths, err := reach.NewGraph(projectAnalysis).Query(fs.Arg(0), fs.Arg(1), *all, *maxPaths)
+	if err != nil {
+		fatalf("Call path query failed: %v", err)
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(paths); err != nil {
+		fatalf("Failed to write call paths: %v", err)
+	}
+	if len(paths.Paths) == 0 {
+		os.Exit(1)
+	}
+}
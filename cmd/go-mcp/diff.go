@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/namikmesic/go-mcp/internal/diff"
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// runDiff implements the "diff" subcommand: compare two analyses written by the JSON
+// renderer, or with --against, the project with its state at a git ref, and print the
+// added, removed and changed interfaces and call edges, or with --api, the changes to
+// the exported API.
+func runDiff(args []string) {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	format := fs.String("format", "json", "Output format: json or text (one line per change)")
+	exitCode := fs.Bool("exit-code", false, "Exit with status 1 if the analyses differ")
+	api := fs.Bool("api", false, fmt.Sprintf("Report changes to the exported API instead, and exit with status %d if any are incompatible", exitIncompatibleChanges))
+	against := fs.String("against", "", "Analyze the project and compare it with its state at this git ref (e.g. origin/main) instead of comparing two analysis files")
+	analysisOpts := registerAnalysisFlags(fs)
+	logOpts := registerLogFlags(fs)
+	fs.Usage = func() {
+		fmt.Println("Usage: go run main.go diff [flags] <old.json> <new.json>")
+		fmt.Println("       go run main.go diff --against <git-ref> [flags] [path-to-go-project]")
+		fmt.Println("  Example: go run main.go --sink json=new.json . && go run main.go diff old.json new.json")
+		fmt.Println("  Example: go run main.go diff --against origin/main --format text .")
+		fmt.Println("  Example: go run main.go diff --api --against v1.2.0 --format text .")
+		fmt.Println("Flags (the analysis flags apply to both versions with --against):")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	logOpts.install()
+	if *format != "json" && *format != "text" {
+		fatalf("Unknown diff format %q (expected json or text)", *format)
+	}
+
+	var from, to *datamodel.ProjectAnalysis
+	if *against != "" {
+		if fs.NArg() > 1 {
+			fs.Usage()
+			os.Exit(1)
+		}
+		targetPathArg := "."
+		if fs.NArg() == 1 {
+			targetPathArg = fs.Arg(0)
+		}
+		from, to = analyzeAgainstRef(*against, targetPathArg, analysisOpts)
+	} else {
+		if fs.NArg() != 2 {
+			fs.Usage()
+			os.Exit(1)
+		}
+		var err error
+		if from, err = diff.Load(fs.Arg(0)); err != nil {
+			fatalf("%v", err)
+		}
+		if to, err = diff.Load(fs.Arg(1)); err != nil {
+			fatalf("%v", err)
+		}
+	}
+	if *api {
+		d := diff.CompareAPI(from, to)
+		if err := writeAPIDiff(d, *format); err != nil {
+			fatalf("Failed to write diff: %v", err)
+		}
+		if len(d.Incompatible) > 0 {
+			os.Exit(exitIncompatibleChanges)
+		}
+		if *exitCode && !d.Empty() {
+			os.Exit(1)
+		}
+		return
+	}
+	d := diff.Compare(from, to)
+	if err := writeDiff(d, *format); err != nil {
+		fatalf("Failed to write diff: %v", err)
+	}
+	if *exitCode && !d.Empty() {
+		os.Exit(1)
+	}
+}
+
+// exitIncompatibleChanges is the exit code of "diff --api" when the exported API
+// changed incompatibly, distinguishing it from fatal errors (1).
+const exitIncompatibleChanges = 3
+
+// analyzeAgainstRef analyzes the project in targetPathArg as checked out at ref, in a
+// temporary git worktree, and as it is on disk, including uncommitted changes.
+func analyzeAgainstRef(ref, targetPathArg string, opts *analysisFlags) (base, current *datamodel.ProjectAnalysis) {
+	pattern := resolveAnalysisPattern(targetPathArg)
+	dir := patternDir(pattern)
+	opts.checkSandbox(dir)
+	analyzer := newAnalyzer(opts)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	baseDir, remove, err := diff.Worktree(ctx, dir, ref)
+	if err != nil {
+		fatalf("Cannot check out the base version: %v", err)
+	}
+	analyze := func(pattern string) (*datamodel.ProjectAnalysis, error) {
+		slog.Info("Starting analysis", "pattern", pattern)
+		analysisCtx, cancel := opts.analysisContext(ctx)
+		defer cancel()
+		return analyzer.Analyze(analysisCtx, pattern)
+	}
+	if _, statErr := os.Stat(baseDir); statErr != nil {
+		err = fmt.Errorf("%s does not exist at %s", targetPathArg, ref)
+	} else {
+		base, err = analyze(baseDir + string(filepath.Separator) + "...")
+	}
+	if removeErr := remove(); removeErr != nil {
+		slog.Warn("Failed to remove the base worktree", "error", removeErr)
+	}
+	if err != nil {
+		fatalf("Analysis of %s failed: %v", ref, err)
+	}
+	if current, err = analyze(pattern); err != nil {
+		fatalf("Analysis failed: %v", err)
+	}
+	return base, current
+}
+
+// writeDiff prints d to standard output in the given format.
+func writeDiff(d *datamodel.AnalysisDiff, format string) error {
+	if format == "text" {
+		return diff.WriteText(os.Stdout, d)
+	}
+	return writeJSON(d)
+}
+
+// writeAPIDiff prints d to standard output in the given format.
+func writeAPIDiff(d *datamodel.APIDiff, format string) error {
+	if format == "text" {
+		return diff.WriteAPIText(os.Stdout, d)
+	}
+	return writeJSON(d)
+}
+
+func writeJSON(v any) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/namikmesic/go-mcp/internal/analyzer/typesystem"
+)
+
+// runExplain implements the "explain" subcommand: print why a type does or does not
+// satisfy an interface, as JSON. It exits with status 1 if the type does not satisfy
+// the interface through either the value or the pointer type.
+func runExplain(args []string) {
+	fs := flag.NewFlagSet("explain", flag.ExitOnError)
+	logOpts := registerLogFlags(fs)
+	fs.Usage = func() {
+		fmt.Println("Usage: go run main.go explain [flags] <pkg/path.Interface> <pkg/path.Type> [path-to-go-project]")
+		fmt.Println("Flags:")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	logOpts.install()
+	if fs.NArg() < 2 {
+		fs.Usage()
+		os.Exit(1)
+	}
+	dir := "."
+	if fs.NArg() > 2 {
+		dir = fs.Arg(2)
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		fatalf("Error converting path %s to absolute path: %v", dir, err)
+	}
+
+	explanation, err := typesystem.NewSatisfactionExplainer(absDir).Explain(fs.Arg(0), fs.Arg(1))
+	if err != nil {
+		fatalf("Explain failed: %v", err)
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(explanation); err != nil {
+		fatalf("Failed to write explanation: %v", err)
+	}
+	if !explanation.Satisfied && !explanation.SatisfiedByPointer {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/namikmesic/go-mcp/internal/analyzer/typesystem"
+)
+
+// runExtract implements the "extract" subcommand: suggest the smallest interface
+// covering the methods of a concrete type that its callers use, from the call sites of
+// an analysis file or a fresh analysis of a project. It prints the Go declaration, or
+// the suggestion as JSON with --json, and exits with status 1 if no caller uses the
+// type's methods.
+func runExtract(args []string) {
+	fs := flag.NewFlagSet("extract", flag.ExitOnError)
+	name := fs.String("name", "", "Name of the interface (default: derived from the method or type name)")
+	from := fs.String("from", "", "Only count calls from this package (import path or path suffix)")
+	asJSON := fs.Bool("json", false, "Print the suggestion as JSON, with the callers of each method")
+	analysisOpts := registerAnalysisFlags(fs)
+	logOpts := registerLogFlags(fs)
+	fs.Usage = func() {
+		fmt.Println("Usage: go run main.go extract [flags] <pkg/path.Type> [analysis.json | path-to-go-project]")
+		fmt.Println("  Example: go run main.go extract --from internal/server store.MemStore .")
+		fmt.Println("Flags (the analysis flags apply when analyzing a project):")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	logOpts.install()
+	if fs.NArg() < 1 || fs.NArg() > 2 {
+		fs.Usage()
+		os.Exit(1)
+	}
+	if analysisOpts.noCallGraph {
+		fatalf("extract needs the call graph and cannot be used with --no-callgraph.")
+	}
+	target := "."
+	if fs.NArg() == 2 {
+		target = fs.Arg(1)
+	}
+
+	extractor := typesystem.NewInterfaceExtractor(analysisOpts.loadOrAnalyze(target))
+	suggestion, err := extractor.Extract(fs.Arg(0), *from, *name)
+	if err != nil {
+		fatalf("Interface extraction failed: %v", err)
+	}
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(suggestion); err != nil {
+			fatalf("Failed to write suggestion: %v", err)
+		}
+	} else {
+		fmt.Print(suggestion.Declaration)
+	}
+	if len(suggestion.Methods) == 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,363 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/namikmesic/go-mcp/internal/analyzer/filter"
+	"github.com/namikmesic/go-mcp/internal/analyzer/reach"
+	"github.com/namikmesic/go-mcp/internal/analyzer/ssa"
+	"github.com/namikmesic/go-mcp/internal/analyzer/typesystem"
+	"github.com/namikmesic/go-mcp/internal/analyzer/utils"
+	"github.com/namikmesic/go-mcp/internal/diff"
+	"github.com/namikmesic/go-mcp/internal/sandbox"
+	"github.com/namikmesic/go-mcp/internal/server"
+	"github.com/namikmesic/go-mcp/pkg/analysis"
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// analysisFlags holds command-line settings that configure the analysis components.
+// They are shared between the default (one-shot) mode and the serve subcommand.
+type analysisFlags struct {
+	docStripMarkers bool
+	docNormalize    bool
+	docMaxLen       int
+	docEllipsis     string
+
+	tests  bool
+	gopath string
+	vendor string
+
+	progress bool
+	timeout  time.Duration
+	workers  int
+
+	noCallGraph bool
+	lowMemory   bool
+	callGraph   string
+	ssaOptions  ssa.BuildOptions
+
+	reachabilityRoots  []string
+	centralityMeasures []string
+
+	excludeGenerated bool
+	exportedOnly     bool
+	includePackages  []string
+	excludePackages  []string
+
+	rulesPath string
+	analyzers []string
+	disable   []string
+
+	embedMetadata bool
+
+	nearMisses    int
+	stdInterfaces string
+	crossModule   bool
+
+	deps        bool
+	depCache    bool
+	depCacheDir string
+
+	allowRoots  []string
+	redactPaths bool
+	pathStyle   string
+	zeroBased   bool
+}
+
+// registerAnalysisFlags defines the analysis flags on fs.
+func registerAnalysisFlags(fs *flag.FlagSet) *analysisFlags {
+	f := &analysisFlags{ssaOptions: ssa.DefaultBuildOptions()}
+	fs.BoolVar(&f.docStripMarkers, "doc-strip-markers", true, "Strip comment markers (//, /* */) from doc comments")
+	fs.BoolVar(&f.docNormalize, "doc-normalize", false, "Collapse whitespace and newlines in doc comments into single spaces")
+	fs.IntVar(&f.docMaxLen, "doc-max-len", 0, "Truncate doc comments longer than this many characters (0 = no limit)")
+	fs.StringVar(&f.docEllipsis, "doc-ellipsis", utils.DefaultEllipsis, "Marker appended to truncated doc comments")
+	fs.StringVar(&f.gopath, "gopath", "", "Load in GOPATH mode with this GOPATH (default: automatic for directories outside any module)")
+	fs.Func("vendor", "Vendored packages: \"include\" analyzes them like the project's own packages, \"skip\" leaves them out of all results (default: dependencies only)", func(s string) error {
+		if s != "include" && s != "skip" {
+			return errors.New(`must be "include" or "skip"`)
+		}
+		f.vendor = s
+		return nil
+	})
+	fs.DurationVar(&f.timeout, "timeout", 0, "Abort an analysis that takes longer than this (e.g. 5m; 0 means no limit)")
+	fs.BoolVar(&f.noCallGraph, "no-callgraph", false, "Skip building SSA and the call graph; only interfaces, implementations and the other AST-based results are reported (much faster)")
+	fs.Func("callgraph", "Resolve the candidate callees of dynamic and interface calls with this call graph algorithm: "+strings.Join(ssa.Algorithms, ", ")+" (default: none)", func(s string) error {
+		for _, algorithm := range ssa.Algorithms {
+			if s == algorithm {
+				f.callGraph = s
+				return nil
+			}
+		}
+		return fmt.Errorf("must be one of %s", strings.Join(ssa.Algorithms, ", "))
+	})
+	fs.Func("reachability", "Mark the functions reachable from these roots (comma-separated: "+strings.Join(reach.RootKinds, ", ")+")", func(s string) error {
+		f.reachabilityRoots = nil
+		for _, kind := range strings.Split(s, ",") {
+			if kind = strings.TrimSpace(kind); kind == "" {
+				continue
+			}
+			if !reach.IsRootKind(kind) {
+				return fmt.Errorf("unknown root %q; must be one of %s", kind, strings.Join(reach.RootKinds, ", "))
+			}
+			f.reachabilityRoots = append(f.reachabilityRoots, kind)
+		}
+		return nil
+	})
+	fs.Func("centrality", "Also compute these call graph centrality measures for each function, besides in- and out-degree (comma-separated: "+strings.Join(reach.CentralityMeasures, ", ")+")", func(s string) error {
+		f.centralityMeasures = nil
+		for _, measure := range strings.Split(s, ",") {
+			if measure = strings.TrimSpace(measure); measure == "" {
+				continue
+			}
+			if !reach.IsCentralityMeasure(measure) {
+				return fmt.Errorf("unknown measure %q; must be one of %s", measure, strings.Join(reach.CentralityMeasures, ", "))
+			}
+			f.centralityMeasures = append(f.centralityMeasures, measure)
+		}
+		return nil
+	})
+	fs.BoolVar(&f.lowMemory, "low-memory", false, "Keep memory use bounded on very large projects: build SSA one package at a time and release each package's syntax and type information once it is analyzed")
+	fs.BoolVar(&f.ssaOptions.SanityCheckFunctions, "ssa-sanity-check", f.ssaOptions.SanityCheckFunctions, "Run the SSA builder's sanity checks on every function (use --ssa-sanity-check=false for a much faster build)")
+	fs.BoolVar(&f.ssaOptions.BuildSerially, "ssa-serial", f.ssaOptions.BuildSerially, "Build SSA one package at a time (use --ssa-serial=false to build packages in parallel)")
+	fs.BoolVar(&f.ssaOptions.InstantiateGenerics, "ssa-instantiate-generics", f.ssaOptions.InstantiateGenerics, "Build every instantiation of generic functions, reporting calls in generic code per instantiation")
+	fs.IntVar(&f.workers, "workers", 0, "Analyze at most this many packages concurrently when finding interfaces and implementations (0 = number of CPUs)")
+	fs.BoolVar(&f.progress, "progress", false, "Report analysis progress (packages loaded, SSA built, interfaces and implementations processed) on stderr")
+	fs.BoolVar(&f.tests, "tests", true, "Also analyze _test.go files (use --tests=false to skip them)")
+	fs.BoolVar(&f.excludeGenerated, "exclude-generated", false, "Skip symbols declared in generated files (\"Code generated ... DO NOT EDIT.\")")
+	fs.BoolVar(&f.exportedOnly, "exported-only", false, "Only report exported interfaces, methods, implementations, functions, types and values, and calls made from exported functions")
+	fs.Func("include", "Only analyze packages whose import path matches this pattern (repeatable; \"...\" or \"**\" span path elements, \"*\" matches within one, \"re:\" starts a regular expression)", patternFlag(&f.includePackages))
+	fs.Func("exclude", "Skip packages whose import path matches this pattern (repeatable; same syntax as --include)", patternFlag(&f.excludePackages))
+	fs.StringVar(&f.rulesPath, "rules", "", "Check dependencies against the architecture rules in this JSON file")
+	fs.Func("analyzers", "Only run these analysis passes and the passes they require (repeatable or comma-separated: "+strings.Join(analysis.Passes(), ", ")+")", listFlag(&f.analyzers))
+	fs.Func("disable", "Turn off these analysis passes (repeatable or comma-separated; see --analyzers)", listFlag(&f.disable))
+	fs.BoolVar(&f.embedMetadata, "embed-metadata", false, "Record the size, SHA-256 hash and content type of each file embedded with //go:embed")
+	fs.IntVar(&f.nearMisses, "near-misses", 0, "Report types missing at most this many methods of an interface (0 = off)")
+	fs.StringVar(&f.stdInterfaces, "std-interfaces", "", "Also find implementations of these standard library interfaces (comma-separated, e.g. io.Reader,net/http.Handler; \"default\" for a common set)")
+	fs.BoolVar(&f.crossModule, "cross-module", false, "Also match interfaces and types across the analyzed module and its dependency modules")
+	fs.BoolVar(&f.deps, "deps", false, "Also report the exported interfaces of imported dependency packages")
+	fs.BoolVar(&f.depCache, "dep-cache", true, "Cache dependency package results per module@version (with --deps)")
+	fs.StringVar(&f.depCacheDir, "dep-cache-dir", "", "Dependency cache directory (default: go-mcp/deps in the user cache directory)")
+	fs.Func("allow-root", "Only analyze projects under this directory (repeatable or comma-separated; default: any)", func(s string) error {
+		for _, root := range strings.Split(s, ",") {
+			if root = strings.TrimSpace(root); root != "" {
+				f.allowRoots = append(f.allowRoots, root)
+			}
+		}
+		return nil
+	})
+	fs.BoolVar(&f.redactPaths, "redact-paths", false, "Remove absolute paths (module directory, module cache, GOROOT) from the results")
+	fs.Func("paths", "Write file paths in this style: "+strings.Join(pathStyles, ", ")+" (default: module-relative; files outside the module stay absolute)", pathStyleFlag(&f.pathStyle))
+	fs.BoolVar(&f.zeroBased, "zero-based", false, "Report 0-based lines and columns, as in the Language Server Protocol (default: 1-based, as in compiler messages)")
+	return f
+}
+
+// listFlag returns a flag function appending the comma-separated names to list.
+func listFlag(list *[]string) func(string) error {
+	return func(s string) error {
+		for _, name := range strings.Split(s, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				*list = append(*list, name)
+			}
+		}
+		return nil
+	}
+}
+
+// patternFlag returns a flag function appending validated package patterns to patterns.
+func patternFlag(patterns *[]string) func(string) error {
+	return func(s string) error {
+		if _, err := filter.CompilePattern(s); err != nil {
+			return err
+		}
+		*patterns = append(*patterns, s)
+		return nil
+	}
+}
+
+// analysisContext derives the context for one analysis run from ctx, applying --timeout.
+func (f *analysisFlags) analysisContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if f.timeout > 0 {
+		return context.WithTimeout(ctx, f.timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// docCommentOptions converts the flags into the library's doc comment options.
+func (f *analysisFlags) docCommentOptions() analysis.DocCommentOptions {
+	return analysis.DocCommentOptions{
+		StripMarkers:        f.docStripMarkers,
+		NormalizeWhitespace: f.docNormalize,
+		MaxLength:           f.docMaxLen,
+		Ellipsis:            f.docEllipsis,
+	}
+}
+
+// stdInterfaceList expands --std-interfaces, replacing "default" with typesystem.DefaultStdInterfaces.
+func (f *analysisFlags) stdInterfaceList() []string {
+	var names []string
+	for _, name := range strings.Split(f.stdInterfaces, ",") {
+		switch name = strings.TrimSpace(name); name {
+		case "":
+		case "default":
+			names = append(names, typesystem.DefaultStdInterfaces...)
+		default:
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// checkSandbox exits the process unless dir lies within the allowed roots.
+func (f *analysisFlags) checkSandbox(dir string) {
+	policy, err := sandbox.NewPolicy(f.allowRoots)
+	if err != nil {
+		fatalf("Invalid --allow-root: %v", err)
+	}
+	if err := policy.Check(dir); err != nil {
+		fatalf("Refusing to analyze: %v", err)
+	}
+}
+
+// rewriteOutput applies the output flags to analysis: --redact-paths strips absolute
+// paths, --paths converts the others and --zero-based converts positions.
+func (f *analysisFlags) rewriteOutput(analysis *datamodel.ProjectAnalysis) {
+	if f.redactPaths {
+		sandbox.NewRedactor(analysis.ModuleDir).Redact(analysis)
+	}
+	if rewrite := f.pathRewriter(analysis.ModuleDir); rewrite != nil {
+		datamodel.RewriteFiles(analysis, rewrite)
+	}
+	if f.redactPaths || f.pathStyle != "" {
+		datamodel.SlashPaths(analysis) // Rewritten paths use the platform's separators
+	}
+	if f.zeroBased {
+		datamodel.ZeroBasePositions(analysis)
+		analysis.ZeroBased = true
+	}
+}
+
+// locationRewriter returns the function applying the output flags to the locations
+// reachable from a result computed on demand for the module in moduleDir, or nil if
+// the flags leave locations unchanged.
+func (f *analysisFlags) locationRewriter(moduleDir string) func(v any) {
+	rewritePath := f.pathRewriter(moduleDir)
+	if !f.redactPaths && rewritePath == nil && !f.zeroBased {
+		return nil
+	}
+	var redactor *sandbox.Redactor
+	if f.redactPaths {
+		redactor = sandbox.NewRedactor(moduleDir)
+	}
+	return func(v any) {
+		if redactor != nil {
+			redactor.RedactLocations(v)
+		}
+		if rewritePath != nil {
+			datamodel.RewriteLocations(v, rewritePath)
+		}
+		if f.redactPaths || rewritePath != nil {
+			datamodel.RewriteLocations(v, filepath.ToSlash)
+		}
+		if f.zeroBased {
+			datamodel.ZeroBasePositions(v)
+		}
+	}
+}
+
+// pathRewriter returns the function applying --paths to the file paths of an analysis
+// of moduleDir, or nil if they are left module-relative.
+func (f *analysisFlags) pathRewriter(moduleDir string) func(string) string {
+	rewrite, err := pathRewriter(f.pathStyle, moduleDir)
+	if err != nil {
+		fatalf("%v", err)
+	}
+	return rewrite
+}
+
+// loadOrAnalyze reads the analysis in target, a file written by the JSON renderer, or
+// produces it by analyzing the project at target with these flags.
+func (f *analysisFlags) loadOrAnalyze(target string) *datamodel.ProjectAnalysis {
+	if info, err := os.Stat(target); err == nil && info.Mode().IsRegular() {
+		analysis, err := diff.Load(target)
+		if err != nil {
+			fatalf("%v", err)
+		}
+		return analysis
+	}
+	pattern := resolveAnalysisPattern(target)
+	f.checkSandbox(patternDir(pattern))
+	analyzer := newAnalyzer(f)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	analysisCtx, cancel := f.analysisContext(ctx)
+	defer cancel()
+	slog.Info("Starting analysis", "pattern", pattern)
+	analysis, err := analyzer.Analyze(analysisCtx, pattern)
+	if err != nil {
+		fatalf("Analysis failed: %v", err)
+	}
+	f.rewriteOutput(analysis)
+	return analysis
+}
+
+// explainer creates the satisfaction explainer for the module in moduleDir, rewriting
+// the locations it reports like the analysis.
+func (f *analysisFlags) explainer(moduleDir string) server.Explainer {
+	explainer := typesystem.NewSatisfactionExplainer(moduleDir)
+	rewrite := f.locationRewriter(moduleDir)
+	if rewrite == nil {
+		return explainer
+	}
+	return &rewritingExplainer{next: explainer, rewrite: rewrite}
+}
+
+// referenceFinder creates the reference finder for the module in moduleDir, rewriting
+// the locations it reports like the analysis.
+func (f *analysisFlags) referenceFinder(moduleDir string) server.ReferenceFinder {
+	finder := typesystem.NewReferenceFinder(moduleDir)
+	finder.Config.Tests = f.tests
+	rewrite := f.locationRewriter(moduleDir)
+	if rewrite == nil {
+		return finder
+	}
+	return &rewritingReferenceFinder{next: finder, rewrite: rewrite}
+}
+
+// rewritingReferenceFinder applies the output flags to the references of another
+// ReferenceFinder.
+type rewritingReferenceFinder struct {
+	next    server.ReferenceFinder
+	rewrite func(v any)
+}
+
+func (f *rewritingReferenceFinder) Find(pkgPath, name string) (*datamodel.References, error) {
+	refs, err := f.next.Find(pkgPath, name)
+	if err == nil {
+		f.rewrite(refs)
+	}
+	return refs, err
+}
+
+// rewritingExplainer applies the output flags to the explanations of another Explainer.
+type rewritingExplainer struct {
+	next    server.Explainer
+	rewrite func(v any)
+}
+
+func (e *rewritingExplainer) Explain(ifaceName, typeName string) (*datamodel.SatisfactionExplanation, error) {
+	explanation, err := e.next.Explain(ifaceName, typeName)
+	if err == nil {
+		e.rewrite(explanation)
+	}
+	return explanation, err
+}
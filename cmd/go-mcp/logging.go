@@ -0,0 +1,58 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+)
+
+// logFlags selects the level and format of the diagnostics written to stderr.
+type logFlags struct {
+	level slog.Level
+	json  bool
+	quiet bool
+}
+
+func registerLogFlags(fs *flag.FlagSet) *logFlags {
+	f := &logFlags{level: slog.LevelInfo}
+	fs.Func("log-level", "Minimum level of log messages: debug, info, warn or error (default info)", func(s string) error {
+		return f.level.UnmarshalText([]byte(s))
+	})
+	fs.Func("log-format", "Log message format: text or json", func(s string) error {
+		switch s {
+		case "text":
+			f.json = false
+		case "json":
+			f.json = true
+		default:
+			return errors.New(`must be "text" or "json"`)
+		}
+		return nil
+	})
+	fs.BoolVar(&f.quiet, "quiet", false, "Only log errors (same as --log-level error)")
+	return f
+}
+
+// install makes the configured logger the slog default, which the analysis components
+// and the standard log package write through.
+func (f *logFlags) install() {
+	level := f.level
+	if f.quiet {
+		level = slog.LevelError
+	}
+	opts := &slog.HandlerOptions{Level: level}
+	var handler slog.Handler = slog.NewTextHandler(os.Stderr, opts)
+	if f.json {
+		handler = slog.NewJSONHandler(os.Stderr, opts)
+	}
+	slog.SetDefault(slog.New(handler))
+}
+
+// fatalf logs an error and exits. Unlike log.Fatalf it honours the configured
+// handler without being filtered out by --quiet.
+func fatalf(format string, args ...any) {
+	slog.Error(fmt.Sprintf(format, args...))
+	os.Exit(1)
+}
@@ -1,88 +1,178 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
+	"flag"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
+	"os/signal"
 	"path/filepath" // Import filepath for absolute paths
 	"strings"       // Import strings for suffix operations
+	"syscall"
 
 	// Adjust import paths according to your project structure and module name
-	"github.com/namikmesic/go-mcp/internal/analyzer/ast"
-	"github.com/namikmesic/go-mcp/internal/analyzer/ssa"
-	"github.com/namikmesic/go-mcp/internal/analyzer/typesystem"
-	"github.com/namikmesic/go-mcp/internal/loader"
-	"github.com/namikmesic/go-mcp/internal/service"
+	"github.com/namikmesic/go-mcp/internal/output"
+	"github.com/namikmesic/go-mcp/internal/sink"
+	"github.com/namikmesic/go-mcp/pkg/analysis"
 )
 
+func usage() {
+	fmt.Println("Usage: go run main.go [flags] <path-to-go-project-or-package>...")
+	fmt.Println("       go run main.go serve [flags] [path-to-go-project]")
+	fmt.Println("       go run main.go explain [flags] <pkg/path.Interface> <pkg/path.Type> [path-to-go-project]")
+	fmt.Println("       go run main.go refs [flags] <pkg/path> <Name|Type.Member> [path-to-go-project]")
+	fmt.Println("       go run main.go callpath [flags] <from> <to> [path-to-go-project]")
+	fmt.Println("       go run main.go extract [flags] <pkg/path.Type> [analysis.json | path-to-go-project]")
+	fmt.Println("       go run main.go tests [flags] <function> [analysis.json | path-to-go-project]")
+	fmt.Println("       go run main.go tests --report [flags] [analysis.json | path-to-go-project]")
+	fmt.Println("       go run main.go stub [flags] <pkg/path.Interface> <TypeName> [path-to-go-project]")
+	fmt.Println("       go run main.go diff [flags] <old.json> <new.json>")
+	fmt.Println("       go run main.go browse [flags] [analysis.json | path-to-go-project]")
+	fmt.Println("       go run main.go query [flags] <query> [analysis.json | path-to-go-project]")
+	fmt.Println("       go run main.go schema [flags]")
+	fmt.Println("  Example: go run main.go .")
+	fmt.Println("  Example: go run main.go ./...") // Usually handled by loader now
+	fmt.Println("  Example: go run main.go /path/to/your/project")
+	fmt.Println("  Example: go run main.go ../service-a ../service-b")
+	fmt.Println("  Example: go run main.go --template report.tmpl .")
+	fmt.Println("  Example: go run main.go --format dot . | dot -Tsvg > calls.svg")
+	fmt.Println("  Example: go run main.go --xref xref.json .")
+	fmt.Println("  Example: go run main.go -o analysis.json .")
+	fmt.Println("  Example: go run main.go --split-by-package -o analysis/ .")
+	fmt.Println("  Example: go run main.go --sink json=analysis.json --sink http=:8080 .")
+	fmt.Println("  Example: go run main.go --rules rules.json .")
+	fmt.Println("  Example: NEO4J_PASSWORD=secret go run main.go --neo4j-uri neo4j://localhost:7687 .")
+	fmt.Println("  Example: go run main.go serve --http :8080 .")
+	fmt.Println("Flags:")
+	flag.PrintDefaults()
+}
+
 func main() {
-	if len(os.Args) < 2 {
-		fmt.Println("Usage: go run main.go <path-to-go-project-or-package>")
-		fmt.Println("  Example: go run main.go .")
-		fmt.Println("  Example: go run main.go ./...") // Usually handled by loader now
-		fmt.Println("  Example: go run main.go /path/to/your/project")
-		os.Exit(1)
+	// Subcommands are dispatched before flag parsing so they can own their flag sets
+	if len(os.Args) > 1 && os.Args[1] == "serve" {
+		runServe(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "explain" {
+		runExplain(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "refs" {
+		runRefs(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "callpath" {
+		runCallPath(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "extract" {
+		runExtract(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "tests" {
+		runTests(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "stub" {
+		runStub(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "diff" {
+		runDiff(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "browse" {
+		runBrowse(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "query" {
+		runQuery(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "schema" {
+		runSchema(os.Args[2:])
+		return
 	}
-	// The argument should be the directory containing the code (or where go.mod resides)
-	targetPathArg := os.Args[1]
 
-	// Ensure the path is absolute for consistency, especially for the loader's Dir config.
-	targetPath, err := filepath.Abs(targetPathArg)
-	if err != nil {
-		log.Fatalf("Error converting path %s to absolute path: %v", targetPathArg, err)
+	format := flag.String("format", "json", "Output format: json, dot (Graphviz call graph), dot-imports (Graphviz package import graph), mermaid (interface class diagram), api (exported API, one declaration per line), pb (binary protobuf) or csv (tables in --out-dir)")
+	outDir := flag.String("out-dir", ".", "Directory receiving the files of multi-file formats (csv)")
+	outPath := flag.String("o", "", "Write the results to this file instead of standard output (for csv and --split-by-package, the directory receiving the files, instead of --out-dir)")
+	splitByPackage := flag.Bool("split-by-package", false, "Write the JSON results as one file per package: project.json and packages/<import path>.json under --out-dir or -o")
+	templatePath := flag.String("template", "", "Render results through a text/template file instead of JSON")
+	xrefPath := flag.String("xref", "", "Also write a compact cross-reference index (symbol -> references) to this file")
+	var sinkFlags sinkSpecs
+	flag.Var(&sinkFlags, "sink", "Send results to this output instead of standard output (repeatable): json|dot|dot-imports|mermaid|api|pb|xref[=file], template=tmpl[:file], csv[=dir], neo4j[=uri], http=addr or grpc=addr")
+	analysisOpts := registerAnalysisFlags(flag.CommandLine)
+	neo4jOpts := registerNeo4jFlags(flag.CommandLine)
+	logOpts := registerLogFlags(flag.CommandLine)
+	flag.Usage = usage
+	flag.Parse()
+	logOpts.install()
+
+	if flag.NArg() < 1 {
+		usage()
+		os.Exit(1)
 	}
+	// Each argument should be a directory containing the code (or where go.mod resides)
+	targetPathArgs := flag.Args()
 
-	// Check if the target path exists and is a directory
-	info, err := os.Stat(targetPath)
+	// Prepare the outputs up front so configuration errors surface before a long analysis
+	// Multi-file formats write into a directory, which -o overrides like --out-dir
+	multiFile := *format == "csv" || *splitByPackage
+	dir, target := *outDir, orStdout(*outPath)
+	if multiFile && *outPath != "" {
+		dir, target = *outPath, "-"
+	}
+	renderer, err := selectRenderer(*format, *templatePath, dir)
 	if err != nil {
-		if os.IsNotExist(err) {
-			log.Fatalf("Error: Target path does not exist: %s", targetPath)
+		fatalf("Error preparing output: %v", err)
+	}
+	if *splitByPackage {
+		if *format != "json" || *templatePath != "" {
+			fatalf("Error preparing output: --split-by-package only applies to --format json")
 		}
-		log.Fatalf("Error accessing target path %s: %v", targetPath, err)
+		renderer = output.NewSplitJSONRenderer(dir)
 	}
-	if !info.IsDir() {
-		log.Fatalf("Error: Target path must be a directory: %s", targetPath)
+	if len(sinkFlags) > 0 && *outPath != "" {
+		fatalf("Error preparing output: -o cannot be combined with --sink; give each sink its own target")
+	}
+	sinks, err := configureSinks(sinkFlags, renderer, target, *xrefPath, analysisOpts, neo4jOpts)
+	if err != nil {
+		fatalf("Error preparing output: %v", err)
 	}
 
-	// Construct the pattern for analysis properly for cross-platform compatibility
-	// Use filepath.Separator for platform-specific path separator
-	recursiveSuffix := string(filepath.Separator) + "..."
-	analysisPattern := targetPath
-	if !strings.HasSuffix(analysisPattern, recursiveSuffix) {
-		analysisPattern = targetPath + recursiveSuffix
+	// Pass selection errors, like the other option errors, come before any analysis
+	analyzer := newAnalyzer(analysisOpts)
+
+	analysisPatterns := make([]string, 0, len(targetPathArgs))
+	for _, arg := range targetPathArgs {
+		analysisPattern := resolveAnalysisPattern(arg)
+		analysisOpts.checkSandbox(patternDir(analysisPattern))
+		slog.Info("Starting analysis", "pattern", analysisPattern)
+		analysisPatterns = append(analysisPatterns, analysisPattern)
 	}
-	log.Printf("Starting analysis for directory using pattern: %s", analysisPattern)
-
-	// --- Dependency Injection ---
-	// Create concrete instances of our components
-	pkgLoader := loader.NewGoPackagesLoader()
-	ifAnalyzer := ast.NewASTInterfaceAnalyzer()
-	implFinder := typesystem.NewTypeBasedImplementationFinder()
-	callAnalyzer := ssa.NewSSACallGraphAnalyzer()
-
-	// Create the analysis service, injecting the components
-	analysisService := service.NewAnalysisService(
-		pkgLoader,
-		ifAnalyzer,
-		implFinder,
-		callAnalyzer,
-	)
-	// --- End Dependency Injection ---
-
-	// Run the analysis using the pattern
-	projectAnalysis, err := analysisService.AnalyzeProject(analysisPattern)
+
+	// SIGINT/SIGTERM cancel a running analysis and stop serving sinks
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Run the analysis using the patterns; several roots are merged into one result
+	analysisCtx, cancel := analysisOpts.analysisContext(ctx)
+	projectAnalysis, err := analyzer.Analyze(analysisCtx, analysisPatterns...)
+	cancel()
 	if err != nil {
-		log.Fatalf("Analysis failed: %v", err)
+		fatalf("Analysis failed: %v", err)
 	}
+	setExplainers(sinks, analysisOpts, projectAnalysis.ModuleDir)
+	analysisOpts.rewriteOutput(projectAnalysis)
 
 	// --- Output ---
-	// Output the results as JSON to standard output
-	fmt.Println("\n===== ANALYSIS RESULTS (JSON) =====")
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ") // Pretty print JSON
-	if err := encoder.Encode(projectAnalysis); err != nil {
-		log.Fatalf("Failed to encode results to JSON: %v", err)
+	// Fan the results out to all sinks; serving sinks run until SIGINT/SIGTERM
+	err = sink.Run(ctx, sinks, projectAnalysis)
+	stop()
+	if err != nil {
+		fatalf("Failed to write results: %v", err)
 	}
 
 	// Optional: Print summary after JSON output
@@ -108,4 +198,129 @@ func main() {
 	} else {
 		fmt.Fprintln(os.Stderr, "Project analysis result was nil.")
 	}
+
+	// Fail CI builds on architecture rule violations (after all output has been written)
+	if projectAnalysis != nil && projectAnalysis.Findings != nil && len(projectAnalysis.Findings.RuleViolations) > 0 {
+		violations := projectAnalysis.Findings.RuleViolations
+		for _, v := range violations {
+			fmt.Fprintf(os.Stderr, "%s:%d: %s dependency %s -> %s violates rule %q\n",
+				v.Location.Filename, v.Location.Line, strings.ToLower(v.Kind), v.From, v.To, v.Rule)
+		}
+		fmt.Fprintf(os.Stderr, "Found %d architecture rule violations.\n", len(violations))
+		os.Exit(exitRuleViolations)
+	}
+}
+
+// exitRuleViolations is the exit code used when the analysis succeeded but
+// architecture rules were violated, distinguishing it from fatal errors (1).
+const exitRuleViolations = 3
+
+// resolveAnalysisPattern validates the target directory and returns the recursive
+// load pattern for it. It exits the process on invalid input.
+func resolveAnalysisPattern(targetPathArg string) string {
+	// Ensure the path is absolute for consistency, especially for the loader's Dir config.
+	targetPath, err := filepath.Abs(targetPathArg)
+	if err != nil {
+		fatalf("Error converting path %s to absolute path: %v", targetPathArg, err)
+	}
+
+	// Check if the target path exists and is a directory
+	info, err := os.Stat(targetPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			fatalf("Target path does not exist: %s", targetPath)
+		}
+		fatalf("Error accessing target path %s: %v", targetPath, err)
+	}
+	if !info.IsDir() {
+		fatalf("Target path must be a directory: %s", targetPath)
+	}
+
+	// Construct the pattern for analysis properly for cross-platform compatibility
+	// Use filepath.Separator for platform-specific path separator
+	recursiveSuffix := string(filepath.Separator) + "..."
+	analysisPattern := targetPath
+	if !strings.HasSuffix(analysisPattern, recursiveSuffix) {
+		analysisPattern = targetPath + recursiveSuffix
+	}
+	return analysisPattern
+}
+
+// patternDir returns the directory of a pattern built by resolveAnalysisPattern.
+func patternDir(pattern string) string {
+	return strings.TrimSuffix(pattern, string(filepath.Separator)+"...")
+}
+
+// selectRenderer returns the renderer for the requested output format. A template,
+// when given, takes precedence over the format. Multi-file formats write into outDir.
+func selectRenderer(format, templatePath, outDir string) (output.Renderer, error) {
+	if templatePath != "" {
+		return output.NewTemplateRenderer(templatePath)
+	}
+	switch format {
+	case "json":
+		return output.NewJSONRenderer(), nil
+	case "dot":
+		return output.NewDOTRenderer(), nil
+	case "dot-imports":
+		return output.NewImportGraphRenderer(), nil
+	case "mermaid":
+		return output.NewMermaidRenderer(), nil
+	case "api":
+		return output.NewAPIRenderer(), nil
+	case "pb":
+		return output.NewProtobufRenderer(), nil
+	case "csv":
+		return output.NewCSVRenderer(outDir), nil
+	}
+	return nil, fmt.Errorf("unknown output format %q (expected json, dot, dot-imports, mermaid, api, pb or csv)", format)
+}
+
+// newAnalyzer prepares the library analyzer configured by opts. It exits the process on
+// invalid options, such as a broken rules file.
+func newAnalyzer(opts *analysisFlags) *analysis.Analyzer {
+	docOptions := opts.docCommentOptions()
+	ssaOptions := analysis.SSAOptions{
+		SanityCheckFunctions: opts.ssaOptions.SanityCheckFunctions,
+		BuildSerially:        opts.ssaOptions.BuildSerially,
+		InstantiateGenerics:  opts.ssaOptions.InstantiateGenerics,
+	}
+	libOpts := analysis.Options{
+		SkipTests:          !opts.tests,
+		GOPATH:             opts.gopath,
+		Vendor:             opts.vendor,
+		Workers:            opts.workers,
+		NoCallGraph:        opts.noCallGraph,
+		LowMemory:          opts.lowMemory,
+		CallGraph:          opts.callGraph,
+		SSA:                &ssaOptions,
+		DocComments:        &docOptions,
+		ReachabilityRoots:  opts.reachabilityRoots,
+		CentralityMeasures: opts.centralityMeasures,
+		ExcludeGenerated:   opts.excludeGenerated,
+		ExportedOnly:       opts.exportedOnly,
+		IncludePackages:    opts.includePackages,
+		ExcludePackages:    opts.excludePackages,
+		RulesFile:          opts.rulesPath,
+		EmbedMetadata:      opts.embedMetadata,
+		NearMisses:         opts.nearMisses,
+		StdInterfaces:      opts.stdInterfaceList(),
+		CrossModule:        opts.crossModule,
+		Dependencies:       opts.deps,
+		NoDependencyCache:  !opts.depCache,
+		DependencyCacheDir: opts.depCacheDir,
+		Analyzers:          opts.analyzers,
+		Disable:            opts.disable,
+	}
+	if opts.redactPaths && opts.pathStyle != "" && opts.pathStyle != pathsModuleRelative {
+		fatalf("Error: --paths=%s cannot be combined with --redact-paths", opts.pathStyle)
+	}
+	if opts.progress {
+		libOpts.Progress = newProgressPrinter(os.Stderr)
+	}
+	analyzer, err := analysis.New(libOpts)
+	if err != nil {
+		fatalf("Error: %v", err)
+	}
+	return analyzer
 }
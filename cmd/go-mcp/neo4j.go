@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/namikmesic/go-mcp/internal/neo4jstore"
+	"github.com/namikmesic/go-mcp/internal/sink"
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// neo4jFlags holds the connection settings for optionally persisting results to Neo4j.
+// The password is read from the NEO4J_PASSWORD environment variable so it does not
+// show up in process listings.
+type neo4jFlags struct {
+	uri         string
+	user        string
+	database    string
+	batchSize   int
+	incremental bool
+}
+
+func registerNeo4jFlags(fs *flag.FlagSet) *neo4jFlags {
+	f := &neo4jFlags{}
+	fs.StringVar(&f.uri, "neo4j-uri", "", "Store results in the Neo4j database at this URI (password from NEO4J_PASSWORD)")
+	fs.StringVar(&f.user, "neo4j-user", "neo4j", "Neo4j username")
+	fs.StringVar(&f.database, "neo4j-database", "", "Neo4j database name (empty for the default database)")
+	fs.IntVar(&f.batchSize, "neo4j-batch-size", neo4jstore.DefaultBatchSize, "Number of rows written per Neo4j transaction")
+	fs.BoolVar(&f.incremental, "neo4j-incremental", false, "Only rewrite packages that changed since the last Neo4j store instead of replacing the module")
+	return f
+}
+
+// enabled reports whether Neo4j persistence was requested.
+func (f *neo4jFlags) enabled() bool {
+	return f.uri != ""
+}
+
+// storeInNeo4j writes the analysis to the configured Neo4j database.
+func storeInNeo4j(ctx context.Context, f *neo4jFlags, analysis *datamodel.ProjectAnalysis) error {
+	store, err := neo4jstore.NewNeo4jStore(ctx, f.uri, f.user, os.Getenv("NEO4J_PASSWORD"), f.database)
+	if err != nil {
+		return err
+	}
+	defer store.Close(ctx)
+	store.BatchSize = f.batchSize
+	store.Incremental = f.incremental
+
+	if err := store.StoreAnalysis(ctx, analysis); err != nil {
+		return fmt.Errorf("storing analysis: %w", err)
+	}
+	return nil
+}
+
+// neo4jSink wraps storeInNeo4j as an output sink.
+func neo4jSink(f *neo4jFlags) sink.Sink {
+	return &sink.FuncSink{
+		Label: "neo4j " + f.uri,
+		Fn: func(ctx context.Context, analysis *datamodel.ProjectAnalysis) error {
+			return storeInNeo4j(ctx, f, analysis)
+		},
+	}
+}
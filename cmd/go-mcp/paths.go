@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Values of --paths, selecting how file paths are written.
+const (
+	pathsAbsolute       = "absolute"
+	pathsModuleRelative = "module-relative"
+	pathsCwdRelative    = "cwd-relative"
+)
+
+var pathStyles = []string{pathsAbsolute, pathsModuleRelative, pathsCwdRelative}
+
+// pathStyleFlag returns a flag function storing a valid --paths value in style.
+func pathStyleFlag(style *string) func(string) error {
+	return func(s string) error {
+		for _, known := range pathStyles {
+			if s == known {
+				*style = s
+				return nil
+			}
+		}
+		return fmt.Errorf("must be one of %s", strings.Join(pathStyles, ", "))
+	}
+}
+
+// pathRewriter returns the function converting the module-relative file paths of an
+// analysis of moduleDir to style, or nil if the paths are already in that style. Files
+// outside the module, such as those of the standard library, are absolute in every
+// style but module-relative; with cwd-relative, files within the module that lie
+// outside the working directory are written with "..".
+func pathRewriter(style, moduleDir string) (func(string) string, error) {
+	if style == "" || style == pathsModuleRelative || moduleDir == "" {
+		return nil, nil
+	}
+	absolute := func(path string) string {
+		if path == "" || filepath.IsAbs(path) {
+			return path
+		}
+		return filepath.Join(moduleDir, path)
+	}
+	if style == pathsAbsolute {
+		return absolute, nil
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("cannot write paths relative to the working directory: %w", err)
+	}
+	return func(path string) string {
+		path = absolute(path)
+		if path == "" || !within(moduleDir, path) {
+			return path
+		}
+		if rel, err := filepath.Rel(cwd, path); err == nil {
+			return rel
+		}
+		return path
+	}, nil
+}
+
+// within reports whether path lies in dir.
+func within(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
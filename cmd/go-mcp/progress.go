@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sync"
+)
+
+// progressPrinter writes analysis progress to w, one line per stage step of at least
+// 10%, so long analyses show they are advancing without flooding the log.
+type progressPrinter struct {
+	w io.Writer
+
+	mu      sync.Mutex
+	stage   string
+	percent int
+}
+
+func newProgressPrinter(w io.Writer) *progressPrinter {
+	return &progressPrinter{w: w}
+}
+
+// Progress implements analyzer.ProgressReporter.
+func (p *progressPrinter) Progress(stage string, done, total int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	percent := 0
+	if total > 0 {
+		percent = done * 100 / total
+	}
+	if stage == p.stage && percent < p.percent+10 && done != total {
+		return
+	}
+	if stage == p.stage && percent == p.percent {
+		return // Already reported, e.g. a repeated completion update
+	}
+	p.stage, p.percent = stage, percent
+	if total == 0 {
+		fmt.Fprintf(p.w, "Progress: %s...\n", stage)
+		return
+	}
+	fmt.Fprintf(p.w, "Progress: %s %d/%d (%d%%)\n", stage, done, total, percent)
+}
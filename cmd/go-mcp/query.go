@@ -0,0 +1,53 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/namikmesic/go-mcp/internal/memstore"
+	"github.com/namikmesic/go-mcp/internal/query"
+)
+
+// runQuery implements the "query" subcommand: evaluate a query language expression
+// against an analysis, read from a file written by the JSON renderer or produced by
+// analyzing a project, and print the result as JSON. It exits with status 1 when
+// nothing matches.
+func runQuery(args []string) {
+	fs := flag.NewFlagSet("query", flag.ExitOnError)
+	analysisOpts := registerAnalysisFlags(fs)
+	logOpts := registerLogFlags(fs)
+	fs.Usage = func() {
+		fmt.Println("Usage: go run main.go query [flags] <query> [analysis.json | path-to-go-project]")
+		fmt.Println("  Example: go run main.go query 'interfaces where methods > 5 and implementations == 0' .")
+		fmt.Println("  Example: go run main.go query 'calls from internal/server to internal/memstore' analysis.json")
+		fmt.Println("Flags (the analysis flags apply when analyzing a project):")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	logOpts.install()
+	if fs.NArg() < 1 || fs.NArg() > 2 {
+		fs.Usage()
+		os.Exit(1)
+	}
+	// Parsed up front so mistakes are reported before a long analysis
+	q, err := query.Parse(fs.Arg(0))
+	if err != nil {
+		fatalf("Invalid query: %v", err)
+	}
+	target := "."
+	if fs.NArg() == 2 {
+		target = fs.Arg(1)
+	}
+
+	result := q.Eval(memstore.FromAnalysis(analysisOpts.loadOrAnalyze(target)))
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		fatalf("Failed to write query result: %v", err)
+	}
+	if result.Total == 0 {
+		os.Exit(1)
+	}
+}
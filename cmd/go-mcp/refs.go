@@ -0,0 +1,54 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/namikmesic/go-mcp/internal/analyzer/typesystem"
+)
+
+// runRefs implements the "refs" subcommand: print the references to a symbol across
+// the packages of the project as JSON. It exits with status 1 if there are none.
+func runRefs(args []string) {
+	fs := flag.NewFlagSet("refs", flag.ExitOnError)
+	tests := fs.Bool("tests", false, "Also search _test.go files")
+	logOpts := registerLogFlags(fs)
+	fs.Usage = func() {
+		fmt.Println("Usage: go run main.go refs [flags] <pkg/path> <Name|Type.Member> [path-to-go-project]")
+		fmt.Println("  Example: go run main.go refs github.com/you/proj/store Store.Get .")
+		fmt.Println("Flags:")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	logOpts.install()
+	if fs.NArg() < 2 {
+		fs.Usage()
+		os.Exit(1)
+	}
+	dir := "."
+	if fs.NArg() > 2 {
+		dir = fs.Arg(2)
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		fatalf("Error converting path %s to absolute path: %v", dir, err)
+	}
+
+	finder := typesystem.NewReferenceFinder(absDir)
+	finder.Config.Tests = *tests
+	refs, err := finder.Find(fs.Arg(0), fs.Arg(1))
+	if err != nil {
+		fatalf("Finding references failed: %v", err)
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(refs); err != nil {
+		fatalf("Failed to write references: %v", err)
+	}
+	if len(refs.References) == 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// runSchema implements the "schema" subcommand: print the JSON Schema of the JSON
+// output, or with --version, only its version.
+func runSchema(args []string) {
+	fs := flag.NewFlagSet("schema", flag.ExitOnError)
+	versionOnly := fs.Bool("version", false, "Print only the schema version")
+	fs.Usage = func() {
+		fmt.Println("Usage: go run main.go schema [flags]")
+		fmt.Println("  Example: go run main.go schema > go-mcp.schema.json")
+		fmt.Println("Flags:")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() > 0 {
+		fs.Usage()
+		os.Exit(1)
+	}
+
+	if *versionOnly {
+		fmt.Println(datamodel.SchemaVersion)
+		return
+	}
+	schema, err := datamodel.JSONSchema()
+	if err != nil {
+		fatalf("Error generating schema: %v", err)
+	}
+	fmt.Println(string(schema))
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/namikmesic/go-mcp/internal/grpcapi"
+	"github.com/namikmesic/go-mcp/internal/server"
+	"github.com/namikmesic/go-mcp/internal/snapshot"
+	"github.com/namikmesic/go-mcp/internal/watch"
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// shutdownTimeout bounds how long in-flight requests may take after a stop signal.
+const shutdownTimeout = 10 * time.Second
+
+// runServe implements the "serve" subcommand: analyze, then expose the results over
+// HTTP and/or gRPC until the process is stopped. With --watch the project is
+// re-analyzed whenever its sources change. On SIGINT/SIGTERM the listeners are shut
+// down gracefully and the current analysis is persisted, so a restart with unchanged
+// sources and options skips the cold analysis.
+func runServe(args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	httpAddr := fs.String("http", ":8080", "Address for the HTTP API to listen on (empty disables HTTP)")
+	grpcAddr := fs.String("grpc", "", "Address for the gRPC API to listen on (empty disables gRPC)")
+	ui := fs.Bool("ui", false, "Also serve an interactive web UI of the interface and call graphs at /ui/ on the HTTP address")
+	watchInterval := fs.Duration("watch", 0, "Poll for source changes at this interval and re-analyze (0 disables)")
+	persist := fs.Bool("persist", true, "Persist the analysis on shutdown and reuse it on startup if the sources are unchanged")
+	stateDir := fs.String("state-dir", "", "Directory for persisted analyses (default: go-mcp/state in the user cache directory)")
+	analysisOpts := registerAnalysisFlags(fs)
+	logOpts := registerLogFlags(fs)
+	fs.Usage = func() {
+		fmt.Println("Usage: go run main.go serve [flags] [path-to-go-project]")
+		fmt.Println("Flags:")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	logOpts.install()
+
+	if *httpAddr == "" && *grpcAddr == "" {
+		fatalf("At least one of --http or --grpc must be set.")
+	}
+	if *ui && *httpAddr == "" {
+		fatalf("--ui requires --http.")
+	}
+
+	targetPathArg := "."
+	if fs.NArg() > 0 {
+		targetPathArg = fs.Arg(0)
+	}
+
+	analysisPattern := resolveAnalysisPattern(targetPathArg)
+	analysisOpts.checkSandbox(patternDir(analysisPattern))
+	poller := watch.NewPoller(patternDir(analysisPattern), *watchInterval)
+	state := &serveState{pattern: analysisPattern, options: fmt.Sprintf("%+v", *analysisOpts)}
+	if *persist {
+		path, err := snapshot.DefaultPath(*stateDir, analysisPattern)
+		if err != nil {
+			slog.Warn("Persistence disabled", "error", err)
+		}
+		state.path = path
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	analyzer := newAnalyzer(analysisOpts)
+	projectAnalysis := state.restore(poller)
+	// Persisted analyses may have been redacted, in which case ModuleDir is relative
+	moduleDir := poller.Root
+	if projectAnalysis == nil {
+		slog.Info("Starting analysis", "pattern", analysisPattern)
+		fingerprint, _ := poller.Fingerprint()
+		analysisCtx, cancel := analysisOpts.analysisContext(ctx)
+		analysis, err := analyzer.Analyze(analysisCtx, analysisPattern)
+		cancel()
+		if err != nil {
+			fatalf("Analysis failed: %v", err)
+		}
+		moduleDir = analysis.ModuleDir
+		analysisOpts.rewriteOutput(analysis)
+		state.set(analysis, fingerprint)
+		projectAnalysis = analysis
+	}
+
+	// Each listener runs in its own goroutine; the first one to fail stops the process
+	errCh := make(chan error, 3)
+	var httpServer *server.Server
+	var grpcServer *grpcapi.Server
+	if *httpAddr != "" {
+		httpServer = server.NewServer(projectAnalysis)
+		httpServer.Explainer = analysisOpts.explainer(moduleDir)
+		httpServer.References = analysisOpts.referenceFinder(moduleDir)
+		if *ui {
+			httpServer.EnableUI()
+			slog.Info("Serving web UI", "path", "/ui/")
+		}
+		go func() {
+			errCh <- fmt.Errorf("http: %w", httpServer.ListenAndServe(*httpAddr))
+		}()
+	}
+	if *grpcAddr != "" {
+		grpcServer = grpcapi.NewServer(projectAnalysis)
+		go func() {
+			errCh <- fmt.Errorf("grpc: %w", grpcServer.ListenAndServe(*grpcAddr))
+		}()
+	}
+
+	if *watchInterval > 0 {
+		go func() {
+			err := poller.Run(ctx, func() {
+				slog.Info("Detected source changes, re-analyzing", "pattern", analysisPattern)
+				fingerprint, _ := poller.Fingerprint()
+				analysisCtx, cancel := analysisOpts.analysisContext(ctx)
+				updated, err := analyzer.Analyze(analysisCtx, analysisPattern)
+				cancel()
+				if err != nil {
+					// Keep serving the previous results until the sources analyze again
+					slog.Warn("Re-analysis failed", "error", err)
+					return
+				}
+				analysisOpts.rewriteOutput(updated)
+				state.set(updated, fingerprint)
+				if httpServer != nil {
+					httpServer.Update(updated)
+				}
+				if grpcServer != nil {
+					grpcServer.Update(updated)
+				}
+			})
+			if ctx.Err() == nil {
+				errCh <- fmt.Errorf("watch: %w", err)
+			}
+		}()
+	}
+
+	select {
+	case err := <-errCh:
+		fmt.Fprintf(os.Stderr, "Server stopped: %v\n", err)
+		os.Exit(1)
+	case <-ctx.Done():
+	}
+
+	slog.Info("Shutting down")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if httpServer != nil {
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			slog.Warn("HTTP shutdown did not complete", "error", err)
+		}
+	}
+	if grpcServer != nil {
+		grpcServer.Stop()
+	}
+	state.save()
+}
+
+// serveState tracks the analysis currently served and persists it across restarts.
+type serveState struct {
+	path    string // Snapshot file; empty disables persistence
+	pattern string
+	options string // Analysis options, so changed flags force a fresh analysis
+
+	mu          sync.Mutex
+	analysis    *datamodel.ProjectAnalysis
+	fingerprint string
+}
+
+func (s *serveState) set(analysis *datamodel.ProjectAnalysis, fingerprint string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.analysis = analysis
+	s.fingerprint = fingerprint
+}
+
+// restore returns the persisted analysis if it matches the current sources and options.
+func (s *serveState) restore(poller *watch.Poller) *datamodel.ProjectAnalysis {
+	if s.path == "" {
+		return nil
+	}
+	snap, err := snapshot.Load(s.path)
+	if err != nil {
+		slog.Warn("Ignoring persisted analysis", "error", err)
+		return nil
+	}
+	if snap == nil {
+		return nil
+	}
+	fingerprint, err := poller.Fingerprint()
+	if err != nil || !snap.Matches(s.pattern, s.options, fingerprint) {
+		slog.Info("Persisted analysis is stale, re-analyzing", "path", s.path)
+		return nil
+	}
+	slog.Info("Restored analysis", "path", s.path)
+	s.set(snap.Analysis, fingerprint)
+	return snap.Analysis
+}
+
+// save persists the current analysis, if persistence is enabled.
+func (s *serveState) save() {
+	if s.path == "" {
+		return
+	}
+	s.mu.Lock()
+	snap := &snapshot.Snapshot{Pattern: s.pattern, Options: s.options, Fingerprint: s.fingerprint, Analysis: s.analysis}
+	s.mu.Unlock()
+	if err := snapshot.Save(s.path, snap); err != nil {
+		slog.Warn("Failed to persist analysis", "error", err)
+		return
+	}
+	slog.Info("Persisted analysis", "path", s.path)
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/namikmesic/go-mcp/internal/output"
+	"github.com/namikmesic/go-mcp/internal/sink"
+)
+
+// sinkSpecs collects repeated --sink flags of the form kind[=target].
+type sinkSpecs []string
+
+func (s *sinkSpecs) String() string { return strings.Join(*s, ",") }
+
+func (s *sinkSpecs) Set(v string) error {
+	*s = append(*s, v)
+	return nil
+}
+
+// configureSinks builds the outputs of a one-shot run. Without --sink flags the
+// results are rendered to target ("-" for standard output) with the renderer selected
+// by --format or --template; --xref and --neo4j-uri add their sinks in either case.
+func configureSinks(specs sinkSpecs, renderer output.Renderer, target, xrefPath string, analysisOpts *analysisFlags, neo4jOpts *neo4jFlags) ([]sink.Sink, error) {
+	var sinks []sink.Sink
+	if len(specs) == 0 {
+		results := sink.NewRendererSink(renderer, target)
+		if _, isJSON := renderer.(*output.JSONRenderer); isJSON && target == "-" {
+			results.Header = "\n===== ANALYSIS RESULTS (JSON) ====="
+		}
+		sinks = append(sinks, results)
+	}
+	hasNeo4j := false
+	for _, spec := range specs {
+		s, err := buildSink(spec, analysisOpts, neo4jOpts)
+		if err != nil {
+			return nil, err
+		}
+		if kind, _, _ := strings.Cut(spec, "="); kind == "neo4j" {
+			hasNeo4j = true
+		}
+		sinks = append(sinks, s)
+	}
+	// The cross-reference index is a separate artifact, decoupled from the main document
+	if xrefPath != "" {
+		sinks = append(sinks, sink.NewRendererSink(output.NewXRefRenderer(), xrefPath))
+	}
+	if neo4jOpts.enabled() && !hasNeo4j {
+		sinks = append(sinks, neo4jSink(neo4jOpts))
+	}
+	return sinks, checkStdout(sinks)
+}
+
+// setExplainers enables /explain on HTTP sinks for the module in moduleDir.
+func setExplainers(sinks []sink.Sink, analysisOpts *analysisFlags, moduleDir string) {
+	for _, s := range sinks {
+		if hs, ok := s.(*sink.HTTPSink); ok {
+			hs.Explainer = analysisOpts.explainer(moduleDir)
+		}
+	}
+}
+
+// buildSink creates the sink for one --sink spec. Renderer kinds write to a file, or
+// standard output when the target is "-" or omitted; csv writes into the target
+// directory; http and grpc serve on the target address until the process is stopped.
+func buildSink(spec string, analysisOpts *analysisFlags, neo4jOpts *neo4jFlags) (sink.Sink, error) {
+	kind, target, _ := strings.Cut(spec, "=")
+	switch kind {
+	case "json", "dot", "dot-imports", "mermaid", "api", "pb":
+		renderer, err := selectRenderer(kind, "", "")
+		if err != nil {
+			return nil, err
+		}
+		return sink.NewRendererSink(renderer, orStdout(target)), nil
+	case "xref":
+		return sink.NewRendererSink(output.NewXRefRenderer(), orStdout(target)), nil
+	case "template":
+		path, out, _ := strings.Cut(target, ":")
+		renderer, err := output.NewTemplateRenderer(path)
+		if err != nil {
+			return nil, err
+		}
+		return sink.NewRendererSink(renderer, orStdout(out)), nil
+	case "csv":
+		if target == "" {
+			target = "."
+		}
+		return &sink.RendererSink{Renderer: output.NewCSVRenderer(target), Out: io.Discard}, nil
+	case "neo4j":
+		if target != "" {
+			neo4jOpts.uri = target
+		}
+		if !neo4jOpts.enabled() {
+			return nil, fmt.Errorf("neo4j sink needs a URI (neo4j=<uri> or --neo4j-uri)")
+		}
+		return neo4jSink(neo4jOpts), nil
+	case "http":
+		if target == "" {
+			return nil, fmt.Errorf("http sink needs an address (http=:8080)")
+		}
+		return &sink.HTTPSink{Addr: target}, nil
+	case "grpc":
+		if target == "" {
+			return nil, fmt.Errorf("grpc sink needs an address (grpc=:9090)")
+		}
+		return &sink.GRPCSink{Addr: target}, nil
+	}
+	return nil, fmt.Errorf("unknown sink %q (expected json, dot, dot-imports, mermaid, api, pb, csv, xref, template, neo4j, http or grpc)", kind)
+}
+
+func orStdout(path string) string {
+	if path == "" {
+		return "-"
+	}
+	return path
+}
+
+// checkStdout rejects configurations where several sinks would interleave on standard output.
+func checkStdout(sinks []sink.Sink) error {
+	count := 0
+	for _, s := range sinks {
+		if rs, ok := s.(*sink.RendererSink); ok && rs.Path == "-" {
+			count++
+		}
+	}
+	if count > 1 {
+		return fmt.Errorf("%d outputs write to standard output; give all but one a file", count)
+	}
+	return nil
+}
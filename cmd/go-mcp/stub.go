@@ -0,0 +1,65 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/namikmesic/go-mcp/internal/analyzer/typesystem"
+)
+
+// runStub implements the "stub" subcommand: print or write a skeleton type
+// implementing an interface, with every method stubbed.
+func runStub(args []string) {
+	flags := flag.NewFlagSet("stub", flag.ExitOnError)
+	targetPkg := flags.String("package", "", "Import path of the package receiving the type (default: the interface's package)")
+	receiver := flags.String("receiver", typesystem.ReceiverAuto, "Method receivers: auto (follow the target package), pointer or value")
+	out := flags.String("out", "", "Write the stub to this file instead of standard output; it must not exist")
+	logOpts := registerLogFlags(flags)
+	flags.Usage = func() {
+		fmt.Println("Usage: go run main.go stub [flags] <pkg/path.Interface> <TypeName> [path-to-go-project]")
+		fmt.Println("  Example: go run main.go stub --package example.com/app/memstore --out memstore/store.go example.com/app/store.Store MemStore .")
+		fmt.Println("Flags:")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	logOpts.install()
+	if flags.NArg() < 2 || flags.NArg() > 3 {
+		flags.Usage()
+		os.Exit(1)
+	}
+	dir := "."
+	if flags.NArg() > 2 {
+		dir = flags.Arg(2)
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		fatalf("Error converting path %s to absolute path: %v", dir, err)
+	}
+
+	src, err := typesystem.NewStubGenerator(absDir).Generate(flags.Arg(0), *targetPkg, flags.Arg(1), *receiver)
+	if err != nil {
+		fatalf("Stub generation failed: %v", err)
+	}
+	if *out == "" {
+		os.Stdout.Write(src)
+		return
+	}
+	f, err := os.OpenFile(*out, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if errors.Is(err, fs.ErrExist) {
+		fatalf("Refusing to overwrite %s", *out)
+	}
+	if err != nil {
+		fatalf("Failed to create %s: %v", *out, err)
+	}
+	if _, err := f.Write(src); err != nil {
+		f.Close()
+		fatalf("Failed to write %s: %v", *out, err)
+	}
+	if err := f.Close(); err != nil {
+		fatalf("Failed to write %s: %v", *out, err)
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/namikmesic/go-mcp/internal/analyzer/reach"
+)
+
+// runTests implements the "tests" subcommand: print as JSON the tests, benchmarks, fuzz
+// targets and examples that exercise a function through the call graph, from an
+// analysis file or a fresh analysis of a project. The function is given by ID or by an
+// unambiguous ID suffix. It exits with status 1 if no test reaches it. With --report it
+// prints the benchmarks, fuzz targets and untested exported functions instead.
+func runTests(args []string) {
+	fs := flag.NewFlagSet("tests", flag.ExitOnError)
+	report := fs.Bool("report", false, "Print the benchmarks and fuzz targets with the functions they drive, and the exported functions without tests or fuzzing")
+	analysisOpts := registerAnalysisFlags(fs)
+	logOpts := registerLogFlags(fs)
+	fs.Usage = func() {
+		fmt.Println("Usage: go run main.go tests [flags] <function> [analysis.json | path-to-go-project]")
+		fmt.Println("       go run main.go tests --report [flags] [analysis.json | path-to-go-project]")
+		fmt.Println("  Example: go run main.go tests store.DB.Query .")
+		fmt.Println("Flags (the analysis flags apply when analyzing a project):")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	logOpts.install()
+	minArgs := 1
+	if *report {
+		minArgs = 0
+	}
+	if fs.NArg() < minArgs || fs.NArg() > minArgs+1 {
+		fs.Usage()
+		os.Exit(1)
+	}
+	if analysisOpts.noCallGraph || !analysisOpts.tests {
+		fatalf("tests needs the call graph and the tests, and cannot be used with --no-callgraph or --tests=false.")
+	}
+	target := "."
+	if fs.NArg() > minArgs {
+		target = fs.Arg(minArgs)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if *report {
+		if err := encoder.Encode(reach.NewTestReport(analysisOpts.loadOrAnalyze(target))); err != nil {
+			fatalf("Failed to write test report: %v", err)
+		}
+		return
+	}
+	coverage, err := reach.TestsCovering(analysisOpts.loadOrAnalyze(target), fs.Arg(0))
+	if err != nil {
+		fatalf("Test query failed: %v", err)
+	}
+	if err := encoder.Encode(coverage); err != nil {
+		fatalf("Failed to write tests: %v", err)
+	}
+	if len(coverage.Tests) == 0 {
+		os.Exit(1)
+	}
+}
@@ -4,10 +4,16 @@ go 1.23.7
 
 require (
 	github.com/neo4j/neo4j-go-driver/v5 v5.28.0
+	golang.org/x/mod v0.24.0
+	golang.org/x/sys v0.32.0
 	golang.org/x/tools v0.32.0
+	google.golang.org/grpc v1.73.0
+	google.golang.org/protobuf v1.36.6
 )
 
 require (
-	golang.org/x/mod v0.24.0 // indirect
+	golang.org/x/net v0.39.0 // indirect
 	golang.org/x/sync v0.13.0 // indirect
+	golang.org/x/text v0.24.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20250324211829-b45e905df463 // indirect
 )
@@ -2,19 +2,27 @@
 package analyzer
 
 import (
+	"context"
+	"go/ast"
 	"go/token"
+	"log/slog"
+	"path/filepath"
+	"strings"
 
 	"golang.org/x/tools/go/packages"
 	"golang.org/x/tools/go/ssa"
 
-	"github.com/namikmesic/go-mcp/internal/datamodel" // Adjusted import path
+	"github.com/namikmesic/go-mcp/pkg/datamodel" // Adjusted import path
 )
 
+// The analysis stages below take a context.Context and return ctx.Err() when it is
+// cancelled before they complete. Partial results are discarded by the caller.
+
 // InterfaceAnalyzer extracts interface definitions from packages.
 type InterfaceAnalyzer interface {
 	// AnalyzeInterfaces analyzes packages and returns a map where the key is a unique identifier
 	// (e.g., packagePath + "." + interfaceName) and the value is the Interface details.
-	AnalyzeInterfaces(pkgs []*packages.Package) (map[string]*datamodel.Interface, error)
+	AnalyzeInterfaces(ctx context.Context, pkgs []*packages.Package) (map[string]*datamodel.Interface, error)
 }
 
 // ImplementationFinder finds implementations of interfaces across packages.
@@ -23,6 +31,7 @@ type ImplementationFinder interface {
 	// FindImplementations searches through the packages to find types that implement the interfaces
 	// provided in the 'interfaces' map. It modifies the Implementations field within the map's values.
 	FindImplementations(
+		ctx context.Context,
 		pkgs []*packages.Package,
 		interfaces map[string]*datamodel.Interface, // Pass in the interfaces to find impls for
 		fset *token.FileSet, // FileSet needed for locating implementation types
@@ -32,9 +41,147 @@ type ImplementationFinder interface {
 // CallGraphAnalyzer extracts call site information using SSA.
 type CallGraphAnalyzer interface {
 	// AnalyzeCalls builds the SSA representation and extracts call sites.
-	// It returns a map linking original packages to their call sites, the built SSA program,
-	// and the FileSet used by SSA (crucial for consistent positioning).
+	// It returns a map linking original packages to their call sites, the built SSA program
+	// (nil if it was not retained), and the FileSet used by SSA (crucial for consistent
+	// positioning).
 	AnalyzeCalls(
+		ctx context.Context,
 		pkgs []*packages.Package,
 	) (map[*packages.Package][]datamodel.CallSite, *ssa.Program, *token.FileSet, error)
 }
+
+// Env carries run-wide information shared with PackageAnalyzers and ProjectAnalyzers.
+type Env struct {
+	Fset      *token.FileSet // FileSet shared by all loaded packages
+	ModuleDir string         // Root directory of the main module; output paths are relative to it
+}
+
+// RelPath returns filename relative to ModuleDir when it lies inside the module.
+// Files outside the module (e.g. cgo output in the build cache) are returned unchanged.
+func (e *Env) RelPath(filename string) string {
+	if e == nil || e.ModuleDir == "" || !filepath.IsAbs(filename) {
+		return filename
+	}
+	rel, err := filepath.Rel(e.ModuleDir, filename)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return filename
+	}
+	return rel
+}
+
+// Location converts pos into a datamodel.Location with a module-relative filename.
+func (e *Env) Location(pos token.Pos) datamodel.Location {
+	if e == nil || e.Fset == nil || !pos.IsValid() {
+		return datamodel.Location{}
+	}
+	loc := datamodel.NewLocation(e.Fset.Position(pos))
+	loc.Filename = e.RelPath(loc.Filename)
+	return loc
+}
+
+// Span is like Location, but also records the end of the declaration, node.End().
+func (e *Env) Span(pos token.Pos, node ast.Node) datamodel.Location {
+	loc := e.Location(pos)
+	if loc.Line == 0 || node == nil || !node.End().IsValid() {
+		return loc
+	}
+	end := e.Fset.Position(node.End())
+	loc.EndLine = end.Line
+	loc.EndColumn = end.Column
+	loc.EndOffset = end.Offset
+	return loc
+}
+
+// PackageAnalyzer extracts additional per-package information into the PackageAnalysis
+// being assembled for pkg. Implementations should only add to result.
+type PackageAnalyzer interface {
+	AnalyzePackage(ctx context.Context, env *Env, pkg *packages.Package, result *datamodel.PackageAnalysis) error
+}
+
+// ProjectAnalyzer derives project-wide information from the fully assembled analysis.
+// AnalyzeProject is called even if ctx was cancelled during assembly, so analyzers
+// that gather state in AnalyzePackage must reset it before returning ctx.Err().
+type ProjectAnalyzer interface {
+	AnalyzeProject(ctx context.Context, env *Env, analysis *datamodel.ProjectAnalysis) error
+}
+
+// SymbolKind identifies the kind of declaration a FilterPolicy is asked about.
+type SymbolKind string
+
+const (
+	KindInterface      SymbolKind = "Interface"      // Interface type declarations
+	KindMethod         SymbolKind = "Method"         // Methods declared in interfaces
+	KindImplementation SymbolKind = "Implementation" // Named types implementing interfaces
+	KindFunction       SymbolKind = "Function"       // Package-level functions and methods
+	KindStruct         SymbolKind = "Struct"         // Struct type declarations
+	KindField          SymbolKind = "Field"          // Fields of struct types
+	KindType           SymbolKind = "Type"           // Type aliases and other defined types
+	KindConst          SymbolKind = "Const"          // Package-level constants
+	KindVar            SymbolKind = "Var"            // Package-level variables
+)
+
+// Symbol describes a declaration being considered for inclusion in the results.
+type Symbol struct {
+	Kind        SymbolKind
+	Name        string
+	PackagePath string
+	Exported    bool
+	Generated   bool // Declared in a file carrying a "Code generated ... DO NOT EDIT." header
+}
+
+// FilterPolicy decides which packages and symbols the analyzers include in their results.
+type FilterPolicy interface {
+	// IncludePackage reports whether results for the package should be produced at all.
+	IncludePackage(pkg *packages.Package) bool
+	// IncludeSymbol reports whether a single declaration should be included.
+	IncludeSymbol(sym Symbol) bool
+}
+
+// Filterable is implemented by components that honour a FilterPolicy.
+type Filterable interface {
+	SetFilterPolicy(policy FilterPolicy)
+}
+
+// Analysis stages reported to a ProgressReporter, in the order they run. Progress is
+// counted in packages, except for StageProject, which counts project analyzers.
+const (
+	StageLoad            = "load"            // Loading and type-checking packages
+	StageInterfaces      = "interfaces"      // Extracting interface definitions, per package
+	StageSSA             = "ssa"             // Building SSA and extracting call sites
+	StageImplementations = "implementations" // Checking types against interfaces, per package
+	StagePackages        = "packages"        // Assembling packages and running package analyzers
+	StageProject         = "project"         // Running project analyzers
+)
+
+// ProgressReporter receives progress updates of long-running analyses, e.g. to drive a
+// progress bar. done counts the completed units of stage out of total; total is 0
+// while it is not yet known. Implementations must be safe for concurrent use.
+type ProgressReporter interface {
+	Progress(stage string, done, total int)
+}
+
+// ProgressAware is implemented by components that report progress within their stage.
+type ProgressAware interface {
+	SetProgressReporter(reporter ProgressReporter)
+}
+
+// ReportProgress forwards an update to reporter if it is non-nil.
+func ReportProgress(reporter ProgressReporter, stage string, done, total int) {
+	if reporter != nil {
+		reporter.Progress(stage, done, total)
+	}
+}
+
+// LoggerAware is implemented by components that log through an injected structured logger.
+type LoggerAware interface {
+	SetLogger(logger *slog.Logger)
+}
+
+// LoggerOrDefault returns logger, or the current slog.Default() if logger is nil.
+// Components resolve their logger on use, so a default installed later still applies.
+func LoggerOrDefault(logger *slog.Logger) *slog.Logger {
+	if logger != nil {
+		return logger
+	}
+	return slog.Default()
+}
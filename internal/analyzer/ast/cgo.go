@@ -0,0 +1,139 @@
+// analyzer/ast/cgo.go
+package ast
+
+import (
+	"context"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/namikmesic/go-mcp/internal/analyzer"
+	"github.com/namikmesic/go-mcp/internal/analyzer/filter"
+	"github.com/namikmesic/go-mcp/internal/analyzer/utils"
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// cgoExportPrefix is the name prefix of the wrappers cmd/cgo generates for functions
+// exported with //export: "_cgoexp_" + hash + "_" + name.
+const cgoExportPrefix = "_cgoexp_"
+
+// cgoSourceExts are the extensions of the non-Go sources cgo compiles or includes.
+var cgoSourceExts = map[string]bool{
+	".c": true, ".h": true,
+	".cc": true, ".cpp": true, ".cxx": true, ".hh": true, ".hpp": true, ".hxx": true,
+	".m": true,
+	".f": true, ".F": true, ".for": true, ".f90": true,
+}
+
+// CgoAnalyzer implements PackageAnalyzer by describing the packages using cgo in
+// PackageAnalysis.Cgo. Files importing "C" are only loaded with cgo enabled.
+type CgoAnalyzer struct {
+	// Filter decides which packages are analyzed.
+	Filter analyzer.FilterPolicy
+}
+
+// Compile-time checks to ensure CgoAnalyzer implements the analyzer interfaces.
+var (
+	_ analyzer.PackageAnalyzer = (*CgoAnalyzer)(nil)
+	_ analyzer.Filterable      = (*CgoAnalyzer)(nil)
+)
+
+func NewCgoAnalyzer() *CgoAnalyzer {
+	return &CgoAnalyzer{
+		Filter: filter.AllowAll(),
+	}
+}
+
+// SetFilterPolicy implements analyzer.Filterable.
+func (a *CgoAnalyzer) SetFilterPolicy(policy analyzer.FilterPolicy) {
+	a.Filter = policy
+}
+
+func (a *CgoAnalyzer) AnalyzePackage(ctx context.Context, env *analyzer.Env, pkg *packages.Package, result *datamodel.PackageAnalysis) error {
+	if !a.Filter.IncludePackage(pkg) {
+		return nil
+	}
+	cgo := &datamodel.Cgo{}
+	fset := token.NewFileSet()
+	for _, filename := range pkg.GoFiles {
+		// The syntax of cgo files is cmd/cgo's output, without the import of "C"
+		file, err := parser.ParseFile(fset, filename, nil, parser.ImportsOnly|parser.ParseComments)
+		if err != nil {
+			continue
+		}
+		found := false
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.IMPORT {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				imp := spec.(*ast.ImportSpec)
+				if path, _ := strconv.Unquote(imp.Path.Value); path != "C" {
+					continue
+				}
+				found = true
+				preamble := imp.Doc
+				if preamble == nil && len(gen.Specs) == 1 {
+					preamble = gen.Doc
+				}
+				cgo.Directives = append(cgo.Directives, cgoDirectives(preamble)...)
+			}
+		}
+		if found {
+			cgo.GoFiles = append(cgo.GoFiles, env.RelPath(filename))
+		}
+	}
+	if len(cgo.GoFiles) == 0 {
+		return nil
+	}
+
+	for _, filename := range pkg.OtherFiles {
+		if cgoSourceExts[filepath.Ext(filename)] {
+			cgo.CFiles = append(cgo.CFiles, env.RelPath(filename))
+		}
+	}
+	if pkg.Types != nil {
+		for _, file := range pkg.Syntax {
+			for _, decl := range file.Decls {
+				funcDecl, ok := decl.(*ast.FuncDecl)
+				if !ok || funcDecl.Recv != nil || !strings.HasPrefix(funcDecl.Name.Name, cgoExportPrefix) {
+					continue
+				}
+				rest := strings.TrimPrefix(funcDecl.Name.Name, cgoExportPrefix)
+				name := rest[strings.IndexByte(rest, '_')+1:]
+				fn, ok := pkg.Types.Scope().Lookup(name).(*types.Func)
+				if !ok {
+					continue
+				}
+				cgo.Exports = append(cgo.Exports, datamodel.CgoExport{
+					Name:       name,
+					FunctionID: utils.FuncID(fn),
+					Location:   env.Location(fn.Pos()),
+				})
+			}
+		}
+		sort.Slice(cgo.Exports, func(i, j int) bool { return cgo.Exports[i].Name < cgo.Exports[j].Name })
+	}
+	result.Cgo = cgo
+	return nil
+}
+
+// cgoDirectives returns the #cgo lines of the preamble of an import of "C".
+func cgoDirectives(preamble *ast.CommentGroup) []string {
+	var directives []string
+	for _, line := range strings.Split(preamble.Text(), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "#cgo ") {
+			directives = append(directives, line)
+		}
+	}
+	return directives
+}
@@ -0,0 +1,193 @@
+// analyzer/ast/clone_detector.go
+package ast
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"go/ast"
+	"go/types"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/namikmesic/go-mcp/internal/analyzer"
+	"github.com/namikmesic/go-mcp/internal/analyzer/filter"
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// DefaultCloneMinNodes is the smallest function body (in AST nodes) considered for clone detection.
+// It keeps trivial getters and one-line wrappers out of the report.
+const DefaultCloneMinNodes = 40
+
+// CloneDetector finds structurally similar functions by fingerprinting their bodies.
+// It implements PackageAnalyzer to collect fingerprints and ProjectAnalyzer to report
+// groups of functions sharing a fingerprint in ProjectAnalysis.Findings.Clones,
+// so it must be registered as both.
+type CloneDetector struct {
+	// MinNodes is the minimum body size in AST nodes. Zero means DefaultCloneMinNodes.
+	MinNodes int
+	// Filter decides which functions are fingerprinted.
+	Filter analyzer.FilterPolicy
+
+	mu     sync.Mutex
+	groups map[string]*datamodel.CloneGroup // Key: fingerprint
+	seen   map[string]bool                  // Positions already fingerprinted (test variants repeat files)
+}
+
+// Compile-time checks to ensure CloneDetector implements both analyzer passes.
+var (
+	_ analyzer.PackageAnalyzer = (*CloneDetector)(nil)
+	_ analyzer.ProjectAnalyzer = (*CloneDetector)(nil)
+)
+
+func NewCloneDetector() *CloneDetector {
+	return &CloneDetector{
+		MinNodes: DefaultCloneMinNodes,
+		Filter:   filter.AllowAll(),
+	}
+}
+
+// SetFilterPolicy implements analyzer.Filterable.
+func (d *CloneDetector) SetFilterPolicy(policy analyzer.FilterPolicy) {
+	d.Filter = policy
+}
+
+func (d *CloneDetector) AnalyzePackage(ctx context.Context, env *analyzer.Env, pkg *packages.Package, result *datamodel.PackageAnalysis) error {
+	if !d.Filter.IncludePackage(pkg) {
+		return nil
+	}
+	minNodes := d.MinNodes
+	if minNodes <= 0 {
+		minNodes = DefaultCloneMinNodes
+	}
+
+	for _, file := range pkg.Syntax {
+		if file == nil {
+			continue
+		}
+		generated := ast.IsGenerated(file)
+		for _, decl := range file.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Body == nil || funcDecl.Name == nil {
+				continue
+			}
+			if !d.Filter.IncludeSymbol(analyzer.Symbol{
+				Kind:        analyzer.KindFunction,
+				Name:        funcDecl.Name.Name,
+				PackagePath: pkg.PkgPath,
+				Exported:    funcDecl.Name.IsExported(),
+				Generated:   generated,
+			}) {
+				continue
+			}
+
+			fingerprint, nodeCount := fingerprintBody(funcDecl.Body)
+			if nodeCount < minNodes {
+				continue
+			}
+
+			member := datamodel.CloneMember{
+				Function:    pkg.PkgPath + "." + funcDecl.Name.Name,
+				PackagePath: pkg.PkgPath,
+				Location:    env.Location(funcDecl.Name.Pos()),
+			}
+			if fn, ok := pkg.TypesInfo.Defs[funcDecl.Name].(*types.Func); ok {
+				member.Function = fn.FullName()
+			}
+			d.add(fingerprint, nodeCount, member)
+		}
+	}
+	return nil
+}
+
+func (d *CloneDetector) add(fingerprint string, nodeCount int, member datamodel.CloneMember) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.groups == nil {
+		d.groups = make(map[string]*datamodel.CloneGroup)
+		d.seen = make(map[string]bool)
+	}
+	posKey := fmt.Sprintf("%s:%d", member.Location.Filename, member.Location.Line)
+	if d.seen[posKey] {
+		return
+	}
+	d.seen[posKey] = true
+
+	group, ok := d.groups[fingerprint]
+	if !ok {
+		group = &datamodel.CloneGroup{Fingerprint: fingerprint, NodeCount: nodeCount}
+		d.groups[fingerprint] = group
+	}
+	group.Functions = append(group.Functions, member)
+}
+
+// AnalyzeProject reports the collected clone groups and resets the detector for the next run.
+func (d *CloneDetector) AnalyzeProject(ctx context.Context, env *analyzer.Env, analysis *datamodel.ProjectAnalysis) error {
+	d.mu.Lock()
+	groups := d.groups
+	d.groups, d.seen = nil, nil
+	d.mu.Unlock()
+
+	var clones []datamodel.CloneGroup
+	for _, group := range groups {
+		if len(group.Functions) < 2 {
+			continue
+		}
+		sort.Slice(group.Functions, func(i, j int) bool {
+			return group.Functions[i].Function < group.Functions[j].Function
+		})
+		clones = append(clones, *group)
+	}
+	// Largest clones first: they offer the most deduplication value
+	sort.Slice(clones, func(i, j int) bool {
+		if clones[i].NodeCount != clones[j].NodeCount {
+			return clones[i].NodeCount > clones[j].NodeCount
+		}
+		return clones[i].Fingerprint < clones[j].Fingerprint
+	})
+
+	if len(clones) > 0 {
+		if analysis.Findings == nil {
+			analysis.Findings = &datamodel.Findings{}
+		}
+		analysis.Findings.Clones = clones
+	}
+	return nil
+}
+
+// fingerprintBody hashes the shape of a function body. Node types, operators and
+// literal kinds are kept; identifier names and literal values are dropped, so
+// functions differing only in naming or constants share a fingerprint.
+func fingerprintBody(body *ast.BlockStmt) (string, int) {
+	var sb strings.Builder
+	count := 0
+	ast.Inspect(body, func(n ast.Node) bool {
+		if n == nil {
+			sb.WriteString(")")
+			return false
+		}
+		count++
+		fmt.Fprintf(&sb, "(%T", n)
+		switch x := n.(type) {
+		case *ast.BinaryExpr:
+			sb.WriteString(x.Op.String())
+		case *ast.UnaryExpr:
+			sb.WriteString(x.Op.String())
+		case *ast.AssignStmt:
+			sb.WriteString(x.Tok.String())
+		case *ast.IncDecStmt:
+			sb.WriteString(x.Tok.String())
+		case *ast.BranchStmt:
+			sb.WriteString(x.Tok.String())
+		case *ast.BasicLit:
+			sb.WriteString(x.Kind.String())
+		}
+		return true
+	})
+	sum := sha256.Sum256([]byte(sb.String()))
+	return hex.EncodeToString(sum[:8]), count
+}
@@ -0,0 +1,140 @@
+// analyzer/ast/external_functions.go
+package ast
+
+import (
+	"context"
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/namikmesic/go-mcp/internal/analyzer"
+	"github.com/namikmesic/go-mcp/internal/analyzer/filter"
+	"github.com/namikmesic/go-mcp/internal/analyzer/utils"
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// cgoStubPrefixes are the name prefixes cmd/cgo uses for generated Go stubs of C functions.
+var cgoStubPrefixes = []string{"_Cfunc_", "_C2func_"}
+
+// cgoPlumbingPrefixes are the name prefixes of cmd/cgo runtime helpers, which are not reported.
+var cgoPlumbingPrefixes = []string{"_cgo", "_Cgo"}
+
+// ExternalFunctionAnalyzer implements PackageAnalyzer by recording functions that are
+// declared in Go but implemented elsewhere: assembly, //go:linkname, or cgo stubs.
+type ExternalFunctionAnalyzer struct {
+	// Filter decides which functions are reported.
+	Filter analyzer.FilterPolicy
+}
+
+// Compile-time check to ensure ExternalFunctionAnalyzer implements PackageAnalyzer.
+var _ analyzer.PackageAnalyzer = (*ExternalFunctionAnalyzer)(nil)
+
+func NewExternalFunctionAnalyzer() *ExternalFunctionAnalyzer {
+	return &ExternalFunctionAnalyzer{
+		Filter: filter.AllowAll(),
+	}
+}
+
+// SetFilterPolicy implements analyzer.Filterable.
+func (a *ExternalFunctionAnalyzer) SetFilterPolicy(policy analyzer.FilterPolicy) {
+	a.Filter = policy
+}
+
+func (a *ExternalFunctionAnalyzer) AnalyzePackage(ctx context.Context, env *analyzer.Env, pkg *packages.Package, result *datamodel.PackageAnalysis) error {
+	if pkg.TypesInfo == nil || !a.Filter.IncludePackage(pkg) {
+		return nil
+	}
+
+	hasAssembly := false
+	for _, f := range pkg.OtherFiles {
+		if strings.HasSuffix(f, ".s") || strings.HasSuffix(f, ".S") {
+			hasAssembly = true
+			break
+		}
+	}
+
+	for _, file := range pkg.Syntax {
+		if file == nil {
+			continue
+		}
+		linknamed := linknameTargets(file)
+		generated := ast.IsGenerated(file)
+
+		for _, decl := range file.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Name == nil {
+				continue
+			}
+			name := funcDecl.Name.Name
+			if hasAnyPrefix(name, cgoPlumbingPrefixes) {
+				continue
+			}
+
+			var kind string
+			switch {
+			case hasAnyPrefix(name, cgoStubPrefixes):
+				kind = datamodel.ExternalCgo
+			case funcDecl.Body != nil:
+				continue // Regular Go function
+			case linknamed[name]:
+				kind = datamodel.ExternalLinkname
+			case hasAssembly:
+				kind = datamodel.ExternalAssembly
+			default:
+				kind = datamodel.ExternalUnknown
+			}
+
+			if !a.Filter.IncludeSymbol(analyzer.Symbol{
+				Kind:        analyzer.KindFunction,
+				Name:        name,
+				PackagePath: pkg.PkgPath,
+				Exported:    funcDecl.Name.IsExported(),
+				Generated:   generated,
+			}) {
+				continue
+			}
+
+			ext := datamodel.ExternalFunction{
+				Name:      name,
+				FullName:  pkg.PkgPath + "." + name,
+				Signature: name + utils.FormatFuncType(funcDecl.Type, pkg),
+				Kind:      kind,
+				Location:  env.Location(funcDecl.Name.Pos()),
+			}
+			// Prefer the type checker's name, which matches SSA's function descriptions
+			if fn, ok := pkg.TypesInfo.Defs[funcDecl.Name].(*types.Func); ok {
+				ext.FullName = fn.FullName()
+			}
+			if funcDecl.Recv != nil && len(funcDecl.Recv.List) > 0 {
+				ext.Receiver = utils.ExprToString(funcDecl.Recv.List[0].Type, pkg)
+			}
+			result.ExternalFunctions = append(result.ExternalFunctions, ext)
+		}
+	}
+	return nil
+}
+
+// linknameTargets returns the local names bound by //go:linkname directives in file.
+func linknameTargets(file *ast.File) map[string]bool {
+	names := make(map[string]bool)
+	for _, cg := range file.Comments {
+		for _, c := range cg.List {
+			fields := strings.Fields(c.Text)
+			if len(fields) >= 2 && fields[0] == "//go:linkname" {
+				names[fields[1]] = true
+			}
+		}
+	}
+	return names
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+	return false
+}
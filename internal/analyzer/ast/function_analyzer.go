@@ -0,0 +1,103 @@
+// analyzer/ast/function_analyzer.go
+package ast
+
+import (
+	"context"
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/namikmesic/go-mcp/internal/analyzer"
+	"github.com/namikmesic/go-mcp/internal/analyzer/filter"
+	"github.com/namikmesic/go-mcp/internal/analyzer/utils"
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// FunctionAnalyzer implements PackageAnalyzer by listing every package-level function
+// and method declaration, so that the functions named by call sites have definitions.
+type FunctionAnalyzer struct {
+	// DocOptions controls how function doc comments are rendered.
+	DocOptions utils.DocCommentOptions
+	// Filter decides which functions are reported.
+	Filter analyzer.FilterPolicy
+}
+
+// Compile-time check to ensure FunctionAnalyzer implements PackageAnalyzer.
+var _ analyzer.PackageAnalyzer = (*FunctionAnalyzer)(nil)
+
+func NewFunctionAnalyzer() *FunctionAnalyzer {
+	return &FunctionAnalyzer{
+		DocOptions: utils.DefaultDocCommentOptions(),
+		Filter:     filter.AllowAll(),
+	}
+}
+
+// SetFilterPolicy implements analyzer.Filterable.
+func (a *FunctionAnalyzer) SetFilterPolicy(policy analyzer.FilterPolicy) {
+	a.Filter = policy
+}
+
+func (a *FunctionAnalyzer) AnalyzePackage(ctx context.Context, env *analyzer.Env, pkg *packages.Package, result *datamodel.PackageAnalysis) error {
+	if pkg.TypesInfo == nil || !a.Filter.IncludePackage(pkg) {
+		return nil
+	}
+
+	inits := 0 // init functions are numbered in declaration order, like SSA does
+	for _, file := range pkg.Syntax {
+		if file == nil {
+			continue
+		}
+		generated := ast.IsGenerated(file)
+
+		for _, decl := range file.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Name == nil {
+				continue
+			}
+			name := funcDecl.Name.Name
+			if name == "init" && funcDecl.Recv == nil {
+				inits++
+			}
+			if !a.Filter.IncludeSymbol(analyzer.Symbol{
+				Kind:        analyzer.KindFunction,
+				Name:        name,
+				PackagePath: pkg.PkgPath,
+				Exported:    funcDecl.Name.IsExported(),
+				Generated:   generated,
+			}) {
+				continue
+			}
+
+			fn := datamodel.Function{
+				Name:       name,
+				FullName:   pkg.PkgPath + "." + name,
+				Signature:  utils.FormatMethodSignature(name, funcDecl.Type, pkg),
+				DocComment: utils.FormatDocComment(funcDecl.Doc, a.DocOptions),
+				Exported:   funcDecl.Name.IsExported(),
+				Location:   env.Span(funcDecl.Name.Pos(), funcDecl),
+			}
+			// Prefer the type checker's name, which matches SSA's function descriptions
+			if obj, ok := pkg.TypesInfo.Defs[funcDecl.Name].(*types.Func); ok {
+				fn.FullName = obj.FullName()
+				fn.ID = utils.FuncID(obj)
+				if sig, ok := obj.Type().(*types.Signature); ok {
+					fn.TypeParams = utils.ExtractTypeParams(sig.TypeParams(), pkg)
+					if fn.TypeParams == nil {
+						fn.TypeParams = utils.ExtractTypeParams(sig.RecvTypeParams(), pkg)
+					}
+				}
+			}
+			if funcDecl.Recv != nil && len(funcDecl.Recv.List) > 0 {
+				fn.Receiver = utils.ExprToString(funcDecl.Recv.List[0].Type, pkg)
+			} else if name == "init" {
+				fn.ID = utils.InitFuncID(pkg.PkgPath, inits)
+			}
+			if fn.ID == "" {
+				fn.ID = pkg.PkgPath + "." + name
+			}
+			result.Functions = append(result.Functions, fn)
+		}
+	}
+	return nil
+}
@@ -2,145 +2,225 @@
 package ast
 
 import (
+	"context"
 	"go/ast"
 	"go/types"
 
 	// "go/types" // Removed as not directly used here, utils handles type strings
-	"log"
-	"strings"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
 
 	"golang.org/x/tools/go/packages"
 
+	"github.com/namikmesic/go-mcp/internal/analyzer"
+	"github.com/namikmesic/go-mcp/internal/analyzer/filter"
 	"github.com/namikmesic/go-mcp/internal/analyzer/utils" // Adjusted import path
-	"github.com/namikmesic/go-mcp/internal/datamodel"      // Adjusted import path
+	"github.com/namikmesic/go-mcp/pkg/datamodel"           // Adjusted import path
 )
 
 // ASTInterfaceAnalyzer implements InterfaceAnalyzer using AST traversal.
-type ASTInterfaceAnalyzer struct{}
+type ASTInterfaceAnalyzer struct {
+	// DocOptions controls how interface and method doc comments are rendered.
+	DocOptions utils.DocCommentOptions
+	// Filter decides which packages, interfaces and methods are reported.
+	Filter analyzer.FilterPolicy
+	// Progress receives per-package progress; may be nil.
+	Progress analyzer.ProgressReporter
+	// Logger receives diagnostics; nil logs to slog.Default().
+	Logger *slog.Logger
+	// Workers bounds the number of packages analyzed concurrently; <= 0 uses GOMAXPROCS.
+	Workers int
+}
 
 func NewASTInterfaceAnalyzer() *ASTInterfaceAnalyzer {
-	return &ASTInterfaceAnalyzer{}
+	return &ASTInterfaceAnalyzer{
+		DocOptions: utils.DefaultDocCommentOptions(),
+		Filter:     filter.AllowAll(),
+	}
+}
+
+// SetFilterPolicy implements analyzer.Filterable.
+func (a *ASTInterfaceAnalyzer) SetFilterPolicy(policy analyzer.FilterPolicy) {
+	a.Filter = policy
+}
+
+// SetLogger implements analyzer.LoggerAware.
+func (a *ASTInterfaceAnalyzer) SetLogger(logger *slog.Logger) {
+	a.Logger = logger
 }
 
-func (a *ASTInterfaceAnalyzer) AnalyzeInterfaces(pkgs []*packages.Package) (map[string]*datamodel.Interface, error) {
+// SetProgressReporter implements analyzer.ProgressAware.
+func (a *ASTInterfaceAnalyzer) SetProgressReporter(reporter analyzer.ProgressReporter) {
+	a.Progress = reporter
+}
+
+func (a *ASTInterfaceAnalyzer) AnalyzeInterfaces(ctx context.Context, pkgs []*packages.Package) (map[string]*datamodel.Interface, error) {
+	// Packages are analyzed concurrently; their results are merged in package order
+	perPackage := make([][]*datamodel.Interface, len(pkgs))
+	var done atomic.Int64
+	err := analyzer.Parallel(ctx, a.Workers, len(pkgs), func(i int) {
+		perPackage[i] = a.packageInterfaces(pkgs[i])
+		analyzer.ReportProgress(a.Progress, analyzer.StageInterfaces, int(done.Add(1)), len(pkgs))
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	interfaces := make(map[string]*datamodel.Interface) // Key: packagePath + "." + interfaceName
+	for _, found := range perPackage {
+		for _, iface := range found {
+			// Store using a unique key (package path + name)
+			mapKey := iface.PackagePath + "." + iface.Name
+			// Check for duplicates before adding (could happen if file is listed multiple times?)
+			if _, exists := interfaces[mapKey]; !exists {
+				interfaces[mapKey] = iface
+			} else {
+				analyzer.LoggerOrDefault(a.Logger).Debug("Duplicate interface definition encountered; keeping first", "interface", mapKey)
+			}
+		}
+	}
+	return interfaces, nil
+}
 
-	for _, pkg := range pkgs {
-		// Ensure necessary components are available
-		if pkg.Types == nil || pkg.Fset == nil || len(pkg.Syntax) == 0 || pkg.TypesInfo == nil {
-			log.Printf("Skipping package %s for interface analysis: missing types, fileset, syntax trees, or types info.", pkg.ID)
-			continue // Skip packages without essential info
+// packageInterfaces extracts the interfaces declared in pkg, in source order.
+func (a *ASTInterfaceAnalyzer) packageInterfaces(pkg *packages.Package) []*datamodel.Interface {
+	// Ensure necessary components are available
+	if pkg.Types == nil || pkg.Fset == nil || len(pkg.Syntax) == 0 || pkg.TypesInfo == nil {
+		analyzer.LoggerOrDefault(a.Logger).Debug("Skipping package for interface analysis: missing types, fileset, syntax trees, or types info", "package", pkg.ID)
+		return nil // Skip packages without essential info
+	}
+	if !a.Filter.IncludePackage(pkg) {
+		return nil
+	}
+	var found []*datamodel.Interface
+	fset := pkg.Fset
+
+	for _, file := range pkg.Syntax {
+		if file == nil {
+			continue // Defensive check
 		}
-		fset := pkg.Fset
+		generated := ast.IsGenerated(file)
+		// fileName := fset.File(file.Pos()).Name() // Keep if needed for logging
 
-		for _, file := range pkg.Syntax {
-			if file == nil {
-				continue // Defensive check
+		ast.Inspect(file, func(n ast.Node) bool {
+			typeSpec, ok := n.(*ast.TypeSpec)
+			if !ok || typeSpec.Name == nil {
+				return true // Not a type spec or name is nil, continue
 			}
-			// fileName := fset.File(file.Pos()).Name() // Keep if needed for logging
 
-			ast.Inspect(file, func(n ast.Node) bool {
-				typeSpec, ok := n.(*ast.TypeSpec)
-				if !ok || typeSpec.Name == nil {
-					return true // Not a type spec or name is nil, continue
-				}
+			interfaceType, ok := typeSpec.Type.(*ast.InterfaceType)
+			if !ok {
+				return true // Not an interface type, continue
+			}
 
-				interfaceType, ok := typeSpec.Type.(*ast.InterfaceType)
-				if !ok {
-					return true // Not an interface type, continue
-				}
+			// Check if the definition exists in TypesInfo - helps filter out issues
+			// Use Defs for type definitions
+			obj := pkg.TypesInfo.Defs[typeSpec.Name]
+			if obj == nil {
+				// It might be a Use if the type is defined elsewhere but used here.
+				// We are interested in definitions found within the syntax tree.
+				analyzer.LoggerOrDefault(a.Logger).Warn("No type definition object found in TypesInfo.Defs, skipping", "type", typeSpec.Name.Name, "package", pkg.PkgPath)
+				return true // Skip if type info doesn't know about this type spec as a definition
+			}
+			// Further check if the object corresponds to an interface type
+			if _, ok := obj.Type().Underlying().(*types.Interface); !ok {
+				// This TypeSpec is not defining an interface according to type info
+				return true
+			}
 
-				// Check if the definition exists in TypesInfo - helps filter out issues
-				// Use Defs for type definitions
-				obj := pkg.TypesInfo.Defs[typeSpec.Name]
-				if obj == nil {
-					// It might be a Use if the type is defined elsewhere but used here.
-					// We are interested in definitions found within the syntax tree.
-					log.Printf("Warning: No type definition object found for %s in package %s using TypesInfo.Defs, skipping.", typeSpec.Name.Name, pkg.PkgPath)
-					return true // Skip if type info doesn't know about this type spec as a definition
-				}
-				// Further check if the object corresponds to an interface type
-				if _, ok := obj.Type().Underlying().(*types.Interface); !ok {
-					// This TypeSpec is not defining an interface according to type info
-					return true
-				}
+			if !a.Filter.IncludeSymbol(analyzer.Symbol{
+				Kind:        analyzer.KindInterface,
+				Name:        typeSpec.Name.Name,
+				PackagePath: pkg.PkgPath,
+				Exported:    typeSpec.Name.IsExported(),
+				Generated:   generated,
+			}) {
+				return true
+			}
 
-				defPos := fset.Position(typeSpec.Name.Pos())
-				iface := &datamodel.Interface{
-					Name:            typeSpec.Name.Name,
-					PackageName:     pkg.Name,
-					PackagePath:     pkg.PkgPath,
-					Location:        datamodel.NewLocation(defPos),
-					Methods:         []datamodel.Method{},         // Initialize explicitly
-					Embeds:          []string{},                   // Initialize explicitly
-					Implementations: []datamodel.Implementation{}, // Initialize explicitly
-				}
+			defPos := fset.Position(typeSpec.Name.Pos())
+			endPos := fset.Position(typeSpec.End())
+			iface := &datamodel.Interface{
+				Name:            typeSpec.Name.Name,
+				PackageName:     pkg.Name,
+				PackagePath:     pkg.PkgPath,
+				Location:        datamodel.NewSpan(defPos, endPos),
+				Methods:         []datamodel.Method{},         // Initialize explicitly
+				Embeds:          []string{},                   // Initialize explicitly
+				Implementations: []datamodel.Implementation{}, // Initialize explicitly
+			}
 
-				if typeSpec.Doc != nil {
-					iface.DocComment = strings.TrimSpace(typeSpec.Doc.Text())
-				}
+			iface.DocComment = utils.FormatDocComment(typeSpec.Doc, a.DocOptions)
+			if named, ok := obj.Type().(*types.Named); ok {
+				iface.TypeParams = utils.ExtractTypeParams(named.TypeParams(), pkg)
+			}
 
-				// Extract methods and embeds
-				if interfaceType.Methods != nil {
-					for _, field := range interfaceType.Methods.List {
-						if field == nil {
-							continue // Defensive check
+			// Extract methods and embeds
+			if interfaceType.Methods != nil {
+				for _, field := range interfaceType.Methods.List {
+					if field == nil {
+						continue // Defensive check
+					}
+
+					// Embedded interface
+					if len(field.Names) == 0 && field.Type != nil {
+						// Use helper for qualified names, ensure pkg is passed
+						embedName := utils.ExprToString(field.Type, pkg)
+						if embedName != "" && embedName != "?" { // Avoid adding invalid names
+							iface.Embeds = append(iface.Embeds, embedName)
 						}
+						continue
+					}
 
-						// Embedded interface
-						if len(field.Names) == 0 && field.Type != nil {
-							// Use helper for qualified names, ensure pkg is passed
-							embedName := utils.ExprToString(field.Type, pkg)
-							if embedName != "" && embedName != "?" { // Avoid adding invalid names
-								iface.Embeds = append(iface.Embeds, embedName)
-							}
+					// Regular method
+					if len(field.Names) > 0 && field.Names[0] != nil && field.Type != nil {
+						methodName := field.Names[0].Name
+						if !a.Filter.IncludeSymbol(analyzer.Symbol{
+							Kind:        analyzer.KindMethod,
+							Name:        methodName,
+							PackagePath: pkg.PkgPath,
+							Exported:    field.Names[0].IsExported(),
+							Generated:   generated,
+						}) {
 							continue
 						}
+						methodPos := fset.Position(field.Pos()) // Position of the method field itself
+						methodInfo := datamodel.Method{
+							Name:        methodName,
+							Location:    datamodel.NewSpan(methodPos, fset.Position(field.End())),
+							Parameters:  []datamodel.Parameter{}, // Initialize
+							ReturnTypes: []string{},              // Initialize
+							TypeParams:  iface.TypeParams,
+						}
 
-						// Regular method
-						if len(field.Names) > 0 && field.Names[0] != nil && field.Type != nil {
-							methodName := field.Names[0].Name
-							methodPos := fset.Position(field.Pos()) // Position of the method field itself
-							methodInfo := datamodel.Method{
-								Name:        methodName,
-								Location:    datamodel.NewLocation(methodPos),
-								Parameters:  []datamodel.Parameter{}, // Initialize
-								ReturnTypes: []string{},              // Initialize
-							}
-
-							if field.Doc != nil {
-								methodInfo.DocComment = strings.TrimSpace(field.Doc.Text())
-							}
-
-							if funcType, ok := field.Type.(*ast.FuncType); ok {
-								// Use utility functions for formatting and extraction
-								methodInfo.Signature = utils.FormatMethodSignature(methodName, funcType, pkg)
-								methodInfo.Parameters = utils.ExtractParameters(funcType, pkg)
-								methodInfo.ReturnTypes = utils.ExtractReturnTypes(funcType, pkg)
-							} else {
-								// Handle cases where method type is not FuncType (e.g., error in code)
-								log.Printf("Warning: Method '%s' in interface '%s' (pkg: %s) has non-function type %T", methodName, iface.Name, pkg.PkgPath, field.Type)
-								methodInfo.Signature = methodName + "(...) // Analysis Error: Non-FuncType" // Placeholder signature
-							}
-							iface.Methods = append(iface.Methods, methodInfo)
+						methodInfo.DocComment = utils.FormatDocComment(field.Doc, a.DocOptions)
+
+						if funcType, ok := field.Type.(*ast.FuncType); ok {
+							// Use utility functions for formatting and extraction
+							methodInfo.Signature = utils.FormatMethodSignature(methodName, funcType, pkg)
+							methodInfo.Parameters = utils.ExtractParameters(funcType, pkg)
+							methodInfo.ReturnTypes = utils.ExtractReturnTypes(funcType, pkg)
+						} else {
+							// Handle cases where method type is not FuncType (e.g., error in code)
+							analyzer.LoggerOrDefault(a.Logger).Warn("Interface method has non-function type", "method", methodName, "interface", iface.Name, "package", pkg.PkgPath, "type", fmt.Sprintf("%T", field.Type))
+							methodInfo.Signature = methodName + "(...) // Analysis Error: Non-FuncType" // Placeholder signature
 						}
+						iface.Methods = append(iface.Methods, methodInfo)
 					}
 				}
+			}
 
-				// Store using a unique key (package path + name)
-				mapKey := pkg.PkgPath + "." + iface.Name
-				// Check for duplicates before adding (could happen if file is listed multiple times?)
-				if _, exists := interfaces[mapKey]; !exists {
-					interfaces[mapKey] = iface
-				} else {
-					log.Printf("Warning: Duplicate interface definition encountered for %s. Keeping first.", mapKey)
-				}
-				// Don't return false here, allow inspection to continue for other types in the file.
-				// Returning true continues the walk; returning false prunes the walk at this node.
-				// We want to find all top-level type specs.
-				return true
-			})
-		}
+			if len(iface.Embeds) > 0 {
+				iface.MethodSet = utils.InterfaceMethods(obj.Type().Underlying().(*types.Interface), fset, pkg)
+			}
+
+			found = append(found, iface)
+			// Don't return false here, allow inspection to continue for other types in the file.
+			// Returning true continues the walk; returning false prunes the walk at this node.
+			// We want to find all top-level type specs.
+			return true
+		})
 	}
-	return interfaces, nil
+	return found
 }
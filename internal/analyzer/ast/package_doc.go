@@ -0,0 +1,87 @@
+// analyzer/ast/package_doc.go
+package ast
+
+import (
+	"context"
+	"go/ast"
+	"go/doc"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/namikmesic/go-mcp/internal/analyzer"
+	"github.com/namikmesic/go-mcp/internal/analyzer/utils"
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// PackageDocAnalyzer implements PackageAnalyzer by recording the package doc comment
+// and its synopsis (first sentence).
+type PackageDocAnalyzer struct {
+	// DocOptions controls how the package doc comment is rendered. The synopsis is
+	// always a single line.
+	DocOptions utils.DocCommentOptions
+}
+
+// Compile-time check to ensure PackageDocAnalyzer implements PackageAnalyzer.
+var _ analyzer.PackageAnalyzer = (*PackageDocAnalyzer)(nil)
+
+func NewPackageDocAnalyzer() *PackageDocAnalyzer {
+	return &PackageDocAnalyzer{
+		DocOptions: utils.DefaultDocCommentOptions(),
+	}
+}
+
+func (a *PackageDocAnalyzer) AnalyzePackage(ctx context.Context, env *analyzer.Env, pkg *packages.Package, result *datamodel.PackageAnalysis) error {
+	cg := packageDocComment(pkg)
+	if cg == nil {
+		return nil
+	}
+	result.Doc = utils.FormatDocComment(cg, a.DocOptions)
+	result.Synopsis = new(doc.Package).Synopsis(cg.Text())
+	return nil
+}
+
+// packageDocComment picks the package doc comment the way godoc readers expect: doc.go
+// first, then the first file (by name) that has one; test files only as a last resort.
+func packageDocComment(pkg *packages.Package) *ast.CommentGroup {
+	type candidate struct {
+		name string
+		doc  *ast.CommentGroup
+	}
+	var candidates []candidate
+	for _, file := range pkg.Syntax {
+		if file == nil || file.Doc == nil || pkg.Fset == nil || isFileHeader(file.Doc) {
+			continue
+		}
+		name := filepath.Base(pkg.Fset.Position(file.Package).Filename)
+		candidates = append(candidates, candidate{name: name, doc: file.Doc})
+	}
+	rank := func(name string) int {
+		switch {
+		case name == "doc.go":
+			return 0
+		case strings.HasSuffix(name, "_test.go"):
+			return 2
+		}
+		return 1
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		ri, rj := rank(candidates[i].name), rank(candidates[j].name)
+		if ri != rj {
+			return ri < rj
+		}
+		return candidates[i].name < candidates[j].name
+	})
+	if len(candidates) == 0 {
+		return nil
+	}
+	return candidates[0].doc
+}
+
+// isFileHeader reports whether cg is a "// dir/file.go" banner rather than documentation.
+func isFileHeader(cg *ast.CommentGroup) bool {
+	text := strings.TrimSpace(cg.Text())
+	return !strings.ContainsAny(text, " \n") && strings.HasSuffix(text, ".go")
+}
@@ -0,0 +1,165 @@
+// analyzer/ast/struct_analyzer.go
+package ast
+
+import (
+	"context"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/namikmesic/go-mcp/internal/analyzer"
+	"github.com/namikmesic/go-mcp/internal/analyzer/filter"
+	"github.com/namikmesic/go-mcp/internal/analyzer/utils"
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// StructAnalyzer implements PackageAnalyzer by recording package-level struct types
+// with their fields, tags and doc comments.
+type StructAnalyzer struct {
+	// DocOptions controls how struct and field doc comments are rendered.
+	DocOptions utils.DocCommentOptions
+	// Filter decides which structs and fields are reported.
+	Filter analyzer.FilterPolicy
+}
+
+// Compile-time check to ensure StructAnalyzer implements PackageAnalyzer.
+var _ analyzer.PackageAnalyzer = (*StructAnalyzer)(nil)
+
+func NewStructAnalyzer() *StructAnalyzer {
+	return &StructAnalyzer{
+		DocOptions: utils.DefaultDocCommentOptions(),
+		Filter:     filter.AllowAll(),
+	}
+}
+
+// SetFilterPolicy implements analyzer.Filterable.
+func (a *StructAnalyzer) SetFilterPolicy(policy analyzer.FilterPolicy) {
+	a.Filter = policy
+}
+
+func (a *StructAnalyzer) AnalyzePackage(ctx context.Context, env *analyzer.Env, pkg *packages.Package, result *datamodel.PackageAnalysis) error {
+	if pkg.TypesInfo == nil || !a.Filter.IncludePackage(pkg) {
+		return nil
+	}
+
+	for _, file := range pkg.Syntax {
+		if file == nil {
+			continue
+		}
+		generated := ast.IsGenerated(file)
+
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				typeSpec, ok := spec.(*ast.TypeSpec)
+				if !ok || typeSpec.Name == nil || typeSpec.Assign.IsValid() {
+					continue // Aliases do not declare a new struct type
+				}
+				structType, ok := typeSpec.Type.(*ast.StructType)
+				if !ok {
+					continue
+				}
+				obj, ok := pkg.TypesInfo.Defs[typeSpec.Name].(*types.TypeName)
+				if !ok {
+					continue
+				}
+				typesStruct, ok := obj.Type().Underlying().(*types.Struct)
+				if !ok {
+					continue
+				}
+				if !a.Filter.IncludeSymbol(analyzer.Symbol{
+					Kind:        analyzer.KindStruct,
+					Name:        typeSpec.Name.Name,
+					PackagePath: pkg.PkgPath,
+					Exported:    typeSpec.Name.IsExported(),
+					Generated:   generated,
+				}) {
+					continue
+				}
+
+				// An unparenthesized declaration attaches its doc comment to the GenDecl
+				doc := typeSpec.Doc
+				if doc == nil && !genDecl.Lparen.IsValid() {
+					doc = genDecl.Doc
+				}
+				s := datamodel.Struct{
+					Name:        typeSpec.Name.Name,
+					PackageName: pkg.Name,
+					PackagePath: pkg.PkgPath,
+					DocComment:  utils.FormatDocComment(doc, a.DocOptions),
+					Fields:      []datamodel.Field{},
+					Location:    env.Span(typeSpec.Name.Pos(), typeSpec),
+				}
+				if named, ok := obj.Type().(*types.Named); ok {
+					s.TypeParams = utils.ExtractTypeParams(named.TypeParams(), pkg)
+				}
+				s.Fields = a.fields(env, pkg, structType, typesStruct, generated)
+				result.Structs = append(result.Structs, s)
+			}
+		}
+	}
+	return nil
+}
+
+// fields converts the fields of a struct type. The syntax provides tags, comments and
+// positions; the type checker provides the field objects, in the same order.
+func (a *StructAnalyzer) fields(env *analyzer.Env, pkg *packages.Package, structType *ast.StructType, typesStruct *types.Struct, generated bool) []datamodel.Field {
+	fields := []datamodel.Field{}
+	index := 0
+	for _, field := range structType.Fields.List {
+		typeStr := utils.ExprToString(field.Type, pkg)
+		tag := ""
+		if field.Tag != nil {
+			if unquoted, err := strconv.Unquote(field.Tag.Value); err == nil {
+				tag = unquoted
+			}
+		}
+		doc := field.Doc
+		if doc == nil {
+			doc = field.Comment // Trailing line comment
+		}
+
+		// An embedded field declares one field; otherwise one per name
+		count := len(field.Names)
+		if count == 0 {
+			count = 1
+		}
+		for i := 0; i < count; i++ {
+			if index >= typesStruct.NumFields() {
+				return fields
+			}
+			v := typesStruct.Field(index)
+			index++
+			pos := field.Type.Pos()
+			if len(field.Names) > 0 {
+				pos = field.Names[i].Pos()
+			}
+			if !a.Filter.IncludeSymbol(analyzer.Symbol{
+				Kind:        analyzer.KindField,
+				Name:        v.Name(),
+				PackagePath: pkg.PkgPath,
+				Exported:    v.Exported(),
+				Generated:   generated,
+			}) {
+				continue
+			}
+			fields = append(fields, datamodel.Field{
+				Name:       v.Name(),
+				Type:       typeStr,
+				Tag:        tag,
+				Embedded:   v.Embedded(),
+				Exported:   v.Exported(),
+				DocComment: utils.FormatDocComment(doc, a.DocOptions),
+				Sync:       utils.SyncKind(v.Type()),
+				Location:   env.Location(pos),
+			})
+		}
+	}
+	return fields
+}
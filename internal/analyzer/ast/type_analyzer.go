@@ -0,0 +1,123 @@
+// analyzer/ast/type_analyzer.go
+package ast
+
+import (
+	"context"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/namikmesic/go-mcp/internal/analyzer"
+	"github.com/namikmesic/go-mcp/internal/analyzer/filter"
+	"github.com/namikmesic/go-mcp/internal/analyzer/utils"
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// TypeAnalyzer implements PackageAnalyzer by recording type aliases and defined types
+// other than structs and interfaces (which have their own analyzers), with their
+// underlying types.
+type TypeAnalyzer struct {
+	// DocOptions controls how type doc comments are rendered.
+	DocOptions utils.DocCommentOptions
+	// Filter decides which types are reported.
+	Filter analyzer.FilterPolicy
+}
+
+// Compile-time check to ensure TypeAnalyzer implements PackageAnalyzer.
+var _ analyzer.PackageAnalyzer = (*TypeAnalyzer)(nil)
+
+func NewTypeAnalyzer() *TypeAnalyzer {
+	return &TypeAnalyzer{
+		DocOptions: utils.DefaultDocCommentOptions(),
+		Filter:     filter.AllowAll(),
+	}
+}
+
+// SetFilterPolicy implements analyzer.Filterable.
+func (a *TypeAnalyzer) SetFilterPolicy(policy analyzer.FilterPolicy) {
+	a.Filter = policy
+}
+
+func (a *TypeAnalyzer) AnalyzePackage(ctx context.Context, env *analyzer.Env, pkg *packages.Package, result *datamodel.PackageAnalysis) error {
+	if pkg.TypesInfo == nil || !a.Filter.IncludePackage(pkg) {
+		return nil
+	}
+
+	for _, file := range pkg.Syntax {
+		if file == nil {
+			continue
+		}
+		generated := ast.IsGenerated(file)
+
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				typeSpec, ok := spec.(*ast.TypeSpec)
+				if !ok || typeSpec.Name == nil {
+					continue
+				}
+				obj, ok := pkg.TypesInfo.Defs[typeSpec.Name].(*types.TypeName)
+				if !ok {
+					continue
+				}
+
+				t := datamodel.NamedType{
+					Name:        typeSpec.Name.Name,
+					PackageName: pkg.Name,
+					PackagePath: pkg.PkgPath,
+					Kind:        datamodel.TypeDefined,
+					Underlying:  utils.TypeString(obj.Type().Underlying(), pkg),
+					Exported:    typeSpec.Name.IsExported(),
+					Location:    env.Span(typeSpec.Name.Pos(), typeSpec),
+				}
+				if obj.IsAlias() {
+					t.Kind = datamodel.TypeAlias
+					t.Target = qualifiedTypeName(types.Unalias(obj.Type()))
+					if alias, ok := obj.Type().(*types.Alias); ok {
+						t.TypeParams = utils.ExtractTypeParams(alias.TypeParams(), pkg)
+					}
+				} else {
+					if named, ok := obj.Type().(*types.Named); ok {
+						t.TypeParams = utils.ExtractTypeParams(named.TypeParams(), pkg)
+					}
+					switch obj.Type().Underlying().(type) {
+					case *types.Struct, *types.Interface:
+						continue // Reported as Structs and Interfaces
+					}
+				}
+				if !a.Filter.IncludeSymbol(analyzer.Symbol{
+					Kind:        analyzer.KindType,
+					Name:        t.Name,
+					PackagePath: pkg.PkgPath,
+					Exported:    t.Exported,
+					Generated:   generated,
+				}) {
+					continue
+				}
+
+				// An unparenthesized declaration attaches its doc comment to the GenDecl
+				doc := typeSpec.Doc
+				if doc == nil && !genDecl.Lparen.IsValid() {
+					doc = genDecl.Doc
+				}
+				t.DocComment = utils.FormatDocComment(doc, a.DocOptions)
+				result.Types = append(result.Types, t)
+			}
+		}
+	}
+	return nil
+}
+
+// qualifiedTypeName names t the way interface and implementation keys do
+// (packagePath + "." + name) for named types, and spells out other types in full.
+func qualifiedTypeName(t types.Type) string {
+	if named, ok := t.(*types.Named); ok && named.Obj().Pkg() != nil {
+		return named.Obj().Pkg().Path() + "." + named.Obj().Name()
+	}
+	return types.TypeString(t, nil)
+}
@@ -0,0 +1,126 @@
+// analyzer/ast/unsafe_usage.go
+package ast
+
+import (
+	"context"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/namikmesic/go-mcp/internal/analyzer"
+	"github.com/namikmesic/go-mcp/internal/analyzer/filter"
+	"github.com/namikmesic/go-mcp/internal/analyzer/utils"
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// UnsafeAnalyzer implements PackageAnalyzer by recording every reference to a member of
+// package unsafe, flagging pointer arithmetic.
+type UnsafeAnalyzer struct {
+	// Filter decides which packages are analyzed.
+	Filter analyzer.FilterPolicy
+}
+
+// Compile-time checks to ensure UnsafeAnalyzer implements the analyzer interfaces.
+var (
+	_ analyzer.PackageAnalyzer = (*UnsafeAnalyzer)(nil)
+	_ analyzer.Filterable      = (*UnsafeAnalyzer)(nil)
+)
+
+func NewUnsafeAnalyzer() *UnsafeAnalyzer {
+	return &UnsafeAnalyzer{
+		Filter: filter.AllowAll(),
+	}
+}
+
+// SetFilterPolicy implements analyzer.Filterable.
+func (a *UnsafeAnalyzer) SetFilterPolicy(policy analyzer.FilterPolicy) {
+	a.Filter = policy
+}
+
+func (a *UnsafeAnalyzer) AnalyzePackage(ctx context.Context, env *analyzer.Env, pkg *packages.Package, result *datamodel.PackageAnalysis) error {
+	if pkg.TypesInfo == nil || pkg.Types == nil || !a.Filter.IncludePackage(pkg) || !importsUnsafe(pkg.Types) {
+		return nil
+	}
+
+	inits := 0 // Numbered like FunctionAnalyzer does
+	for _, file := range pkg.Syntax {
+		if file == nil {
+			continue
+		}
+		for _, decl := range file.Decls {
+			var funcID string
+			if funcDecl, ok := decl.(*ast.FuncDecl); ok && funcDecl.Name != nil {
+				if funcDecl.Recv == nil && funcDecl.Name.Name == "init" {
+					inits++
+					funcID = utils.InitFuncID(pkg.PkgPath, inits)
+				} else if obj, ok := pkg.TypesInfo.Defs[funcDecl.Name].(*types.Func); ok {
+					funcID = utils.FuncID(obj)
+				}
+			}
+			// Conversions are visited before the selector they call
+			conversions := make(map[*ast.SelectorExpr]*ast.CallExpr)
+			ast.Inspect(decl, func(n ast.Node) bool {
+				switch n := n.(type) {
+				case *ast.CallExpr:
+					if sel, ok := ast.Unparen(n.Fun).(*ast.SelectorExpr); ok {
+						conversions[sel] = n
+					}
+				case *ast.SelectorExpr:
+					obj := pkg.TypesInfo.Uses[n.Sel]
+					if obj == nil || obj.Pkg() == nil || obj.Pkg().Path() != "unsafe" {
+						return true
+					}
+					use := datamodel.UnsafeUse{
+						Name:       obj.Name(),
+						FunctionID: funcID,
+						Arithmetic: obj.Name() == "Add",
+						Location:   env.Location(n.Pos()),
+					}
+					if call := conversions[n]; call != nil && obj.Name() == "Pointer" && len(call.Args) == 1 {
+						if t := pkg.TypesInfo.TypeOf(call.Args[0]); t != nil {
+							use.From = types.TypeString(t, nil)
+							use.Arithmetic = isUintptr(t) && computed(call.Args[0])
+						}
+					}
+					result.Unsafe = append(result.Unsafe, use)
+					return false
+				}
+				return true
+			})
+		}
+	}
+	return nil
+}
+
+// importsUnsafe reports whether pkg imports package unsafe.
+func importsUnsafe(pkg *types.Package) bool {
+	for _, imp := range pkg.Imports() {
+		if imp.Path() == "unsafe" {
+			return true
+		}
+	}
+	return false
+}
+
+func isUintptr(t types.Type) bool {
+	basic, ok := t.Underlying().(*types.Basic)
+	return ok && basic.Kind() == types.Uintptr
+}
+
+// computed reports whether expr computes a value with arithmetic operators, rather than
+// converting or loading one.
+func computed(expr ast.Expr) bool {
+	found := false
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if b, ok := n.(*ast.BinaryExpr); ok {
+			switch b.Op {
+			case token.ADD, token.SUB, token.MUL, token.QUO, token.REM, token.AND, token.OR, token.XOR, token.AND_NOT, token.SHL, token.SHR:
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
@@ -0,0 +1,123 @@
+// analyzer/ast/value_analyzer.go
+package ast
+
+import (
+	"context"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/namikmesic/go-mcp/internal/analyzer"
+	"github.com/namikmesic/go-mcp/internal/analyzer/filter"
+	"github.com/namikmesic/go-mcp/internal/analyzer/utils"
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// maxValueLength bounds the length of recorded initializer expressions, which can be
+// arbitrarily large composite literals.
+const maxValueLength = 200
+
+// ValueAnalyzer implements PackageAnalyzer by recording package-level constants and
+// variables, e.g. to find where a sentinel error is defined.
+type ValueAnalyzer struct {
+	// DocOptions controls how doc comments are rendered.
+	DocOptions utils.DocCommentOptions
+	// Filter decides which constants and variables are reported.
+	Filter analyzer.FilterPolicy
+}
+
+// Compile-time check to ensure ValueAnalyzer implements PackageAnalyzer.
+var _ analyzer.PackageAnalyzer = (*ValueAnalyzer)(nil)
+
+func NewValueAnalyzer() *ValueAnalyzer {
+	return &ValueAnalyzer{
+		DocOptions: utils.DefaultDocCommentOptions(),
+		Filter:     filter.AllowAll(),
+	}
+}
+
+// SetFilterPolicy implements analyzer.Filterable.
+func (a *ValueAnalyzer) SetFilterPolicy(policy analyzer.FilterPolicy) {
+	a.Filter = policy
+}
+
+func (a *ValueAnalyzer) AnalyzePackage(ctx context.Context, env *analyzer.Env, pkg *packages.Package, result *datamodel.PackageAnalysis) error {
+	if pkg.TypesInfo == nil || !a.Filter.IncludePackage(pkg) {
+		return nil
+	}
+
+	for _, file := range pkg.Syntax {
+		if file == nil {
+			continue
+		}
+		generated := ast.IsGenerated(file)
+
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || (genDecl.Tok != token.CONST && genDecl.Tok != token.VAR) {
+				continue
+			}
+			kind := analyzer.KindVar
+			if genDecl.Tok == token.CONST {
+				kind = analyzer.KindConst
+			}
+			for _, spec := range genDecl.Specs {
+				valueSpec, ok := spec.(*ast.ValueSpec)
+				if !ok {
+					continue
+				}
+				// An unparenthesized declaration attaches its doc comment to the GenDecl
+				doc := valueSpec.Doc
+				if doc == nil && !genDecl.Lparen.IsValid() {
+					doc = genDecl.Doc
+				}
+				if doc == nil {
+					doc = valueSpec.Comment // Trailing line comment
+				}
+
+				for i, ident := range valueSpec.Names {
+					if ident.Name == "_" {
+						continue // Blank declarations, e.g. compile-time interface checks
+					}
+					obj := pkg.TypesInfo.Defs[ident]
+					if obj == nil {
+						continue
+					}
+					if !a.Filter.IncludeSymbol(analyzer.Symbol{
+						Kind:        kind,
+						Name:        ident.Name,
+						PackagePath: pkg.PkgPath,
+						Exported:    ident.IsExported(),
+						Generated:   generated,
+					}) {
+						continue
+					}
+
+					v := datamodel.Value{
+						Name:       ident.Name,
+						Type:       utils.TypeString(obj.Type(), pkg),
+						DocComment: utils.FormatDocComment(doc, a.DocOptions),
+						Exported:   ident.IsExported(),
+						Location:   env.Location(ident.Pos()),
+					}
+					switch {
+					case len(valueSpec.Values) == len(valueSpec.Names):
+						v.Value = utils.ExprSource(pkg.Fset, valueSpec.Values[i], maxValueLength)
+					case len(valueSpec.Values) == 1:
+						// Several names initialized from one multi-value expression
+						v.Value = utils.ExprSource(pkg.Fset, valueSpec.Values[0], maxValueLength)
+					}
+					if c, ok := obj.(*types.Const); ok {
+						v.Constant = c.Val().ExactString()
+						result.Constants = append(result.Constants, v)
+					} else {
+						result.Variables = append(result.Variables, v)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
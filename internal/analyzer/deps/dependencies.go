@@ -0,0 +1,174 @@
+// analyzer/deps/dependencies.go
+package deps
+
+import (
+	"context"
+	"go/ast"
+	"log"
+	"log/slog"
+	"sort"
+	"sync"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/namikmesic/go-mcp/internal/analyzer"
+	"github.com/namikmesic/go-mcp/internal/analyzer/filter"
+	"github.com/namikmesic/go-mcp/internal/depcache"
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// DependencyAnalyzer extracts the exported interfaces of packages imported from
+// versioned dependency modules into ProjectAnalysis.Dependencies. Results are
+// cached per module@version, so projects sharing dependencies only process each
+// dependency package once. It must be registered as both a PackageAnalyzer and a
+// ProjectAnalyzer.
+type DependencyAnalyzer struct {
+	// Interfaces extracts the interfaces of a dependency package.
+	Interfaces analyzer.InterfaceAnalyzer
+	// Cache stores extracted results; nil disables caching.
+	Cache *depcache.Cache
+	// SkipVendored ignores dependency packages resolved from a vendor directory.
+	SkipVendored bool
+	// Logger receives diagnostics; nil logs to slog.Default().
+	Logger *slog.Logger
+
+	mu   sync.Mutex
+	deps map[string]*packages.Package // Imported dependency packages by path
+}
+
+// Compile-time checks to ensure DependencyAnalyzer implements both analyzer passes.
+var (
+	_ analyzer.PackageAnalyzer = (*DependencyAnalyzer)(nil)
+	_ analyzer.ProjectAnalyzer = (*DependencyAnalyzer)(nil)
+	_ analyzer.LoggerAware     = (*DependencyAnalyzer)(nil)
+)
+
+func NewDependencyAnalyzer(interfaces analyzer.InterfaceAnalyzer, cache *depcache.Cache) *DependencyAnalyzer {
+	if interfaces == nil {
+		log.Panicln("Error: Cannot create DependencyAnalyzer with nil interface analyzer.")
+	}
+	return &DependencyAnalyzer{Interfaces: interfaces, Cache: cache}
+}
+
+// SetLogger implements analyzer.LoggerAware.
+func (d *DependencyAnalyzer) SetLogger(logger *slog.Logger) {
+	d.Logger = logger
+}
+
+// AnalyzePackage records the packages pkg imports from immutable dependency modules.
+func (d *DependencyAnalyzer) AnalyzePackage(ctx context.Context, env *analyzer.Env, pkg *packages.Package, result *datamodel.PackageAnalysis) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.deps == nil {
+		d.deps = make(map[string]*packages.Package)
+	}
+	for path, imp := range pkg.Imports {
+		if isVersionedDependency(imp) && !(d.SkipVendored && filter.IsVendored(imp)) {
+			d.deps[path] = imp
+		}
+	}
+	return nil
+}
+
+// AnalyzeProject extracts (or loads from the cache) the recorded dependency packages
+// and resets the recorded state.
+func (d *DependencyAnalyzer) AnalyzeProject(ctx context.Context, env *analyzer.Env, analysis *datamodel.ProjectAnalysis) error {
+	d.mu.Lock()
+	deps := d.deps
+	d.deps = nil
+	d.mu.Unlock()
+	if len(deps) == 0 {
+		return nil
+	}
+
+	paths := make([]string, 0, len(deps))
+	for path := range deps {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	logger := analyzer.LoggerOrDefault(d.Logger)
+	cached := 0
+	for _, path := range paths {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		pkg := deps[path]
+		mod := moduleOf(pkg)
+		if d.Cache != nil {
+			entry, ok, err := d.Cache.Load(mod.Path, mod.Version, path)
+			if err != nil {
+				logger.Warn("Ignoring dependency cache entry", "package", path, "error", err)
+			} else if ok {
+				analysis.Dependencies = append(analysis.Dependencies, entry)
+				cached++
+				continue
+			}
+		}
+
+		entry, err := d.extract(ctx, env, pkg, mod)
+		if err != nil {
+			logger.Warn("Failed to analyze dependency package", "package", path, "error", err)
+			continue
+		}
+		analysis.Dependencies = append(analysis.Dependencies, entry)
+		if d.Cache != nil {
+			if err := d.Cache.Store(entry); err != nil {
+				logger.Warn("Failed to cache dependency package", "package", path, "error", err)
+			}
+		}
+	}
+	logger.Info("Analyzed dependency packages", "count", len(paths), "cached", cached)
+	return nil
+}
+
+// extract runs the interface analyzer on a single dependency package.
+func (d *DependencyAnalyzer) extract(ctx context.Context, env *analyzer.Env, pkg *packages.Package, mod *packages.Module) (*datamodel.DependencyPackage, error) {
+	interfaces, err := d.Interfaces.AnalyzeInterfaces(ctx, []*packages.Package{pkg})
+	if err != nil {
+		return nil, err
+	}
+	entry := &datamodel.DependencyPackage{
+		Name:       pkg.Name,
+		Path:       pkg.PkgPath,
+		Module:     mod.Path,
+		Version:    mod.Version,
+		Vendored:   filter.IsVendored(pkg),
+		Interfaces: []datamodel.Interface{},
+	}
+	for _, iface := range interfaces {
+		if !ast.IsExported(iface.Name) {
+			continue // Only the exported API can be implemented or called from the project
+		}
+		iface.Location.Filename = env.RelPath(iface.Location.Filename)
+		for i := range iface.Methods {
+			iface.Methods[i].Location.Filename = env.RelPath(iface.Methods[i].Location.Filename)
+		}
+		for i := range iface.MethodSet {
+			iface.MethodSet[i].Location.Filename = env.RelPath(iface.MethodSet[i].Location.Filename)
+		}
+		iface.UnderlyingType = nil // Type-checker state is not part of the cached result
+		entry.Interfaces = append(entry.Interfaces, *iface)
+	}
+	sort.Slice(entry.Interfaces, func(i, j int) bool { return entry.Interfaces[i].Name < entry.Interfaces[j].Name })
+	return entry, nil
+}
+
+// isVersionedDependency reports whether pkg belongs to a module whose contents are
+// fixed by its version: not the main module, not the standard library and not
+// replaced by a local directory.
+func isVersionedDependency(pkg *packages.Package) bool {
+	if pkg.Module == nil || pkg.Module.Main || pkg.Types == nil {
+		return false
+	}
+	mod := moduleOf(pkg)
+	return mod.Version != ""
+}
+
+// moduleOf returns the module providing pkg's files, following replacements.
+func moduleOf(pkg *packages.Package) *packages.Module {
+	if pkg.Module.Replace != nil {
+		return pkg.Module.Replace
+	}
+	return pkg.Module
+}
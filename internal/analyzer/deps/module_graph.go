@@ -0,0 +1,207 @@
+// analyzer/deps/module_graph.go
+package deps
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"sort"
+	"sync"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/namikmesic/go-mcp/internal/analyzer"
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// ModuleGraphAnalyzer builds ProjectAnalysis.ModuleGraph: the modules of the analyzed
+// packages, the modules their import closure spans, and the requirements declared in
+// the main modules' go.mod files. Packages without module information (GOPATH mode,
+// standard library) are ignored. It must be registered as both a PackageAnalyzer and
+// a ProjectAnalyzer.
+type ModuleGraphAnalyzer struct {
+	// Logger receives diagnostics; nil logs to slog.Default().
+	Logger *slog.Logger
+
+	mu        sync.Mutex
+	modules   map[string]*packages.Module // Module path -> module
+	visited   map[string]bool             // IDs of packages whose imports were recorded
+	imports   map[string]map[string]bool  // Module path -> imported module paths
+	importers map[string]map[string]bool  // Module path -> analyzed packages importing it
+}
+
+// Compile-time checks to ensure ModuleGraphAnalyzer implements both analyzer passes.
+var (
+	_ analyzer.PackageAnalyzer = (*ModuleGraphAnalyzer)(nil)
+	_ analyzer.ProjectAnalyzer = (*ModuleGraphAnalyzer)(nil)
+	_ analyzer.LoggerAware     = (*ModuleGraphAnalyzer)(nil)
+)
+
+func NewModuleGraphAnalyzer() *ModuleGraphAnalyzer {
+	return &ModuleGraphAnalyzer{}
+}
+
+// SetLogger implements analyzer.LoggerAware.
+func (m *ModuleGraphAnalyzer) SetLogger(logger *slog.Logger) {
+	m.Logger = logger
+}
+
+func (m *ModuleGraphAnalyzer) reset() {
+	m.modules = make(map[string]*packages.Module)
+	m.visited = make(map[string]bool)
+	m.imports = make(map[string]map[string]bool)
+	m.importers = make(map[string]map[string]bool)
+}
+
+// AnalyzePackage records the modules pkg imports directly and the module dependencies
+// of its import closure not recorded for an earlier package.
+func (m *ModuleGraphAnalyzer) AnalyzePackage(ctx context.Context, env *analyzer.Env, pkg *packages.Package, result *datamodel.PackageAnalysis) error {
+	if pkg.Module == nil {
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.modules == nil {
+		m.reset()
+	}
+	for _, imp := range pkg.Imports {
+		if imp.Module != nil && imp.Module.Path != pkg.Module.Path {
+			addEdge(m.importers, imp.Module.Path, pkg.PkgPath)
+		}
+	}
+	stack := []*packages.Package{pkg}
+	for len(stack) > 0 {
+		p := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if m.visited[p.ID] {
+			continue
+		}
+		m.visited[p.ID] = true
+		if p.Module != nil {
+			m.modules[p.Module.Path] = p.Module
+		}
+		for _, imp := range p.Imports {
+			if p.Module != nil && imp.Module != nil && imp.Module.Path != p.Module.Path {
+				addEdge(m.imports, p.Module.Path, imp.Module.Path)
+			}
+			stack = append(stack, imp)
+		}
+	}
+	return nil
+}
+
+// AnalyzeProject assembles the module graph from the recorded modules and the main
+// modules' go.mod files, and resets the recorded state.
+func (m *ModuleGraphAnalyzer) AnalyzeProject(ctx context.Context, env *analyzer.Env, analysis *datamodel.ProjectAnalysis) error {
+	m.mu.Lock()
+	modules, imports, importers := m.modules, m.imports, m.importers
+	m.reset()
+	m.mu.Unlock()
+	if len(modules) == 0 {
+		return nil
+	}
+
+	nodes := make(map[string]*datamodel.ModuleNode)
+	indirect := make(map[string]bool) // Cleared by any direct requirement
+	for path, mod := range modules {
+		node := &datamodel.ModuleNode{
+			Path:      path,
+			Version:   mod.Version,
+			Main:      mod.Main,
+			GoVersion: mod.GoVersion,
+			Imports:   sortedKeys(imports[path]),
+			Importers: sortedKeys(importers[path]),
+		}
+		if mod.Replace != nil {
+			node.Replace = replacement(mod.Replace.Path, mod.Replace.Version)
+		}
+		nodes[path] = node
+	}
+	for path, mod := range modules {
+		if !mod.Main || mod.GoMod == "" {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		file, err := parseGoMod(mod.GoMod)
+		if err != nil {
+			analyzer.LoggerOrDefault(m.Logger).Warn("Failed to parse go.mod; module requirements are incomplete", "module", path, "error", err)
+			continue
+		}
+		for _, req := range file.Require {
+			node := nodes[req.Mod.Path]
+			if node == nil {
+				// Required but none of its packages are imported
+				node = &datamodel.ModuleNode{Path: req.Mod.Path, Version: req.Mod.Version}
+				for _, rep := range file.Replace {
+					if rep.Old.Path == req.Mod.Path && (rep.Old.Version == "" || rep.Old.Version == req.Mod.Version) {
+						node.Replace = replacement(rep.New.Path, rep.New.Version)
+					}
+				}
+				nodes[req.Mod.Path] = node
+				indirect[req.Mod.Path] = true
+			} else if len(node.RequiredBy) == 0 {
+				indirect[req.Mod.Path] = true
+			}
+			node.RequiredBy = append(node.RequiredBy, path)
+			if !req.Indirect {
+				indirect[req.Mod.Path] = false
+			}
+		}
+	}
+
+	graph := &datamodel.ModuleGraph{Modules: make([]datamodel.ModuleNode, 0, len(nodes))}
+	for path, node := range nodes {
+		node.Indirect = indirect[path]
+		sort.Strings(node.RequiredBy)
+		graph.Modules = append(graph.Modules, *node)
+	}
+	sort.Slice(graph.Modules, func(i, j int) bool {
+		a, b := graph.Modules[i], graph.Modules[j]
+		if a.Main != b.Main {
+			return a.Main
+		}
+		return a.Path < b.Path
+	})
+	analysis.ModuleGraph = graph
+	return nil
+}
+
+// parseGoMod reads and parses the go.mod file at path.
+func parseGoMod(path string) (*modfile.File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return modfile.Parse(path, data, nil)
+}
+
+// replacement formats the target of a replace directive: "path@version", or the
+// local directory for directory replacements.
+func replacement(path, version string) string {
+	if version == "" {
+		return path
+	}
+	return path + "@" + version
+}
+
+func addEdge(edges map[string]map[string]bool, from, to string) {
+	if edges[from] == nil {
+		edges[from] = make(map[string]bool)
+	}
+	edges[from][to] = true
+}
+
+func sortedKeys(set map[string]bool) []string {
+	if len(set) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
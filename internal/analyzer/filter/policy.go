@@ -0,0 +1,187 @@
+// analyzer/filter/policy.go
+package filter
+
+import (
+	"go/ast"
+	"go/token"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/namikmesic/go-mcp/internal/analyzer"
+)
+
+// RulePolicy implements analyzer.FilterPolicy using simple include/exclude rules.
+// The zero value includes everything.
+type RulePolicy struct {
+	// IncludeKinds, when non-empty, restricts results to these symbol kinds.
+	IncludeKinds []analyzer.SymbolKind
+	// ExcludeKinds removes these symbol kinds from results.
+	ExcludeKinds []analyzer.SymbolKind
+	// ExportedOnly drops unexported symbols.
+	ExportedOnly bool
+	// ExcludeGenerated drops symbols declared in generated files.
+	ExcludeGenerated bool
+	// IncludePackages, when non-empty, restricts results to packages matching one of
+	// these import path patterns (see MatchPattern).
+	IncludePackages []string
+	// ExcludePackages removes packages matching any of these import path patterns.
+	ExcludePackages []string
+	// ModulePath, when set, also matches the package patterns against import paths
+	// relative to this module, as architecture rules do. The analysis service sets it
+	// to the analyzed module.
+	ModulePath string
+	// ExcludeVendored removes packages resolved from a vendor directory.
+	ExcludeVendored bool
+}
+
+// Compile-time check to ensure RulePolicy implements FilterPolicy.
+var _ analyzer.FilterPolicy = (*RulePolicy)(nil)
+
+// AllowAll returns a policy that includes every package and symbol.
+func AllowAll() *RulePolicy {
+	return &RulePolicy{}
+}
+
+func (p *RulePolicy) IncludePackage(pkg *packages.Package) bool {
+	if pkg == nil {
+		return false
+	}
+	if p.ExcludeVendored && IsVendored(pkg) {
+		return false
+	}
+	return p.includePackagePath(pkg.PkgPath)
+}
+
+func (p *RulePolicy) IncludeSymbol(sym analyzer.Symbol) bool {
+	if len(p.IncludeKinds) > 0 && !containsKind(p.IncludeKinds, sym.Kind) {
+		return false
+	}
+	if containsKind(p.ExcludeKinds, sym.Kind) {
+		return false
+	}
+	if p.ExportedOnly && !sym.Exported {
+		return false
+	}
+	if p.ExcludeGenerated && sym.Generated {
+		return false
+	}
+	return p.includePackagePath(sym.PackagePath)
+}
+
+func (p *RulePolicy) includePackagePath(path string) bool {
+	if len(p.IncludePackages) > 0 && !MatchAnyPattern(p.IncludePackages, path, p.ModulePath) {
+		return false
+	}
+	return !MatchAnyPattern(p.ExcludePackages, path, p.ModulePath)
+}
+
+func containsKind(kinds []analyzer.SymbolKind, kind analyzer.SymbolKind) bool {
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchAnyPattern reports whether path matches at least one of the patterns, in full or
+// relative to modulePath (see MatchModulePattern).
+func MatchAnyPattern(patterns []string, path, modulePath string) bool {
+	for _, pattern := range patterns {
+		if MatchModulePattern(pattern, path, modulePath) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchModulePattern reports whether the import path matches pattern either in full or,
+// for packages of the module modulePath, relative to the module: "internal/..." matches
+// "example.com/app/internal/db" in module example.com/app. An empty modulePath only
+// matches full paths.
+func MatchModulePattern(pattern, path, modulePath string) bool {
+	if MatchPattern(pattern, path) {
+		return true
+	}
+	rel, ok := strings.CutPrefix(path, modulePath+"/")
+	return modulePath != "" && ok && MatchPattern(pattern, rel)
+}
+
+// MatchPattern reports whether the import path matches pattern. Patterns use the go
+// tool syntax, where "..." matches any string (including slashes), extended with globs:
+// "**" also matches any string and "*" matches within one path element. As with the
+// go tool, "x/..." also matches "x" itself. A "re:" prefix makes the rest of the
+// pattern a regular expression matched against the whole path. Invalid patterns
+// never match; use CompilePattern to validate them.
+func MatchPattern(pattern, path string) bool {
+	if !strings.Contains(pattern, "...") && !strings.Contains(pattern, "*") && !strings.HasPrefix(pattern, RegexpPrefix) {
+		return pattern == path
+	}
+	re, err := CompilePattern(pattern)
+	return err == nil && re.MatchString(path)
+}
+
+// RegexpPrefix marks a pattern as a regular expression.
+const RegexpPrefix = "re:"
+
+var compiledPatterns sync.Map // pattern -> *regexp.Regexp
+
+// CompilePattern converts a MatchPattern pattern into an anchored regular expression.
+func CompilePattern(pattern string) (*regexp.Regexp, error) {
+	if re, ok := compiledPatterns.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	var expr string
+	if rest, ok := strings.CutPrefix(pattern, RegexpPrefix); ok {
+		expr = "^(?:" + rest + ")$"
+	} else {
+		re := regexp.QuoteMeta(strings.ReplaceAll(pattern, "...", "**"))
+		// Special case: "foo/..." matches "foo" too
+		if strings.HasSuffix(re, `/\*\*`) {
+			re = strings.TrimSuffix(re, `/\*\*`) + `(/.*)?`
+		}
+		re = strings.ReplaceAll(re, `\*\*`, `.*`)
+		re = strings.ReplaceAll(re, `\*`, `[^/]*`)
+		expr = "^" + re + "$"
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	compiledPatterns.Store(pattern, re)
+	return re, nil
+}
+
+// IsGenerated reports whether pos lies in a file of pkg marked as generated.
+func IsGenerated(pkg *packages.Package, pos token.Pos) bool {
+	if pkg == nil || !pos.IsValid() {
+		return false
+	}
+	for _, file := range pkg.Syntax {
+		if file != nil && file.FileStart <= pos && pos <= file.FileEnd {
+			return ast.IsGenerated(file)
+		}
+	}
+	return false
+}
+
+// IsVendored reports whether pkg was resolved from a vendor directory: in GOPATH mode its
+// import path has a vendor element, in module mode its files lie below a vendor
+// directory of a module other than its own.
+func IsVendored(pkg *packages.Package) bool {
+	if pkg == nil {
+		return false
+	}
+	if strings.HasPrefix(pkg.PkgPath, "vendor/") || strings.Contains(pkg.PkgPath, "/vendor/") {
+		return true
+	}
+	if pkg.Module == nil || pkg.Module.Main || len(pkg.GoFiles) == 0 {
+		return false
+	}
+	dir := filepath.ToSlash(filepath.Dir(pkg.GoFiles[0]))
+	return strings.Contains(dir, "/vendor/"+pkg.PkgPath) && (pkg.Module.Dir == "" || !strings.HasPrefix(dir, filepath.ToSlash(pkg.Module.Dir)+"/"))
+}
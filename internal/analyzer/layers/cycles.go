@@ -0,0 +1,134 @@
+// analyzer/layers/cycles.go
+package layers
+
+import (
+	"context"
+	"sort"
+
+	"github.com/namikmesic/go-mcp/internal/analyzer"
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// CycleDetector implements analyzer.ProjectAnalyzer by reporting import cycles between
+// analyzed packages in ProjectAnalysis.Findings.ImportCycles. The go tool drops the
+// offending import from the loaded package graph, so cycles are found from
+// PackageAnalysis.ImportEdges, which ImportEdgeAnalyzer must have recorded.
+// Cycles closed by imports of _test.go files ("import cycle not allowed in test") are
+// reported as TestOnly. External test packages (package p_test) cannot form cycles.
+type CycleDetector struct{}
+
+// Compile-time check to ensure CycleDetector implements ProjectAnalyzer.
+var _ analyzer.ProjectAnalyzer = (*CycleDetector)(nil)
+
+func NewCycleDetector() *CycleDetector {
+	return &CycleDetector{}
+}
+
+// AnalyzeProject reports the cycles among the recorded import edges.
+func (d *CycleDetector) AnalyzeProject(ctx context.Context, env *analyzer.Env, analysis *datamodel.ProjectAnalysis) error {
+	// Only imports between analyzed packages can close a cycle
+	all := make(map[string]map[string]bool)
+	nonTest := make(map[string]map[string]bool)
+	for _, pkg := range analysis.Packages {
+		if pkg != nil {
+			all[pkg.Path] = make(map[string]bool)
+			nonTest[pkg.Path] = make(map[string]bool)
+		}
+	}
+	imports := make(map[[2]string]datamodel.ImportEdge)
+	for _, pkg := range analysis.Packages {
+		if pkg == nil {
+			continue
+		}
+		for _, edge := range pkg.ImportEdges {
+			if _, analyzed := all[edge.Imported]; !analyzed || edge.Imported == edge.Importer {
+				continue
+			}
+			imports[[2]string{edge.Importer, edge.Imported}] = edge
+			all[edge.Importer][edge.Imported] = true
+			if !edge.TestOnly {
+				nonTest[edge.Importer][edge.Imported] = true
+			}
+		}
+	}
+
+	inNonTestCycle := make(map[string]bool)
+	for _, scc := range stronglyConnected(nonTest) {
+		if len(scc) > 1 {
+			for _, path := range scc {
+				inNonTestCycle[path] = true
+			}
+		}
+	}
+	var cycles []datamodel.ImportCycle
+	for _, scc := range stronglyConnected(all) {
+		if len(scc) < 2 {
+			continue
+		}
+		sort.Strings(scc)
+		cycle := datamodel.ImportCycle{Packages: scc, TestOnly: true}
+		graph, start := all, scc[0]
+		for _, path := range scc {
+			if inNonTestCycle[path] {
+				// Show a cycle that breaks the build, not only the tests
+				cycle.TestOnly = false
+				graph, start = nonTest, path
+				break
+			}
+		}
+		for _, step := range shortestCycle(graph, start) {
+			imp := imports[step]
+			cycle.Imports = append(cycle.Imports, datamodel.CycleImport{
+				From:     step[0],
+				To:       step[1],
+				Test:     imp.TestOnly,
+				Location: imp.Location,
+			})
+		}
+		cycles = append(cycles, cycle)
+	}
+	if len(cycles) == 0 {
+		return nil
+	}
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i].Packages[0] < cycles[j].Packages[0] })
+	if analysis.Findings == nil {
+		analysis.Findings = &datamodel.Findings{}
+	}
+	analysis.Findings.ImportCycles = cycles
+	return nil
+}
+
+// shortestCycle returns the edges of a shortest cycle through start, as from/to pairs.
+func shortestCycle(graph map[string]map[string]bool, start string) [][2]string {
+	via := make(map[string]string)
+	queue := []string{start}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		succs := make([]string, 0, len(graph[node]))
+		for succ := range graph[node] {
+			succs = append(succs, succ)
+		}
+		sort.Strings(succs) // Deterministic choice among equally short cycles
+		for _, succ := range succs {
+			if succ == start {
+				var edges [][2]string
+				for to, from := start, node; ; to, from = from, via[from] {
+					edges = append(edges, [2]string{from, to})
+					if from == start {
+						break
+					}
+				}
+				for i, j := 0, len(edges)-1; i < j; i, j = i+1, j-1 {
+					edges[i], edges[j] = edges[j], edges[i]
+				}
+				return edges
+			}
+			if _, seen := via[succ]; !seen {
+				via[succ] = node
+				queue = append(queue, succ)
+			}
+		}
+	}
+	return nil
+}
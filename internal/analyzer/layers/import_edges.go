@@ -0,0 +1,64 @@
+// analyzer/layers/import_edges.go
+package layers
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/namikmesic/go-mcp/internal/analyzer"
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// ImportEdgeAnalyzer implements analyzer.PackageAnalyzer by recording the imports
+// declared in the package's files as PackageAnalysis.ImportEdges. Unlike Imports,
+// this keeps imports the go tool drops because they close an import cycle, and marks
+// imports only made by _test.go files.
+type ImportEdgeAnalyzer struct{}
+
+// Compile-time check to ensure ImportEdgeAnalyzer implements PackageAnalyzer.
+var _ analyzer.PackageAnalyzer = (*ImportEdgeAnalyzer)(nil)
+
+func NewImportEdgeAnalyzer() *ImportEdgeAnalyzer {
+	return &ImportEdgeAnalyzer{}
+}
+
+// AnalyzePackage records one edge per imported package, sorted by imported path.
+func (a *ImportEdgeAnalyzer) AnalyzePackage(ctx context.Context, env *analyzer.Env, pkg *packages.Package, result *datamodel.PackageAnalysis) error {
+	edges := make(map[string]*datamodel.ImportEdge)
+	for _, file := range pkg.Syntax {
+		if file == nil {
+			continue
+		}
+		test := env != nil && env.Fset != nil && strings.HasSuffix(env.Fset.Position(file.Pos()).Filename, "_test.go")
+		for _, spec := range file.Imports {
+			path, err := strconv.Unquote(spec.Path.Value)
+			if err != nil {
+				continue
+			}
+			if imported := pkg.Imports[path]; imported != nil {
+				path = imported.PkgPath // Resolves vendored import paths
+			}
+			existing := edges[path]
+			if existing == nil || (existing.TestOnly && !test) {
+				edges[path] = &datamodel.ImportEdge{
+					Importer: pkg.PkgPath,
+					Imported: path,
+					TestOnly: test,
+					Location: env.Location(spec.Pos()),
+				}
+			}
+		}
+	}
+	result.ImportEdges = make([]datamodel.ImportEdge, 0, len(edges))
+	for _, edge := range edges {
+		result.ImportEdges = append(result.ImportEdges, *edge)
+	}
+	sort.Slice(result.ImportEdges, func(i, j int) bool {
+		return result.ImportEdges[i].Imported < result.ImportEdges[j].Imported
+	})
+	return nil
+}
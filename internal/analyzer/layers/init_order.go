@@ -0,0 +1,217 @@
+// analyzer/layers/init_order.go
+package layers
+
+import (
+	"context"
+	"go/ast"
+	"go/types"
+	"sort"
+	"sync"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/namikmesic/go-mcp/internal/analyzer"
+	"github.com/namikmesic/go-mcp/internal/analyzer/utils"
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// InitAnalyzer records what runs when each package is initialized in
+// PackageAnalysis.Init, and the order packages are initialized in
+// ProjectAnalysis.InitOrder. It must be registered as both a PackageAnalyzer and a
+// ProjectAnalyzer.
+//
+// The order follows the Go 1.21 rule: among the packages whose imports are all
+// initialized, the first by import path is initialized next. It is computed over the
+// whole import closure, standard library included, as if one program imported every
+// analyzed package.
+type InitAnalyzer struct {
+	mu       sync.Mutex
+	imports  map[string][]string // Package path -> imported package paths, for the import closure
+	analyzed map[string]bool     // Paths of the analyzed packages
+}
+
+// Compile-time checks to ensure InitAnalyzer implements both analyzer passes.
+var (
+	_ analyzer.PackageAnalyzer = (*InitAnalyzer)(nil)
+	_ analyzer.ProjectAnalyzer = (*InitAnalyzer)(nil)
+)
+
+func NewInitAnalyzer() *InitAnalyzer {
+	return &InitAnalyzer{}
+}
+
+func (a *InitAnalyzer) reset() {
+	a.imports = make(map[string][]string)
+	a.analyzed = make(map[string]bool)
+}
+
+// AnalyzePackage sets result.Init and records the import closure of pkg.
+func (a *InitAnalyzer) AnalyzePackage(ctx context.Context, env *analyzer.Env, pkg *packages.Package, result *datamodel.PackageAnalysis) error {
+	if pkg.TypesInfo != nil {
+		result.Init = packageInit(env, pkg)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.imports == nil {
+		a.reset()
+	}
+	a.analyzed[pkg.PkgPath] = true
+	stack := []*packages.Package{pkg}
+	for len(stack) > 0 {
+		p := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if _, seen := a.imports[p.PkgPath]; seen {
+			continue
+		}
+		imports := make([]string, 0, len(p.Imports))
+		for _, imp := range p.Imports {
+			imports = append(imports, imp.PkgPath)
+			stack = append(stack, imp)
+		}
+		a.imports[p.PkgPath] = imports
+	}
+	return nil
+}
+
+// packageInit returns the variable initializers and init functions of pkg, or nil if
+// it has none.
+func packageInit(env *analyzer.Env, pkg *packages.Package) *datamodel.PackageInit {
+	init := &datamodel.PackageInit{}
+	for _, initializer := range pkg.TypesInfo.InitOrder {
+		v := datamodel.VarInit{
+			Expr:     types.ExprString(initializer.Rhs),
+			Calls:    calledFuncs(pkg.TypesInfo, initializer.Rhs),
+			Location: env.Location(initializer.Lhs[0].Pos()),
+		}
+		for _, lhs := range initializer.Lhs {
+			v.Names = append(v.Names, lhs.Name())
+		}
+		init.Variables = append(init.Variables, v)
+	}
+	inits := 0 // Numbered in declaration order, like SSA does
+	for _, file := range pkg.Syntax {
+		if file == nil {
+			continue
+		}
+		for _, decl := range file.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil && fn.Name.Name == "init" {
+				inits++
+				init.Funcs = append(init.Funcs, utils.InitFuncID(pkg.PkgPath, inits))
+			}
+		}
+	}
+	if len(init.Variables) == 0 && len(init.Funcs) == 0 {
+		return nil
+	}
+	return init
+}
+
+// calledFuncs returns the IDs of the functions and methods expr calls statically,
+// outside function literals, in order of appearance.
+func calledFuncs(info *types.Info, expr ast.Expr) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	ast.Inspect(expr, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.CallExpr:
+			fun := ast.Unparen(n.Fun)
+			switch index := fun.(type) { // Explicit instantiations
+			case *ast.IndexExpr:
+				fun = ast.Unparen(index.X)
+			case *ast.IndexListExpr:
+				fun = ast.Unparen(index.X)
+			}
+			var ident *ast.Ident
+			switch fun := fun.(type) {
+			case *ast.Ident:
+				ident = fun
+			case *ast.SelectorExpr:
+				ident = fun.Sel
+			}
+			if ident == nil {
+				return true
+			}
+			if fn, ok := info.Uses[ident].(*types.Func); ok {
+				if id := utils.FuncID(fn); id != "" && !seen[id] {
+					seen[id] = true
+					ids = append(ids, id)
+				}
+			}
+		}
+		return true
+	})
+	return ids
+}
+
+// AnalyzeProject sets analysis.InitOrder and resets the recorded state.
+func (a *InitAnalyzer) AnalyzeProject(ctx context.Context, env *analyzer.Env, analysis *datamodel.ProjectAnalysis) error {
+	a.mu.Lock()
+	imports, analyzed := a.imports, a.analyzed
+	a.reset()
+	a.mu.Unlock()
+	if len(analyzed) == 0 {
+		return nil
+	}
+
+	// Count the uninitialized imports of each package; ready packages have none
+	pending := make(map[string]int, len(imports))
+	importers := make(map[string][]string)
+	for path, imps := range imports {
+		for _, imp := range unique(imps) {
+			pending[path]++
+			importers[imp] = append(importers[imp], path)
+		}
+	}
+	var ready []string
+	for path := range imports {
+		if pending[path] == 0 {
+			ready = append(ready, path)
+		}
+	}
+	done := make(map[string]bool, len(imports))
+	var order []string
+	for len(done) < len(imports) {
+		if len(ready) == 0 {
+			// Only possible through test variants; break the cycle at the first package
+			for path := range imports {
+				if !done[path] && (len(ready) == 0 || path < ready[0]) {
+					ready = []string{path}
+				}
+			}
+		}
+		sort.Strings(ready)
+		path := ready[0]
+		ready = ready[1:]
+		if done[path] {
+			continue
+		}
+		done[path] = true
+		if analyzed[path] {
+			order = append(order, path)
+		}
+		for _, importer := range importers[path] {
+			pending[importer]--
+			if pending[importer] == 0 && !done[importer] {
+				ready = append(ready, importer)
+			}
+		}
+	}
+	analysis.InitOrder = order
+	return nil
+}
+
+// unique returns paths without duplicates, which test variants can introduce.
+func unique(paths []string) []string {
+	seen := make(map[string]bool, len(paths))
+	out := paths[:0:0]
+	for _, p := range paths {
+		if !seen[p] {
+			seen[p] = true
+			out = append(out, p)
+		}
+	}
+	return out
+}
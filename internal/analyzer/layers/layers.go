@@ -0,0 +1,140 @@
+// analyzer/layers/layers.go
+package layers
+
+import (
+	"context"
+	"sort"
+
+	"github.com/namikmesic/go-mcp/internal/analyzer"
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// Inferrer implements analyzer.ProjectAnalyzer by assigning each package an
+// architectural layer derived from the import graph between analyzed packages.
+type Inferrer struct{}
+
+// Compile-time check to ensure Inferrer implements ProjectAnalyzer.
+var _ analyzer.ProjectAnalyzer = (*Inferrer)(nil)
+
+func NewInferrer() *Inferrer {
+	return &Inferrer{}
+}
+
+// AnalyzeProject implements analyzer.ProjectAnalyzer.
+func (l *Inferrer) AnalyzeProject(ctx context.Context, env *analyzer.Env, analysis *datamodel.ProjectAnalysis) error {
+	Infer(analysis)
+	return nil
+}
+
+// Infer sets the Layer field of all packages and returns the number of layers.
+//
+// Layers are topological strata of the import graph restricted to analyzed packages:
+// packages importing no other analyzed package are layer 0, and every other package
+// sits one layer above the highest layer it imports. Packages in an import cycle
+// (only possible between test variants) share a layer.
+func Infer(analysis *datamodel.ProjectAnalysis) int {
+	// Test variants share a path with the package under test; merge their imports
+	deps := make(map[string]map[string]bool)
+	for _, pkg := range analysis.Packages {
+		if pkg != nil && deps[pkg.Path] == nil {
+			deps[pkg.Path] = make(map[string]bool)
+		}
+	}
+	for _, pkg := range analysis.Packages {
+		if pkg == nil {
+			continue
+		}
+		for _, imp := range pkg.Imports {
+			if _, analyzed := deps[imp]; analyzed && imp != pkg.Path {
+				deps[pkg.Path][imp] = true
+			}
+		}
+	}
+
+	layerOf := make(map[string]int)
+	numLayers := 0
+	for _, scc := range stronglyConnected(deps) {
+		// Components come out in reverse topological order, so all dependencies are done
+		layer := 0
+		for _, path := range scc {
+			for dep := range deps[path] {
+				if l, done := layerOf[dep]; done && l+1 > layer {
+					layer = l + 1
+				}
+			}
+		}
+		for _, path := range scc {
+			layerOf[path] = layer
+		}
+		if layer+1 > numLayers {
+			numLayers = layer + 1
+		}
+	}
+
+	for _, pkg := range analysis.Packages {
+		if pkg != nil {
+			pkg.Layer = layerOf[pkg.Path]
+		}
+	}
+	return numLayers
+}
+
+// stronglyConnected returns the strongly connected components of the graph using
+// Tarjan's algorithm, in reverse topological order (dependencies first).
+func stronglyConnected(graph map[string]map[string]bool) [][]string {
+	nodes := make([]string, 0, len(graph))
+	for n := range graph {
+		nodes = append(nodes, n)
+	}
+	sort.Strings(nodes) // Deterministic traversal
+
+	index := make(map[string]int)
+	lowlink := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	var components [][]string
+	next := 0
+
+	var visit func(n string)
+	visit = func(n string) {
+		index[n] = next
+		lowlink[n] = next
+		next++
+		stack = append(stack, n)
+		onStack[n] = true
+
+		succs := make([]string, 0, len(graph[n]))
+		for s := range graph[n] {
+			succs = append(succs, s)
+		}
+		sort.Strings(succs)
+		for _, s := range succs {
+			if _, seen := index[s]; !seen {
+				visit(s)
+				lowlink[n] = min(lowlink[n], lowlink[s])
+			} else if onStack[s] {
+				lowlink[n] = min(lowlink[n], index[s])
+			}
+		}
+
+		if lowlink[n] == index[n] {
+			var scc []string
+			for {
+				top := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[top] = false
+				scc = append(scc, top)
+				if top == n {
+					break
+				}
+			}
+			components = append(components, scc)
+		}
+	}
+	for _, n := range nodes {
+		if _, seen := index[n]; !seen {
+			visit(n)
+		}
+	}
+	return components
+}
@@ -0,0 +1,94 @@
+// analyzer/metrics/embed_files.go
+package metrics
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/namikmesic/go-mcp/internal/analyzer"
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// sniffLen is the number of bytes http.DetectContentType considers.
+const sniffLen = 512
+
+// EmbedFileAnalyzer implements analyzer.PackageAnalyzer by recording the size, SHA-256
+// hash and content type of each file the package embeds with //go:embed in
+// PackageAnalysis.EmbedMetadata. It reads every embedded file, so it is opt-in.
+type EmbedFileAnalyzer struct {
+	// Logger receives diagnostics; nil logs to slog.Default().
+	Logger *slog.Logger
+}
+
+// Compile-time checks to ensure EmbedFileAnalyzer implements the analyzer interfaces.
+var (
+	_ analyzer.PackageAnalyzer = (*EmbedFileAnalyzer)(nil)
+	_ analyzer.LoggerAware     = (*EmbedFileAnalyzer)(nil)
+)
+
+func NewEmbedFileAnalyzer() *EmbedFileAnalyzer {
+	return &EmbedFileAnalyzer{}
+}
+
+// SetLogger implements analyzer.LoggerAware.
+func (a *EmbedFileAnalyzer) SetLogger(logger *slog.Logger) {
+	a.Logger = logger
+}
+
+func (a *EmbedFileAnalyzer) AnalyzePackage(ctx context.Context, env *analyzer.Env, pkg *packages.Package, result *datamodel.PackageAnalysis) error {
+	for _, filename := range pkg.EmbedFiles {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		file, err := embedFile(filename)
+		if err != nil {
+			analyzer.LoggerOrDefault(a.Logger).Warn("Failed to read embedded file", "package", pkg.PkgPath, "file", filename, "error", err)
+			continue
+		}
+		result.EmbedMetadata = append(result.EmbedMetadata, *file)
+	}
+	return nil
+}
+
+// embedFile reads the file at filename. The content type comes from the file
+// extension, or else from the content as http.DetectContentType sniffs it.
+func embedFile(filename string) (*datamodel.EmbedFile, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(f, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+	head = head[:n]
+	hash := sha256.New()
+	hash.Write(head)
+	rest, err := io.Copy(hash, f)
+	if err != nil {
+		return nil, err
+	}
+
+	contentType := mime.TypeByExtension(filepath.Ext(filename))
+	if contentType == "" {
+		contentType = http.DetectContentType(head)
+	}
+	return &datamodel.EmbedFile{
+		Path:        filename,
+		Size:        int64(n) + rest,
+		SHA256:      hex.EncodeToString(hash.Sum(nil)),
+		ContentType: contentType,
+	}, nil
+}
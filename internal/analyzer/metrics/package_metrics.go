@@ -0,0 +1,231 @@
+// analyzer/metrics/package_metrics.go
+package metrics
+
+import (
+	"context"
+	"go/ast"
+	"go/types"
+	"math"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/namikmesic/go-mcp/internal/analyzer"
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// PackageMetricsAnalyzer computes cohesion, coupling, instability and abstractness.
+// As a PackageAnalyzer it derives the type-based metrics (abstractness, LCOM, cohesion)
+// from each package's syntax; as a ProjectAnalyzer it derives coupling from the
+// assembled import lists. It must be registered as both.
+type PackageMetricsAnalyzer struct{}
+
+// Compile-time checks to ensure PackageMetricsAnalyzer implements both analyzer passes.
+var (
+	_ analyzer.PackageAnalyzer = (*PackageMetricsAnalyzer)(nil)
+	_ analyzer.ProjectAnalyzer = (*PackageMetricsAnalyzer)(nil)
+)
+
+func NewPackageMetricsAnalyzer() *PackageMetricsAnalyzer {
+	return &PackageMetricsAnalyzer{}
+}
+
+func (a *PackageMetricsAnalyzer) AnalyzePackage(ctx context.Context, env *analyzer.Env, pkg *packages.Package, result *datamodel.PackageAnalysis) error {
+	if pkg.Types == nil || pkg.TypesInfo == nil {
+		return nil
+	}
+	m := ensureMetrics(result)
+
+	scope := pkg.Types.Scope()
+	for _, name := range scope.Names() {
+		typeName, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || typeName.IsAlias() {
+			continue
+		}
+		if types.IsInterface(typeName.Type()) {
+			m.AbstractTypes++
+		} else {
+			m.ConcreteTypes++
+		}
+	}
+	if total := m.AbstractTypes + m.ConcreteTypes; total > 0 {
+		m.Abstractness = round(float64(m.AbstractTypes) / float64(total))
+	}
+
+	m.LCOM, m.Cohesion = cohesion(pkg)
+	return nil
+}
+
+// cohesion builds a graph whose nodes are the package's top-level types and functions
+// (methods are merged into their receiver type) and whose edges are references between
+// them. It returns the number of connected components (LCOM4) and the relational
+// cohesion (R + 1) / N, where R is the number of distinct relationships.
+func cohesion(pkg *packages.Package) (int, float64) {
+	scope := pkg.Types.Scope()
+
+	// owner maps a package-level object to the graph node it belongs to
+	owner := func(obj types.Object) types.Object {
+		if obj == nil || obj.Pkg() != pkg.Types {
+			return nil
+		}
+		if fn, ok := obj.(*types.Func); ok {
+			if recv := fn.Type().(*types.Signature).Recv(); recv != nil {
+				t := recv.Type()
+				if ptr, ok := t.(*types.Pointer); ok {
+					t = ptr.Elem()
+				}
+				if named, ok := t.(*types.Named); ok {
+					return named.Obj()
+				}
+				return nil
+			}
+		}
+		switch obj.(type) {
+		case *types.TypeName, *types.Func:
+			if obj.Parent() == scope {
+				return obj
+			}
+		}
+		return nil
+	}
+
+	nodes := make(map[types.Object]bool)
+	edges := make(map[[2]types.Object]bool)
+	addEdges := func(from types.Object, root ast.Node) {
+		ast.Inspect(root, func(n ast.Node) bool {
+			ident, ok := n.(*ast.Ident)
+			if !ok {
+				return true
+			}
+			to := owner(pkg.TypesInfo.Uses[ident])
+			if to == nil || to == from {
+				return true
+			}
+			// Store undirected edges with a stable orientation
+			key := [2]types.Object{from, to}
+			if from.Pos() > to.Pos() {
+				key = [2]types.Object{to, from}
+			}
+			edges[key] = true
+			return true
+		})
+	}
+
+	for _, file := range pkg.Syntax {
+		if file == nil {
+			continue
+		}
+		for _, decl := range file.Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				from := owner(pkg.TypesInfo.Defs[d.Name])
+				if from == nil {
+					continue
+				}
+				nodes[from] = true
+				addEdges(from, d)
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					ts, ok := spec.(*ast.TypeSpec)
+					if !ok {
+						continue
+					}
+					from := owner(pkg.TypesInfo.Defs[ts.Name])
+					if from == nil {
+						continue
+					}
+					nodes[from] = true
+					addEdges(from, ts.Type)
+				}
+			}
+		}
+	}
+	if len(nodes) == 0 {
+		return 0, 0
+	}
+
+	// Count connected components with union-find
+	parent := make(map[types.Object]types.Object, len(nodes))
+	var find func(types.Object) types.Object
+	find = func(o types.Object) types.Object {
+		if parent[o] == nil || parent[o] == o {
+			parent[o] = o
+			return o
+		}
+		root := find(parent[o])
+		parent[o] = root
+		return root
+	}
+	components := len(nodes)
+	for edge := range edges {
+		if !nodes[edge[0]] || !nodes[edge[1]] {
+			continue // Reference to something declared in a file we did not see
+		}
+		a, b := find(edge[0]), find(edge[1])
+		if a != b {
+			parent[a] = b
+			components--
+		}
+	}
+	return components, round(float64(len(edges)+1) / float64(len(nodes)))
+}
+
+// AnalyzeProject computes afferent/efferent coupling, instability and distance
+// from the import lists of the assembled packages.
+func (a *PackageMetricsAnalyzer) AnalyzeProject(ctx context.Context, env *analyzer.Env, analysis *datamodel.ProjectAnalysis) error {
+	analyzed := make(map[string]bool)
+	for _, pkg := range analysis.Packages {
+		if pkg != nil {
+			analyzed[pkg.Path] = true
+		}
+	}
+
+	// Distinct importers and imports by package path; test variants share a path
+	importers := make(map[string]map[string]bool)
+	imports := make(map[string]map[string]bool)
+	for _, pkg := range analysis.Packages {
+		if pkg == nil {
+			continue
+		}
+		from := strings.TrimSuffix(pkg.Path, "_test") // External test packages count as their package
+		for _, imp := range pkg.Imports {
+			if !analyzed[imp] || imp == from {
+				continue
+			}
+			if importers[imp] == nil {
+				importers[imp] = make(map[string]bool)
+			}
+			importers[imp][from] = true
+			if imports[pkg.Path] == nil {
+				imports[pkg.Path] = make(map[string]bool)
+			}
+			imports[pkg.Path][imp] = true
+		}
+	}
+
+	for _, pkg := range analysis.Packages {
+		if pkg == nil {
+			continue
+		}
+		m := ensureMetrics(pkg)
+		m.AfferentCoupling = len(importers[pkg.Path])
+		m.EfferentCoupling = len(imports[pkg.Path])
+		if total := m.AfferentCoupling + m.EfferentCoupling; total > 0 {
+			m.Instability = round(float64(m.EfferentCoupling) / float64(total))
+		}
+		m.Distance = round(math.Abs(m.Abstractness + m.Instability - 1))
+	}
+	return nil
+}
+
+func ensureMetrics(pkg *datamodel.PackageAnalysis) *datamodel.PackageMetrics {
+	if pkg.Metrics == nil {
+		pkg.Metrics = &datamodel.PackageMetrics{}
+	}
+	return pkg.Metrics
+}
+
+// round keeps three decimals, which is plenty for these ratios and keeps output compact.
+func round(v float64) float64 {
+	return math.Round(v*1000) / 1000
+}
@@ -0,0 +1,97 @@
+// analyzer/metrics/size.go
+package metrics
+
+import (
+	"context"
+	"go/ast"
+	"go/scanner"
+	"go/token"
+	"os"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/namikmesic/go-mcp/internal/analyzer"
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// SizeAnalyzer implements analyzer.PackageAnalyzer by recording the size of each
+// parsed file (lines, code lines, functions, statements) and their package totals
+// in PackageAnalysis.Metrics.
+type SizeAnalyzer struct{}
+
+// Compile-time check to ensure SizeAnalyzer implements PackageAnalyzer.
+var _ analyzer.PackageAnalyzer = (*SizeAnalyzer)(nil)
+
+func NewSizeAnalyzer() *SizeAnalyzer {
+	return &SizeAnalyzer{}
+}
+
+func (a *SizeAnalyzer) AnalyzePackage(ctx context.Context, env *analyzer.Env, pkg *packages.Package, result *datamodel.PackageAnalysis) error {
+	if env == nil || env.Fset == nil || len(pkg.Syntax) == 0 {
+		return nil
+	}
+	m := ensureMetrics(result)
+	for _, file := range pkg.Syntax {
+		if file == nil {
+			continue
+		}
+		tf := env.Fset.File(file.Pos())
+		if tf == nil {
+			continue
+		}
+		fm := datamodel.FileMetrics{
+			File:      env.RelPath(tf.Name()),
+			Lines:     tf.LineCount(),
+			CodeLines: codeLines(tf.Name()),
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch n.(type) {
+			case *ast.FuncDecl:
+				fm.Functions++
+			case *ast.BlockStmt, *ast.EmptyStmt, *ast.CaseClause, *ast.CommClause, *ast.LabeledStmt:
+			case ast.Stmt:
+				fm.Statements++
+			}
+			return true
+		})
+		m.Lines += fm.Lines
+		m.CodeLines += fm.CodeLines
+		m.Functions += fm.Functions
+		m.Statements += fm.Statements
+		m.Files = append(m.Files, fm)
+	}
+	sort.Slice(m.Files, func(i, j int) bool { return m.Files[i].File < m.Files[j].File })
+	return nil
+}
+
+// codeLines counts the lines of the file at path that hold at least one token, so
+// blank and comment-only lines are skipped. It returns 0 if the file cannot be read.
+func codeLines(path string) int {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+	fset := token.NewFileSet()
+	file := fset.AddFile(path, -1, len(src))
+	var s scanner.Scanner
+	s.Init(file, src, nil, 0) // Comments are skipped
+	lines := make(map[int]bool)
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		if tok == token.SEMICOLON && lit == "\n" {
+			continue // Automatically inserted at the end of the line
+		}
+		line := file.Line(pos)
+		lines[line] = true
+		// Raw strings may span several lines
+		for i := 1; i <= strings.Count(lit, "\n"); i++ {
+			lines[line+i] = true
+		}
+	}
+	return len(lines)
+}
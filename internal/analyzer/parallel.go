@@ -0,0 +1,41 @@
+// analyzer/parallel.go
+package analyzer
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// Parallel calls fn for every index in [0, n) on up to workers goroutines and waits for
+// them to finish. workers <= 0 uses runtime.GOMAXPROCS(0). Once ctx is cancelled no
+// further indices are started and ctx.Err() is returned. fn must only write state
+// owned by its index, e.g. one slot of a pre-sized result slice.
+func Parallel(ctx context.Context, workers, n int, fn func(i int)) error {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > n {
+		workers = n
+	}
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n && ctx.Err() == nil; i++ {
+		select {
+		case indices <- i:
+		case <-ctx.Done():
+		}
+	}
+	close(indices)
+	wg.Wait()
+	return ctx.Err()
+}
@@ -0,0 +1,255 @@
+// analyzer/reach/centrality.go
+package reach
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/namikmesic/go-mcp/internal/analyzer"
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// Optional centrality measures; degrees are always computed.
+const (
+	CentralityPageRank    = "pagerank"
+	CentralityBetweenness = "betweenness"
+)
+
+// CentralityMeasures lists the selectable optional measures.
+var CentralityMeasures = []string{CentralityPageRank, CentralityBetweenness}
+
+// IsCentralityMeasure reports whether name is one of CentralityMeasures.
+func IsCentralityMeasure(name string) bool {
+	for _, measure := range CentralityMeasures {
+		if name == measure {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	pageRankDamping    = 0.85
+	pageRankIterations = 100
+	pageRankTolerance  = 1e-9
+)
+
+// CentralityAnalyzer implements analyzer.ProjectAnalyzer by setting the Centrality of
+// each function with calls from or to it in the call Graph. Closures are merged into their enclosing
+// function, so a function's calls include those made by its closures. Functions
+// outside the analysis (e.g. standard library callees) are part of the graph but
+// have no Function record to report on.
+type CentralityAnalyzer struct {
+	// Measures holds the optional measures to compute (CentralityPageRank,
+	// CentralityBetweenness).
+	Measures []string
+}
+
+// Compile-time check to ensure CentralityAnalyzer implements ProjectAnalyzer.
+var _ analyzer.ProjectAnalyzer = (*CentralityAnalyzer)(nil)
+
+func NewCentralityAnalyzer(measures []string) *CentralityAnalyzer {
+	return &CentralityAnalyzer{Measures: measures}
+}
+
+// AnalyzeProject implements analyzer.ProjectAnalyzer.
+func (a *CentralityAnalyzer) AnalyzeProject(ctx context.Context, env *analyzer.Env, analysis *datamodel.ProjectAnalysis) error {
+	if analysis == nil {
+		return nil
+	}
+	var pageRank, betweenness bool
+	for _, measure := range a.Measures {
+		switch measure {
+		case CentralityPageRank:
+			pageRank = true
+		case CentralityBetweenness:
+			betweenness = true
+		default:
+			return fmt.Errorf("unknown centrality measure %q", measure)
+		}
+	}
+
+	nodes, succs := NewGraph(analysis).functionGraph()
+	if len(nodes) == 0 {
+		return nil
+	}
+	index := make(map[string]int, len(nodes))
+	for i, id := range nodes {
+		index[id] = i
+	}
+	inDegree := make([]int, len(nodes))
+	for _, out := range succs {
+		for _, to := range out {
+			inDegree[to]++
+		}
+	}
+	var ranks, between []float64
+	if pageRank {
+		ranks = pageRanks(succs)
+	}
+	if betweenness {
+		var err error
+		if between, err = betweennesses(ctx, succs); err != nil {
+			return err
+		}
+	}
+
+	for _, pkg := range analysis.Packages {
+		if pkg == nil {
+			continue
+		}
+		for i := range pkg.Functions {
+			fn := &pkg.Functions[i]
+			n, ok := index[fn.ID]
+			if !ok || inDegree[n]+len(succs[n]) == 0 {
+				continue
+			}
+			c := &datamodel.FunctionCentrality{InDegree: inDegree[n], OutDegree: len(succs[n])}
+			if ranks != nil {
+				c.PageRank = round(ranks[n] * float64(len(nodes)))
+			}
+			if between != nil {
+				c.Betweenness = round(between[n])
+			}
+			fn.Centrality = c
+		}
+	}
+	return nil
+}
+
+// functionGraph returns the functions of the call graph, with closures merged into
+// their enclosing function, and the successors of each by index.
+func (g *Graph) functionGraph() ([]string, [][]int) {
+	enclosing := func(id string) string {
+		if i := strings.Index(id, "$"); i >= 0 {
+			return id[:i]
+		}
+		return id
+	}
+	set := make(map[string]bool)
+	for id := range g.known {
+		set[enclosing(id)] = true
+	}
+	nodes := make([]string, 0, len(set))
+	for id := range set {
+		nodes = append(nodes, id)
+	}
+	sort.Strings(nodes)
+	index := make(map[string]int, len(nodes))
+	for i, id := range nodes {
+		index[id] = i
+	}
+
+	edges := make([]map[int]bool, len(nodes))
+	for caller, steps := range g.edges {
+		from := index[enclosing(caller)]
+		for _, step := range steps {
+			to := index[enclosing(step.CalleeID)]
+			if to == from {
+				continue // Closure edges, and recursion
+			}
+			if edges[from] == nil {
+				edges[from] = make(map[int]bool)
+			}
+			edges[from][to] = true
+		}
+	}
+	succs := make([][]int, len(nodes))
+	for from, out := range edges {
+		for to := range out {
+			succs[from] = append(succs[from], to)
+		}
+		sort.Ints(succs[from])
+	}
+	return nodes, succs
+}
+
+// pageRanks computes the PageRank of each node by power iteration. Functions that
+// call nothing distribute their rank evenly over all functions.
+func pageRanks(succs [][]int) []float64 {
+	n := float64(len(succs))
+	ranks := make([]float64, len(succs))
+	for i := range ranks {
+		ranks[i] = 1 / n
+	}
+	next := make([]float64, len(succs))
+	for iter := 0; iter < pageRankIterations; iter++ {
+		dangling := 0.0
+		for i, out := range succs {
+			if len(out) == 0 {
+				dangling += ranks[i]
+			}
+		}
+		base := (1-pageRankDamping)/n + pageRankDamping*dangling/n
+		for i := range next {
+			next[i] = base
+		}
+		for i, out := range succs {
+			share := pageRankDamping * ranks[i] / float64(len(out))
+			for _, to := range out {
+				next[to] += share
+			}
+		}
+		delta := 0.0
+		for i := range ranks {
+			delta += math.Abs(next[i] - ranks[i])
+		}
+		ranks, next = next, ranks
+		if delta < pageRankTolerance {
+			break
+		}
+	}
+	return ranks
+}
+
+// betweennesses computes the betweenness of each node, the number of shortest call
+// paths between other functions that pass through it (split evenly among equally
+// short paths), with Brandes' algorithm.
+func betweennesses(ctx context.Context, succs [][]int) ([]float64, error) {
+	between := make([]float64, len(succs))
+	sigma := make([]float64, len(succs))
+	dist := make([]int, len(succs))
+	delta := make([]float64, len(succs))
+	preds := make([][]int, len(succs))
+	for s := range succs {
+		if s%1000 == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		for i := range succs {
+			sigma[i], dist[i], delta[i], preds[i] = 0, -1, 0, preds[i][:0]
+		}
+		sigma[s], dist[s] = 1, 0
+		order := []int{s}
+		for head := 0; head < len(order); head++ {
+			v := order[head]
+			for _, w := range succs[v] {
+				if dist[w] < 0 {
+					dist[w] = dist[v] + 1
+					order = append(order, w)
+				}
+				if dist[w] == dist[v]+1 {
+					sigma[w] += sigma[v]
+					preds[w] = append(preds[w], v)
+				}
+			}
+		}
+		for i := len(order) - 1; i > 0; i-- {
+			w := order[i]
+			for _, v := range preds[w] {
+				delta[v] += sigma[v] / sigma[w] * (1 + delta[w])
+			}
+			between[w] += delta[w]
+		}
+	}
+	return between, nil
+}
+
+// round keeps three decimals, which is plenty for these scores and keeps output compact.
+func round(v float64) float64 {
+	return math.Round(v*1000) / 1000
+}
@@ -0,0 +1,273 @@
+// analyzer/reach/graph.go
+package reach
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// DefaultMaxPaths bounds the number of paths returned by an all-paths query.
+const DefaultMaxPaths = 100
+
+// Graph is the call graph of an analysis between function IDs. Edges follow call sites
+// by CalleeID, the Targets of interface calls and, with --callgraph, the Callees of
+// dynamic calls. Every function also has an edge to each closure it creates.
+type Graph struct {
+	edges map[string][]datamodel.CallStep // Caller ID -> one step per callee, sorted by callee
+	known map[string]bool                 // IDs of declared functions, callers and callees
+}
+
+// NewGraph builds the call graph of analysis.
+func NewGraph(analysis *datamodel.ProjectAnalysis) *Graph {
+	g := &Graph{
+		edges: make(map[string][]datamodel.CallStep),
+		known: make(map[string]bool),
+	}
+	if analysis == nil {
+		return g
+	}
+	// Call descriptions of --callgraph Callees map to IDs through Function.FullName
+	idByName := make(map[string]string)
+	for _, pkg := range analysis.Packages {
+		if pkg == nil {
+			continue
+		}
+		for _, fn := range pkg.Functions {
+			idByName[fn.FullName] = fn.ID
+			g.known[fn.ID] = true
+		}
+	}
+	for _, pkg := range analysis.Packages {
+		if pkg == nil {
+			continue
+		}
+		for _, call := range pkg.Calls {
+			if call.CallerID == "" {
+				continue
+			}
+			g.known[call.CallerID] = true
+			step := datamodel.CallStep{CallerID: call.CallerID, CallType: call.CallType, Location: call.Location}
+			if len(call.Targets) > 0 {
+				for _, target := range call.Targets {
+					g.add(step, target)
+				}
+			} else if call.CalleeID != "" {
+				// Unresolved interface calls end at the interface method
+				g.add(step, call.CalleeID)
+			}
+			for _, name := range call.Callees {
+				if id, ok := idByName[name]; ok {
+					g.add(step, id)
+				}
+			}
+			// Closures ("Func$1$2") run as part of their enclosing function
+			for id := call.CallerID; strings.Contains(id, "$"); {
+				parent := id[:strings.LastIndex(id, "$")]
+				g.add(datamodel.CallStep{CallerID: parent, CallType: datamodel.CallStepClosure}, id)
+				id = parent
+			}
+		}
+	}
+	for caller, steps := range g.edges {
+		g.edges[caller] = uniqueSteps(steps)
+	}
+	return g
+}
+
+func (g *Graph) add(step datamodel.CallStep, callee string) {
+	step.CalleeID = callee
+	g.known[callee] = true
+	g.edges[step.CallerID] = append(g.edges[step.CallerID], step)
+}
+
+// uniqueSteps sorts steps by callee and keeps one step per callee: the first call site
+// in source order, or the closure step if there is none.
+func uniqueSteps(steps []datamodel.CallStep) []datamodel.CallStep {
+	sort.Slice(steps, func(i, j int) bool {
+		a, b := steps[i], steps[j]
+		if a.CalleeID != b.CalleeID {
+			return a.CalleeID < b.CalleeID
+		}
+		if (a.CallType == datamodel.CallStepClosure) != (b.CallType == datamodel.CallStepClosure) {
+			return b.CallType == datamodel.CallStepClosure
+		}
+		if a.Location.Filename != b.Location.Filename {
+			return a.Location.Filename < b.Location.Filename
+		}
+		if a.Location.Line != b.Location.Line {
+			return a.Location.Line < b.Location.Line
+		}
+		return a.Location.Column < b.Location.Column
+	})
+	unique := steps[:0]
+	for i, step := range steps {
+		if i == 0 || step.CalleeID != steps[i-1].CalleeID {
+			unique = append(unique, step)
+		}
+	}
+	return unique
+}
+
+// Reachable returns the IDs of the functions reachable from roots, including the roots.
+func (g *Graph) Reachable(roots []string) map[string]bool {
+	reachable := make(map[string]bool)
+	queue := make([]string, 0, len(roots))
+	for _, root := range roots {
+		if !reachable[root] {
+			reachable[root] = true
+			queue = append(queue, root)
+		}
+	}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, step := range g.edges[id] {
+			if !reachable[step.CalleeID] {
+				reachable[step.CalleeID] = true
+				queue = append(queue, step.CalleeID)
+			}
+		}
+	}
+	return reachable
+}
+
+// Resolve returns the function ID name refers to: an exact ID, or else the only ID
+// ending in name after a "." or "/" (e.g. "Server.handleCalls").
+func (g *Graph) Resolve(name string) (string, error) {
+	if g.known[name] {
+		return name, nil
+	}
+	var matches []string
+	for id := range g.known {
+		if strings.HasSuffix(id, "."+name) || strings.HasSuffix(id, "/"+name) {
+			matches = append(matches, id)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no function %q in the call graph", name)
+	case 1:
+		return matches[0], nil
+	}
+	sort.Strings(matches)
+	if len(matches) > 5 {
+		matches = append(matches[:5], "...")
+	}
+	return "", fmt.Errorf("%q is ambiguous: %s", name, strings.Join(matches, ", "))
+}
+
+// Query resolves from and to (see Resolve) and finds the paths between them: a
+// shortest path, or with all, up to limit paths without repeated functions (limit <= 0
+// uses DefaultMaxPaths). The result has no paths if to is not reachable from from.
+func (g *Graph) Query(from, to string, all bool, limit int) (*datamodel.CallPaths, error) {
+	fromID, err := g.Resolve(from)
+	if err != nil {
+		return nil, err
+	}
+	toID, err := g.Resolve(to)
+	if err != nil {
+		return nil, err
+	}
+	result := &datamodel.CallPaths{From: fromID, To: toID, Paths: []datamodel.CallPath{}}
+	if !all {
+		if path, ok := g.ShortestPath(fromID, toID); ok {
+			result.Paths = append(result.Paths, path)
+		}
+		return result, nil
+	}
+	if limit <= 0 {
+		limit = DefaultMaxPaths
+	}
+	result.Paths, result.Truncated = g.Paths(fromID, toID, limit)
+	return result, nil
+}
+
+// ShortestPath returns a path from one function ID to another with the fewest calls.
+func (g *Graph) ShortestPath(from, to string) (datamodel.CallPath, bool) {
+	via := map[string]datamodel.CallStep{from: {}}
+	queue := []string{from}
+	for len(queue) > 0 && queue[0] != to {
+		id := queue[0]
+		queue = queue[1:]
+		for _, step := range g.edges[id] {
+			if _, seen := via[step.CalleeID]; !seen {
+				via[step.CalleeID] = step
+				queue = append(queue, step.CalleeID)
+			}
+		}
+	}
+	if _, found := via[to]; !found {
+		return datamodel.CallPath{}, false
+	}
+	steps := []datamodel.CallStep{}
+	for id := to; id != from; id = via[id].CallerID {
+		steps = append(steps, via[id])
+	}
+	for i, j := 0, len(steps)-1; i < j; i, j = i+1, j-1 {
+		steps[i], steps[j] = steps[j], steps[i]
+	}
+	return datamodel.CallPath{Steps: steps}, true
+}
+
+// Paths returns up to limit paths from one function ID to another that visit no
+// function twice, shortest first, and whether more paths exist.
+func (g *Graph) Paths(from, to string, limit int) ([]datamodel.CallPath, bool) {
+	// Only functions that can reach the target are worth exploring
+	callers := make(map[string][]string)
+	for caller, steps := range g.edges {
+		for _, step := range steps {
+			callers[step.CalleeID] = append(callers[step.CalleeID], caller)
+		}
+	}
+	leadsToTarget := map[string]bool{to: true}
+	queue := []string{to}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, caller := range callers[id] {
+			if !leadsToTarget[caller] {
+				leadsToTarget[caller] = true
+				queue = append(queue, caller)
+			}
+		}
+	}
+
+	paths := []datamodel.CallPath{}
+	truncated := false
+	if !leadsToTarget[from] {
+		return paths, false
+	}
+	onPath := map[string]bool{from: true}
+	var steps []datamodel.CallStep
+	var visit func(id string) bool // Returns false once the limit is exceeded
+	visit = func(id string) bool {
+		if id == to {
+			if len(paths) == limit {
+				truncated = true
+				return false
+			}
+			paths = append(paths, datamodel.CallPath{Steps: append([]datamodel.CallStep{}, steps...)})
+			return true
+		}
+		for _, step := range g.edges[id] {
+			if onPath[step.CalleeID] || !leadsToTarget[step.CalleeID] {
+				continue
+			}
+			onPath[step.CalleeID] = true
+			steps = append(steps, step)
+			ok := visit(step.CalleeID)
+			steps = steps[:len(steps)-1]
+			onPath[step.CalleeID] = false
+			if !ok {
+				return false
+			}
+		}
+		return true
+	}
+	visit(from)
+	sort.SliceStable(paths, func(i, j int) bool { return len(paths[i].Steps) < len(paths[j].Steps) })
+	return paths, truncated
+}
@@ -0,0 +1,148 @@
+// analyzer/reach/panics.go
+package reach
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/namikmesic/go-mcp/internal/analyzer"
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// PanicAnalyzer implements analyzer.ProjectAnalyzer by listing in
+// Findings.UnrecoveredPanics the exported functions (as for RootExported) that can
+// panic without recovering, from the PanicHandling of the packages.
+//
+// A function can panic if it calls panic or calls, directly or through closures it
+// creates, a function that can. Panics do not cross go statements, and functions
+// deferring a call to a function calling recover are assumed to recover every panic.
+// Runtime panics and panics in code outside the analysis are not known.
+type PanicAnalyzer struct{}
+
+// Compile-time check to ensure PanicAnalyzer implements ProjectAnalyzer.
+var _ analyzer.ProjectAnalyzer = (*PanicAnalyzer)(nil)
+
+func NewPanicAnalyzer() *PanicAnalyzer {
+	return &PanicAnalyzer{}
+}
+
+// AnalyzeProject implements analyzer.ProjectAnalyzer.
+func (a *PanicAnalyzer) AnalyzeProject(ctx context.Context, env *analyzer.Env, analysis *datamodel.ProjectAnalysis) error {
+	if analysis == nil {
+		return nil
+	}
+	panics := make(map[string]datamodel.PanicSite) // Function ID -> first panic
+	recovers := make(map[string]bool)
+	spawned := make(map[string]bool) // Closures run by go statements
+	for _, pkg := range analysis.Packages {
+		if pkg == nil {
+			continue
+		}
+		for _, h := range pkg.PanicHandling {
+			for _, d := range h.Defers {
+				if d.Recovers {
+					recovers[h.FunctionID] = true
+				}
+			}
+			if len(h.Panics) > 0 {
+				panics[h.FunctionID] = h.Panics[0]
+			}
+		}
+		for _, g := range pkg.Goroutines {
+			if g.Closure {
+				spawned[g.TargetID] = true
+			}
+		}
+	}
+	if len(panics) == 0 {
+		return nil
+	}
+
+	// Walk the call graph backwards from the functions calling panic, recording for each
+	// function reached the step towards the nearest one
+	callers := make(map[string][]datamodel.CallStep)
+	for _, steps := range NewGraph(analysis).edges {
+		for _, step := range steps {
+			if step.CallType == "Go" || step.CallType == datamodel.CallStepClosure && spawned[step.CalleeID] {
+				continue
+			}
+			callers[step.CalleeID] = append(callers[step.CalleeID], step)
+		}
+	}
+	// Closures calling nothing have no closure step in the graph
+	for id := range panics {
+		if i := strings.LastIndex(id, "$"); i >= 0 && !spawned[id] {
+			callers[id] = append(callers[id], datamodel.CallStep{CallerID: id[:i], CalleeID: id, CallType: datamodel.CallStepClosure})
+		}
+	}
+	for _, steps := range callers {
+		sort.SliceStable(steps, func(i, j int) bool {
+			if steps[i].CallerID != steps[j].CallerID {
+				return steps[i].CallerID < steps[j].CallerID
+			}
+			return steps[j].CallType == datamodel.CallStepClosure && steps[i].CallType != datamodel.CallStepClosure
+		})
+	}
+	next := make(map[string]datamodel.CallStep)
+	var queue []string
+	for id := range panics {
+		if !recovers[id] {
+			queue = append(queue, id)
+		}
+	}
+	sort.Strings(queue)
+	reached := make(map[string]bool, len(queue))
+	for _, id := range queue {
+		reached[id] = true
+	}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, step := range callers[id] {
+			if reached[step.CallerID] || recovers[step.CallerID] {
+				continue
+			}
+			reached[step.CallerID] = true
+			next[step.CallerID] = step
+			queue = append(queue, step.CallerID)
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var found []datamodel.UnrecoveredPanic
+	for _, pkg := range analysis.Packages {
+		if pkg == nil {
+			continue
+		}
+		for i := range pkg.Functions {
+			fn := &pkg.Functions[i]
+			if !reached[fn.ID] || !isExportedAPI(pkg, fn) {
+				continue
+			}
+			p := datamodel.UnrecoveredPanic{FunctionID: fn.ID}
+			id := fn.ID
+			for {
+				step, ok := next[id]
+				if !ok {
+					break
+				}
+				p.Steps = append(p.Steps, step)
+				id = step.CalleeID
+			}
+			p.Panic = panics[id]
+			found = append(found, p)
+		}
+	}
+	if len(found) == 0 {
+		return nil
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].FunctionID < found[j].FunctionID })
+	if analysis.Findings == nil {
+		analysis.Findings = &datamodel.Findings{}
+	}
+	analysis.Findings.UnrecoveredPanics = found
+	return nil
+}
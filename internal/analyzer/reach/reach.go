@@ -0,0 +1,168 @@
+// analyzer/reach/reach.go
+package reach
+
+import (
+	"context"
+	"fmt"
+	"go/token"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/namikmesic/go-mcp/internal/analyzer"
+	"github.com/namikmesic/go-mcp/internal/analyzer/stability"
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// Root kinds selecting the functions reachability starts from.
+const (
+	RootMain     = "main"     // main functions of main packages
+	RootExported = "exported" // Exported functions and methods of exported types outside internal packages and tests
+	RootTests    = "tests"    // Tests, benchmarks, fuzz targets and examples in _test.go files
+)
+
+// RootKinds lists the selectable root kinds.
+var RootKinds = []string{RootMain, RootExported, RootTests}
+
+// Analyzer implements analyzer.ProjectAnalyzer by marking the functions reachable from
+// the selected roots in the call Graph of the analysis.
+//
+// Init functions are always roots, as they run whenever their package is linked.
+// Functions whose calls are not visible in the analysis are reported unreachable:
+// those passed as function values to code outside it (e.g. HTTP handlers), called
+// through function values without --callgraph, or called through interfaces by other
+// modules, such as String methods called by fmt.
+type Analyzer struct {
+	// Roots holds the root kinds to start from (RootMain, RootExported, RootTests).
+	Roots []string
+}
+
+// Compile-time check to ensure Analyzer implements ProjectAnalyzer.
+var _ analyzer.ProjectAnalyzer = (*Analyzer)(nil)
+
+func NewAnalyzer(roots []string) *Analyzer {
+	return &Analyzer{Roots: roots}
+}
+
+// AnalyzeProject implements analyzer.ProjectAnalyzer.
+func (a *Analyzer) AnalyzeProject(ctx context.Context, env *analyzer.Env, analysis *datamodel.ProjectAnalysis) error {
+	if analysis == nil {
+		return nil
+	}
+	for _, kind := range a.Roots {
+		if !IsRootKind(kind) {
+			return fmt.Errorf("unknown reachability root %q", kind)
+		}
+	}
+
+	var roots []string
+	for _, pkg := range analysis.Packages {
+		if pkg == nil {
+			continue
+		}
+		// The synthetic package initializer runs the package-level var initializers. It
+		// is not declared, so it is not among the functions
+		roots = append(roots, pkg.Path+".init")
+		for _, fn := range pkg.Functions {
+			if a.isRoot(pkg, &fn) {
+				roots = append(roots, fn.ID)
+			}
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	reachable := NewGraph(analysis).Reachable(roots)
+	for _, pkg := range analysis.Packages {
+		if pkg == nil {
+			continue
+		}
+		for i := range pkg.Functions {
+			pkg.Functions[i].Reachable = reachable[pkg.Functions[i].ID]
+		}
+	}
+	analysis.ReachabilityRoots = append([]string(nil), a.Roots...)
+	return nil
+}
+
+// isRoot reports whether fn, declared in pkg, is a root of the selected kinds.
+func (a *Analyzer) isRoot(pkg *datamodel.PackageAnalysis, fn *datamodel.Function) bool {
+	if fn.Receiver == "" && (fn.ID == pkg.Path+".init" || strings.HasPrefix(fn.ID, pkg.Path+".init#")) {
+		return true
+	}
+	for _, kind := range a.Roots {
+		switch kind {
+		case RootMain:
+			if pkg.Name == "main" && fn.Receiver == "" && fn.Name == "main" {
+				return true
+			}
+		case RootExported:
+			if isExportedAPI(pkg, fn) {
+				return true
+			}
+		case RootTests:
+			if testKind(fn) != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isExportedAPI reports whether fn, declared in pkg, is an exported function or a method
+// of an exported type outside main and internal packages and tests.
+func isExportedAPI(pkg *datamodel.PackageAnalysis, fn *datamodel.Function) bool {
+	return pkg.Name != "main" && fn.Exported && !stability.IsInternal(pkg.Path, fn.Name) &&
+		!strings.HasSuffix(fn.Location.Filename, "_test.go") &&
+		(fn.Receiver == "" || token.IsExported(receiverTypeName(fn.Receiver)))
+}
+
+// receiverTypeName strips the pointer and type parameters from a receiver, e.g.
+// "*Box[T]" gives "Box".
+func receiverTypeName(receiver string) string {
+	name := strings.TrimPrefix(receiver, "*")
+	if i := strings.IndexByte(name, '['); i >= 0 {
+		name = name[:i]
+	}
+	return name
+}
+
+// testKinds lists the test function kinds, which are also their name prefixes.
+var testKinds = []string{datamodel.TestKindTest, datamodel.TestKindBenchmark, datamodel.TestKindFuzz, datamodel.TestKindExample}
+
+// testKind returns the kind of test fn is, or "" if it is not a test, benchmark, fuzz
+// target or example declared in a _test.go file.
+func testKind(fn *datamodel.Function) string {
+	if fn.Receiver != "" || !strings.HasSuffix(fn.Location.Filename, "_test.go") {
+		return ""
+	}
+	for _, kind := range testKinds {
+		if isTestFunc(fn.Name, kind) {
+			return kind
+		}
+	}
+	return ""
+}
+
+// isTestFunc reports whether name is a test function name with prefix, following the
+// go test rule that the prefix is not followed by a lower-case letter.
+func isTestFunc(name, prefix string) bool {
+	if !strings.HasPrefix(name, prefix) {
+		return false
+	}
+	if len(name) == len(prefix) {
+		return true
+	}
+	r, _ := utf8.DecodeRuneInString(name[len(prefix):])
+	return !unicode.IsLower(r)
+}
+
+// IsRootKind reports whether kind is one of RootKinds.
+func IsRootKind(kind string) bool {
+	for _, k := range RootKinds {
+		if kind == k {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,169 @@
+// analyzer/reach/tests.go
+package reach
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/namikmesic/go-mcp/internal/analyzer"
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// TestAnalyzer implements analyzer.ProjectAnalyzer by listing the tests, benchmarks,
+// fuzz targets and examples of the analysis in ProjectAnalysis.Tests, each with the
+// functions outside _test.go files it exercises through the call Graph, and the
+// exported functions (as for RootExported) no test or fuzz target exercises in
+// Findings.UntestedFunctions. The same limits as for reachability apply: calls not
+// visible in the analysis are not followed.
+type TestAnalyzer struct{}
+
+// Compile-time check to ensure TestAnalyzer implements ProjectAnalyzer.
+var _ analyzer.ProjectAnalyzer = (*TestAnalyzer)(nil)
+
+func NewTestAnalyzer() *TestAnalyzer {
+	return &TestAnalyzer{}
+}
+
+// AnalyzeProject implements analyzer.ProjectAnalyzer.
+func (a *TestAnalyzer) AnalyzeProject(ctx context.Context, env *analyzer.Env, analysis *datamodel.ProjectAnalysis) error {
+	if analysis == nil {
+		return nil
+	}
+	var tests []datamodel.TestFunction
+	code := make(map[string]bool) // IDs of the functions declared outside _test.go files
+	for _, pkg := range analysis.Packages {
+		if pkg == nil {
+			continue
+		}
+		for i := range pkg.Functions {
+			fn := &pkg.Functions[i]
+			if !strings.HasSuffix(fn.Location.Filename, "_test.go") {
+				code[fn.ID] = true
+			} else if kind := testKind(fn); kind != "" {
+				tests = append(tests, datamodel.TestFunction{
+					FunctionID:  fn.ID,
+					Kind:        kind,
+					PackagePath: pkg.Path,
+					Location:    fn.Location,
+				})
+			}
+		}
+	}
+	if len(tests) == 0 {
+		return nil
+	}
+
+	g := NewGraph(analysis)
+	for i := range tests {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		for id := range g.Reachable([]string{tests[i].FunctionID}) {
+			if code[id] {
+				tests[i].Exercises = append(tests[i].Exercises, id)
+			}
+		}
+		sort.Strings(tests[i].Exercises)
+	}
+	sort.Slice(tests, func(i, j int) bool { return tests[i].FunctionID < tests[j].FunctionID })
+	analysis.Tests = tests
+
+	if _, untested, _ := exportedCoverage(analysis); len(untested) > 0 {
+		if analysis.Findings == nil {
+			analysis.Findings = &datamodel.Findings{}
+		}
+		analysis.Findings.UntestedFunctions = untested
+	}
+	return nil
+}
+
+// exportedCoverage returns the number of exported functions of analysis, those no test
+// or fuzz target of analysis.Tests exercises, and those fuzz targets exercise.
+func exportedCoverage(analysis *datamodel.ProjectAnalysis) (exported int, untested, fuzzed []string) {
+	tested := make(map[string]bool)
+	fuzzedSet := make(map[string]bool)
+	for _, test := range analysis.Tests {
+		if test.Kind != datamodel.TestKindTest && test.Kind != datamodel.TestKindFuzz {
+			continue
+		}
+		for _, id := range test.Exercises {
+			tested[id] = true
+			if test.Kind == datamodel.TestKindFuzz {
+				fuzzedSet[id] = true
+			}
+		}
+	}
+	for _, pkg := range analysis.Packages {
+		if pkg == nil {
+			continue
+		}
+		for i := range pkg.Functions {
+			fn := &pkg.Functions[i]
+			if !isExportedAPI(pkg, fn) {
+				continue
+			}
+			exported++
+			if !tested[fn.ID] {
+				untested = append(untested, fn.ID)
+			}
+			if fuzzedSet[fn.ID] {
+				fuzzed = append(fuzzed, fn.ID)
+			}
+		}
+	}
+	sort.Strings(untested)
+	sort.Strings(fuzzed)
+	return exported, untested, fuzzed
+}
+
+// NewTestReport summarizes analysis.Tests: the benchmarks and fuzz targets with the
+// functions they drive, and the exported functions left without tests or fuzzing.
+func NewTestReport(analysis *datamodel.ProjectAnalysis) *datamodel.TestReport {
+	report := &datamodel.TestReport{
+		Benchmarks:  []datamodel.TestFunction{},
+		FuzzTargets: []datamodel.TestFunction{},
+		Untested:    []string{},
+		Fuzzed:      []string{},
+	}
+	if analysis == nil {
+		return report
+	}
+	for _, test := range analysis.Tests {
+		switch test.Kind {
+		case datamodel.TestKindTest:
+			report.Tests++
+		case datamodel.TestKindExample:
+			report.Examples++
+		case datamodel.TestKindBenchmark:
+			report.Benchmarks = append(report.Benchmarks, test)
+		case datamodel.TestKindFuzz:
+			report.FuzzTargets = append(report.FuzzTargets, test)
+		}
+	}
+	exported, untested, fuzzed := exportedCoverage(analysis)
+	report.ExportedFunctions = exported
+	report.Untested = append(report.Untested, untested...)
+	report.Fuzzed = append(report.Fuzzed, fuzzed...)
+	return report
+}
+
+// TestsCovering returns the tests of analysis.Tests exercising the function name refers
+// to, given by ID or unambiguous ID suffix (see Graph.Resolve).
+func TestsCovering(analysis *datamodel.ProjectAnalysis, name string) (*datamodel.TestCoverage, error) {
+	if analysis == nil {
+		return nil, fmt.Errorf("no function %q in the call graph", name)
+	}
+	id, err := NewGraph(analysis).Resolve(name)
+	if err != nil {
+		return nil, err
+	}
+	coverage := &datamodel.TestCoverage{FunctionID: id, Tests: []string{}}
+	for _, test := range analysis.Tests {
+		if i := sort.SearchStrings(test.Exercises, id); i < len(test.Exercises) && test.Exercises[i] == id {
+			coverage.Tests = append(coverage.Tests, test.FunctionID)
+		}
+	}
+	return coverage, nil
+}
@@ -0,0 +1,141 @@
+// analyzer/rules/checker.go
+package rules
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/namikmesic/go-mcp/internal/analyzer"
+	"github.com/namikmesic/go-mcp/internal/analyzer/utils"
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// Checker reports dependencies that break the configured rules, using both the
+// import graph and the call graph. As a PackageAnalyzer it records where each
+// import is declared; as a ProjectAnalyzer it writes ProjectAnalysis.Findings.RuleViolations.
+// It must be registered as both.
+type Checker struct {
+	Rules []Rule
+
+	mu          sync.Mutex
+	importSites map[string]map[string]datamodel.Location // importer path -> imported path -> first import spec
+}
+
+// Compile-time checks to ensure Checker implements both analyzer passes.
+var (
+	_ analyzer.PackageAnalyzer = (*Checker)(nil)
+	_ analyzer.ProjectAnalyzer = (*Checker)(nil)
+)
+
+func NewChecker(rules []Rule) *Checker {
+	return &Checker{Rules: rules}
+}
+
+func (c *Checker) AnalyzePackage(ctx context.Context, env *analyzer.Env, pkg *packages.Package, result *datamodel.PackageAnalysis) error {
+	if len(c.Rules) == 0 {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.importSites == nil {
+		c.importSites = make(map[string]map[string]datamodel.Location)
+	}
+	sites := c.importSites[pkg.PkgPath]
+	if sites == nil {
+		sites = make(map[string]datamodel.Location)
+		c.importSites[pkg.PkgPath] = sites
+	}
+	for _, file := range pkg.Syntax {
+		if file == nil {
+			continue
+		}
+		for _, spec := range file.Imports {
+			path, err := strconv.Unquote(spec.Path.Value)
+			if err != nil {
+				continue
+			}
+			if _, ok := sites[path]; !ok {
+				sites[path] = env.Location(spec.Pos())
+			}
+		}
+	}
+	return nil
+}
+
+// AnalyzeProject checks all imports and call sites against the rules and resets
+// the recorded import positions for the next run.
+func (c *Checker) AnalyzeProject(ctx context.Context, env *analyzer.Env, analysis *datamodel.ProjectAnalysis) error {
+	c.mu.Lock()
+	importSites := c.importSites
+	c.importSites = nil
+	c.mu.Unlock()
+	if len(c.Rules) == 0 {
+		return nil
+	}
+
+	m := &matcher{modulePath: analysis.ModulePath}
+	seen := make(map[string]bool) // Test variants repeat the same imports and calls
+	var violations []datamodel.RuleViolation
+	report := func(v datamodel.RuleViolation) {
+		key := fmt.Sprintf("%s|%s|%s|%s|%s:%d", v.Rule, v.Kind, v.From, v.To, v.Location.Filename, v.Location.Line)
+		if !seen[key] {
+			seen[key] = true
+			violations = append(violations, v)
+		}
+	}
+
+	for _, pkg := range analysis.Packages {
+		if pkg == nil {
+			continue
+		}
+		for _, rule := range c.Rules {
+			for _, imp := range pkg.Imports {
+				if m.denies(rule, pkg.Path, imp) {
+					report(datamodel.RuleViolation{
+						Rule:     rule.Name,
+						Kind:     datamodel.ViolationImport,
+						From:     pkg.Path,
+						To:       imp,
+						Location: importSites[pkg.Path][imp],
+					})
+				}
+			}
+			for _, call := range pkg.Calls {
+				callee := utils.PackageOfFuncDesc(call.CalleeDesc)
+				if callee != "" && m.denies(rule, pkg.Path, callee) {
+					report(datamodel.RuleViolation{
+						Rule:     rule.Name,
+						Kind:     datamodel.ViolationCall,
+						From:     pkg.Path,
+						To:       callee,
+						Detail:   call.CallerFuncDesc + " -> " + call.CalleeDesc,
+						Location: call.Location,
+					})
+				}
+			}
+		}
+	}
+
+	sort.Slice(violations, func(i, j int) bool {
+		a, b := violations[i], violations[j]
+		if a.Location.Filename != b.Location.Filename {
+			return a.Location.Filename < b.Location.Filename
+		}
+		if a.Location.Line != b.Location.Line {
+			return a.Location.Line < b.Location.Line
+		}
+		return a.Rule < b.Rule
+	})
+	if len(violations) > 0 {
+		if analysis.Findings == nil {
+			analysis.Findings = &datamodel.Findings{}
+		}
+		analysis.Findings.RuleViolations = violations
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+// analyzer/rules/rules.go
+package rules
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/namikmesic/go-mcp/internal/analyzer/filter"
+)
+
+// Rule declares that packages matching From must not depend on packages matching Deny,
+// unless they also match Allow.
+//
+// Patterns are import paths in which "**" (or "...") matches any sequence of path
+// elements and "*" matches within a single element, and a "re:" prefix makes the rest a
+// regular expression, as for --include and --exclude (see filter.MatchPattern). A
+// pattern matches either the full import path or the path relative to the analyzed
+// module, so "internal/datamodel" and "net/http" both work as expected.
+type Rule struct {
+	Name  string   `json:"name"`
+	From  string   `json:"from"`
+	Deny  []string `json:"deny"`
+	Allow []string `json:"allow,omitempty"`
+}
+
+// RuleSet is the on-disk rules configuration.
+type RuleSet struct {
+	Rules []Rule `json:"rules"`
+}
+
+// LoadRules reads a JSON rules file.
+func LoadRules(path string) ([]Rule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading rules file %s: %w", path, err)
+	}
+	var set RuleSet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("parsing rules file %s: %w", path, err)
+	}
+	for i, r := range set.Rules {
+		if r.From == "" || len(r.Deny) == 0 {
+			return nil, fmt.Errorf("rule %d in %s: both \"from\" and \"deny\" are required", i, path)
+		}
+		if r.Name == "" {
+			set.Rules[i].Name = fmt.Sprintf("%s must not depend on %s", r.From, strings.Join(r.Deny, ", "))
+		}
+		for _, p := range append(append([]string{r.From}, r.Deny...), r.Allow...) {
+			if _, err := filter.CompilePattern(p); err != nil {
+				return nil, fmt.Errorf("rule %q in %s: invalid pattern %q: %w", set.Rules[i].Name, path, p, err)
+			}
+		}
+	}
+	return set.Rules, nil
+}
+
+// matcher matches package paths against the patterns of a rule set, in full or
+// relative to the module, with the syntax of --include and --exclude (see
+// filter.MatchPattern).
+type matcher struct {
+	modulePath string
+}
+
+func (m *matcher) match(pattern, pkgPath string) bool {
+	return filter.MatchModulePattern(pattern, pkgPath, m.modulePath)
+}
+
+func (m *matcher) matchAny(patterns []string, pkgPath string) bool {
+	for _, p := range patterns {
+		if m.match(p, pkgPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// denies reports whether rule r forbids a dependency from -> to.
+func (m *matcher) denies(r Rule, from, to string) bool {
+	return from != to &&
+		m.match(r.From, from) &&
+		m.matchAny(r.Deny, to) &&
+		!m.matchAny(r.Allow, to)
+}
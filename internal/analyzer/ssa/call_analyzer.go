@@ -2,46 +2,128 @@
 package ssa
 
 import (
+	"context"
 	"fmt"
+	"go/ast"
 	"go/token"
 	"go/types"
-	"log"
+	"log/slog"
+	"strconv"
+	"strings"
 
 	"golang.org/x/tools/go/packages"
 	"golang.org/x/tools/go/ssa"
 	"golang.org/x/tools/go/ssa/ssautil"
 
-	"github.com/namikmesic/go-mcp/internal/datamodel" // Adjusted import path
+	"github.com/namikmesic/go-mcp/internal/analyzer"
+	"github.com/namikmesic/go-mcp/internal/analyzer/filter"
+	"github.com/namikmesic/go-mcp/internal/analyzer/utils"
+	"github.com/namikmesic/go-mcp/pkg/datamodel" // Adjusted import path
 )
 
+// BuildOptions controls how the SSA program is built.
+type BuildOptions struct {
+	// SanityCheckFunctions adds extra checks during SSA construction. They catch builder
+	// bugs but slow the build down considerably.
+	SanityCheckFunctions bool
+	// BuildSerially builds packages one at a time instead of in parallel. This can help
+	// avoid certain race conditions in the builder.
+	BuildSerially bool
+	// InstantiateGenerics builds a function body for every instantiation of a generic
+	// function, so calls inside generic code are reported per instantiation.
+	InstantiateGenerics bool
+}
+
+// DefaultBuildOptions returns the conservative options matching the historical output:
+// sanity checks on, serial building, generics instantiated.
+func DefaultBuildOptions() BuildOptions {
+	return BuildOptions{
+		SanityCheckFunctions: true,
+		BuildSerially:        true,
+		InstantiateGenerics:  true,
+	}
+}
+
+// Mode converts the options into an ssa.BuilderMode.
+func (o BuildOptions) Mode() ssa.BuilderMode {
+	var mode ssa.BuilderMode
+	if o.SanityCheckFunctions {
+		mode |= ssa.SanityCheckFunctions
+	}
+	if o.BuildSerially {
+		mode |= ssa.BuildSerially
+	}
+	if o.InstantiateGenerics {
+		mode |= ssa.InstantiateGenerics
+	}
+	return mode
+}
+
 // SSACallGraphAnalyzer implements CallGraphAnalyzer using SSA.
-type SSACallGraphAnalyzer struct{}
+type SSACallGraphAnalyzer struct {
+	// BuildOptions controls how the SSA program is built.
+	BuildOptions BuildOptions
+	// LowMemory builds the SSA packages one at a time and drops their function bodies
+	// once their call sites are extracted, instead of retaining the whole built program.
+	// AnalyzeCalls then returns no program.
+	LowMemory bool
+	// Algorithm selects the call graph algorithm resolving the candidate callees of
+	// dynamic and interface calls (one of Algorithms); AlgorithmNone only scans the
+	// call instructions.
+	Algorithm string
+	// Filter decides which packages and caller functions have their call sites reported.
+	Filter analyzer.FilterPolicy
+	// Logger receives diagnostics; nil logs to slog.Default().
+	Logger *slog.Logger
+
+	// facts holds what the last AnalyzeCalls found besides call sites, until
+	// AnalyzePackage moves it into the package results
+	facts map[*packages.Package]*functionFacts
+}
+
+// NewSSACallGraphAnalyzer creates an analyzer building SSA with opts; use
+// DefaultBuildOptions for the conservative defaults.
+func NewSSACallGraphAnalyzer(opts BuildOptions) *SSACallGraphAnalyzer {
+	return &SSACallGraphAnalyzer{
+		BuildOptions: opts,
+		Filter:       filter.AllowAll(),
+	}
+}
+
+// SetFilterPolicy implements analyzer.Filterable.
+func (a *SSACallGraphAnalyzer) SetFilterPolicy(policy analyzer.FilterPolicy) {
+	a.Filter = policy
+}
 
-func NewSSACallGraphAnalyzer() *SSACallGraphAnalyzer {
-	return &SSACallGraphAnalyzer{}
+// SetLogger implements analyzer.LoggerAware.
+func (a *SSACallGraphAnalyzer) SetLogger(logger *slog.Logger) {
+	a.Logger = logger
 }
 
-func (a *SSACallGraphAnalyzer) AnalyzeCalls(pkgs []*packages.Package) (map[*packages.Package][]datamodel.CallSite, *ssa.Program, *token.FileSet, error) {
+func (a *SSACallGraphAnalyzer) AnalyzeCalls(ctx context.Context, pkgs []*packages.Package) (map[*packages.Package][]datamodel.CallSite, *ssa.Program, *token.FileSet, error) {
+	a.facts = make(map[*packages.Package]*functionFacts)
 	// Build SSA for the loaded packages.
-	// BuildSerially can help avoid certain race conditions in the builder
-	// InstantiateGenerics is important for handling generic code.
-	// SanityCheckFunctions adds extra checks during SSA construction.
-	ssaBuildMode := ssa.InstantiateGenerics | ssa.BuildSerially | ssa.SanityCheckFunctions
+	ssaBuildMode := a.BuildOptions.Mode()
+	logger := analyzer.LoggerOrDefault(a.Logger)
+	logger.Debug("Building SSA", "mode", ssaBuildMode, "lowMemory", a.LowMemory)
+	if a.LowMemory {
+		if a.Algorithm == AlgorithmNone {
+			return a.analyzeCallsPerPackage(ctx, pkgs, ssaBuildMode, logger)
+		}
+		logger.Warn("Call graph algorithms need the whole program; building SSA at once despite low-memory mode", "algorithm", a.Algorithm)
+	}
 	prog, ssaPkgs := ssautil.Packages(pkgs, ssaBuildMode)
 	if prog == nil {
 		// This can happen if pkgs is empty or has critical errors preventing SSA construction.
-		log.Println("Error: ssautil.Packages returned nil program. Check package load errors.")
+		logger.Error("ssautil.Packages returned nil program; check package load errors")
 		// Check pkgs length and errors if debugging is needed.
 		if len(pkgs) == 0 {
-			log.Println("Reason: No packages provided to ssautil.Packages.")
+			logger.Error("No packages provided to ssautil.Packages")
 		} else {
 			// Log errors from input packages
 			for _, pkg := range pkgs {
-				if len(pkg.Errors) > 0 {
-					log.Printf("Errors in package %s:", pkg.ID)
-					for _, err := range pkg.Errors {
-						log.Printf("  - %s", err)
-					}
+				for _, err := range pkg.Errors {
+					logger.Error("Package error", "package", pkg.ID, "error", err)
 				}
 			}
 		}
@@ -49,7 +131,14 @@ func (a *SSACallGraphAnalyzer) AnalyzeCalls(pkgs []*packages.Package) (map[*pack
 	}
 
 	// It's crucial to build the whole program *before* analyzing members.
+	// Building cannot be interrupted, so cancellation is checked around it.
+	if err := ctx.Err(); err != nil {
+		return nil, nil, nil, err
+	}
 	prog.Build()
+	if err := ctx.Err(); err != nil {
+		return nil, nil, nil, err
+	}
 
 	fset := prog.Fset // Use the FileSet from the SSA program for consistent positions
 	if fset == nil {
@@ -58,6 +147,21 @@ func (a *SSACallGraphAnalyzer) AnalyzeCalls(pkgs []*packages.Package) (map[*pack
 	}
 	callsByPackage := make(map[*packages.Package][]datamodel.CallSite)
 
+	// Resolve the candidate callees of dynamic and interface calls, if requested
+	cg, reachable, err := buildCallGraph(prog, ssaPkgs, a.Algorithm)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	callees := candidateCallees(cg)
+	if reachable != nil {
+		logger.Info("Built call graph", "algorithm", a.Algorithm, "sites", len(callees), "reachable", len(reachable))
+	} else if cg != nil {
+		logger.Info("Built call graph", "algorithm", a.Algorithm, "sites", len(callees))
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, nil, nil, err
+	}
+
 	// Map ssa.Package back to the original packages.Package for result association
 	ssaToOrigMap := make(map[*ssa.Package]*packages.Package)
 	for i, ssaPkg := range ssaPkgs {
@@ -67,162 +171,387 @@ func (a *SSACallGraphAnalyzer) AnalyzeCalls(pkgs []*packages.Package) (map[*pack
 			if pkgs[i] != nil {
 				ssaToOrigMap[ssaPkg] = pkgs[i]
 			} else {
-				log.Printf("Warning: SSA package %s corresponds to a nil original package at index %d.", ssaPkg.Pkg.Path(), i)
+				logger.Warn("SSA package corresponds to a nil original package", "package", ssaPkg.Pkg.Path(), "index", i)
 			}
 		} else if ssaPkg != nil {
 			// This might happen if ssautil includes packages not in the original input list (e.g., dependencies for certain build modes)
-			// log.Printf("Debug: SSA package %s built but not found in original input package list (index %d).", ssaPkg.Pkg.Path(), i)
+			// logger.Debug("SSA package built but not found in original input package list", "package", ssaPkg.Pkg.Path(), "index", i)
 		}
 	}
 
 	// Iterate through all functions in the SSA program
 	allFuncs := ssautil.AllFunctions(prog)
 	for fn := range allFuncs {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, nil, err
+		}
 		// Basic sanity checks for the function and its components
 		if fn == nil || fn.Package() == nil || fn.Package().Pkg == nil || fn.Blocks == nil {
-			// log.Printf("Debug: Skipping SSA function analysis (nil function, package, Pkg, or blocks): %v", fn)
+			// logger.Debug("Skipping SSA function analysis (nil function, package, Pkg, or blocks)", "function", fn)
 			continue // Skip functions without bodies or essential package info
 		}
+		if reachable != nil && !reachable[fn] {
+			continue // Not reachable from the entry points of the call graph algorithm
+		}
 
 		origPkg, ok := ssaToOrigMap[fn.Package()]
 		if !ok {
 			// This might happen for synthesized functions (like bound methods, thunks for generics)
 			// or if mapping failed. Often safe to ignore for basic call graph.
-			// log.Printf("Warning: Could not map SSA package '%s' (for function '%s') back to original package. Skipping calls within.", fn.Package().Pkg.Path(), fn.String())
+			// logger.Warn("Could not map SSA package back to original package; skipping calls within", "package", fn.Package().Pkg.Path(), "function", fn.String())
+			continue
+		}
+		if !a.Filter.IncludePackage(origPkg) || !a.Filter.IncludeSymbol(analyzer.Symbol{
+			Kind:        analyzer.KindFunction,
+			Name:        fn.Name(),
+			PackagePath: origPkg.PkgPath,
+			Exported:    token.IsExported(fn.Name()),
+			Generated:   filter.IsGenerated(origPkg, fn.Pos()),
+		}) {
 			continue
 		}
 
-		callerName := fn.String() // Readable name for the caller function
+		calls := a.functionCalls(fn, fset, ssaToOrigMap, callees, a.factsOf(origPkg), logger)
+		if len(calls) > 0 {
+			callsByPackage[origPkg] = append(callsByPackage[origPkg], calls...)
+		}
+	}
 
+	// Return the map, the program, the fileset, and no error
+	return callsByPackage, prog, fset, nil
+}
+
+// analyzeCallsPerPackage builds the packages one at a time and drops the function bodies
+// of each once its call sites are extracted, so only one package's bodies are held in
+// memory. It returns no program, since the returned one would have no bodies.
+func (a *SSACallGraphAnalyzer) analyzeCallsPerPackage(ctx context.Context, pkgs []*packages.Package, mode ssa.BuilderMode, logger *slog.Logger) (map[*packages.Package][]datamodel.CallSite, *ssa.Program, *token.FileSet, error) {
+	prog, ssaPkgs := ssautil.Packages(pkgs, mode)
+	if prog == nil || prog.Fset == nil {
+		return nil, nil, nil, fmt.Errorf("failed to create SSA program (check package load errors)")
+	}
+	fset := prog.Fset
+	callsByPackage := make(map[*packages.Package][]datamodel.CallSite)
+
+	ssaToOrigMap := make(map[*ssa.Package]*packages.Package)
+	for i, ssaPkg := range ssaPkgs {
+		if ssaPkg != nil && pkgs[i] != nil {
+			ssaToOrigMap[ssaPkg] = pkgs[i]
+		}
+	}
+
+	for i, ssaPkg := range ssaPkgs {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, nil, err
+		}
+		pkg := pkgs[i]
+		if ssaPkg == nil || pkg == nil || !a.Filter.IncludePackage(pkg) {
+			continue
+		}
+		ssaPkg.Build()
+		funcs := packageFunctions(prog, ssaPkg)
+		for _, fn := range funcs {
+			if fn.Blocks == nil {
+				continue
+			}
+			if !a.Filter.IncludeSymbol(analyzer.Symbol{
+				Kind:        analyzer.KindFunction,
+				Name:        fn.Name(),
+				PackagePath: pkg.PkgPath,
+				Exported:    token.IsExported(fn.Name()),
+				Generated:   filter.IsGenerated(pkg, fn.Pos()),
+			}) {
+				continue
+			}
+			calls := a.functionCalls(fn, fset, ssaToOrigMap, nil, a.factsOf(pkg), logger)
+			if len(calls) > 0 {
+				callsByPackage[pkg] = append(callsByPackage[pkg], calls...)
+			}
+		}
+		// Building other packages only needs the functions themselves, not their bodies
+		for _, fn := range funcs {
+			fn.Blocks = nil
+			fn.Recover = nil
+			fn.Locals = nil
+		}
+	}
+	return callsByPackage, nil, fset, nil
+}
+
+// packageFunctions returns the built functions of pkg: its package-level functions, the
+// methods of its named types and every function of pkg they reference, such as closures
+// and init functions, like ssautil.AllFunctions does for the whole program. Unlike
+// AllFunctions it includes the methods of unexported types that are never used.
+func packageFunctions(prog *ssa.Program, pkg *ssa.Package) []*ssa.Function {
+	var funcs []*ssa.Function
+	seen := make(map[*ssa.Function]bool)
+	var visit func(fn *ssa.Function)
+	visit = func(fn *ssa.Function) {
+		if fn == nil || seen[fn] || (fn.Package() != pkg && fn.Package() != nil) {
+			return
+		}
+		seen[fn] = true
+		// Wrappers and generic instances belong to no package, but may lead back to pkg
+		if fn.Package() == pkg {
+			funcs = append(funcs, fn)
+		}
+		var operands []*ssa.Value
 		for _, b := range fn.Blocks {
-			if b == nil {
+			for _, instr := range b.Instrs {
+				for _, op := range instr.Operands(operands[:0]) {
+					if f, ok := (*op).(*ssa.Function); ok {
+						visit(f)
+					}
+				}
+			}
+		}
+		for _, anon := range fn.AnonFuncs {
+			visit(anon)
+		}
+	}
+	for _, member := range pkg.Members {
+		switch m := member.(type) {
+		case *ssa.Function:
+			visit(m)
+		case *ssa.Type:
+			if types.IsInterface(m.Type()) {
+				continue
+			}
+			for _, T := range []types.Type{m.Type(), types.NewPointer(m.Type())} {
+				mset := prog.MethodSets.MethodSet(T)
+				for i := 0; i < mset.Len(); i++ {
+					visit(prog.MethodValue(mset.At(i)))
+				}
+			}
+		}
+	}
+	return funcs
+}
+
+// functionCalls extracts the call sites in the body of fn, with positions from fset.
+// Dynamic and interface calls list their candidate callees from callees, if any. Go
+// statements, channel operations, mutex and sync.Map calls, defer statements, panics and
+// recover calls are also added to facts.
+func (a *SSACallGraphAnalyzer) functionCalls(fn *ssa.Function, fset *token.FileSet, ssaToOrigMap map[*ssa.Package]*packages.Package, callees map[ssa.CallInstruction][]string, facts *functionFacts, logger *slog.Logger) []datamodel.CallSite {
+	var calls []datamodel.CallSite
+	callerName := fn.String() // Readable name for the caller function
+	callerID := functionID(fn)
+
+	for _, b := range fn.Blocks {
+		if b == nil {
+			continue
+		} // Defensive check
+		for _, instr := range b.Instrs {
+			if instr == nil {
 				continue
 			} // Defensive check
-			for _, instr := range b.Instrs {
-				if instr == nil {
-					continue
-				} // Defensive check
 
-				// Get source position using the SSA program's FileSet
-				pos := fset.Position(instr.Pos())
-				if !pos.IsValid() {
-					// log.Printf("Debug: Skipping instruction with invalid position in %s: %v", callerName, instr)
-					continue // Skip calls without valid source positions
+			// Get source position using the SSA program's FileSet
+			pos := fset.Position(instr.Pos())
+			if !pos.IsValid() {
+				// logger.Debug("Skipping instruction with invalid position", "caller", callerName, "instruction", instr)
+				continue // Skip calls without valid source positions
+			}
+
+			location := datamodel.NewLocation(pos)
+			if op := channelOp(instr, fn, fset); op != nil {
+				op.Location = location
+				facts.ChannelOps = append(facts.ChannelOps, *op)
+			}
+			if p, ok := instr.(*ssa.Panic); ok {
+				addPanic(facts, fn, p, location)
+			}
+			var callInfo *datamodel.CallSite
+
+			// Use type switch on the instruction itself first
+			switch call := instr.(type) {
+			case ssa.CallInstruction: // Common interface for Call, Go, Defer
+				common := call.Common()
+				// Check if common is nil (can happen for certain synthetic instructions)
+				if common == nil {
+					// logger.Debug("Skipping CallInstruction with nil Common()", "caller", callerName, "instruction", instr)
+					continue
 				}
 
-				location := datamodel.NewLocation(pos)
-				var callInfo *datamodel.CallSite
-
-				// Use type switch on the instruction itself first
-				switch call := instr.(type) {
-				case ssa.CallInstruction: // Common interface for Call, Go, Defer
-					common := call.Common()
-					// Check if common is nil (can happen for certain synthetic instructions)
-					if common == nil {
-						// log.Printf("Debug: Skipping CallInstruction with nil Common() in %s: %v", callerName, instr)
-						continue
-					}
+				var callType, calleeDesc string
 
-					var callType, calleeDesc string
-
-					// Determine call type and description based on the concrete type
-					switch c := call.(type) {
-					case *ssa.Call:
-						callType = "Static" // Default assumption
-						if common.IsInvoke() {
-							callType = "Interface"
-							// Method and Value should be non-nil for invokes
-							if common.Method != nil && common.Value != nil && common.Value.Type() != nil {
-								// Try to get the concrete type being called if available
-								calleeDesc = fmt.Sprintf("Interface method %s on %s", common.Method.Name(), types.TypeString(common.Value.Type(), nil))
-							} else {
-								calleeDesc = "Unknown Interface Call (nil method/value/type)"
-								log.Printf("Warning: Interface call with nil components in %s: Method=%v, Value=%v", callerName, common.Method, common.Value)
-							}
+				// Determine call type and description based on the concrete type
+				switch c := call.(type) {
+				case *ssa.Call:
+					callType = "Static" // Default assumption
+					if common.IsInvoke() {
+						callType = "Interface"
+						// Method and Value should be non-nil for invokes
+						if common.Method != nil && common.Value != nil && common.Value.Type() != nil {
+							// Try to get the concrete type being called if available
+							calleeDesc = fmt.Sprintf("Interface method %s on %s", common.Method.Name(), types.TypeString(common.Value.Type(), nil))
 						} else {
-							// Regular static or dynamic function call
-							callee := common.StaticCallee()
-							if callee != nil {
-								calleeDesc = callee.String() // Static call
-							} else if common.Value != nil && common.Value.Type() != nil {
-								// Dynamic call via function value
-								callType = "Dynamic" // More specific than just 'Static'
-								name := common.Value.Name()
-								if name == "" {
-									name = "anonymous_func_value"
-								} // Handle unnamed function values
-								calleeDesc = fmt.Sprintf("Dynamic via %s (%s)", name, types.TypeString(common.Value.Type(), nil))
-							} else {
-								calleeDesc = "Unknown Static/Dynamic Call"
-								log.Printf("Warning: Non-invoke call with nil StaticCallee and nil/invalid Value in %s: %v", callerName, common.Value)
-							}
+							calleeDesc = "Unknown Interface Call (nil method/value/type)"
+							logger.Warn("Interface call with nil components", "caller", callerName, "method", common.Method, "value", common.Value)
 						}
-					case *ssa.Go:
-						callType = "Go"
+					} else {
+						// Regular static or dynamic function call
 						callee := common.StaticCallee()
 						if callee != nil {
-							calleeDesc = callee.String()
+							calleeDesc = callee.String() // Static call
 						} else if common.Value != nil && common.Value.Type() != nil {
+							// Dynamic call via function value
+							callType = "Dynamic" // More specific than just 'Static'
 							name := common.Value.Name()
 							if name == "" {
 								name = "anonymous_func_value"
-							}
+							} // Handle unnamed function values
 							calleeDesc = fmt.Sprintf("Dynamic via %s (%s)", name, types.TypeString(common.Value.Type(), nil))
 						} else {
-							calleeDesc = "Unknown Go Callee"
-							log.Printf("Warning: Go instruction with nil StaticCallee and nil/invalid Value in %s: %v", callerName, common.Value)
+							calleeDesc = "Unknown Static/Dynamic Call"
+							logger.Warn("Non-invoke call with nil StaticCallee and nil/invalid Value", "caller", callerName, "value", common.Value)
 						}
-					case *ssa.Defer:
-						callType = "Defer"
-						callee := common.StaticCallee()
-						if callee != nil {
-							calleeDesc = callee.String()
-						} else if common.Value != nil && common.Value.Type() != nil {
-							name := common.Value.Name()
-							if name == "" {
-								name = "anonymous_func_value"
-							}
-							calleeDesc = fmt.Sprintf("Dynamic via %s (%s)", name, types.TypeString(common.Value.Type(), nil))
-						} else {
-							calleeDesc = "Unknown Defer Callee"
-							log.Printf("Warning: Defer instruction with nil StaticCallee and nil/invalid Value in %s: %v", callerName, common.Value)
+					}
+				case *ssa.Go:
+					callType = "Go"
+					callee := common.StaticCallee()
+					if callee != nil {
+						calleeDesc = callee.String()
+					} else if common.Value != nil && common.Value.Type() != nil {
+						name := common.Value.Name()
+						if name == "" {
+							name = "anonymous_func_value"
 						}
-					default:
-						// Should not happen if it implements CallInstruction, but good practice
-						log.Printf("Debug: Unhandled CallInstruction type %T in %s", c, callerName)
-						continue
+						calleeDesc = fmt.Sprintf("Dynamic via %s (%s)", name, types.TypeString(common.Value.Type(), nil))
+					} else {
+						calleeDesc = "Unknown Go Callee"
+						logger.Warn("Go instruction with nil StaticCallee and nil/invalid Value", "caller", callerName, "value", common.Value)
 					}
-
-					// Ensure calleeDesc is not empty
-					if calleeDesc == "" {
-						calleeDesc = "Analysis Error: Empty Callee Description"
-						log.Printf("Error: Empty callee description generated for %s call in %s. Instruction: %v", callType, callerName, instr)
+				case *ssa.Defer:
+					callType = "Defer"
+					callee := common.StaticCallee()
+					if callee != nil {
+						calleeDesc = callee.String()
+					} else if common.Value != nil && common.Value.Type() != nil {
+						name := common.Value.Name()
+						if name == "" {
+							name = "anonymous_func_value"
+						}
+						calleeDesc = fmt.Sprintf("Dynamic via %s (%s)", name, types.TypeString(common.Value.Type(), nil))
+					} else {
+						calleeDesc = "Unknown Defer Callee"
+						logger.Warn("Defer instruction with nil StaticCallee and nil/invalid Value", "caller", callerName, "value", common.Value)
 					}
+				default:
+					// Should not happen if it implements CallInstruction, but good practice
+					logger.Debug("Unhandled CallInstruction type", "type", fmt.Sprintf("%T", c), "caller", callerName)
+					continue
+				}
 
-					callInfo = &datamodel.CallSite{
-						CallerFuncDesc: callerName,
-						CalleeDesc:     calleeDesc,
-						CallType:       callType,
-						Location:       location,
-					}
-					// Add cases for other instruction types if needed in the future
-					// case *ssa.Send:
-					// case *ssa.Select:
-					// ...
+				// Ensure calleeDesc is not empty
+				if calleeDesc == "" {
+					calleeDesc = "Analysis Error: Empty Callee Description"
+					logger.Error("Empty callee description generated", "callType", callType, "caller", callerName, "instruction", instr)
 				}
 
-				// Append the valid call info to the correct package's list
-				if callInfo != nil {
-					// Ensure the slice exists for the package
-					if _, exists := callsByPackage[origPkg]; !exists {
-						callsByPackage[origPkg] = []datamodel.CallSite{}
-					}
-					callsByPackage[origPkg] = append(callsByPackage[origPkg], *callInfo)
+				callInfo = &datamodel.CallSite{
+					CallerFuncDesc: callerName,
+					CalleeDesc:     calleeDesc,
+					CallType:       callType,
+					Location:       location,
+					CallerID:       callerID,
+					CalleeOpaque:   isOpaqueCallee(common.StaticCallee(), ssaToOrigMap),
 				}
+				if common.IsInvoke() {
+					callInfo.CalleeID = utils.FuncID(common.Method)
+				} else {
+					callInfo.CalleeID = functionID(common.StaticCallee())
+				}
+				if common.StaticCallee() == nil && callees[call] != nil {
+					callInfo.Callees = callees[call]
+					callInfo.Algorithm = a.Algorithm
+				}
+				addLockOp(facts, fn, call, location, fset)
+				addRecoverOrDefer(facts, fn, call, calleeDesc, location)
+				if callType == "Go" {
+					facts.Goroutines = append(facts.Goroutines, datamodel.Goroutine{
+						SpawnerID: callerID,
+						Target:    calleeDesc,
+						TargetID:  callInfo.CalleeID,
+						Closure:   isClosure(common.Value),
+						Location:  location,
+					})
+				}
+				// Add cases for other instruction types if needed in the future
+				// case *ssa.Send:
+				// case *ssa.Select:
+				// ...
+			}
+
+			// Append the valid call info
+			if callInfo != nil {
+				calls = append(calls, *callInfo)
 			}
 		}
 	}
+	return calls
+}
 
-	// Return the map, the program, the fileset, and no error
-	return callsByPackage, prog, fset, nil
+// functionID returns the stable ID of fn (see utils.FuncID). Closures extend the ID of
+// their enclosing function with their position among its closures ("$1"), like their SSA
+// names; generic instances and wrappers use the ID of the function they instantiate or
+// wrap. It returns "" for nil and for synthetic functions without a declaration.
+func functionID(fn *ssa.Function) string {
+	if fn == nil {
+		return ""
+	}
+	if parent := fn.Parent(); parent != nil {
+		for i, anon := range parent.AnonFuncs {
+			if anon == fn {
+				return functionID(parent) + "$" + strconv.Itoa(i+1)
+			}
+		}
+		return ""
+	}
+	if origin := fn.Origin(); origin != nil {
+		fn = origin
+	}
+	if strings.HasPrefix(fn.Name(), "init#") && fn.Pkg != nil {
+		return fn.Pkg.Pkg.Path() + "." + fn.Name() // Several init functions share their object name
+	}
+	if obj, ok := fn.Object().(*types.Func); ok {
+		return utils.FuncID(obj)
+	}
+	if fn.Pkg != nil && fn.Synthetic == "package initializer" {
+		return fn.Pkg.Pkg.Path() + "." + fn.Name()
+	}
+	return ""
+}
+
+// isOpaqueCallee reports whether the analysis cannot see into callee: it is a cgo stub,
+// or it is declared without a Go body (assembly, linkname) in one of the analyzed packages.
+// Functions from dependencies are never opaque here since their bodies are simply not loaded.
+func isOpaqueCallee(callee *ssa.Function, analyzed map[*ssa.Package]*packages.Package) bool {
+	if callee == nil {
+		return false
+	}
+	if strings.HasPrefix(callee.Name(), "_Cfunc_") || strings.HasPrefix(callee.Name(), "_C2func_") {
+		return true
+	}
+	if callee.Blocks != nil || callee.Synthetic != "" || callee.Package() == nil {
+		return false
+	}
+	pkg, ok := analyzed[callee.Package()]
+	return ok && !declaresBody(pkg, callee.Pos())
+}
+
+// declaresBody reports whether pkg declares a function with a Go body at pos. Callees
+// outside the package being analyzed are not built in low-memory mode, so their lack of
+// blocks says nothing about their body.
+func declaresBody(pkg *packages.Package, pos token.Pos) bool {
+	for _, file := range pkg.Syntax {
+		if pos < file.FileStart || pos > file.FileEnd {
+			continue
+		}
+		for _, decl := range file.Decls {
+			if fd, ok := decl.(*ast.FuncDecl); ok && fd.Name.Pos() == pos {
+				return fd.Body != nil
+			}
+		}
+	}
+	return false
 }
@@ -0,0 +1,130 @@
+// analyzer/ssa/callgraph.go
+package ssa
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/callgraph"
+	"golang.org/x/tools/go/callgraph/cha"
+	"golang.org/x/tools/go/callgraph/rta"
+	"golang.org/x/tools/go/callgraph/vta"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
+)
+
+// Call graph algorithms resolving the candidate callees of dynamic and interface calls.
+const (
+	AlgorithmNone = ""    // Flat scan of the call instructions: only static callees are known
+	AlgorithmCHA  = "cha" // Class Hierarchy Analysis: every method of every type implementing the interface
+	AlgorithmRTA  = "rta" // Rapid Type Analysis: only types instantiated by code reachable from the entry points
+	AlgorithmVTA  = "vta" // Variable Type Analysis: only types flowing into the receiver or function value
+)
+
+// Algorithms lists the selectable call graph algorithms.
+var Algorithms = []string{AlgorithmCHA, AlgorithmRTA, AlgorithmVTA}
+
+// buildCallGraph builds the call graph of prog with algorithm, starting from the entry
+// points of pkgs where the algorithm needs them. It also returns the functions reachable
+// from those entry points, or nil if the algorithm considers all functions reachable.
+// The graph is nil for AlgorithmNone.
+func buildCallGraph(prog *ssa.Program, pkgs []*ssa.Package, algorithm string) (*callgraph.Graph, map[*ssa.Function]bool, error) {
+	switch algorithm {
+	case AlgorithmNone:
+		return nil, nil, nil
+	case AlgorithmCHA:
+		return cha.CallGraph(prog), nil, nil
+	case AlgorithmRTA:
+		roots := entryPoints(prog, pkgs)
+		if len(roots) == 0 {
+			return nil, nil, errors.New("RTA found no entry points: no main package or test functions among the analyzed packages")
+		}
+		res := rta.Analyze(roots, true)
+		reachable := make(map[*ssa.Function]bool, len(res.Reachable))
+		for fn := range res.Reachable {
+			reachable[fn] = true
+		}
+		return res.CallGraph, reachable, nil
+	case AlgorithmVTA:
+		// A nil initial graph makes VTA build the CHA graph it refines more efficiently
+		return vta.CallGraph(ssautil.AllFunctions(prog), nil), nil, nil
+	}
+	return nil, nil, fmt.Errorf("unknown call graph algorithm %q", algorithm)
+}
+
+// entryPoints returns the functions a program built from pkgs can start at: main and
+// init of main packages, and the tests, benchmarks, fuzz targets and examples of test
+// packages along with their package initializers.
+func entryPoints(prog *ssa.Program, pkgs []*ssa.Package) []*ssa.Function {
+	var roots []*ssa.Function
+	for _, pkg := range pkgs {
+		if pkg == nil {
+			continue
+		}
+		if pkg.Pkg.Name() == "main" {
+			if main := pkg.Func("main"); main != nil {
+				roots = append(roots, main, pkg.Func("init"))
+			}
+		}
+		tests := 0
+		for _, member := range pkg.Members {
+			fn, ok := member.(*ssa.Function)
+			if !ok || !strings.HasSuffix(prog.Fset.Position(fn.Pos()).Filename, "_test.go") {
+				continue
+			}
+			if isTestFunc(fn.Name(), "Test") || isTestFunc(fn.Name(), "Benchmark") ||
+				isTestFunc(fn.Name(), "Fuzz") || isTestFunc(fn.Name(), "Example") {
+				roots = append(roots, fn)
+				tests++
+			}
+		}
+		if tests > 0 && pkg.Pkg.Name() != "main" {
+			roots = append(roots, pkg.Func("init"))
+		}
+	}
+	return roots
+}
+
+// isTestFunc reports whether name is a test function name with prefix, following the
+// go test rule that the prefix is not followed by a lower-case letter.
+func isTestFunc(name, prefix string) bool {
+	if !strings.HasPrefix(name, prefix) {
+		return false
+	}
+	if len(name) == len(prefix) {
+		return true
+	}
+	r, _ := utf8.DecodeRuneInString(name[len(prefix):])
+	return !unicode.IsLower(r)
+}
+
+// candidateCallees indexes the edges of cg by call site. A nil graph gives a nil index.
+func candidateCallees(cg *callgraph.Graph) map[ssa.CallInstruction][]string {
+	if cg == nil {
+		return nil
+	}
+	callees := make(map[ssa.CallInstruction][]string)
+	for _, node := range cg.Nodes {
+		for _, edge := range node.Out {
+			if edge.Site == nil || edge.Callee == nil || edge.Callee.Func == nil {
+				continue
+			}
+			callees[edge.Site] = append(callees[edge.Site], edge.Callee.Func.String())
+		}
+	}
+	for site, names := range callees {
+		sort.Strings(names)
+		unique := names[:0]
+		for i, name := range names {
+			if i == 0 || name != names[i-1] {
+				unique = append(unique, name)
+			}
+		}
+		callees[site] = unique
+	}
+	return callees
+}
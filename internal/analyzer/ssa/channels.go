@@ -0,0 +1,186 @@
+// analyzer/ssa/channels.go
+package ssa
+
+import (
+	"go/token"
+	"go/types"
+	"strconv"
+
+	"golang.org/x/tools/go/ssa"
+
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// channelOp returns the channel operation performed by instr in fn, or nil if it
+// performs none. Range loops over channels are receives in SSA.
+func channelOp(instr ssa.Instruction, fn *ssa.Function, fset *token.FileSet) *datamodel.ChannelOp {
+	op := &datamodel.ChannelOp{FunctionID: functionID(fn), Function: fn.String()}
+	switch i := instr.(type) {
+	case *ssa.MakeChan:
+		op.Op = datamodel.ChannelMake
+		op.Channel = channelRef(i, fset, map[ssa.Value]bool{})
+		op.ChanType = types.TypeString(i.Type(), nil)
+	case *ssa.Send:
+		op.Op = datamodel.ChannelSend
+		op.Channel = channelRef(i.Chan, fset, map[ssa.Value]bool{})
+		op.ChanType = types.TypeString(i.Chan.Type(), nil)
+	case *ssa.UnOp:
+		if i.Op != token.ARROW {
+			return nil
+		}
+		op.Op = datamodel.ChannelReceive
+		op.Channel = channelRef(i.X, fset, map[ssa.Value]bool{})
+		op.ChanType = types.TypeString(i.X.Type(), nil)
+	case ssa.CallInstruction: // Including defer close(ch)
+		common := i.Common()
+		builtin, ok := common.Value.(*ssa.Builtin)
+		if !ok || builtin.Name() != "close" || len(common.Args) != 1 {
+			return nil
+		}
+		op.Op = datamodel.ChannelClose
+		op.Channel = channelRef(common.Args[0], fset, map[ssa.Value]bool{})
+		op.ChanType = types.TypeString(common.Args[0].Type(), nil)
+	case *ssa.Select:
+		op.Op = datamodel.ChannelSelect
+		op.Blocking = i.Blocking
+		for _, state := range i.States {
+			c := datamodel.SelectCase{
+				Op:       datamodel.ChannelReceive,
+				Channel:  channelRef(state.Chan, fset, map[ssa.Value]bool{}),
+				ChanType: types.TypeString(state.Chan.Type(), nil),
+			}
+			if state.Dir == types.SendOnly {
+				c.Op = datamodel.ChannelSend
+			}
+			op.Cases = append(op.Cases, c)
+		}
+	default:
+		return nil
+	}
+	return op
+}
+
+// channelRef identifies the channel v evaluates to, as described for
+// datamodel.ChannelOp.Channel, or returns "" if the analysis cannot tell. seen holds
+// the values being resolved, guarding against loops through phi nodes.
+func channelRef(v ssa.Value, fset *token.FileSet, seen map[ssa.Value]bool) string {
+	if v == nil || seen[v] {
+		return ""
+	}
+	seen[v] = true
+	defer delete(seen, v)
+	switch v := v.(type) {
+	case *ssa.MakeChan:
+		if home := storedIn(v); home != "" {
+			return home
+		}
+		return functionID(v.Parent()) + "@" + strconv.Itoa(fset.Position(v.Pos()).Line)
+	case *ssa.Parameter:
+		return functionID(v.Parent()) + "(" + v.Name() + ")"
+	case *ssa.FreeVar:
+		return channelRef(binding(v), fset, seen)
+	case *ssa.Field:
+		return fieldRef(v.X.Type(), v.Field)
+	case *ssa.UnOp:
+		if v.Op == token.MUL {
+			return pointeeRef(v.X, fset, seen)
+		}
+	case *ssa.ChangeType:
+		return channelRef(v.X, fset, seen)
+	case *ssa.Phi:
+		ref := ""
+		for _, edge := range v.Edges {
+			r := channelRef(edge, fset, seen)
+			if r == "" || ref != "" && r != ref {
+				return ""
+			}
+			ref = r
+		}
+		return ref
+	}
+	return ""
+}
+
+// pointeeRef identifies the channel stored at the address ptr.
+func pointeeRef(ptr ssa.Value, fset *token.FileSet, seen map[ssa.Value]bool) string {
+	switch p := ptr.(type) {
+	case *ssa.FieldAddr:
+		return fieldRef(p.X.Type(), p.Field)
+	case *ssa.Global:
+		return p.Pkg.Pkg.Path() + "." + p.Name()
+	case *ssa.FreeVar:
+		return pointeeRef(binding(p), fset, seen)
+	case *ssa.Alloc:
+		// A local captured by reference: follow its only assignment
+		var stored ssa.Value
+		for _, ref := range *p.Referrers() {
+			if store, ok := ref.(*ssa.Store); ok && store.Addr == p {
+				if stored != nil {
+					return ""
+				}
+				stored = store.Val
+			}
+		}
+		return channelRef(stored, fset, seen)
+	}
+	return ""
+}
+
+// storedIn returns the field or package variable the channel made by mc is stored in
+// by its function, if any.
+func storedIn(mc *ssa.MakeChan) string {
+	for _, ref := range *mc.Referrers() {
+		store, ok := ref.(*ssa.Store)
+		if !ok || store.Val != mc {
+			continue
+		}
+		switch addr := store.Addr.(type) {
+		case *ssa.FieldAddr:
+			return fieldRef(addr.X.Type(), addr.Field)
+		case *ssa.Global:
+			return addr.Pkg.Pkg.Path() + "." + addr.Name()
+		}
+	}
+	return ""
+}
+
+// fieldRef returns "pkg/path.Type.field" for field index of the named struct type t
+// (or pointer to it), or "" for fields of unnamed structs.
+func fieldRef(t types.Type, index int) string {
+	if ptr, ok := t.Underlying().(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return ""
+	}
+	st, ok := named.Underlying().(*types.Struct)
+	if !ok || index >= st.NumFields() {
+		return ""
+	}
+	return named.Obj().Pkg().Path() + "." + named.Obj().Name() + "." + st.Field(index).Name()
+}
+
+// binding returns the value captured by fv where its closure is created, or nil if
+// the closure is not created in its enclosing function.
+func binding(fv *ssa.FreeVar) ssa.Value {
+	closure := fv.Parent()
+	index := -1
+	for i, v := range closure.FreeVars {
+		if v == fv {
+			index = i
+		}
+	}
+	parent := closure.Parent()
+	if index < 0 || parent == nil {
+		return nil
+	}
+	for _, b := range parent.Blocks {
+		for _, instr := range b.Instrs {
+			if mc, ok := instr.(*ssa.MakeClosure); ok && mc.Fn == closure && index < len(mc.Bindings) {
+				return mc.Bindings[index]
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+// analyzer/ssa/facts.go
+package ssa
+
+import (
+	"context"
+	"sort"
+
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/ssa"
+
+	"github.com/namikmesic/go-mcp/internal/analyzer"
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// SSACallGraphAnalyzer also implements PackageAnalyzer, adding what AnalyzeCalls found
+// in a package's function bodies besides call sites to the package results.
+var _ analyzer.PackageAnalyzer = (*SSACallGraphAnalyzer)(nil)
+
+// functionFacts collects the instructions of interest found while extracting call
+// sites, with absolute positions.
+type functionFacts struct {
+	Goroutines []datamodel.Goroutine
+	ChannelOps []datamodel.ChannelOp
+	Locking    []datamodel.LockUsage
+	locking    map[string]int // Function ID, lock and kind -> index in Locking
+	// Defer statements, panics and recover calls by function
+	PanicHandling []datamodel.PanicHandling
+	panics        map[string]int // Function ID -> index in PanicHandling
+}
+
+// factsOf returns the facts gathered for pkg, creating them on first use.
+func (a *SSACallGraphAnalyzer) factsOf(pkg *packages.Package) *functionFacts {
+	facts := a.facts[pkg]
+	if facts == nil {
+		facts = &functionFacts{}
+		a.facts[pkg] = facts
+	}
+	return facts
+}
+
+// AnalyzePackage implements analyzer.PackageAnalyzer. It must run after AnalyzeCalls;
+// without the call graph there is nothing to add.
+func (a *SSACallGraphAnalyzer) AnalyzePackage(ctx context.Context, env *analyzer.Env, pkg *packages.Package, result *datamodel.PackageAnalysis) error {
+	facts := a.facts[pkg]
+	if facts == nil {
+		return nil
+	}
+	delete(a.facts, pkg)
+	datamodel.RewriteLocations(facts, env.RelPath)
+	// Functions are visited in no particular order
+	sort.SliceStable(facts.Goroutines, func(i, j int) bool {
+		return locationLess(facts.Goroutines[i].Location, facts.Goroutines[j].Location)
+	})
+	sort.SliceStable(facts.ChannelOps, func(i, j int) bool {
+		return locationLess(facts.ChannelOps[i].Location, facts.ChannelOps[j].Location)
+	})
+	result.Goroutines = append(result.Goroutines, facts.Goroutines...)
+	result.ChannelOps = append(result.ChannelOps, facts.ChannelOps...)
+	finishLocking(facts.Locking)
+	result.Locking = append(result.Locking, facts.Locking...)
+	finishPanicHandling(facts.PanicHandling)
+	result.PanicHandling = append(result.PanicHandling, facts.PanicHandling...)
+	return nil
+}
+
+func locationLess(a, b datamodel.Location) bool {
+	if a.Filename != b.Filename {
+		return a.Filename < b.Filename
+	}
+	if a.Line != b.Line {
+		return a.Line < b.Line
+	}
+	return a.Column < b.Column
+}
+
+// isClosure reports whether v, the function called by a go or defer statement, is a
+// function literal. Bound method values are closures over a synthetic wrapper.
+func isClosure(v ssa.Value) bool {
+	if mc, ok := v.(*ssa.MakeClosure); ok {
+		v = mc.Fn
+	}
+	f, ok := v.(*ssa.Function)
+	return ok && f.Parent() != nil
+}
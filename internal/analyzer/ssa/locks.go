@@ -0,0 +1,102 @@
+// analyzer/ssa/locks.go
+package ssa
+
+import (
+	"go/token"
+	"sort"
+	"strconv"
+
+	"golang.org/x/tools/go/ssa"
+
+	"github.com/namikmesic/go-mcp/internal/analyzer/utils"
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// lockCounterparts pairs the mutex methods that acquire a lock with those releasing it.
+var lockCounterparts = []struct{ acquire, tryAcquire, release string }{
+	{"Lock", "TryLock", "Unlock"},
+	{"RLock", "TryRLock", "RUnlock"},
+}
+
+// addLockOp adds call to facts if it calls a method of sync.Mutex, sync.RWMutex or
+// sync.Map, grouping the calls of fn by the value they are made on.
+func addLockOp(facts *functionFacts, fn *ssa.Function, call ssa.CallInstruction, location datamodel.Location, fset *token.FileSet) {
+	common := call.Common()
+	callee := common.StaticCallee()
+	if common.IsInvoke() || callee == nil || callee.Signature.Recv() == nil || len(common.Args) == 0 {
+		return
+	}
+	kind := utils.SyncKind(callee.Signature.Recv().Type())
+	if kind == "" {
+		return
+	}
+	_, deferred := call.(*ssa.Defer)
+	op := datamodel.LockOp{Method: callee.Name(), Deferred: deferred, Location: location}
+
+	id := functionID(fn)
+	lock := lockRef(common.Args[0], fset)
+	key := id + "\x00" + lock + "\x00" + kind
+	if facts.locking == nil {
+		facts.locking = make(map[string]int)
+	}
+	i, ok := facts.locking[key]
+	if !ok {
+		i = len(facts.Locking)
+		facts.locking[key] = i
+		facts.Locking = append(facts.Locking, datamodel.LockUsage{FunctionID: id, Function: fn.String(), Lock: lock, Kind: kind})
+	}
+	facts.Locking[i].Ops = append(facts.Locking[i].Ops, op)
+}
+
+// lockRef identifies the mutex or sync.Map the pointer v refers to (see
+// datamodel.LockUsage.Lock), or returns "" if the analysis cannot tell.
+func lockRef(v ssa.Value, fset *token.FileSet) string {
+	switch v := v.(type) {
+	case *ssa.Alloc:
+		return functionID(v.Parent()) + "@" + strconv.Itoa(fset.Position(v.Pos()).Line)
+	case *ssa.FieldAddr, *ssa.Global:
+		return pointeeRef(v, fset, map[ssa.Value]bool{})
+	case *ssa.Parameter:
+		return functionID(v.Parent()) + "(" + v.Name() + ")"
+	case *ssa.FreeVar:
+		return lockRef(binding(v), fset)
+	case *ssa.UnOp:
+		// A pointer to the lock loaded from a field or variable
+		if v.Op == token.MUL {
+			return pointeeRef(v.X, fset, map[ssa.Value]bool{})
+		}
+	}
+	return ""
+}
+
+// finishLocking sorts the ops of each usage and the usages by their first op, and sets
+// Unpaired.
+func finishLocking(usages []datamodel.LockUsage) {
+	for i := range usages {
+		u := &usages[i]
+		sort.SliceStable(u.Ops, func(a, b int) bool { return locationLess(u.Ops[a].Location, u.Ops[b].Location) })
+		if u.Kind == datamodel.SyncMap {
+			continue
+		}
+		calls := make(map[string]int)
+		for _, op := range u.Ops {
+			calls[op.Method]++
+		}
+		for _, c := range lockCounterparts {
+			acquired := calls[c.acquire] + calls[c.tryAcquire]
+			switch {
+			case acquired > 0 && calls[c.release] == 0:
+				if calls[c.acquire] > 0 {
+					u.Unpaired = append(u.Unpaired, c.acquire)
+				} else {
+					u.Unpaired = append(u.Unpaired, c.tryAcquire)
+				}
+			case acquired == 0 && calls[c.release] > 0:
+				u.Unpaired = append(u.Unpaired, c.release)
+			}
+		}
+	}
+	sort.SliceStable(usages, func(i, j int) bool {
+		return locationLess(usages[i].Ops[0].Location, usages[j].Ops[0].Location)
+	})
+}
@@ -0,0 +1,93 @@
+// analyzer/ssa/panics.go
+package ssa
+
+import (
+	"go/types"
+	"sort"
+
+	"golang.org/x/tools/go/ssa"
+
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// panicHandlingOf returns the entry of fn in facts, creating it on first use.
+func panicHandlingOf(facts *functionFacts, fn *ssa.Function) *datamodel.PanicHandling {
+	id := functionID(fn)
+	if facts.panics == nil {
+		facts.panics = make(map[string]int)
+	}
+	i, ok := facts.panics[id]
+	if !ok {
+		i = len(facts.PanicHandling)
+		facts.panics[id] = i
+		facts.PanicHandling = append(facts.PanicHandling, datamodel.PanicHandling{FunctionID: id, Function: fn.String()})
+	}
+	return &facts.PanicHandling[i]
+}
+
+// addPanic adds the call to panic p in fn to facts.
+func addPanic(facts *functionFacts, fn *ssa.Function, p *ssa.Panic, location datamodel.Location) {
+	value := p.X
+	switch v := value.(type) {
+	case *ssa.MakeInterface:
+		value = v.X
+	case *ssa.ChangeInterface: // e.g. an error
+		value = v.X
+	}
+	h := panicHandlingOf(facts, fn)
+	h.Panics = append(h.Panics, datamodel.PanicSite{Value: types.TypeString(value.Type(), nil), Location: location})
+}
+
+// addRecoverOrDefer adds call to facts if it is a defer statement or calls recover.
+// calleeDesc describes the called function as in the call site.
+func addRecoverOrDefer(facts *functionFacts, fn *ssa.Function, call ssa.CallInstruction, calleeDesc string, location datamodel.Location) {
+	common := call.Common()
+	if _, ok := call.(*ssa.Defer); ok {
+		h := panicHandlingOf(facts, fn)
+		h.Defers = append(h.Defers, datamodel.DeferStmt{
+			Callee:   calleeDesc,
+			CalleeID: functionID(common.StaticCallee()),
+			Closure:  isClosure(common.Value),
+			Recovers: callsRecover(common.StaticCallee()),
+			Location: location,
+		})
+	}
+	if isRecover(common) {
+		h := panicHandlingOf(facts, fn)
+		h.Recovers = append(h.Recovers, location)
+	}
+}
+
+// isRecover reports whether common calls the recover builtin.
+func isRecover(common *ssa.CallCommon) bool {
+	builtin, ok := common.Value.(*ssa.Builtin)
+	return ok && builtin.Name() == "recover"
+}
+
+// callsRecover reports whether the body of fn calls recover. Only the deferred function
+// itself can stop a panic this way, not the functions it calls.
+func callsRecover(fn *ssa.Function) bool {
+	if fn == nil {
+		return false
+	}
+	for _, b := range fn.Blocks {
+		for _, instr := range b.Instrs {
+			if call, ok := instr.(*ssa.Call); ok && isRecover(call.Common()) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// finishPanicHandling sorts the entries of each function in source order, and the
+// functions by ID.
+func finishPanicHandling(entries []datamodel.PanicHandling) {
+	for i := range entries {
+		h := &entries[i]
+		sort.SliceStable(h.Defers, func(a, b int) bool { return locationLess(h.Defers[a].Location, h.Defers[b].Location) })
+		sort.SliceStable(h.Panics, func(a, b int) bool { return locationLess(h.Panics[a].Location, h.Panics[b].Location) })
+		sort.SliceStable(h.Recovers, func(a, b int) bool { return locationLess(h.Recovers[a], h.Recovers[b]) })
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].FunctionID < entries[j].FunctionID })
+}
@@ -0,0 +1,128 @@
+// analyzer/stability/classifier.go
+package stability
+
+import (
+	"context"
+	"go/token"
+	"strings"
+
+	"github.com/namikmesic/go-mcp/internal/analyzer"
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// DefaultCoreThreshold is the number of consuming packages above which a public
+// interface is considered Core.
+const DefaultCoreThreshold = 3
+
+// Classifier implements analyzer.ProjectAnalyzer by assigning a Stability level
+// and consumer count to every interface.
+type Classifier struct {
+	// CoreThreshold is the minimum number of consuming packages for StabilityCore.
+	CoreThreshold int
+}
+
+// Compile-time check to ensure Classifier implements ProjectAnalyzer.
+var _ analyzer.ProjectAnalyzer = (*Classifier)(nil)
+
+func NewClassifier() *Classifier {
+	return &Classifier{CoreThreshold: DefaultCoreThreshold}
+}
+
+// AnalyzeProject implements analyzer.ProjectAnalyzer.
+func (c *Classifier) AnalyzeProject(ctx context.Context, env *analyzer.Env, analysis *datamodel.ProjectAnalysis) error {
+	c.Classify(analysis)
+	return nil
+}
+
+// Classify updates the Stability and ConsumerCount fields of all interfaces in the analysis.
+//
+// A consumer is any package other than the defining one that either implements the
+// interface or calls one of its methods through an interface call.
+// Interfaces that are unexported or live under an /internal/ path are Internal;
+// the remaining ones are Core when consumed by at least CoreThreshold packages and Public otherwise.
+func (c *Classifier) Classify(analysis *datamodel.ProjectAnalysis) {
+	if analysis == nil {
+		return
+	}
+
+	// Collect consuming packages per interface key (packagePath + "." + interfaceName)
+	consumers := make(map[string]map[string]bool)
+	addConsumer := func(ifaceKey, pkgPath string) {
+		if consumers[ifaceKey] == nil {
+			consumers[ifaceKey] = make(map[string]bool)
+		}
+		consumers[ifaceKey][pkgPath] = true
+	}
+
+	ifaceKeys := make(map[string]bool)
+	for _, pkg := range analysis.Packages {
+		if pkg == nil {
+			continue
+		}
+		for _, iface := range pkg.Interfaces {
+			key := iface.PackagePath + "." + iface.Name
+			ifaceKeys[key] = true
+			for _, impl := range iface.Implementations {
+				if impl.PackagePath != iface.PackagePath {
+					addConsumer(key, impl.PackagePath)
+				}
+			}
+		}
+	}
+	for _, pkg := range analysis.Packages {
+		if pkg == nil {
+			continue
+		}
+		for _, call := range pkg.Calls {
+			if call.CallType != "Interface" {
+				continue
+			}
+			// Interface call descriptions look like "Interface method M on pkg/path.Iface"
+			idx := strings.LastIndex(call.CalleeDesc, " on ")
+			if idx < 0 {
+				continue
+			}
+			key := call.CalleeDesc[idx+len(" on "):]
+			if ifaceKeys[key] && !strings.HasPrefix(key, pkg.Path+".") {
+				addConsumer(key, pkg.Path)
+			}
+		}
+	}
+
+	threshold := c.CoreThreshold
+	if threshold <= 0 {
+		threshold = DefaultCoreThreshold
+	}
+	for _, pkg := range analysis.Packages {
+		if pkg == nil {
+			continue
+		}
+		for i := range pkg.Interfaces {
+			iface := &pkg.Interfaces[i]
+			count := len(consumers[iface.PackagePath+"."+iface.Name])
+			iface.ConsumerCount = count
+			switch {
+			case IsInternal(iface.PackagePath, iface.Name):
+				iface.Stability = datamodel.StabilityInternal
+			case count >= threshold:
+				iface.Stability = datamodel.StabilityCore
+			default:
+				iface.Stability = datamodel.StabilityPublic
+			}
+		}
+	}
+}
+
+// IsInternal reports whether a symbol is invisible outside its module: either the
+// name is unexported or the package path contains an "internal" element.
+func IsInternal(pkgPath, name string) bool {
+	if !token.IsExported(name) {
+		return true
+	}
+	for _, elem := range strings.Split(pkgPath, "/") {
+		if elem == "internal" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,241 @@
+// analyzer/typesystem/adapter_gaps.go
+package typesystem
+
+import (
+	"context"
+	"go/types"
+	"sort"
+	"sync"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/namikmesic/go-mcp/internal/analyzer"
+	"github.com/namikmesic/go-mcp/internal/analyzer/filter"
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// AdapterGapAnalyzer diagnoses interface/implementation pairs that the implementation
+// finder cannot connect because they span module boundaries: interfaces with no
+// implementation in any loaded module, and loaded types implementing interfaces from
+// modules that are not loaded (only interfaces of directly imported packages are
+// considered). It must be registered as both a PackageAnalyzer and a ProjectAnalyzer.
+type AdapterGapAnalyzer struct {
+	// Filter decides which types are considered as implementations.
+	Filter analyzer.FilterPolicy
+
+	mu            sync.Mutex
+	loadedModules map[string]bool
+	pkgModules    map[string]string          // package path -> module path
+	candidates    map[string]*gapCandidate   // packagePath.TypeName -> type
+	foreignIfaces map[string]*foreignIface   // packagePath.Name -> interface in another module
+	imports       map[string]map[string]bool // package path -> foreign interface keys visible to it
+}
+
+type gapCandidate struct {
+	obj      *types.TypeName
+	pkgPath  string
+	module   string
+	location datamodel.Location
+}
+
+type foreignIface struct {
+	iface  *types.Interface
+	module string
+}
+
+// Compile-time checks to ensure AdapterGapAnalyzer implements both analyzer passes.
+var (
+	_ analyzer.PackageAnalyzer = (*AdapterGapAnalyzer)(nil)
+	_ analyzer.ProjectAnalyzer = (*AdapterGapAnalyzer)(nil)
+	_ analyzer.Filterable      = (*AdapterGapAnalyzer)(nil)
+)
+
+func NewAdapterGapAnalyzer() *AdapterGapAnalyzer {
+	return &AdapterGapAnalyzer{Filter: filter.AllowAll()}
+}
+
+// SetFilterPolicy implements analyzer.Filterable.
+func (g *AdapterGapAnalyzer) SetFilterPolicy(policy analyzer.FilterPolicy) {
+	g.Filter = policy
+}
+
+func (g *AdapterGapAnalyzer) reset() {
+	g.loadedModules = make(map[string]bool)
+	g.pkgModules = make(map[string]string)
+	g.candidates = make(map[string]*gapCandidate)
+	g.foreignIfaces = make(map[string]*foreignIface)
+	g.imports = make(map[string]map[string]bool)
+}
+
+// AnalyzePackage records the package's module, its concrete named types and the
+// interfaces exported by directly imported packages of other modules.
+func (g *AdapterGapAnalyzer) AnalyzePackage(ctx context.Context, env *analyzer.Env, pkg *packages.Package, result *datamodel.PackageAnalysis) error {
+	if pkg.Types == nil || pkg.Module == nil {
+		return nil
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.loadedModules == nil {
+		g.reset()
+	}
+	module := pkg.Module.Path
+	g.loadedModules[module] = true
+	g.pkgModules[pkg.PkgPath] = module
+
+	scope := pkg.Types.Scope()
+	for _, name := range scope.Names() {
+		typeName, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || typeName.IsAlias() {
+			continue
+		}
+		named, ok := typeName.Type().(*types.Named)
+		if !ok || named.TypeParams().Len() > 0 {
+			continue // Generic types need instantiation before they can be checked
+		}
+		if _, isIface := named.Underlying().(*types.Interface); isIface {
+			continue
+		}
+		key := pkg.PkgPath + "." + name
+		if g.candidates[key] != nil {
+			continue // Test variants repeat the package's types
+		}
+		if !g.Filter.IncludeSymbol(analyzer.Symbol{
+			Kind:        analyzer.KindImplementation,
+			Name:        name,
+			PackagePath: pkg.PkgPath,
+			Exported:    typeName.Exported(),
+			Generated:   filter.IsGenerated(pkg, typeName.Pos()),
+		}) {
+			continue
+		}
+		g.candidates[key] = &gapCandidate{obj: typeName, pkgPath: pkg.PkgPath, module: module, location: env.Location(typeName.Pos())}
+	}
+
+	visible := g.imports[pkg.PkgPath]
+	if visible == nil {
+		visible = make(map[string]bool)
+		g.imports[pkg.PkgPath] = visible
+	}
+	for _, imp := range pkg.Imports {
+		// Standard library packages have no module and are out of scope
+		if imp.Types == nil || imp.Module == nil || imp.Module.Path == module {
+			continue
+		}
+		impScope := imp.Types.Scope()
+		for _, name := range impScope.Names() {
+			typeName, ok := impScope.Lookup(name).(*types.TypeName)
+			if !ok || !typeName.Exported() {
+				continue
+			}
+			named, ok := typeName.Type().(*types.Named)
+			if !ok || named.TypeParams().Len() > 0 {
+				continue
+			}
+			iface, ok := named.Underlying().(*types.Interface)
+			if !ok || iface.NumMethods() == 0 {
+				continue // Everything implements an empty interface
+			}
+			key := imp.PkgPath + "." + name
+			if g.foreignIfaces[key] == nil {
+				g.foreignIfaces[key] = &foreignIface{iface: iface, module: imp.Module.Path}
+			}
+			visible[key] = true
+		}
+	}
+	return nil
+}
+
+// AnalyzeProject writes ProjectAnalysis.Findings.AdapterGaps and resets the recorded state.
+func (g *AdapterGapAnalyzer) AnalyzeProject(ctx context.Context, env *analyzer.Env, analysis *datamodel.ProjectAnalysis) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.loadedModules == nil {
+		return nil
+	}
+	defer func() { g.loadedModules = nil }()
+
+	// Interfaces are reported as implementing themselves (and their embedders);
+	// only concrete types count as implementations here
+	interfaceKeys := make(map[string]bool)
+	for _, pkg := range analysis.Packages {
+		if pkg == nil {
+			continue
+		}
+		for _, iface := range pkg.Interfaces {
+			interfaceKeys[iface.PackagePath+"."+iface.Name] = true
+		}
+	}
+
+	gaps := &datamodel.AdapterGaps{}
+	seen := make(map[string]bool)
+	for _, pkg := range analysis.Packages {
+		if pkg == nil {
+			continue
+		}
+		for _, iface := range pkg.Interfaces {
+			key := iface.PackagePath + "." + iface.Name
+			if seen[key] || hasConcreteImplementation(iface, interfaceKeys) {
+				continue
+			}
+			seen[key] = true
+			gaps.UnimplementedInterfaces = append(gaps.UnimplementedInterfaces, datamodel.UnimplementedInterface{
+				Interface: key,
+				Module:    g.pkgModules[iface.PackagePath],
+				Location:  iface.Location,
+			})
+		}
+	}
+
+	for _, key := range sortedKeys(g.candidates) {
+		c := g.candidates[key]
+		for _, ifaceKey := range sortedKeys(g.imports[c.pkgPath]) {
+			foreign := g.foreignIfaces[ifaceKey]
+			if g.loadedModules[foreign.module] {
+				continue // The implementation finder already covers loaded modules
+			}
+			isPointer := false
+			if !types.Implements(c.obj.Type(), foreign.iface) {
+				if !types.Implements(types.NewPointer(c.obj.Type()), foreign.iface) {
+					continue
+				}
+				isPointer = true
+			}
+			gaps.ExternalImplementations = append(gaps.ExternalImplementations, datamodel.ExternalImplementation{
+				TypeName:        c.obj.Name(),
+				PackagePath:     c.pkgPath,
+				Module:          c.module,
+				Interface:       ifaceKey,
+				InterfaceModule: foreign.module,
+				IsPointer:       isPointer,
+				Location:        c.location,
+			})
+		}
+	}
+
+	if len(gaps.UnimplementedInterfaces) == 0 && len(gaps.ExternalImplementations) == 0 {
+		return nil
+	}
+	if analysis.Findings == nil {
+		analysis.Findings = &datamodel.Findings{}
+	}
+	analysis.Findings.AdapterGaps = gaps
+	return nil
+}
+
+func hasConcreteImplementation(iface datamodel.Interface, interfaceKeys map[string]bool) bool {
+	for _, impl := range iface.Implementations {
+		if !interfaceKeys[impl.PackagePath+"."+impl.TypeName] {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,188 @@
+// analyzer/typesystem/cross_module.go
+package typesystem
+
+import (
+	"context"
+	"go/types"
+	"sync"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/namikmesic/go-mcp/internal/analyzer"
+	"github.com/namikmesic/go-mcp/internal/analyzer/filter"
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// CrossModuleAnalyzer connects the analyzed modules with their dependencies: it
+// matches the interfaces of the analyzed packages against the exported types of
+// every (transitively) imported dependency package, and the dependencies' exported
+// interfaces against the analyzed types. The standard library is not considered a
+// dependency. It must be registered as both a PackageAnalyzer and a ProjectAnalyzer.
+type CrossModuleAnalyzer struct {
+	// Filter decides which analyzed types are considered as implementations.
+	Filter analyzer.FilterPolicy
+	// SkipVendored ignores dependency packages resolved from a vendor directory.
+	SkipVendored bool
+
+	mu            sync.Mutex
+	loadedModules map[string]bool
+	local         map[string]*moduleType // packagePath.Name -> analyzed type or interface
+	imported      map[string]*moduleType // packagePath.Name -> exported dependency type or interface
+	visited       map[string]bool        // Imported package paths already recorded
+}
+
+type moduleType struct {
+	named    *types.Named
+	pkgPath  string
+	module   string
+	location datamodel.Location
+}
+
+// Compile-time checks to ensure CrossModuleAnalyzer implements both analyzer passes.
+var (
+	_ analyzer.PackageAnalyzer = (*CrossModuleAnalyzer)(nil)
+	_ analyzer.ProjectAnalyzer = (*CrossModuleAnalyzer)(nil)
+	_ analyzer.Filterable      = (*CrossModuleAnalyzer)(nil)
+)
+
+func NewCrossModuleAnalyzer() *CrossModuleAnalyzer {
+	return &CrossModuleAnalyzer{Filter: filter.AllowAll()}
+}
+
+// SetFilterPolicy implements analyzer.Filterable.
+func (c *CrossModuleAnalyzer) SetFilterPolicy(policy analyzer.FilterPolicy) {
+	c.Filter = policy
+}
+
+// AnalyzePackage records the named types of pkg and the exported named types of the
+// module packages it imports.
+func (c *CrossModuleAnalyzer) AnalyzePackage(ctx context.Context, env *analyzer.Env, pkg *packages.Package, result *datamodel.PackageAnalysis) error {
+	if pkg.Types == nil || pkg.Module == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.loadedModules == nil {
+		c.loadedModules = make(map[string]bool)
+		c.local = make(map[string]*moduleType)
+		c.imported = make(map[string]*moduleType)
+		c.visited = make(map[string]bool)
+	}
+	c.loadedModules[pkg.Module.Path] = true
+	c.recordTypes(env, pkg, c.local, false)
+
+	imports := make([]*packages.Package, 0, len(pkg.Imports))
+	for _, imp := range pkg.Imports {
+		imports = append(imports, imp)
+	}
+	packages.Visit(imports, func(imp *packages.Package) bool {
+		if c.visited[imp.PkgPath] {
+			return false
+		}
+		c.visited[imp.PkgPath] = true
+		// Standard library packages have no module
+		if imp.Types != nil && imp.Module != nil && !(c.SkipVendored && filter.IsVendored(imp)) {
+			c.recordTypes(env, imp, c.imported, true)
+		}
+		return true
+	}, nil)
+	return nil
+}
+
+// recordTypes adds the non-generic named types of pkg to into. Imported packages
+// contribute exported types only; analyzed types must pass the filter policy.
+func (c *CrossModuleAnalyzer) recordTypes(env *analyzer.Env, pkg *packages.Package, into map[string]*moduleType, imported bool) {
+	scope := pkg.Types.Scope()
+	for _, name := range scope.Names() {
+		typeName, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || typeName.IsAlias() {
+			continue
+		}
+		named, ok := typeName.Type().(*types.Named)
+		if !ok || named.TypeParams().Len() > 0 {
+			continue // Generic types need instantiation before they can be checked
+		}
+		key := pkg.PkgPath + "." + name
+		if into[key] != nil {
+			continue // Test variants repeat the package's types
+		}
+		if imported {
+			if !typeName.Exported() {
+				continue
+			}
+		} else if !c.Filter.IncludeSymbol(analyzer.Symbol{
+			Kind:        analyzer.KindImplementation,
+			Name:        name,
+			PackagePath: pkg.PkgPath,
+			Exported:    typeName.Exported(),
+			Generated:   filter.IsGenerated(pkg, typeName.Pos()),
+		}) {
+			continue
+		}
+		into[key] = &moduleType{named: named, pkgPath: pkg.PkgPath, module: pkg.Module.Path, location: env.Location(typeName.Pos())}
+	}
+}
+
+// AnalyzeProject writes ProjectAnalysis.CrossModuleImplementations and resets the
+// recorded state.
+func (c *CrossModuleAnalyzer) AnalyzeProject(ctx context.Context, env *analyzer.Env, analysis *datamodel.ProjectAnalysis) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.loadedModules == nil {
+		return nil
+	}
+	defer func() { c.loadedModules, c.local, c.imported, c.visited = nil, nil, nil, nil }()
+
+	// Packages of the analyzed modules are reached through imports too
+	deps := make(map[string]*moduleType)
+	for key, t := range c.imported {
+		if !c.loadedModules[t.module] {
+			deps[key] = t
+		}
+	}
+
+	// Dependency types implementing analyzed interfaces, then the reverse
+	var impls []datamodel.ExternalImplementation
+	impls = appendModuleImplementations(impls, c.local, deps)
+	impls = appendModuleImplementations(impls, deps, c.local)
+	analysis.CrossModuleImplementations = impls
+	return nil
+}
+
+// appendModuleImplementations appends an entry for every concrete type in candidates that
+// implements a non-empty interface in ifaces.
+func appendModuleImplementations(impls []datamodel.ExternalImplementation, ifaces, candidates map[string]*moduleType) []datamodel.ExternalImplementation {
+	ifaceKeys := make([]string, 0, len(ifaces))
+	for _, key := range sortedKeys(ifaces) {
+		if iface, ok := ifaces[key].named.Underlying().(*types.Interface); ok && iface.NumMethods() > 0 {
+			ifaceKeys = append(ifaceKeys, key) // Everything implements an empty interface
+		}
+	}
+	for _, typeKey := range sortedKeys(candidates) {
+		t := candidates[typeKey]
+		if _, isIface := t.named.Underlying().(*types.Interface); isIface {
+			continue
+		}
+		for _, ifaceKey := range ifaceKeys {
+			iface := ifaces[ifaceKey]
+			ifaceType := iface.named.Underlying().(*types.Interface)
+			isPointer := false
+			if !types.Implements(t.named, ifaceType) {
+				if !types.Implements(types.NewPointer(t.named), ifaceType) {
+					continue
+				}
+				isPointer = true
+			}
+			impls = append(impls, datamodel.ExternalImplementation{
+				TypeName:        t.named.Obj().Name(),
+				PackagePath:     t.pkgPath,
+				Module:          t.module,
+				Interface:       ifaceKey,
+				InterfaceModule: iface.module,
+				IsPointer:       isPointer,
+				Location:        t.location,
+			})
+		}
+	}
+	return impls
+}
@@ -0,0 +1,217 @@
+// analyzer/typesystem/duplicate_interfaces.go
+package typesystem
+
+import (
+	"context"
+	"go/types"
+	"sort"
+	"strings"
+
+	"github.com/namikmesic/go-mcp/internal/analyzer"
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// DefaultMinSubsetMethods is the smallest method set reported as a subset of another
+// interface. Smaller interfaces (io.Closer) are meant to be contained in many others.
+const DefaultMinSubsetMethods = 2
+
+// DuplicateInterfaceAnalyzer implements analyzer.ProjectAnalyzer by listing in
+// Findings.DuplicateInterfaces the interfaces of different packages whose method sets
+// are identical, or a subset of one another. Methods match by name and fully
+// qualified parameter and result types, whatever the parameter names. An interface is
+// not reported as a subset of the interfaces embedding it, directly or not.
+type DuplicateInterfaceAnalyzer struct {
+	// MinSubsetMethods is the smallest method set reported as a subset.
+	MinSubsetMethods int
+}
+
+// Compile-time check to ensure DuplicateInterfaceAnalyzer implements ProjectAnalyzer.
+var _ analyzer.ProjectAnalyzer = (*DuplicateInterfaceAnalyzer)(nil)
+
+func NewDuplicateInterfaceAnalyzer() *DuplicateInterfaceAnalyzer {
+	return &DuplicateInterfaceAnalyzer{MinSubsetMethods: DefaultMinSubsetMethods}
+}
+
+// methodSetEntry is an interface with a non-empty method set.
+type methodSetEntry struct {
+	key      string
+	pkgPath  string
+	methods  map[string]bool // Method ID + " " + signatureKey
+	embedded map[string]bool // Keys of the interfaces it embeds, directly or not
+}
+
+// AnalyzeProject implements analyzer.ProjectAnalyzer.
+func (a *DuplicateInterfaceAnalyzer) AnalyzeProject(ctx context.Context, env *analyzer.Env, analysis *datamodel.ProjectAnalysis) error {
+	minSubset := a.MinSubsetMethods
+	if minSubset <= 0 {
+		minSubset = DefaultMinSubsetMethods
+	}
+
+	var entries []*methodSetEntry
+	for _, pkg := range analysis.Packages {
+		if pkg == nil {
+			continue
+		}
+		for i := range pkg.Interfaces {
+			if entry := newMethodSetEntry(&pkg.Interfaces[i]); entry != nil {
+				entries = append(entries, entry)
+			}
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].key < entries[j].key })
+
+	// Union-find over entry indexes
+	parent := make([]int, len(entries))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	var relations []datamodel.InterfaceRelation
+	for i, x := range entries {
+		for j := i + 1; j < len(entries); j++ {
+			y := entries[j]
+			if x.pkgPath == y.pkgPath {
+				continue
+			}
+			var rel datamodel.InterfaceRelation
+			switch {
+			case len(x.methods) == len(y.methods) && containsAll(y.methods, x.methods):
+				rel = datamodel.InterfaceRelation{Interface: x.key, Other: y.key, Relation: datamodel.InterfaceIdentical}
+			case len(x.methods) < len(y.methods) && len(x.methods) >= minSubset && !y.embedded[x.key] && containsAll(y.methods, x.methods):
+				rel = datamodel.InterfaceRelation{Interface: x.key, Other: y.key, Relation: datamodel.InterfaceSubset}
+			case len(y.methods) < len(x.methods) && len(y.methods) >= minSubset && !x.embedded[y.key] && containsAll(x.methods, y.methods):
+				rel = datamodel.InterfaceRelation{Interface: y.key, Other: x.key, Relation: datamodel.InterfaceSubset}
+			default:
+				continue
+			}
+			relations = append(relations, rel)
+			parent[find(i)] = find(j)
+		}
+	}
+	if len(relations) == 0 {
+		return nil
+	}
+
+	index := make(map[string]int, len(entries))
+	for i, entry := range entries {
+		index[entry.key] = i
+	}
+	byRoot := make(map[int]*datamodel.InterfaceCluster)
+	var clusters []*datamodel.InterfaceCluster
+	for _, rel := range relations {
+		root := find(index[rel.Interface])
+		cluster := byRoot[root]
+		if cluster == nil {
+			cluster = &datamodel.InterfaceCluster{}
+			byRoot[root] = cluster
+			clusters = append(clusters, cluster)
+		}
+		cluster.Relations = append(cluster.Relations, rel)
+	}
+	for i, entry := range entries {
+		if cluster := byRoot[find(i)]; cluster != nil {
+			cluster.Interfaces = append(cluster.Interfaces, entry.key)
+		}
+	}
+	// Largest first; entries are sorted, so clusters are ordered by their first interface
+	sort.SliceStable(clusters, func(i, j int) bool {
+		return len(clusters[i].Interfaces) > len(clusters[j].Interfaces)
+	})
+
+	if analysis.Findings == nil {
+		analysis.Findings = &datamodel.Findings{}
+	}
+	for _, cluster := range clusters {
+		analysis.Findings.DuplicateInterfaces = append(analysis.Findings.DuplicateInterfaces, *cluster)
+	}
+	return nil
+}
+
+// newMethodSetEntry returns the method set of iface, or nil if it has no methods.
+// Signatures come from the type checker when available and from the declared
+// signatures otherwise (e.g. for cached dependency interfaces).
+func newMethodSetEntry(iface *datamodel.Interface) *methodSetEntry {
+	entry := &methodSetEntry{
+		key:      iface.PackagePath + "." + iface.Name,
+		pkgPath:  iface.PackagePath,
+		methods:  make(map[string]bool),
+		embedded: make(map[string]bool),
+	}
+	if t := iface.UnderlyingType; t != nil {
+		for i := 0; i < t.NumMethods(); i++ {
+			m := t.Method(i)
+			entry.methods[m.Id()+" "+signatureKey(m.Type().(*types.Signature))] = true
+		}
+		addEmbedded(t, entry.embedded, make(map[*types.Interface]bool))
+	} else {
+		methods := iface.Methods
+		if len(iface.MethodSet) > 0 {
+			methods = iface.MethodSet
+		}
+		for _, m := range methods {
+			entry.methods[m.Signature] = true
+		}
+	}
+	if len(entry.methods) == 0 {
+		return nil
+	}
+	return entry
+}
+
+// signatureKey formats the parameter and result types of sig, fully qualified and
+// without their names.
+func signatureKey(sig *types.Signature) string {
+	var b strings.Builder
+	for i, tuple := range []*types.Tuple{sig.Params(), sig.Results()} {
+		if i > 0 {
+			b.WriteString(" ")
+		}
+		b.WriteString("(")
+		for j := 0; j < tuple.Len(); j++ {
+			if j > 0 {
+				b.WriteString(", ")
+			}
+			t := tuple.At(j).Type()
+			if i == 0 && sig.Variadic() && j == tuple.Len()-1 {
+				b.WriteString("...")
+				t = t.(*types.Slice).Elem()
+			}
+			b.WriteString(types.TypeString(t, nil))
+		}
+		b.WriteString(")")
+	}
+	return b.String()
+}
+
+// addEmbedded adds the keys of the named interfaces embedded in t, directly or not.
+func addEmbedded(t *types.Interface, keys map[string]bool, seen map[*types.Interface]bool) {
+	if seen[t] {
+		return
+	}
+	seen[t] = true
+	for i := 0; i < t.NumEmbeddeds(); i++ {
+		embedded := t.EmbeddedType(i)
+		if named, ok := types.Unalias(embedded).(*types.Named); ok && named.Obj().Pkg() != nil {
+			keys[named.Obj().Pkg().Path()+"."+named.Obj().Name()] = true
+		}
+		if it, ok := embedded.Underlying().(*types.Interface); ok {
+			addEmbedded(it, keys, seen)
+		}
+	}
+}
+
+// containsAll reports whether set contains every element of subset.
+func containsAll(set, subset map[string]bool) bool {
+	for m := range subset {
+		if !set[m] {
+			return false
+		}
+	}
+	return true
+}
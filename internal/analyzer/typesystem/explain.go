@@ -0,0 +1,208 @@
+// analyzer/typesystem/explain.go
+package typesystem
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// SatisfactionExplainer explains why a type does or does not satisfy an interface.
+// It loads only the two packages involved (and their dependencies) per query, so
+// servers can answer on demand without keeping type information in memory.
+type SatisfactionExplainer struct {
+	// Config is used to load the packages; Dir should be the module directory.
+	Config packages.Config
+}
+
+func NewSatisfactionExplainer(moduleDir string) *SatisfactionExplainer {
+	return &SatisfactionExplainer{
+		Config: packages.Config{
+			// Type-check from source like the main loader, which avoids depending on
+			// the toolchain's export data format
+			Mode: packages.NeedName |
+				packages.NeedImports |
+				packages.NeedDeps |
+				packages.NeedTypes |
+				packages.NeedSyntax |
+				packages.NeedTypesInfo |
+				packages.NeedModule,
+			Dir:   moduleDir,
+			Tests: true, // Types declared in test files can be explained too
+		},
+	}
+}
+
+// Explain compares the method set of typeName (packagePath + "." + TypeName) with the
+// methods of ifaceName (packagePath + "." + InterfaceName).
+func (e *SatisfactionExplainer) Explain(ifaceName, typeName string) (*datamodel.SatisfactionExplanation, error) {
+	ifacePkgPath, ifaceIdent := splitQualifiedName(ifaceName)
+	typePkgPath, typeIdent := splitQualifiedName(typeName)
+	if ifacePkgPath == "" || typePkgPath == "" {
+		return nil, fmt.Errorf("interface and type must be qualified as packagePath.Name")
+	}
+
+	cfg := e.Config
+	// A single load shares one importer, so both packages agree on type identities
+	pkgs, err := packages.Load(&cfg, ifacePkgPath, typePkgPath)
+	if err != nil {
+		return nil, fmt.Errorf("loading packages: %w", err)
+	}
+	ifaceObj, err := lookupTypeName(pkgs, ifacePkgPath, ifaceIdent)
+	if err != nil {
+		return nil, err
+	}
+	typeObj, err := lookupTypeName(pkgs, typePkgPath, typeIdent)
+	if err != nil {
+		return nil, err
+	}
+	iface, ok := ifaceObj.Type().Underlying().(*types.Interface)
+	if !ok {
+		return nil, fmt.Errorf("%s is not an interface", ifaceName)
+	}
+	named, ok := types.Unalias(typeObj.Type()).(*types.Named)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a named type", typeName)
+	}
+	ifaceNamed, _ := types.Unalias(ifaceObj.Type()).(*types.Named)
+	if named.TypeParams().Len() > 0 || (ifaceNamed != nil && ifaceNamed.TypeParams().Len() > 0) {
+		return nil, fmt.Errorf("generic types must be instantiated and cannot be explained")
+	}
+
+	fset := pkgs[0].Fset
+	ptr := types.NewPointer(named)
+	result := &datamodel.SatisfactionExplanation{
+		Interface:          ifaceName,
+		Type:               typeName,
+		Satisfied:          types.Implements(named, iface),
+		SatisfiedByPointer: types.Implements(ptr, iface),
+	}
+	if !result.SatisfiedByPointer {
+		result.Reason = missingMethodReason(ptr, iface)
+	} else if !result.Satisfied {
+		if method, _ := types.MissingMethod(named, iface, true); method != nil {
+			result.Reason = fmt.Sprintf("only %s satisfies the interface: method %s has a pointer receiver", types.TypeString(ptr, nil), method.Name())
+		}
+	}
+
+	result.Matched, result.Unmatched = compareMethods(named, iface, func(pos token.Pos) datamodel.Location {
+		return explainLocation(fset, pos, cfg.Dir)
+	})
+	return result, nil
+}
+
+// compareMethods matches each method of iface against the method set of *named,
+// using locate to convert positions of the type's methods and fields.
+func compareMethods(named *types.Named, iface *types.Interface, locate func(token.Pos) datamodel.Location) ([]datamodel.MethodMatch, []datamodel.MethodMismatch) {
+	ptr := types.NewPointer(named)
+	matched := []datamodel.MethodMatch{}
+	unmatched := []datamodel.MethodMismatch{}
+	for i := 0; i < iface.NumMethods(); i++ {
+		want := iface.Method(i)
+		wantSig := types.TypeString(want.Type(), nil)
+		obj, _, _ := types.LookupFieldOrMethod(ptr, false, want.Pkg(), want.Name())
+		switch found := obj.(type) {
+		case nil:
+			unmatched = append(unmatched, datamodel.MethodMismatch{
+				Name:   want.Name(),
+				Want:   wantSig,
+				Reason: datamodel.MismatchMissing,
+			})
+		case *types.Func:
+			haveSig := types.TypeString(found.Type(), nil)
+			if !types.Identical(found.Type(), want.Type()) {
+				unmatched = append(unmatched, datamodel.MethodMismatch{
+					Name:     want.Name(),
+					Want:     wantSig,
+					Have:     haveSig,
+					Reason:   datamodel.MismatchSignature,
+					Location: locate(found.Pos()),
+				})
+				continue
+			}
+			valueObj, _, _ := types.LookupFieldOrMethod(named, false, want.Pkg(), want.Name())
+			matched = append(matched, datamodel.MethodMatch{
+				Name:            want.Name(),
+				Signature:       haveSig,
+				PointerReceiver: valueObj == nil, // Only in the method set of the pointer type
+				Promoted:        !sameReceiver(found, named),
+				Location:        locate(found.Pos()),
+			})
+		default:
+			unmatched = append(unmatched, datamodel.MethodMismatch{
+				Name:     want.Name(),
+				Want:     wantSig,
+				Have:     types.TypeString(found.Type(), nil),
+				Reason:   datamodel.MismatchNotMethod,
+				Location: locate(found.Pos()),
+			})
+		}
+	}
+	return matched, unmatched
+}
+
+// missingMethodReason summarizes the first problem reported by types.MissingMethod.
+func missingMethodReason(t types.Type, iface *types.Interface) string {
+	method, wrongType := types.MissingMethod(t, iface, true)
+	if method == nil {
+		return ""
+	}
+	if wrongType {
+		return fmt.Sprintf("%s has method %s with the wrong type or receiver", types.TypeString(t, nil), method.Name())
+	}
+	return fmt.Sprintf("%s is missing method %s", types.TypeString(t, nil), method.Name())
+}
+
+// sameReceiver reports whether fn is declared directly on named (or *named).
+func sameReceiver(fn *types.Func, named *types.Named) bool {
+	sig, ok := fn.Type().(*types.Signature)
+	if !ok || sig.Recv() == nil {
+		return false
+	}
+	recv := sig.Recv().Type()
+	if p, isPtr := recv.(*types.Pointer); isPtr {
+		recv = p.Elem()
+	}
+	return types.Identical(recv, named)
+}
+
+func lookupTypeName(pkgs []*packages.Package, pkgPath, name string) (*types.TypeName, error) {
+	for _, pkg := range pkgs {
+		if pkg.PkgPath != pkgPath || pkg.Types == nil {
+			continue
+		}
+		if obj, ok := pkg.Types.Scope().Lookup(name).(*types.TypeName); ok {
+			return obj, nil
+		}
+	}
+	return nil, fmt.Errorf("type %s.%s not found", pkgPath, name)
+}
+
+// splitQualifiedName splits "pkg/path.Name" into its package path and name.
+func splitQualifiedName(qualified string) (string, string) {
+	if idx := strings.LastIndex(qualified, "."); idx > strings.LastIndex(qualified, "/") {
+		return qualified[:idx], qualified[idx+1:]
+	}
+	return "", qualified
+}
+
+// explainLocation converts pos into a Location relative to dir when inside it.
+func explainLocation(fset *token.FileSet, pos token.Pos, dir string) datamodel.Location {
+	if fset == nil || !pos.IsValid() {
+		return datamodel.Location{}
+	}
+	loc := datamodel.NewLocation(fset.Position(pos))
+	if dir != "" && filepath.IsAbs(loc.Filename) {
+		if rel, err := filepath.Rel(dir, loc.Filename); err == nil && !strings.HasPrefix(rel, "..") {
+			loc.Filename = rel
+		}
+	}
+	loc.Filename = filepath.ToSlash(loc.Filename)
+	return loc
+}
@@ -0,0 +1,175 @@
+// analyzer/typesystem/extract.go
+package typesystem
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// InterfaceExtractor suggests interfaces for concrete types from the call sites of an
+// analysis: the methods called directly on a type, outside its own methods, form the
+// smallest interface its callers could depend on instead.
+type InterfaceExtractor struct {
+	methods map[string][]*datamodel.Function // Type key -> methods in declaration order
+	calls   map[string][]callerSite          // Method ID -> direct calls from other functions
+}
+
+type callerSite struct {
+	id      string // Caller function ID
+	pkgPath string
+}
+
+func NewInterfaceExtractor(analysis *datamodel.ProjectAnalysis) *InterfaceExtractor {
+	e := &InterfaceExtractor{
+		methods: make(map[string][]*datamodel.Function),
+		calls:   make(map[string][]callerSite),
+	}
+	if analysis == nil {
+		return e
+	}
+	for _, pkg := range analysis.Packages {
+		if pkg == nil {
+			continue
+		}
+		for i := range pkg.Functions {
+			fn := &pkg.Functions[i]
+			if fn.Receiver == "" {
+				continue
+			}
+			key := pkg.Path + "." + receiverTypeName(fn.Receiver)
+			e.methods[key] = append(e.methods[key], fn)
+		}
+	}
+	for _, pkg := range analysis.Packages {
+		if pkg == nil {
+			continue
+		}
+		for _, call := range pkg.Calls {
+			// Interface calls already go through an abstraction
+			if call.CalleeID == "" || call.CallerID == "" || call.CallType == "Interface" {
+				continue
+			}
+			e.calls[call.CalleeID] = append(e.calls[call.CalleeID], callerSite{id: call.CallerID, pkgPath: pkg.Path})
+		}
+	}
+	return e
+}
+
+// receiverTypeName strips the pointer and type arguments of a receiver ("*Box[T]").
+func receiverTypeName(recv string) string {
+	recv = strings.TrimPrefix(recv, "*")
+	if i := strings.IndexByte(recv, '['); i >= 0 {
+		recv = recv[:i]
+	}
+	return recv
+}
+
+// Resolve returns the key ("pkg/path.Type") of the type name refers to: an exact key,
+// or else the only key ending in name after a "." or "/" (e.g. "store.Mem").
+func (e *InterfaceExtractor) Resolve(name string) (string, error) {
+	if _, ok := e.methods[name]; ok {
+		return name, nil
+	}
+	var matches []string
+	for key := range e.methods {
+		if strings.HasSuffix(key, "."+name) || strings.HasSuffix(key, "/"+name) {
+			matches = append(matches, key)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no type %q with methods in the analysis", name)
+	case 1:
+		return matches[0], nil
+	}
+	sort.Strings(matches)
+	if len(matches) > 5 {
+		matches = append(matches[:5], "...")
+	}
+	return "", fmt.Errorf("%q is ambiguous: %s", name, strings.Join(matches, ", "))
+}
+
+// Extract suggests an interface for the type typeName (see Resolve) with the methods
+// called on it directly, or only from packages matching from (an import path or path
+// suffix) if it is set. Calls from the type's own methods and their closures do not
+// count, nor do calls of methods promoted from embedded fields. ifaceName names the
+// interface; if empty, a one-method interface is named after the method ("Getter")
+// and others after the type ("StoreAPI"). The result has no methods if no caller uses
+// the type directly.
+func (e *InterfaceExtractor) Extract(typeName, from, ifaceName string) (*datamodel.InterfaceSuggestion, error) {
+	key, err := e.Resolve(typeName)
+	if err != nil {
+		return nil, err
+	}
+	self := key + "."
+	suggestion := &datamodel.InterfaceSuggestion{Type: key, Methods: []datamodel.SuggestedMethod{}}
+	var typeParams []datamodel.TypeParam
+	for _, fn := range e.methods[key] {
+		typeParams = fn.TypeParams
+		callers := make(map[string]bool)
+		calls := 0
+		for _, site := range e.calls[fn.ID] {
+			if strings.HasPrefix(site.id, self) {
+				continue
+			}
+			if from != "" && site.pkgPath != from && !strings.HasSuffix(site.pkgPath, "/"+from) {
+				continue
+			}
+			callers[site.id] = true
+			calls++
+		}
+		if calls == 0 {
+			suggestion.Unused = append(suggestion.Unused, fn.Name)
+			continue
+		}
+		method := datamodel.SuggestedMethod{Name: fn.Name, Signature: fn.Signature, Calls: calls}
+		for id := range callers {
+			method.Callers = append(method.Callers, id)
+		}
+		sort.Strings(method.Callers)
+		suggestion.Methods = append(suggestion.Methods, method)
+	}
+
+	typeIdent := key[strings.LastIndex(key, ".")+1:]
+	suggestion.Name = ifaceName
+	if suggestion.Name == "" {
+		suggestion.Name = suggestedInterfaceName(typeIdent, suggestion.Methods)
+	}
+	var decl strings.Builder
+	fmt.Fprintf(&decl, "// %s is the part of %s used by its callers.\n", suggestion.Name, typeIdent)
+	decl.WriteString("type " + suggestion.Name)
+	if len(typeParams) > 0 {
+		params := make([]string, len(typeParams))
+		for i, tp := range typeParams {
+			params[i] = tp.Name + " " + tp.Constraint
+		}
+		decl.WriteString("[" + strings.Join(params, ", ") + "]")
+	}
+	if len(suggestion.Methods) == 0 {
+		decl.WriteString(" interface{}\n")
+	} else {
+		decl.WriteString(" interface {\n")
+		for _, m := range suggestion.Methods {
+			decl.WriteString("\t" + m.Signature + "\n")
+		}
+		decl.WriteString("}\n")
+	}
+	suggestion.Declaration = decl.String()
+	return suggestion, nil
+}
+
+// suggestedInterfaceName follows the Go convention of naming one-method interfaces
+// after the method plus "er".
+func suggestedInterfaceName(typeIdent string, methods []datamodel.SuggestedMethod) string {
+	if len(methods) == 1 {
+		name := methods[0].Name
+		if strings.HasSuffix(name, "e") {
+			return name + "r"
+		}
+		return name + "er"
+	}
+	return typeIdent + "API"
+}
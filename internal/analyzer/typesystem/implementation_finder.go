@@ -2,30 +2,83 @@
 package typesystem
 
 import (
+	"context"
+	"fmt"
 	"go/ast"
 	"go/token"
 	"go/types"
-	"log"
+	"log/slog"
+	"sync/atomic"
 
 	"golang.org/x/tools/go/packages"
 
-	"github.com/namikmesic/go-mcp/internal/datamodel" // Adjusted import path
+	"github.com/namikmesic/go-mcp/internal/analyzer"
+	"github.com/namikmesic/go-mcp/internal/analyzer/filter"
+	"github.com/namikmesic/go-mcp/internal/analyzer/utils"
+	"github.com/namikmesic/go-mcp/pkg/datamodel" // Adjusted import path
 )
 
+// DefaultStdInterfaces are well-known standard library interfaces worth checking
+// project types against.
+var DefaultStdInterfaces = []string{
+	"error",
+	"fmt.Stringer",
+	"io.Reader",
+	"io.Writer",
+	"io.Closer",
+	"sort.Interface",
+	"encoding/json.Marshaler",
+	"encoding/json.Unmarshaler",
+	"net/http.Handler",
+}
+
 // TypeBasedImplementationFinder finds implementations using go/types.
-type TypeBasedImplementationFinder struct{}
+type TypeBasedImplementationFinder struct {
+	// Filter decides which packages and types are considered as implementations.
+	Filter analyzer.FilterPolicy
+	// StdInterfaces lists standard library interfaces ("io.Reader", "net/http.Handler",
+	// or "error") to check in addition to the analyzed ones. They are added to the
+	// interfaces map with Stdlib set. Interfaces of packages that the analyzed packages
+	// do not import, directly or indirectly, are skipped.
+	StdInterfaces []string
+	// Progress receives per-package progress of the implementation checks; may be nil.
+	Progress analyzer.ProgressReporter
+	// Workers bounds the number of packages checked concurrently; <= 0 uses GOMAXPROCS.
+	Workers int
+	// Logger receives diagnostics; nil logs to slog.Default().
+	Logger *slog.Logger
+}
 
 func NewTypeBasedImplementationFinder() *TypeBasedImplementationFinder {
-	return &TypeBasedImplementationFinder{}
+	return &TypeBasedImplementationFinder{
+		Filter: filter.AllowAll(),
+	}
+}
+
+// SetFilterPolicy implements analyzer.Filterable.
+func (f *TypeBasedImplementationFinder) SetFilterPolicy(policy analyzer.FilterPolicy) {
+	f.Filter = policy
+}
+
+// SetLogger implements analyzer.LoggerAware.
+func (f *TypeBasedImplementationFinder) SetLogger(logger *slog.Logger) {
+	f.Logger = logger
+}
+
+// SetProgressReporter implements analyzer.ProgressAware.
+func (f *TypeBasedImplementationFinder) SetProgressReporter(reporter analyzer.ProgressReporter) {
+	f.Progress = reporter
 }
 
 func (f *TypeBasedImplementationFinder) FindImplementations(
+	ctx context.Context,
 	pkgs []*packages.Package,
 	interfaces map[string]*datamodel.Interface, // Key: packagePath + "." + interfaceName
 	fset *token.FileSet, // Use the FileSet from SSA/prog for consistency
 ) error {
+	logger := analyzer.LoggerOrDefault(f.Logger)
 	if fset == nil {
-		log.Println("Warning: No FileSet provided to FindImplementations. Location finding for implementations will be impaired or potentially inaccurate.")
+		logger.Warn("No FileSet provided to FindImplementations; implementation locations may be inaccurate")
 		// Proceeding without a guaranteed consistent FileSet might lead to incorrect locations.
 		// Consider returning an error or using a default fset if absolutely necessary, but locations might not match SSA.
 		// fset = token.NewFileSet() // Avoid this unless you understand the implications
@@ -39,30 +92,30 @@ func (f *TypeBasedImplementationFinder) FindImplementations(
 		// Find the types.Interface corresponding to our datamodel.Interface
 		pkg := findPackage(pkgs, ifaceData.PackagePath)
 		if pkg == nil || pkg.Types == nil || pkg.TypesInfo == nil {
-			log.Printf("Warning: Could not find loaded package or type info for '%s' while mapping interface '%s'. Skipping implementation checks for this interface.", ifaceData.PackagePath, ifaceData.Name)
+			logger.Warn("Could not find loaded package or type info for interface; skipping implementation checks", "interface", ifaceData.Name, "package", ifaceData.PackagePath)
 			continue
 		}
 		scope := pkg.Types.Scope()
 		if scope == nil {
-			log.Printf("Warning: Package scope is nil for '%s', cannot look up interface '%s'.", ifaceData.PackagePath, ifaceData.Name)
+			logger.Warn("Package scope is nil; cannot look up interface", "interface", ifaceData.Name, "package", ifaceData.PackagePath)
 			continue
 		}
 		obj := scope.Lookup(ifaceData.Name)
 		if obj == nil {
-			log.Printf("Warning: Could not lookup interface '%s' in package '%s' scope.", ifaceData.Name, ifaceData.PackagePath)
+			logger.Warn("Could not look up interface in package scope", "interface", ifaceData.Name, "package", ifaceData.PackagePath)
 			continue
 		}
 
 		typeName, ok := obj.(*types.TypeName)
 		if !ok {
-			log.Printf("Warning: Looked up object '%s' in '%s' is not a TypeName (%T).", ifaceData.Name, ifaceData.PackagePath, obj)
+			logger.Warn("Looked up object is not a TypeName", "interface", ifaceData.Name, "package", ifaceData.PackagePath, "object", fmt.Sprintf("%T", obj))
 			continue
 		}
 
 		typeInterface, ok := typeName.Type().Underlying().(*types.Interface)
 		if !ok {
 			// This can happen if the name exists but isn't an interface (e.g., type alias)
-			log.Printf("Warning: Underlying type of '%s' in '%s' is not *types.Interface (%T).", ifaceData.Name, ifaceData.PackagePath, typeName.Type().Underlying())
+			logger.Warn("Underlying type is not an interface", "interface", ifaceData.Name, "package", ifaceData.PackagePath, "type", fmt.Sprintf("%T", typeName.Type().Underlying()))
 			continue
 		}
 
@@ -72,69 +125,181 @@ func (f *TypeBasedImplementationFinder) FindImplementations(
 		ifaceData.UnderlyingType = typeInterface
 	}
 
-	log.Printf("Mapped %d interfaces to their types.Interface representations.", len(typeToInterfaceMap))
+	logger.Debug("Mapped interfaces to their types.Interface representations", "count", len(typeToInterfaceMap))
 	if len(typeToInterfaceMap) < len(interfaces) {
-		log.Printf("Warning: Mismatch between initial interfaces (%d) and successfully mapped types (%d). Some interfaces may not have implementation checks performed.", len(interfaces), len(typeToInterfaceMap))
+		logger.Warn("Some interfaces could not be mapped to types and are not checked for implementations", "interfaces", len(interfaces), "mapped", len(typeToInterfaceMap))
 	}
 
-	// Iterate through all types in all packages to check for implementations
-	processedTypes := make(map[types.Type]bool) // Avoid redundant checks
+	f.addStdInterfaces(pkgs, interfaces, typeToInterfaceMap, fset)
 
-	for _, pkg := range pkgs {
-		if pkg.Types == nil || pkg.TypesInfo == nil || pkg.Fset == nil { // Ensure Fset is available for location finding
-			log.Printf("Skipping implementation check in package %s: missing types, typesInfo, or fset.", pkg.ID)
+	// Check the types of all packages concurrently, then merge the results in package
+	// order so the output does not depend on scheduling
+	perPackage := make([][]foundImplementation, len(pkgs))
+	var done atomic.Int64
+	err := analyzer.Parallel(ctx, f.Workers, len(pkgs), func(i int) {
+		perPackage[i] = f.packageImplementations(pkgs[i], typeToInterfaceMap, fset)
+		analyzer.ReportProgress(f.Progress, analyzer.StageImplementations, int(done.Add(1)), len(pkgs))
+	})
+	if err != nil {
+		return err
+	}
+	for _, found := range perPackage {
+		for _, fi := range found {
+			appendImplementation(fi.iface, fi.impl)
+		}
+	}
+
+	return nil
+}
+
+// addStdInterfaces resolves StdInterfaces from the import graph of pkgs and registers
+// them in both interfaces and typeToInterfaceMap.
+func (f *TypeBasedImplementationFinder) addStdInterfaces(
+	pkgs []*packages.Package,
+	interfaces map[string]*datamodel.Interface,
+	typeToInterfaceMap map[*types.Interface]*datamodel.Interface,
+	fset *token.FileSet,
+) {
+	if len(f.StdInterfaces) == 0 {
+		return
+	}
+	byPath := make(map[string]*packages.Package)
+	packages.Visit(pkgs, nil, func(p *packages.Package) {
+		byPath[p.PkgPath] = p
+	})
+
+	for _, name := range f.StdInterfaces {
+		pkgPath, ident := splitQualifiedName(name)
+		key := name
+		var obj types.Object
+		var pkgName string
+		if pkgPath == "" {
+			obj = types.Universe.Lookup(ident) // "error"
+		} else {
+			p := byPath[pkgPath]
+			if p == nil || p.Types == nil {
+				// Not imported, so no analyzed type can refer to its parameter types
+				analyzer.LoggerOrDefault(f.Logger).Info("Skipping standard library interface: package is not imported", "interface", name, "package", pkgPath)
+				continue
+			}
+			obj = p.Types.Scope().Lookup(ident)
+			pkgName = p.Name
+			key = pkgPath + "." + ident
+		}
+		typeName, ok := obj.(*types.TypeName)
+		if !ok {
+			analyzer.LoggerOrDefault(f.Logger).Warn("Standard library interface not found", "interface", name)
 			continue
 		}
-		scope := pkg.Types.Scope()
-		if scope == nil {
-			log.Printf("Skipping implementation check in package %s: scope is nil.", pkg.ID)
+		typeInterface, ok := typeName.Type().Underlying().(*types.Interface)
+		if !ok {
+			analyzer.LoggerOrDefault(f.Logger).Warn("Standard library name is not an interface", "interface", name)
 			continue
 		}
+		if _, exists := interfaces[key]; exists {
+			continue // Already analyzed as part of the project
+		}
+		iface := &datamodel.Interface{
+			Name:            ident,
+			PackageName:     pkgName,
+			PackagePath:     pkgPath,
+			Methods:         utils.InterfaceMethods(typeInterface, fset, nil),
+			Embeds:          []string{},
+			Implementations: []datamodel.Implementation{},
+			UnderlyingType:  typeInterface,
+			Stdlib:          true,
+		}
+		if fset != nil && typeName.Pos().IsValid() {
+			iface.Location = datamodel.NewLocation(fset.Position(typeName.Pos()))
+		}
+		interfaces[key] = iface
+		typeToInterfaceMap[typeInterface] = iface
+	}
+}
 
-		for _, name := range scope.Names() {
-			obj := scope.Lookup(name)
-			if obj == nil {
-				continue
-			}
+// foundImplementation is an implementation found by a worker, before it is merged into
+// its interface.
+type foundImplementation struct {
+	iface *datamodel.Interface
+	impl  datamodel.Implementation
+}
 
-			typeName, ok := obj.(*types.TypeName)
-			if !ok {
-				continue // We only care about named types for implementations
-			}
+// packageImplementations checks the named types of pkg against every interface.
+func (f *TypeBasedImplementationFinder) packageImplementations(pkg *packages.Package, typeToInterfaceMap map[*types.Interface]*datamodel.Interface, fset *token.FileSet) []foundImplementation {
+	logger := analyzer.LoggerOrDefault(f.Logger)
+	if pkg.Types == nil || pkg.TypesInfo == nil || pkg.Fset == nil { // Ensure Fset is available for location finding
+		logger.Debug("Skipping implementation check: missing types, typesInfo, or fset", "package", pkg.ID)
+		return nil
+	}
+	scope := pkg.Types.Scope()
+	if scope == nil {
+		logger.Debug("Skipping implementation check: scope is nil", "package", pkg.ID)
+		return nil
+	}
+	if !f.Filter.IncludePackage(pkg) {
+		return nil
+	}
+
+	var found []foundImplementation
+	processedTypes := make(map[types.Type]bool) // Avoid redundant checks
+	for _, name := range scope.Names() {
+		obj := scope.Lookup(name)
+		if obj == nil {
+			continue
+		}
+
+		typeName, ok := obj.(*types.TypeName)
+		if !ok {
+			continue // We only care about named types for implementations
+		}
+		if typeName.IsAlias() {
+			continue // An alias denotes a type that is reported under its own name
+		}
+		if !f.Filter.IncludeSymbol(analyzer.Symbol{
+			Kind:        analyzer.KindImplementation,
+			Name:        typeName.Name(),
+			PackagePath: pkg.PkgPath,
+			Exported:    typeName.Exported(),
+			Generated:   filter.IsGenerated(pkg, typeName.Pos()),
+		}) {
+			continue
+		}
 
-			implementingType := typeName.Type()
-			if implementingType == nil || processedTypes[implementingType] {
-				continue // Skip nil types or already processed types
+		implementingType := typeName.Type()
+		if implementingType == nil || processedTypes[implementingType] {
+			continue // Skip nil types or already processed types
+		}
+		// Also check pointer to named type if the base type is named
+		if named, isNamed := implementingType.(*types.Named); isNamed {
+			ptrToNamed := types.NewPointer(named)
+			if !processedTypes[ptrToNamed] {
+				processedTypes[ptrToNamed] = true // Mark pointer type as processed too
 			}
-			// Also check pointer to named type if the base type is named
-			if named, isNamed := implementingType.(*types.Named); isNamed {
-				ptrToNamed := types.NewPointer(named)
-				if !processedTypes[ptrToNamed] {
-					processedTypes[ptrToNamed] = true // Mark pointer type as processed too
+		}
+		processedTypes[implementingType] = true
+
+		// Check implementation for each known interface
+		for typeInterface, ifaceData := range typeToInterfaceMap {
+			// Check value receiver implementation
+			if types.Implements(implementingType, typeInterface) {
+				// Use the correct FileSet (passed in, ideally from SSA)
+				if impl, ok := f.implementation(ifaceData, typeInterface, typeName, pkg, false, fset); ok {
+					found = append(found, foundImplementation{iface: ifaceData, impl: impl})
 				}
 			}
-			processedTypes[implementingType] = true
-
-			// Check implementation for each known interface
-			for typeInterface, ifaceData := range typeToInterfaceMap {
-				// Check value receiver implementation
-				if types.Implements(implementingType, typeInterface) {
-					// Use the correct FileSet (passed in, ideally from SSA)
-					addImplementation(ifaceData, typeName, pkg, false, fset)
-				}
 
-				// Check pointer receiver implementation
-				// Create pointer type *before* checking Implements and addImplementation
-				ptrType := types.NewPointer(implementingType)
-				if types.Implements(ptrType, typeInterface) {
-					// Use the correct FileSet
-					addImplementation(ifaceData, typeName, pkg, true, fset)
+			// Check pointer receiver implementation
+			// Create pointer type *before* checking Implements
+			ptrType := types.NewPointer(implementingType)
+			if types.Implements(ptrType, typeInterface) {
+				// Use the correct FileSet
+				if impl, ok := f.implementation(ifaceData, typeInterface, typeName, pkg, true, fset); ok {
+					found = append(found, foundImplementation{iface: ifaceData, impl: impl})
 				}
 			}
 		}
 	}
-
-	return nil
+	return found
 }
 
 // Helper to find a package by path
@@ -147,8 +312,11 @@ func findPackage(pkgs []*packages.Package, path string) *packages.Package {
 	return nil
 }
 
-// Helper (adapted for datamodel and using provided FileSet)
-func addImplementation(iface *datamodel.Interface, typeName *types.TypeName, pkg *packages.Package, isPointer bool, fset *token.FileSet) {
+// implementation describes typeName (or *typeName when isPointer) as an implementation
+// of iface, locating it with the provided FileSet. It reports false if no location
+// could be found.
+func (f *TypeBasedImplementationFinder) implementation(iface *datamodel.Interface, typeInterface *types.Interface, typeName *types.TypeName, pkg *packages.Package, isPointer bool, fset *token.FileSet) (datamodel.Implementation, bool) {
+	logger := analyzer.LoggerOrDefault(f.Logger)
 	implLoc := datamodel.Location{}
 	var foundNode ast.Node // Keep track of the specific node
 
@@ -190,52 +358,96 @@ func addImplementation(iface *datamodel.Interface, typeName *types.TypeName, pkg
 			pos := fset.Position(typeName.Pos())
 			if pos.IsValid() {
 				implLoc = datamodel.NewLocation(pos)
-				log.Printf("Warning: Could not find AST node for implementation %s.%s. Using fallback location from typeName.Pos() with provided fset: %s:%d", pkg.PkgPath, typeName.Name(), implLoc.Filename, implLoc.Line)
+				logger.Warn("Could not find AST node for implementation; using typeName.Pos() with provided fset", "type", typeName.Name(), "package", pkg.PkgPath, "file", implLoc.Filename, "line", implLoc.Line)
 			} else {
-				log.Printf("Warning: Could not find AST node or valid typeName.Pos() for implementation %s.%s using provided FileSet.", pkg.PkgPath, typeName.Name())
+				logger.Warn("Could not find AST node or valid typeName.Pos() for implementation using provided FileSet", "type", typeName.Name(), "package", pkg.PkgPath)
 				// Location remains empty
 			}
 		}
 	} else {
 		// Fallback 2: No fset provided. Try using pkg.Fset (might be inconsistent with SSA)
-		log.Printf("Warning: Finding location for implementation %s.%s without provided fset. Using pkg.Fset as fallback.", pkg.PkgPath, typeName.Name())
+		logger.Warn("Finding location for implementation without provided fset; using pkg.Fset", "type", typeName.Name(), "package", pkg.PkgPath)
 		if pkg.Fset != nil {
 			pos := pkg.Fset.Position(typeName.Pos())
 			if pos.IsValid() {
 				implLoc = datamodel.NewLocation(pos)
-				log.Printf("Fallback location found for %s using pkg.Fset: %s:%d", typeName.Name(), implLoc.Filename, implLoc.Line)
+				logger.Debug("Fallback location found using pkg.Fset", "type", typeName.Name(), "file", implLoc.Filename, "line", implLoc.Line)
 			} else {
-				log.Printf("Warning: typeName.Pos() for %s.%s is invalid even with pkg.Fset.", pkg.PkgPath, typeName.Name())
+				logger.Warn("typeName.Pos() is invalid even with pkg.Fset", "type", typeName.Name(), "package", pkg.PkgPath)
 			}
 		} else {
-			log.Printf("Warning: Cannot find location for implementation %s.%s: No fset provided and pkg.Fset is also nil.", pkg.PkgPath, typeName.Name())
+			logger.Warn("Cannot find location for implementation: no fset provided and pkg.Fset is nil", "type", typeName.Name(), "package", pkg.PkgPath)
 		}
 	}
 
 	// If no valid location could be found after all fallbacks, skip adding the implementation.
 	if implLoc.Filename == "" {
-		log.Printf("Skipping implementation %s.%s (pointer: %v) for interface %s due to missing location information.", pkg.PkgPath, typeName.Name(), isPointer, iface.Name)
-		return
+		logger.Warn("Skipping implementation due to missing location information", "type", typeName.Name(), "package", pkg.PkgPath, "pointer", isPointer, "interface", iface.Name)
+		return datamodel.Implementation{}, false
 	}
 	// --- End Location Finding ---
 
+	return datamodel.Implementation{
+		TypeName:    typeName.Name(),
+		PackagePath: pkg.PkgPath,
+		PackageName: pkg.Name,
+		IsPointer:   isPointer,
+		Location:    implLoc,
+		Methods:     satisfyingMethods(typeName, typeInterface, isPointer, fset),
+	}, true
+}
+
+// appendImplementation adds impl to iface unless it is already listed.
+func appendImplementation(iface *datamodel.Interface, impl datamodel.Implementation) {
 	// Avoid duplicate entries (check type name, package path, and pointer status)
 	for _, existingImpl := range iface.Implementations {
-		if existingImpl.TypeName == typeName.Name() &&
-			existingImpl.PackagePath == pkg.PkgPath &&
-			existingImpl.IsPointer == isPointer {
+		if existingImpl.TypeName == impl.TypeName &&
+			existingImpl.PackagePath == impl.PackagePath &&
+			existingImpl.IsPointer == impl.IsPointer {
 			// Optional: Update location if the new one is more specific? For now, just skip duplicates.
-			// log.Printf("Debug: Duplicate implementation found for %s.%s (pointer: %v) for interface %s. Skipping.", pkg.PkgPath, typeName.Name(), isPointer, iface.Name)
 			return // Already added
 		}
 	}
+	iface.Implementations = append(iface.Implementations, impl)
+}
 
-	// Add the implementation
-	iface.Implementations = append(iface.Implementations, datamodel.Implementation{
-		TypeName:    typeName.Name(),
-		PackagePath: pkg.PkgPath,
-		PackageName: pkg.Name,
-		IsPointer:   isPointer,
-		Location:    implLoc,
-	})
+// satisfyingMethods lists the concrete method of typeName (or *typeName when isPointer)
+// that satisfies each method of iface, in the interface's method order.
+func satisfyingMethods(typeName *types.TypeName, iface *types.Interface, isPointer bool, fset *token.FileSet) []datamodel.MethodMatch {
+	named, ok := typeName.Type().(*types.Named)
+	if !ok {
+		return nil
+	}
+	var recv types.Type = named
+	if isPointer {
+		recv = types.NewPointer(named)
+	}
+	matches := make([]datamodel.MethodMatch, 0, iface.NumMethods())
+	for i := 0; i < iface.NumMethods(); i++ {
+		want := iface.Method(i)
+		found, ok := lookupMethod(recv, want)
+		if !ok {
+			continue
+		}
+		valueFound, _ := lookupMethod(named, want)
+		match := datamodel.MethodMatch{
+			Name:            found.Name(),
+			ID:              utils.FuncID(found),
+			Signature:       types.TypeString(found.Type(), nil),
+			PointerReceiver: valueFound == nil, // Only in the method set of the pointer type
+			Promoted:        !sameReceiver(found, named),
+		}
+		if fset != nil {
+			match.Location = datamodel.NewLocation(fset.Position(found.Pos()))
+		}
+		matches = append(matches, match)
+	}
+	return matches
+}
+
+// lookupMethod finds the method of t named like want, if t has one.
+func lookupMethod(t types.Type, want *types.Func) (*types.Func, bool) {
+	obj, _, _ := types.LookupFieldOrMethod(t, false, want.Pkg(), want.Name())
+	fn, ok := obj.(*types.Func)
+	return fn, ok
 }
@@ -0,0 +1,112 @@
+// analyzer/typesystem/interface_calls.go
+package typesystem
+
+import (
+	"context"
+	"sort"
+
+	"github.com/namikmesic/go-mcp/internal/analyzer"
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// InterfaceCallResolver implements analyzer.ProjectAnalyzer by listing, for every call
+// through an interface method, the concrete methods of the interface's implementations
+// as the call's Targets. Together with the static call sites this yields a
+// whole-program call graph keyed by function IDs.
+//
+// Only implementations found by the implementation finder are considered, so interfaces
+// of packages outside the analysis (other than the standard library interfaces reported
+// with --std-interfaces) leave their calls unresolved.
+type InterfaceCallResolver struct{}
+
+// Compile-time check to ensure InterfaceCallResolver implements ProjectAnalyzer.
+var _ analyzer.ProjectAnalyzer = (*InterfaceCallResolver)(nil)
+
+func NewInterfaceCallResolver() *InterfaceCallResolver {
+	return &InterfaceCallResolver{}
+}
+
+// AnalyzeProject implements analyzer.ProjectAnalyzer.
+func (r *InterfaceCallResolver) AnalyzeProject(ctx context.Context, env *analyzer.Env, analysis *datamodel.ProjectAnalysis) error {
+	if analysis == nil {
+		return nil
+	}
+	var ifaces []*datamodel.Interface
+	for _, pkg := range analysis.Packages {
+		if pkg == nil {
+			continue
+		}
+		for i := range pkg.Interfaces {
+			ifaces = append(ifaces, &pkg.Interfaces[i])
+		}
+	}
+	for i := range analysis.StdlibInterfaces {
+		ifaces = append(ifaces, &analysis.StdlibInterfaces[i])
+	}
+
+	// Interfaces are listed among the implementations of the interfaces they embed, but
+	// their methods only dispatch further, so they are not targets
+	abstract := make(map[string]bool)
+	for _, iface := range ifaces {
+		for _, method := range iface.Methods {
+			abstract[interfaceMethodID(iface, method.Name)] = true
+		}
+	}
+	targets := make(map[string][]string) // Interface method ID -> concrete method IDs
+	for _, iface := range ifaces {
+		for _, impl := range iface.Implementations {
+			for _, method := range impl.Methods {
+				id := method.ID
+				if id == "" {
+					id = impl.PackagePath + "." + impl.TypeName + "." + method.Name
+				}
+				if abstract[id] {
+					continue
+				}
+				key := interfaceMethodID(iface, method.Name)
+				targets[key] = append(targets[key], id)
+			}
+		}
+	}
+	for key, ids := range targets {
+		targets[key] = sortedUnique(ids)
+	}
+
+	for _, pkg := range analysis.Packages {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if pkg == nil {
+			continue
+		}
+		for i := range pkg.Calls {
+			call := &pkg.Calls[i]
+			// Calls to concrete functions never carry an interface method ID, so this
+			// also covers interface methods invoked by go and defer statements
+			if ids, ok := targets[call.CalleeID]; ok {
+				call.Targets = ids
+			}
+		}
+	}
+	return nil
+}
+
+// interfaceMethodID returns the ID utils.FuncID gives the named method of iface.
+func interfaceMethodID(iface *datamodel.Interface, name string) string {
+	if iface.PackagePath == "" { // Predeclared error
+		return iface.Name + "." + name
+	}
+	return iface.PackagePath + "." + iface.Name + "." + name
+}
+
+// sortedUnique sorts ids and removes duplicates in place.
+func sortedUnique(ids []string) []string {
+	sort.Strings(ids)
+	unique := ids[:0]
+	for i, id := range ids {
+		if i == 0 || id != ids[i-1] {
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,149 @@
+// analyzer/typesystem/interface_usage.go
+package typesystem
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/namikmesic/go-mcp/internal/analyzer"
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// DefaultFatMinMethods is the smallest method set for which an interface can be
+// reported as fat.
+const DefaultFatMinMethods = 4
+
+// InterfaceUsageAnalyzer implements analyzer.ProjectAnalyzer by setting the Usage of
+// each interface with methods from the interface calls made through it, and listing
+// fat interfaces in Findings.FatInterfaces. Calls are attributed to the static
+// interface type of the receiver, so a call on an io.ReadWriter counts for
+// io.ReadWriter and not for io.Reader.
+type InterfaceUsageAnalyzer struct {
+	// FatMinMethods is the smallest method set for which Usage.Fat can be set.
+	FatMinMethods int
+}
+
+// Compile-time check to ensure InterfaceUsageAnalyzer implements ProjectAnalyzer.
+var _ analyzer.ProjectAnalyzer = (*InterfaceUsageAnalyzer)(nil)
+
+func NewInterfaceUsageAnalyzer() *InterfaceUsageAnalyzer {
+	return &InterfaceUsageAnalyzer{FatMinMethods: DefaultFatMinMethods}
+}
+
+// AnalyzeProject implements analyzer.ProjectAnalyzer.
+func (a *InterfaceUsageAnalyzer) AnalyzeProject(ctx context.Context, env *analyzer.Env, analysis *datamodel.ProjectAnalysis) error {
+	minMethods := a.FatMinMethods
+	if minMethods <= 0 {
+		minMethods = DefaultFatMinMethods
+	}
+
+	// Interface key -> method name -> consuming package -> call sites
+	calls := make(map[string]map[string]map[string]int)
+	for _, pkg := range analysis.Packages {
+		if pkg == nil {
+			continue
+		}
+		for _, call := range pkg.Calls {
+			if call.CallType != "Interface" {
+				continue
+			}
+			key, method, ok := interfaceCallee(call.CalleeDesc)
+			if !ok {
+				continue
+			}
+			if calls[key] == nil {
+				calls[key] = make(map[string]map[string]int)
+			}
+			if calls[key][method] == nil {
+				calls[key][method] = make(map[string]int)
+			}
+			calls[key][method][pkg.Path]++
+		}
+	}
+
+	// Interfaces satisfy themselves and the interfaces they embed, so the
+	// implementation finder lists them among the implementations
+	interfaces := make(map[string]bool)
+	for _, pkg := range analysis.Packages {
+		if pkg == nil {
+			continue
+		}
+		for _, iface := range pkg.Interfaces {
+			interfaces[iface.PackagePath+"."+iface.Name] = true
+		}
+	}
+
+	var fat []string
+	for _, pkg := range analysis.Packages {
+		if pkg == nil {
+			continue
+		}
+		for i := range pkg.Interfaces {
+			iface := &pkg.Interfaces[i]
+			methods := iface.Methods
+			if len(iface.MethodSet) > 0 {
+				methods = iface.MethodSet
+			}
+			if len(methods) == 0 {
+				continue
+			}
+			key := iface.PackagePath + "." + iface.Name
+			usage := &datamodel.InterfaceUsage{
+				MethodCount: len(methods),
+				Methods:     make([]datamodel.MethodUsage, 0, len(methods)),
+			}
+			for _, impl := range iface.Implementations {
+				if !interfaces[impl.PackagePath+"."+impl.TypeName] {
+					usage.Implementations++
+				}
+			}
+			consumers := make(map[string]int) // Package -> distinct methods called
+			for _, m := range methods {
+				mu := datamodel.MethodUsage{Name: m.Name, Consumers: len(calls[key][m.Name])}
+				for consumer, n := range calls[key][m.Name] {
+					mu.CallSites += n
+					consumers[consumer]++
+				}
+				usage.CallSites += mu.CallSites
+				usage.Methods = append(usage.Methods, mu)
+			}
+			usage.Consumers = len(consumers)
+			for _, used := range consumers {
+				usage.MaxMethodsUsed = max(usage.MaxMethodsUsed, used)
+			}
+			usage.Fat = usage.MethodCount >= minMethods && usage.Consumers > 0 && 2*usage.MaxMethodsUsed <= usage.MethodCount
+			if usage.Fat {
+				fat = append(fat, key)
+			}
+			iface.Usage = usage
+		}
+	}
+	if len(fat) == 0 {
+		return nil
+	}
+	sort.Strings(fat)
+	if analysis.Findings == nil {
+		analysis.Findings = &datamodel.Findings{}
+	}
+	analysis.Findings.FatInterfaces = fat
+	return nil
+}
+
+// interfaceCallee splits an interface call description ("Interface method M on
+// pkg/path.Iface", with type arguments for generic interfaces) into the interface key
+// and the method name.
+func interfaceCallee(desc string) (key, method string, ok bool) {
+	rest, ok := strings.CutPrefix(desc, "Interface method ")
+	if !ok {
+		return "", "", false
+	}
+	method, key, ok = strings.Cut(rest, " on ")
+	if !ok {
+		return "", "", false
+	}
+	if i := strings.Index(key, "["); i >= 0 {
+		key = key[:i]
+	}
+	return key, method, true
+}
@@ -0,0 +1,137 @@
+// analyzer/typesystem/near_miss.go
+package typesystem
+
+import (
+	"context"
+	"go/types"
+	"sync"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/namikmesic/go-mcp/internal/analyzer"
+	"github.com/namikmesic/go-mcp/internal/analyzer/filter"
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// NearMissAnalyzer reports concrete types that would implement an interface of the
+// loaded packages if it were not for a few missing or mismatched methods. A type must
+// match at least as many of the interface's methods as it misses, so small interfaces
+// are not reported for every type that shares one method name. It must be registered
+// as both a PackageAnalyzer and a ProjectAnalyzer.
+type NearMissAnalyzer struct {
+	// MaxMissing is the largest number of unmatched methods still reported.
+	MaxMissing int
+	// Filter decides which types are considered as candidates.
+	Filter analyzer.FilterPolicy
+
+	mu         sync.Mutex
+	candidates map[string]*nearMissCandidate // packagePath.TypeName -> type
+	interfaces map[string]*types.Interface   // packagePath.Name -> interface
+}
+
+type nearMissCandidate struct {
+	named    *types.Named
+	pkgPath  string
+	location datamodel.Location
+}
+
+// Compile-time checks to ensure NearMissAnalyzer implements both analyzer passes.
+var (
+	_ analyzer.PackageAnalyzer = (*NearMissAnalyzer)(nil)
+	_ analyzer.ProjectAnalyzer = (*NearMissAnalyzer)(nil)
+	_ analyzer.Filterable      = (*NearMissAnalyzer)(nil)
+)
+
+func NewNearMissAnalyzer(maxMissing int) *NearMissAnalyzer {
+	return &NearMissAnalyzer{MaxMissing: maxMissing, Filter: filter.AllowAll()}
+}
+
+// SetFilterPolicy implements analyzer.Filterable.
+func (n *NearMissAnalyzer) SetFilterPolicy(policy analyzer.FilterPolicy) {
+	n.Filter = policy
+}
+
+// AnalyzePackage records the package's non-generic interfaces and concrete named types.
+func (n *NearMissAnalyzer) AnalyzePackage(ctx context.Context, env *analyzer.Env, pkg *packages.Package, result *datamodel.PackageAnalysis) error {
+	if pkg.Types == nil {
+		return nil
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.candidates == nil {
+		n.candidates = make(map[string]*nearMissCandidate)
+		n.interfaces = make(map[string]*types.Interface)
+	}
+
+	scope := pkg.Types.Scope()
+	for _, name := range scope.Names() {
+		typeName, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || typeName.IsAlias() {
+			continue
+		}
+		named, ok := typeName.Type().(*types.Named)
+		if !ok || named.TypeParams().Len() > 0 {
+			continue // Generic types need instantiation before they can be checked
+		}
+		key := pkg.PkgPath + "." + name
+		if iface, isIface := named.Underlying().(*types.Interface); isIface {
+			if iface.NumMethods() > 0 && n.interfaces[key] == nil {
+				n.interfaces[key] = iface
+			}
+			continue
+		}
+		if n.candidates[key] != nil {
+			continue // Test variants repeat the package's types
+		}
+		if !n.Filter.IncludeSymbol(analyzer.Symbol{
+			Kind:        analyzer.KindImplementation,
+			Name:        name,
+			PackagePath: pkg.PkgPath,
+			Exported:    typeName.Exported(),
+			Generated:   filter.IsGenerated(pkg, typeName.Pos()),
+		}) {
+			continue
+		}
+		n.candidates[key] = &nearMissCandidate{named: named, pkgPath: pkg.PkgPath, location: env.Location(typeName.Pos())}
+	}
+	return nil
+}
+
+// AnalyzeProject writes ProjectAnalysis.Findings.NearMisses and resets the recorded state.
+func (n *NearMissAnalyzer) AnalyzeProject(ctx context.Context, env *analyzer.Env, analysis *datamodel.ProjectAnalysis) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.candidates == nil {
+		return nil
+	}
+	defer func() { n.candidates, n.interfaces = nil, nil }()
+
+	ifaceKeys := sortedKeys(n.interfaces)
+	var nearMisses []datamodel.NearMiss
+	for _, typeKey := range sortedKeys(n.candidates) {
+		c := n.candidates[typeKey]
+		for _, ifaceKey := range ifaceKeys {
+			matched, unmatched := compareMethods(c.named, n.interfaces[ifaceKey], env.Location)
+			// No unmatched methods means *T implements the interface
+			if len(unmatched) == 0 || len(unmatched) > n.MaxMissing || len(matched) < len(unmatched) {
+				continue
+			}
+			nearMisses = append(nearMisses, datamodel.NearMiss{
+				Interface:   ifaceKey,
+				TypeName:    c.named.Obj().Name(),
+				PackagePath: c.pkgPath,
+				Missing:     unmatched,
+				Location:    c.location,
+			})
+		}
+	}
+
+	if len(nearMisses) == 0 {
+		return nil
+	}
+	if analysis.Findings == nil {
+		analysis.Findings = &datamodel.Findings{}
+	}
+	analysis.Findings.NearMisses = nearMisses
+	return nil
+}
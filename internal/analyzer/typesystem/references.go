@@ -0,0 +1,195 @@
+// analyzer/typesystem/references.go
+package typesystem
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/namikmesic/go-mcp/internal/analyzer/utils"
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// ReferenceFinder finds the uses of a declared symbol, as recorded in the type
+// checker's Uses, across the packages matching Patterns. Like SatisfactionExplainer it
+// loads the packages per query.
+type ReferenceFinder struct {
+	// Config is used to load the packages; Dir should be the module directory.
+	Config   packages.Config
+	Patterns []string
+}
+
+func NewReferenceFinder(moduleDir string) *ReferenceFinder {
+	return &ReferenceFinder{
+		Config: packages.Config{
+			Mode: packages.NeedName |
+				packages.NeedFiles |
+				packages.NeedImports |
+				packages.NeedDeps |
+				packages.NeedTypes |
+				packages.NeedSyntax |
+				packages.NeedTypesInfo |
+				packages.NeedModule,
+			Dir:   moduleDir,
+			Tests: true, // Uses in test files count too
+		},
+		Patterns: []string{"./..."},
+	}
+}
+
+// Find returns the references to name, a package-level identifier or "Type.Member" for
+// methods and fields, declared in the package pkgPath. The package need not be one of
+// the searched packages, so uses of dependencies can be found too.
+func (f *ReferenceFinder) Find(pkgPath, name string) (*datamodel.References, error) {
+	if pkgPath == "" || name == "" {
+		return nil, fmt.Errorf("package path and name are required")
+	}
+	cfg := f.Config
+	pkgs, err := packages.Load(&cfg, append(append([]string{}, f.Patterns...), pkgPath)...)
+	if err != nil {
+		return nil, fmt.Errorf("loading packages: %w", err)
+	}
+	var fset *token.FileSet
+	var target types.Object
+	for _, pkg := range pkgs {
+		if pkg.PkgPath == pkgPath && pkg.Types != nil {
+			if target, err = lookupSymbol(pkg.Types, name); err != nil {
+				return nil, err
+			}
+			fset = pkg.Fset
+			break
+		}
+	}
+	if target == nil {
+		return nil, fmt.Errorf("package %s not found", pkgPath)
+	}
+
+	// Test variants type-check the package again, with distinct objects, so uses
+	// are matched by the declaring position instead of object identity
+	declared := fset.Position(target.Pos())
+	result := &datamodel.References{
+		Symbol:     pkgPath + "." + name,
+		Kind:       symbolKind(target),
+		Definition: explainLocation(fset, target.Pos(), cfg.Dir),
+		References: []datamodel.Reference{},
+	}
+	seen := make(map[token.Position]bool)
+	for _, pkg := range pkgs {
+		// The declaring package was only added to the load to resolve the symbol
+		// unless it is part of the main module
+		if pkg.TypesInfo == nil || pkg.PkgPath == pkgPath && (pkg.Module == nil || !pkg.Module.Main) {
+			continue
+		}
+		enclosing := enclosingFunctions(pkg)
+		for ident, obj := range pkg.TypesInfo.Uses {
+			if obj == nil || obj.Name() != target.Name() || !obj.Pos().IsValid() || pkg.Fset.Position(obj.Pos()) != declared {
+				continue
+			}
+			at := pkg.Fset.Position(ident.Pos())
+			if seen[at] {
+				continue // Files shared by a package and its test variant
+			}
+			seen[at] = true
+			result.References = append(result.References, datamodel.Reference{
+				Package:  pkg.PkgPath,
+				Function: enclosing(ident.Pos()),
+				Location: explainLocation(pkg.Fset, ident.Pos(), cfg.Dir),
+			})
+		}
+	}
+	sort.Slice(result.References, func(i, j int) bool {
+		a, b := result.References[i].Location, result.References[j].Location
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return result, nil
+}
+
+// lookupSymbol resolves name, "Ident" or "Type.Member", in the scope of pkg.
+func lookupSymbol(pkg *types.Package, name string) (types.Object, error) {
+	typeName, member, isMember := strings.Cut(name, ".")
+	obj := pkg.Scope().Lookup(typeName)
+	if obj == nil {
+		return nil, fmt.Errorf("%s.%s not found", pkg.Path(), typeName)
+	}
+	if !isMember {
+		return obj, nil
+	}
+	if _, ok := obj.(*types.TypeName); !ok {
+		return nil, fmt.Errorf("%s.%s is not a type", pkg.Path(), typeName)
+	}
+	// Addressable, so methods with pointer receivers are found too
+	found, _, _ := types.LookupFieldOrMethod(obj.Type(), true, pkg, member)
+	if found == nil {
+		return nil, fmt.Errorf("%s.%s has no field or method %s", pkg.Path(), typeName, member)
+	}
+	return found, nil
+}
+
+func symbolKind(obj types.Object) string {
+	switch obj := obj.(type) {
+	case *types.Func:
+		if sig, ok := obj.Type().(*types.Signature); ok && sig.Recv() != nil {
+			return datamodel.SymbolMethod
+		}
+		return datamodel.SymbolFunc
+	case *types.Var:
+		if obj.IsField() {
+			return datamodel.SymbolField
+		}
+		return datamodel.SymbolVar
+	case *types.Const:
+		return datamodel.SymbolConst
+	case *types.TypeName:
+		// Reported like /symbols, so results of both can be joined
+		if _, ok := obj.Type().Underlying().(*types.Interface); ok {
+			return datamodel.SymbolInterface
+		}
+	}
+	return datamodel.SymbolType
+}
+
+// enclosingFunctions returns a function mapping a position in pkg to the ID of the
+// function declaration containing it, or "".
+func enclosingFunctions(pkg *packages.Package) func(token.Pos) string {
+	type span struct {
+		pos, end token.Pos
+		id       string
+	}
+	var spans []span
+	inits := 0
+	for _, f := range pkg.Syntax {
+		for _, decl := range f.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok {
+				continue
+			}
+			id := ""
+			if fd.Recv == nil && fd.Name.Name == "init" {
+				inits++ // Numbered across the package's files, like SSA
+				id = utils.InitFuncID(pkg.PkgPath, inits)
+			} else if fn, ok := pkg.TypesInfo.Defs[fd.Name].(*types.Func); ok {
+				id = utils.FuncID(fn)
+			}
+			spans = append(spans, span{fd.Pos(), fd.End(), id})
+		}
+	}
+	return func(pos token.Pos) string {
+		for _, s := range spans {
+			if s.pos <= pos && pos < s.end {
+				return s.id
+			}
+		}
+		return ""
+	}
+}
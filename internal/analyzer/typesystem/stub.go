@@ -0,0 +1,288 @@
+// analyzer/typesystem/stub.go
+package typesystem
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"go/types"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Receiver kinds of generated stub methods.
+const (
+	ReceiverAuto    = "auto" // Follow the methods already declared in the target package
+	ReceiverPointer = "pointer"
+	ReceiverValue   = "value"
+)
+
+// StubGenerator writes skeleton implementations of interfaces. Like
+// SatisfactionExplainer it loads the packages involved per request.
+type StubGenerator struct {
+	// Config is used to load the packages; Dir should be the module directory.
+	Config packages.Config
+}
+
+func NewStubGenerator(moduleDir string) *StubGenerator {
+	return &StubGenerator{
+		Config: packages.Config{
+			Mode: packages.NeedName |
+				packages.NeedImports |
+				packages.NeedDeps |
+				packages.NeedTypes |
+				packages.NeedSyntax |
+				packages.NeedTypesInfo |
+				packages.NeedModule,
+			Dir: moduleDir,
+		},
+	}
+}
+
+// Generate returns a Go source file declaring typeName in the package targetPkgPath
+// (the interface's package if empty) with a stub of every method of ifaceName
+// (packagePath + "." + InterfaceName), embedded ones included. Stubs panic with a TODO
+// so the file compiles. Signatures are qualified for the target package, which gets
+// the imports they need. receiver is one of the Receiver* constants; ReceiverAuto uses
+// pointer receivers unless most methods in the target package have value receivers.
+// Generic interfaces yield a generic type with the same type parameters.
+func (g *StubGenerator) Generate(ifaceName, targetPkgPath, typeName, receiver string) ([]byte, error) {
+	ifacePkgPath, ifaceIdent := splitQualifiedName(ifaceName)
+	if ifacePkgPath == "" {
+		return nil, fmt.Errorf("interface must be qualified as packagePath.Name")
+	}
+	if !token.IsIdentifier(typeName) {
+		return nil, fmt.Errorf("%q is not a valid type name", typeName)
+	}
+	if targetPkgPath == "" {
+		targetPkgPath = ifacePkgPath
+	}
+	switch receiver {
+	case ReceiverAuto, ReceiverPointer, ReceiverValue:
+	default:
+		return nil, fmt.Errorf("receiver must be one of: %s, %s, %s", ReceiverAuto, ReceiverPointer, ReceiverValue)
+	}
+
+	cfg := g.Config
+	pkgs, err := packages.Load(&cfg, ifacePkgPath, targetPkgPath)
+	if err != nil {
+		return nil, fmt.Errorf("loading packages: %w", err)
+	}
+	ifaceObj, err := lookupTypeName(pkgs, ifacePkgPath, ifaceIdent)
+	if err != nil {
+		return nil, err
+	}
+	iface, ok := ifaceObj.Type().Underlying().(*types.Interface)
+	if !ok {
+		return nil, fmt.Errorf("%s is not an interface", ifaceName)
+	}
+	if !iface.IsMethodSet() {
+		return nil, fmt.Errorf("%s is a constraint and cannot be implemented", ifaceName)
+	}
+	var target *types.Package
+	for _, pkg := range pkgs {
+		if pkg.PkgPath == targetPkgPath {
+			if len(pkg.Errors) > 0 && pkg.Types == nil {
+				return nil, fmt.Errorf("loading %s: %v", targetPkgPath, pkg.Errors[0])
+			}
+			target = pkg.Types
+		}
+	}
+	if target == nil {
+		return nil, fmt.Errorf("package %s not found", targetPkgPath)
+	}
+	if target.Scope().Lookup(typeName) != nil {
+		return nil, fmt.Errorf("%s is already declared in %s", typeName, targetPkgPath)
+	}
+
+	methods := stubMethods(iface)
+	for _, m := range methods {
+		if !m.Exported() && m.Pkg() != target {
+			return nil, fmt.Errorf("%s has unexported method %s and can only be implemented in %s", ifaceName, m.Name(), m.Pkg().Path())
+		}
+	}
+	pointer := receiver == ReceiverPointer || receiver == ReceiverAuto && !mostlyValueReceivers(target)
+
+	imports := newStubImports(target)
+	var typeParams, typeArgs string
+	if named, ok := types.Unalias(ifaceObj.Type()).(*types.Named); ok && named.TypeParams().Len() > 0 {
+		var params, args []string
+		for i := 0; i < named.TypeParams().Len(); i++ {
+			tp := named.TypeParams().At(i)
+			params = append(params, tp.Obj().Name()+" "+types.TypeString(tp.Constraint(), imports.qualifier))
+			args = append(args, tp.Obj().Name())
+		}
+		typeParams = "[" + strings.Join(params, ", ") + "]"
+		typeArgs = "[" + strings.Join(args, ", ") + "]"
+	}
+
+	var body bytes.Buffer
+	ifaceRef := ifaceIdent
+	if ifaceObj.Pkg() != target {
+		ifaceRef = ifaceObj.Pkg().Name() + "." + ifaceIdent // Not imported unless signatures need it
+	}
+	fmt.Fprintf(&body, "// %s implements %s.\ntype %s%s struct{}\n", typeName, ifaceRef, typeName, typeParams)
+	recvType := typeName + typeArgs
+	if pointer {
+		recvType = "*" + recvType
+	}
+	for _, m := range methods {
+		sig := m.Type().(*types.Signature)
+		recvName := receiverName(typeName, sig)
+		fmt.Fprintf(&body, "\nfunc (%s %s) %s%s {\n\tpanic(\"TODO: implement %s.%s\")\n}\n",
+			recvName, recvType, m.Name(), strings.TrimPrefix(types.TypeString(sig, imports.qualifier), "func"), typeName, m.Name())
+	}
+
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "package %s\n", target.Name())
+	if len(imports.paths) > 0 {
+		src.WriteString("\nimport (\n")
+		for _, path := range imports.sorted() {
+			fmt.Fprintf(&src, "\t%s\n", imports.spec(path))
+		}
+		src.WriteString(")\n")
+	}
+	src.WriteString("\n")
+	src.Write(body.Bytes())
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting stub: %w", err)
+	}
+	return formatted, nil
+}
+
+// stubMethods returns the methods of iface in declaration order: its own methods,
+// then those of each embedded interface.
+func stubMethods(iface *types.Interface) []*types.Func {
+	var methods []*types.Func
+	seen := make(map[string]bool)
+	var walk func(it *types.Interface)
+	walk = func(it *types.Interface) {
+		explicit := make([]*types.Func, it.NumExplicitMethods())
+		for i := range explicit {
+			explicit[i] = it.ExplicitMethod(i)
+		}
+		// The type checker sorts methods by name
+		sort.SliceStable(explicit, func(i, j int) bool { return explicit[i].Pos() < explicit[j].Pos() })
+		for _, m := range explicit {
+			if !seen[m.Id()] {
+				seen[m.Id()] = true
+				methods = append(methods, m)
+			}
+		}
+		for i := 0; i < it.NumEmbeddeds(); i++ {
+			if embedded, ok := it.EmbeddedType(i).Underlying().(*types.Interface); ok {
+				walk(embedded)
+			}
+		}
+	}
+	walk(iface)
+	// Methods promoted through instantiated generic interfaces are only complete in
+	// the method set
+	for i := 0; i < iface.NumMethods(); i++ {
+		if m := iface.Method(i); !seen[m.Id()] {
+			seen[m.Id()] = true
+			methods = append(methods, m)
+		}
+	}
+	return methods
+}
+
+// mostlyValueReceivers reports whether most methods declared in pkg have value
+// receivers.
+func mostlyValueReceivers(pkg *types.Package) bool {
+	pointers, values := 0, 0
+	for _, name := range pkg.Scope().Names() {
+		tn, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+		if !ok || tn.IsAlias() {
+			continue
+		}
+		named, ok := tn.Type().(*types.Named)
+		if !ok {
+			continue
+		}
+		for i := 0; i < named.NumMethods(); i++ {
+			if _, isPtr := named.Method(i).Type().(*types.Signature).Recv().Type().(*types.Pointer); isPtr {
+				pointers++
+			} else {
+				values++
+			}
+		}
+	}
+	return values > pointers
+}
+
+// receiverName is the lower-cased first letter of typeName, or "recv" if a parameter
+// or result of sig already has that name.
+func receiverName(typeName string, sig *types.Signature) string {
+	r, _ := utf8.DecodeRuneInString(typeName)
+	name := string(unicode.ToLower(r))
+	for _, tuple := range []*types.Tuple{sig.Params(), sig.Results()} {
+		for i := 0; i < tuple.Len(); i++ {
+			if tuple.At(i).Name() == name {
+				return "recv"
+			}
+		}
+	}
+	return name
+}
+
+// stubImports names the packages referenced from the target package, renaming
+// packages whose names clash.
+type stubImports struct {
+	target *types.Package
+	pkgs   map[string]*types.Package // By import path
+	paths  map[string]string         // Import path -> name used in the stub
+	names  map[string]string         // Name -> import path
+}
+
+func newStubImports(target *types.Package) *stubImports {
+	return &stubImports{
+		target: target,
+		pkgs:   make(map[string]*types.Package),
+		paths:  make(map[string]string),
+		names:  make(map[string]string),
+	}
+}
+
+func (im *stubImports) qualifier(pkg *types.Package) string {
+	if pkg == nil || pkg.Path() == im.target.Path() {
+		return ""
+	}
+	if name, ok := im.paths[pkg.Path()]; ok {
+		return name
+	}
+	name := pkg.Name()
+	for n := 2; im.names[name] != "" || name == im.target.Name(); n++ {
+		name = pkg.Name() + strconv.Itoa(n)
+	}
+	im.pkgs[pkg.Path()] = pkg
+	im.paths[pkg.Path()] = name
+	im.names[name] = pkg.Path()
+	return name
+}
+
+// spec returns the import spec of path, naming the package when it was renamed or
+// its name is not the last element of its path.
+func (im *stubImports) spec(path string) string {
+	name := im.paths[path]
+	if name != im.pkgs[path].Name() || path[strings.LastIndex(path, "/")+1:] != name {
+		return name + " " + strconv.Quote(path)
+	}
+	return strconv.Quote(path)
+}
+
+func (im *stubImports) sorted() []string {
+	paths := make([]string, 0, len(im.paths))
+	for path := range im.paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
@@ -0,0 +1,65 @@
+// analyzer/utils/comments.go
+package utils
+
+import (
+	"go/ast"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultEllipsis is appended to doc comments that were truncated.
+const DefaultEllipsis = "..."
+
+// DocCommentOptions controls how doc comments are rendered into the datamodel.
+type DocCommentOptions struct {
+	// StripMarkers removes the comment markers (//, /* */) as ast.CommentGroup.Text does.
+	// When false, the raw comment lines are kept verbatim.
+	StripMarkers bool
+	// NormalizeWhitespace collapses all runs of whitespace (including newlines) into single spaces.
+	NormalizeWhitespace bool
+	// MaxLength truncates comments longer than this many characters. Zero disables truncation.
+	MaxLength int
+	// Ellipsis is appended to truncated comments.
+	Ellipsis string
+}
+
+// DefaultDocCommentOptions returns the options matching the historical output:
+// markers stripped, whitespace preserved, no truncation.
+func DefaultDocCommentOptions() DocCommentOptions {
+	return DocCommentOptions{
+		StripMarkers: true,
+		Ellipsis:     DefaultEllipsis,
+	}
+}
+
+// FormatDocComment renders a comment group according to opts.
+// It returns an empty string for a nil comment group.
+func FormatDocComment(cg *ast.CommentGroup, opts DocCommentOptions) string {
+	if cg == nil {
+		return ""
+	}
+
+	var text string
+	if opts.StripMarkers {
+		text = cg.Text()
+	} else {
+		lines := make([]string, 0, len(cg.List))
+		for _, c := range cg.List {
+			if c != nil {
+				lines = append(lines, c.Text)
+			}
+		}
+		text = strings.Join(lines, "\n")
+	}
+	text = strings.TrimSpace(text)
+
+	if opts.NormalizeWhitespace {
+		text = strings.Join(strings.Fields(text), " ")
+	}
+
+	if opts.MaxLength > 0 && utf8.RuneCountInString(text) > opts.MaxLength {
+		runes := []rune(text)
+		text = strings.TrimRight(string(runes[:opts.MaxLength]), " \t\n") + opts.Ellipsis
+	}
+	return text
+}
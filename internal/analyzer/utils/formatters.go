@@ -4,13 +4,15 @@ package utils
 import (
 	"fmt"
 	"go/ast"
+	"go/printer"
 	"go/token" // Import token for BasicLit Kind check
 	"go/types"
 	"strings"
+	"unicode/utf8"
 
 	"golang.org/x/tools/go/packages"
 
-	"github.com/namikmesic/go-mcp/internal/datamodel" // Adjusted import path
+	"github.com/namikmesic/go-mcp/pkg/datamodel" // Adjusted import path
 )
 
 // FormatMethodSignature creates a readable method signature string.
@@ -170,6 +172,33 @@ func IsPointerType(expr ast.Expr, pkg *packages.Package) (isPointer bool, baseTy
 	return false, ""
 }
 
+// PackageOfFuncDesc extracts the package import path from a function description as
+// produced by SSA (e.g. "pkg/path.Func", "(*pkg/path.Type).Method", "pkg/path.Func$1",
+// "pkg/path.Generic[int]") or from an interface call description
+// ("Interface method M on pkg/path.Iface"). It returns "" if no package can be determined.
+func PackageOfFuncDesc(desc string) string {
+	if idx := strings.LastIndex(desc, " on "); idx >= 0 && strings.HasPrefix(desc, "Interface method ") {
+		desc = desc[idx+len(" on "):]
+	}
+	if strings.HasPrefix(desc, "(") {
+		// Method: take the receiver type inside the parentheses
+		if end := strings.Index(desc, ")"); end > 0 {
+			desc = desc[1:end]
+		}
+	}
+	desc = strings.TrimLeft(desc, "*")
+	if idx := strings.Index(desc, "["); idx >= 0 {
+		desc = desc[:idx] // Drop type arguments
+	}
+	// The package path ends at the first '.' after the last '/'
+	slash := strings.LastIndex(desc, "/")
+	dot := strings.Index(desc[slash+1:], ".")
+	if dot < 0 {
+		return ""
+	}
+	return desc[:slash+1+dot]
+}
+
 // ExprToString converts an AST expression (representing a type) to its string representation,
 // attempting to handle qualified identifiers using package type information when available.
 func ExprToString(expr ast.Expr, pkg *packages.Package) string {
@@ -308,3 +337,89 @@ func ExprToString(expr ast.Expr, pkg *packages.Package) string {
 		return fmt.Sprintf("?<%T>", expr) // Indicate unknown type and its Go type
 	}
 }
+
+// TypeString formats t relative to pkg: types of pkg itself are unqualified, other
+// packages are qualified by their package name.
+func TypeString(t types.Type, pkg *packages.Package) string {
+	return types.TypeString(t, func(other *types.Package) string {
+		if pkg != nil && pkg.Types == other {
+			return ""
+		}
+		return other.Name()
+	})
+}
+
+// ExprSource renders an expression as Go source on a single line, truncated to
+// maxLen characters (0 = no limit) with DefaultEllipsis appended.
+func ExprSource(fset *token.FileSet, expr ast.Expr, maxLen int) string {
+	var buf strings.Builder
+	if err := printer.Fprint(&buf, fset, expr); err != nil {
+		return types.ExprString(expr)
+	}
+	text := strings.Join(strings.Fields(buf.String()), " ")
+	if maxLen > 0 && utf8.RuneCountInString(text) > maxLen {
+		text = string([]rune(text)[:maxLen]) + DefaultEllipsis
+	}
+	return text
+}
+
+// ExtractTypeParams converts a type parameter list, formatting constraints relative to pkg.
+// It returns nil for non-generic declarations.
+func ExtractTypeParams(list *types.TypeParamList, pkg *packages.Package) []datamodel.TypeParam {
+	if list == nil || list.Len() == 0 {
+		return nil
+	}
+	params := make([]datamodel.TypeParam, 0, list.Len())
+	for i := 0; i < list.Len(); i++ {
+		tp := list.At(i)
+		params = append(params, datamodel.TypeParam{
+			Name:       tp.Obj().Name(),
+			Constraint: TypeString(tp.Constraint(), pkg),
+		})
+	}
+	return params
+}
+
+// InterfaceMethods flattens the complete method set of an interface, recording for each
+// method the interface that declares it so embedding chains need not be followed.
+// Types are formatted relative to pkg, which may be nil.
+func InterfaceMethods(it *types.Interface, fset *token.FileSet, pkg *packages.Package) []datamodel.Method {
+	methods := make([]datamodel.Method, 0, it.NumMethods())
+	for i := 0; i < it.NumMethods(); i++ {
+		fn := it.Method(i)
+		sig, ok := fn.Type().(*types.Signature)
+		if !ok {
+			continue
+		}
+		method := datamodel.Method{
+			Name:        fn.Name(),
+			Signature:   fn.Name() + strings.TrimPrefix(TypeString(sig, pkg), "func"),
+			Parameters:  []datamodel.Parameter{},
+			ReturnTypes: []string{},
+			Location:    datamodel.NewLocation(fset.Position(fn.Pos())),
+		}
+		for j := 0; j < sig.Params().Len(); j++ {
+			param := sig.Params().At(j)
+			t := param.Type()
+			ptr, isPtr := t.(*types.Pointer)
+			if isPtr {
+				t = ptr.Elem()
+			}
+			method.Parameters = append(method.Parameters, datamodel.Parameter{
+				Name:      param.Name(),
+				Type:      TypeString(t, pkg),
+				IsPointer: isPtr,
+			})
+		}
+		for j := 0; j < sig.Results().Len(); j++ {
+			method.ReturnTypes = append(method.ReturnTypes, TypeString(sig.Results().At(j).Type(), pkg))
+		}
+		if recv := sig.Recv(); recv != nil {
+			if named, ok := recv.Type().(*types.Named); ok && named.Obj().Pkg() != nil {
+				method.DeclaredBy = named.Obj().Pkg().Path() + "." + named.Obj().Name()
+			}
+		}
+		methods = append(methods, method)
+	}
+	return methods
+}
@@ -0,0 +1,44 @@
+// analyzer/utils/ids.go
+package utils
+
+import (
+	"go/types"
+	"strconv"
+)
+
+// FuncID returns the stable ID of a function or method: "pkgpath.Func" for functions and
+// "pkgpath.Type.Method" for methods, regardless of pointer receivers and type arguments.
+// Interface methods are identified through their interface. It returns "" for methods of
+// unnamed types.
+func FuncID(obj *types.Func) string {
+	if obj == nil {
+		return ""
+	}
+	obj = obj.Origin()
+	sig, _ := obj.Type().(*types.Signature)
+	if sig == nil || sig.Recv() == nil {
+		if obj.Pkg() == nil {
+			return obj.Name()
+		}
+		return obj.Pkg().Path() + "." + obj.Name()
+	}
+	recv := types.Unalias(sig.Recv().Type())
+	if ptr, ok := recv.(*types.Pointer); ok {
+		recv = types.Unalias(ptr.Elem())
+	}
+	named, ok := recv.(*types.Named)
+	if !ok {
+		return ""
+	}
+	typeName := named.Origin().Obj()
+	if typeName.Pkg() == nil {
+		return typeName.Name() + "." + obj.Name() // Predeclared "error"
+	}
+	return typeName.Pkg().Path() + "." + typeName.Name() + "." + obj.Name()
+}
+
+// InitFuncID returns the stable ID of the n-th (1-based) init function declared in the
+// package, matching SSA's numbering: "pkgpath.init#n".
+func InitFuncID(pkgPath string, n int) string {
+	return pkgPath + ".init#" + strconv.Itoa(n)
+}
@@ -0,0 +1,29 @@
+// analyzer/utils/sync.go
+package utils
+
+import (
+	"go/types"
+
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// SyncKind returns the datamodel.Sync* constant of t if it is sync.Mutex, sync.RWMutex
+// or sync.Map, or a pointer to one of them, and "" otherwise.
+func SyncKind(t types.Type) string {
+	if ptr, ok := types.Unalias(t).(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != "sync" {
+		return ""
+	}
+	switch named.Obj().Name() {
+	case "Mutex":
+		return datamodel.SyncMutex
+	case "RWMutex":
+		return datamodel.SyncRWMutex
+	case "Map":
+		return datamodel.SyncMap
+	}
+	return ""
+}
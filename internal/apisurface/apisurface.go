@@ -0,0 +1,250 @@
+// apisurface/apisurface.go
+package apisurface
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+
+	"github.com/namikmesic/go-mcp/internal/analyzer/stability"
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// Kinds of exported symbols.
+const (
+	KindType            = "type"
+	KindInterfaceMethod = "interface method"
+	KindField           = "field"
+	KindFunc            = "func"
+	KindMethod          = "method"
+	KindConst           = "const"
+	KindVar             = "var"
+)
+
+// Symbol is an exported declaration.
+type Symbol struct {
+	Kind string
+	// Desc describes the symbol so that two versions are equal exactly when code using
+	// it is unaffected: signatures without parameter names, field and value types.
+	Desc string
+	// Parent is the type declaring a method or field.
+	Parent string
+	// Line is the declaration in the format of the Go distribution's api files, e.g.
+	// "method (*Mem) Get(string) (string, bool)", without the package.
+	Line string
+}
+
+// Package holds the exported API of a package.
+type Package struct {
+	Path string
+	// Symbols by name: "Name", or "Type.Member" for methods and fields.
+	Symbols map[string]Symbol
+	// Sealed lists the interfaces with unexported methods, which only the package
+	// itself can implement.
+	Sealed map[string]bool
+}
+
+// IsPublic reports whether pkg is part of the API of its module, as opposed to main,
+// internal, test and vendored packages.
+func IsPublic(pkg *datamodel.PackageAnalysis) bool {
+	return pkg != nil && pkg.Name != "main" && !pkg.Vendored && !strings.HasSuffix(pkg.Path, "_test") &&
+		!stability.IsInternal(pkg.Path, "API")
+}
+
+// Extract collects the exported API of the public packages of an analysis by package
+// path. Declarations in _test.go files are not part of it.
+func Extract(analysis *datamodel.ProjectAnalysis) map[string]*Package {
+	result := make(map[string]*Package)
+	if analysis == nil {
+		return result
+	}
+	for _, pkg := range analysis.Packages {
+		if IsPublic(pkg) {
+			result[pkg.Path] = extractPackage(pkg)
+		}
+	}
+	return result
+}
+
+func extractPackage(pkg *datamodel.PackageAnalysis) *Package {
+	api := &Package{Path: pkg.Path, Symbols: make(map[string]Symbol), Sealed: make(map[string]bool)}
+	add := func(name string, loc datamodel.Location, sym Symbol) {
+		if !strings.HasSuffix(loc.Filename, "_test.go") {
+			api.Symbols[name] = sym
+		}
+	}
+
+	for _, iface := range pkg.Interfaces {
+		if !token.IsExported(iface.Name) {
+			continue
+		}
+		methods := iface.Methods
+		if len(iface.MethodSet) > 0 {
+			methods = iface.MethodSet
+		}
+		typ := "type " + iface.Name + typeParams(iface.TypeParams) + " interface"
+		var names []string
+		for _, m := range methods {
+			if !token.IsExported(m.Name) {
+				api.Sealed[iface.Name] = true
+				continue
+			}
+			names = append(names, m.Name)
+			sig := Signature(m.Signature)
+			add(iface.Name+"."+m.Name, iface.Location, Symbol{
+				Kind: KindInterfaceMethod, Desc: sig, Parent: iface.Name,
+				Line: typ + ", " + m.Name + strings.TrimPrefix(sig, "func"),
+			})
+		}
+		sort.Strings(names)
+		if api.Sealed[iface.Name] {
+			names = append(names, "unexported methods")
+		}
+		add(iface.Name, iface.Location, Symbol{
+			Kind: KindType, Desc: "interface" + typeParams(iface.TypeParams),
+			Line: typ + " { " + strings.Join(names, ", ") + " }",
+		})
+	}
+	for _, s := range pkg.Structs {
+		if !token.IsExported(s.Name) {
+			continue
+		}
+		typ := "type " + s.Name + typeParams(s.TypeParams) + " struct"
+		add(s.Name, s.Location, Symbol{Kind: KindType, Desc: "struct" + typeParams(s.TypeParams), Line: typ})
+		for _, f := range s.Fields {
+			if !f.Exported {
+				continue
+			}
+			line := typ + ", " + f.Name + " " + f.Type
+			if f.Embedded {
+				line = typ + ", embedded " + f.Type
+			}
+			add(s.Name+"."+f.Name, s.Location, Symbol{Kind: KindField, Desc: f.Type, Parent: s.Name, Line: line})
+		}
+	}
+	for _, t := range pkg.Types {
+		if !t.Exported {
+			continue
+		}
+		desc := t.Underlying
+		if t.Kind == datamodel.TypeAlias {
+			desc = "= " + t.Target
+		}
+		add(t.Name, t.Location, Symbol{
+			Kind: KindType, Desc: desc + typeParams(t.TypeParams),
+			Line: "type " + t.Name + typeParams(t.TypeParams) + " " + desc,
+		})
+	}
+	for _, fn := range pkg.Functions {
+		if !fn.Exported {
+			continue
+		}
+		sig := Signature(fn.Signature)
+		if fn.Receiver == "" {
+			add(fn.Name, fn.Location, Symbol{Kind: KindFunc, Desc: sig, Line: "func " + fn.Name + strings.TrimPrefix(sig, "func")})
+			continue
+		}
+		recv := strings.TrimPrefix(fn.Receiver, "*")
+		if i := strings.IndexByte(recv, '['); i >= 0 {
+			recv = recv[:i]
+		}
+		if token.IsExported(recv) {
+			add(recv+"."+fn.Name, fn.Location, Symbol{
+				Kind: KindMethod, Desc: sig, Parent: recv,
+				Line: "method (" + fn.Receiver + ") " + fn.Name + strings.TrimPrefix(sig, "func"),
+			})
+		}
+	}
+	for _, c := range pkg.Constants {
+		if c.Exported {
+			desc := strings.TrimSpace(c.Type + " = " + c.Constant)
+			add(c.Name, c.Location, Symbol{Kind: KindConst, Desc: desc, Line: "const " + c.Name + " " + desc})
+		}
+	}
+	for _, v := range pkg.Variables {
+		if v.Exported {
+			add(v.Name, v.Location, Symbol{Kind: KindVar, Desc: v.Type, Line: "var " + v.Name + " " + v.Type})
+		}
+	}
+	return api
+}
+
+// Lines returns the API of an analysis in the format of the Go distribution's api
+// files, one "pkg path, declaration" line per symbol, sorted.
+func Lines(analysis *datamodel.ProjectAnalysis) []string {
+	var lines []string
+	for path, api := range Extract(analysis) {
+		for _, sym := range api.Symbols {
+			lines = append(lines, "pkg "+path+", "+sym.Line)
+		}
+	}
+	sort.Strings(lines)
+	return lines
+}
+
+func typeParams(params []datamodel.TypeParam) string {
+	if len(params) == 0 {
+		return ""
+	}
+	parts := make([]string, len(params))
+	for i, p := range params {
+		parts[i] = p.Name + " " + p.Constraint
+	}
+	return "[" + strings.Join(parts, ", ") + "]"
+}
+
+// Signature rewrites a signature as written ("Get(k string) (v string, err error)")
+// without the function and parameter names ("func(string) (string, error)"), so
+// renaming parameters does not change it. Unparsable signatures are returned
+// unchanged.
+func Signature(sig string) string {
+	start := strings.IndexAny(sig, "[(")
+	if start < 0 {
+		return sig
+	}
+	file, err := parser.ParseFile(token.NewFileSet(), "", "package p\nfunc _"+sig[start:], 0)
+	if err != nil || len(file.Decls) != 1 {
+		return sig
+	}
+	decl, ok := file.Decls[0].(*ast.FuncDecl)
+	if !ok {
+		return sig
+	}
+	var b strings.Builder
+	b.WriteString("func")
+	if decl.Type.TypeParams != nil {
+		b.WriteString("[" + fieldTypes(decl.Type.TypeParams, true) + "]")
+	}
+	b.WriteString("(" + fieldTypes(decl.Type.Params, false) + ")")
+	if results := decl.Type.Results; results != nil && len(results.List) > 0 {
+		list := fieldTypes(results, false)
+		if strings.Contains(list, ",") {
+			list = "(" + list + ")"
+		}
+		b.WriteString(" " + list)
+	}
+	return b.String()
+}
+
+// fieldTypes lists the types of a field list, once per name ("a, b int" gives "int,
+// int"). Type parameters keep their names, which their constraints and the
+// signature may refer to.
+func fieldTypes(fields *ast.FieldList, withNames bool) string {
+	var parts []string
+	for _, field := range fields.List {
+		typ := types.ExprString(field.Type)
+		if withNames {
+			for _, name := range field.Names {
+				parts = append(parts, name.Name+" "+typ)
+			}
+			continue
+		}
+		for i := 0; i < max(1, len(field.Names)); i++ {
+			parts = append(parts, typ)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
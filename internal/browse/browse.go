@@ -0,0 +1,374 @@
+// browse/browse.go
+package browse
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// Browser is an interactive terminal browser over an analysis. It drills down from
+// the packages to their interfaces, from an interface to its implementations, and
+// from an implementation to the call sites reaching its methods.
+type Browser struct {
+	analysis *datamodel.ProjectAnalysis
+	calls    map[string][]*datamodel.CallSite // Concrete method ID -> calls that may reach it
+	stack    []*view
+}
+
+// view is one level of the drill-down.
+type view struct {
+	title  string
+	items  []item
+	empty  string // Shown instead of an empty list
+	cursor int
+	top    int // First visible item
+}
+
+type item struct {
+	label  string
+	detail string       // Shown in the status line when the item is selected
+	open   func() *view // Nil for leaves
+}
+
+func NewBrowser(analysis *datamodel.ProjectAnalysis) *Browser {
+	b := &Browser{analysis: analysis, calls: make(map[string][]*datamodel.CallSite)}
+	for _, pkg := range analysis.Packages {
+		if pkg == nil {
+			continue
+		}
+		for i := range pkg.Calls {
+			call := &pkg.Calls[i]
+			if len(call.Targets) > 0 {
+				for _, target := range call.Targets {
+					b.calls[target] = append(b.calls[target], call)
+				}
+			} else if call.CalleeID != "" {
+				b.calls[call.CalleeID] = append(b.calls[call.CalleeID], call)
+			}
+		}
+	}
+	return b
+}
+
+// Run takes over the terminal on in and out until the user quits.
+func (b *Browser) Run(in, out *os.File) error {
+	restore, err := makeRaw(int(in.Fd()))
+	if err != nil {
+		return fmt.Errorf("browsing needs an interactive terminal: %w", err)
+	}
+	defer restore()
+	// Alternate screen, hidden cursor
+	fmt.Fprint(out, "\x1b[?1049h\x1b[?25l")
+	defer fmt.Fprint(out, "\x1b[?25h\x1b[?1049l")
+
+	b.stack = []*view{b.packagesView()}
+	buf := make([]byte, 32)
+	for {
+		width, height, err := terminalSize(int(out.Fd()))
+		if err != nil || width <= 0 || height <= 0 {
+			width, height = 80, 24
+		}
+		if err := b.draw(out, width, height); err != nil {
+			return err
+		}
+		n, err := in.Read(buf)
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if !b.handle(decodeKey(buf[:n]), listHeight(height)) {
+			return nil
+		}
+	}
+}
+
+// Keys understood by the browser.
+const (
+	keyNone = iota
+	keyUp
+	keyDown
+	keyPageUp
+	keyPageDown
+	keyHome
+	keyEnd
+	keyOpen
+	keyBack
+	keyQuit
+)
+
+// decodeKey maps the bytes of one read from the terminal to a key. Both arrow keys and
+// vi-style letters move around.
+func decodeKey(input []byte) int {
+	switch string(input) {
+	case "\x1b[A", "\x1bOA", "k":
+		return keyUp
+	case "\x1b[B", "\x1bOB", "j":
+		return keyDown
+	case "\x1b[5~":
+		return keyPageUp
+	case "\x1b[6~", " ":
+		return keyPageDown
+	case "\x1b[H", "\x1b[1~", "\x1bOH", "g":
+		return keyHome
+	case "\x1b[F", "\x1b[4~", "\x1bOF", "G":
+		return keyEnd
+	case "\r", "\n", "\x1b[C", "\x1bOC", "l":
+		return keyOpen
+	case "\x1b", "\x1b[D", "\x1bOD", "h", "\x7f", "\b":
+		return keyBack
+	case "q", "\x03", "\x04":
+		return keyQuit
+	}
+	return keyNone
+}
+
+// handle applies a key to the current view and reports whether to keep running.
+func (b *Browser) handle(key, rows int) bool {
+	v := b.stack[len(b.stack)-1]
+	switch key {
+	case keyQuit:
+		return false
+	case keyUp:
+		v.cursor--
+	case keyDown:
+		v.cursor++
+	case keyPageUp:
+		v.cursor -= rows
+	case keyPageDown:
+		v.cursor += rows
+	case keyHome:
+		v.cursor = 0
+	case keyEnd:
+		v.cursor = len(v.items) - 1
+	case keyOpen:
+		if v.cursor < len(v.items) && v.items[v.cursor].open != nil {
+			b.stack = append(b.stack, v.items[v.cursor].open())
+			return true
+		}
+	case keyBack:
+		if len(b.stack) > 1 {
+			b.stack = b.stack[:len(b.stack)-1]
+		}
+		return true
+	}
+	v.cursor = max(0, min(v.cursor, len(v.items)-1))
+	if v.cursor < v.top {
+		v.top = v.cursor
+	}
+	if v.cursor >= v.top+rows {
+		v.top = v.cursor - rows + 1
+	}
+	return true
+}
+
+// listHeight is the number of list rows of a screen of the given height, which also
+// shows a title, a status and a help line.
+func listHeight(height int) int {
+	return max(1, height-3)
+}
+
+// draw renders the current view. The terminal is in raw mode, so lines end in "\r\n".
+func (b *Browser) draw(w io.Writer, width, height int) error {
+	v := b.stack[len(b.stack)-1]
+	rows := listHeight(height)
+	if v.cursor >= v.top+rows {
+		v.top = v.cursor - rows + 1
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("\x1b[H\x1b[2J")
+	titles := make([]string, len(b.stack))
+	for i, s := range b.stack {
+		titles[i] = s.title
+	}
+	fmt.Fprintf(&buf, "\x1b[7m%s\x1b[0m\r\n", pad(strings.Join(titles, " > "), width))
+	for row := 0; row < rows; row++ {
+		i := v.top + row
+		switch {
+		case len(v.items) == 0 && row == 0:
+			fmt.Fprintf(&buf, "  \x1b[2m%s\x1b[0m", truncate(v.empty, width-2))
+		case i < len(v.items):
+			it := v.items[i]
+			marker := "  "
+			if it.open != nil {
+				marker = "> "
+			}
+			line := truncate(marker+it.label, width)
+			if i == v.cursor {
+				line = "\x1b[7m" + pad(line, width) + "\x1b[0m"
+			}
+			buf.WriteString(line)
+		}
+		buf.WriteString("\r\n")
+	}
+	status := ""
+	if v.cursor < len(v.items) {
+		status = v.items[v.cursor].detail
+	}
+	fmt.Fprintf(&buf, "\x1b[2m%s\x1b[0m\r\n", truncate(status, width))
+	help := fmt.Sprintf("%d/%d  up/down j/k move  enter/l open  esc/h back  q quit", min(v.cursor+1, len(v.items)), len(v.items))
+	buf.WriteString(truncate(help, width))
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func truncate(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:width-1]) + "~"
+}
+
+func pad(s string, width int) string {
+	s = truncate(s, width)
+	return s + strings.Repeat(" ", max(0, width-utf8.RuneCountInString(s)))
+}
+
+func (b *Browser) packagesView() *view {
+	v := &view{title: "Packages", empty: "The analysis has no packages"}
+	pkgs := make([]*datamodel.PackageAnalysis, 0, len(b.analysis.Packages))
+	for _, pkg := range b.analysis.Packages {
+		if pkg != nil {
+			pkgs = append(pkgs, pkg)
+		}
+	}
+	sort.Slice(pkgs, func(i, j int) bool { return pkgs[i].Path < pkgs[j].Path })
+	for _, pkg := range pkgs {
+		v.items = append(v.items, item{
+			label: fmt.Sprintf("%s (%d interfaces)", pkg.Path, len(pkg.Interfaces)),
+			detail: fmt.Sprintf("package %s: %d interfaces, %d structs, %d functions, %d calls",
+				pkg.Name, len(pkg.Interfaces), len(pkg.Structs), len(pkg.Functions), len(pkg.Calls)),
+			open: func() *view { return b.interfacesView(pkg) },
+		})
+	}
+	return v
+}
+
+func (b *Browser) interfacesView(pkg *datamodel.PackageAnalysis) *view {
+	v := &view{title: pkg.Path, empty: "No interfaces in this package"}
+	ifaces := make([]*datamodel.Interface, len(pkg.Interfaces))
+	for i := range pkg.Interfaces {
+		ifaces[i] = &pkg.Interfaces[i]
+	}
+	sort.Slice(ifaces, func(i, j int) bool { return ifaces[i].Name < ifaces[j].Name })
+	for _, iface := range ifaces {
+		methods := iface.Methods
+		if len(iface.MethodSet) > 0 {
+			methods = iface.MethodSet
+		}
+		detail := b.location(iface.Location)
+		if doc, _, _ := strings.Cut(strings.TrimSpace(iface.DocComment), "\n"); doc != "" {
+			detail += "  " + doc
+		}
+		v.items = append(v.items, item{
+			label:  fmt.Sprintf("%s (%d methods, %d implementations)", iface.Name, len(methods), len(iface.Implementations)),
+			detail: detail,
+			open:   func() *view { return b.implementationsView(iface) },
+		})
+	}
+	return v
+}
+
+func (b *Browser) implementationsView(iface *datamodel.Interface) *view {
+	v := &view{title: iface.Name, empty: "No implementations of this interface"}
+	impls := make([]*datamodel.Implementation, len(iface.Implementations))
+	for i := range iface.Implementations {
+		impls[i] = &iface.Implementations[i]
+	}
+	sort.Slice(impls, func(i, j int) bool { return implName(impls[i]) < implName(impls[j]) })
+	for _, impl := range impls {
+		calls := b.callSites(iface, impl)
+		v.items = append(v.items, item{
+			label:  fmt.Sprintf("%s (%d call sites)", implName(impl), len(calls)),
+			detail: b.location(impl.Location),
+			open:   func() *view { return b.callSitesView(implName(impl), calls) },
+		})
+	}
+	return v
+}
+
+func implName(impl *datamodel.Implementation) string {
+	name := impl.PackagePath + "." + impl.TypeName
+	if impl.IsPointer {
+		return "*" + name
+	}
+	return name
+}
+
+// callSites returns the calls that may reach the methods of impl through iface, or
+// call them directly, by location.
+func (b *Browser) callSites(iface *datamodel.Interface, impl *datamodel.Implementation) []*datamodel.CallSite {
+	ifacePrefix := iface.PackagePath + "." + iface.Name + "."
+	seen := make(map[*datamodel.CallSite]bool)
+	var calls []*datamodel.CallSite
+	for _, m := range impl.Methods {
+		for _, call := range b.calls[m.ID] {
+			// Interface calls through other interfaces belong to those
+			if seen[call] || len(call.Targets) > 0 && !strings.HasPrefix(call.CalleeID, ifacePrefix) {
+				continue
+			}
+			seen[call] = true
+			calls = append(calls, call)
+		}
+	}
+	sort.Slice(calls, func(i, j int) bool {
+		a, b := calls[i].Location, calls[j].Location
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return calls
+}
+
+func (b *Browser) callSitesView(title string, calls []*datamodel.CallSite) *view {
+	v := &view{title: title, empty: "No call sites reach this implementation"}
+	if len(b.calls) == 0 {
+		v.empty += " (the analysis has no call graph)"
+	}
+	for _, call := range calls {
+		caller, callee := call.CallerID, call.CalleeID
+		if caller == "" {
+			caller = call.CallerFuncDesc
+		}
+		if callee == "" {
+			callee = call.CalleeDesc
+		}
+		v.items = append(v.items, item{
+			label:  fmt.Sprintf("%s -> %s (%s)", caller, callee, call.CallType),
+			detail: b.location(call.Location),
+		})
+	}
+	return v
+}
+
+// location formats loc relative to the module directory.
+func (b *Browser) location(loc datamodel.Location) string {
+	name := loc.Filename
+	if b.analysis.ModuleDir != "" && filepath.IsAbs(name) {
+		if rel, err := filepath.Rel(b.analysis.ModuleDir, name); err == nil && !strings.HasPrefix(rel, "..") {
+			name = rel
+		}
+	}
+	if loc.Column == 0 {
+		return fmt.Sprintf("%s:%d", name, loc.Line)
+	}
+	return fmt.Sprintf("%s:%d:%d", name, loc.Line, loc.Column)
+}
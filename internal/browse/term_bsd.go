@@ -0,0 +1,11 @@
+//go:build darwin || freebsd || netbsd || openbsd
+
+// browse/term_bsd.go
+package browse
+
+import "golang.org/x/sys/unix"
+
+const (
+	ioctlGetTermios = unix.TIOCGETA
+	ioctlSetTermios = unix.TIOCSETA
+)
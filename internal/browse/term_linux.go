@@ -0,0 +1,9 @@
+// browse/term_linux.go
+package browse
+
+import "golang.org/x/sys/unix"
+
+const (
+	ioctlGetTermios = unix.TCGETS
+	ioctlSetTermios = unix.TCSETS
+)
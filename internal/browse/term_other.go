@@ -0,0 +1,19 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd)
+
+// browse/term_other.go
+package browse
+
+import (
+	"errors"
+	"runtime"
+)
+
+var errUnsupported = errors.New("raw terminal mode is not supported on " + runtime.GOOS)
+
+func makeRaw(fd int) (func() error, error) {
+	return nil, errUnsupported
+}
+
+func terminalSize(fd int) (int, int, error) {
+	return 0, 0, errUnsupported
+}
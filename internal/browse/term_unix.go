@@ -0,0 +1,37 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd
+
+// browse/term_unix.go
+package browse
+
+import "golang.org/x/sys/unix"
+
+// makeRaw puts the terminal on fd into raw mode: input is read byte by byte without
+// echo, and output is not post-processed. The returned function restores the
+// previous mode.
+func makeRaw(fd int) (func() error, error) {
+	saved, err := unix.IoctlGetTermios(fd, ioctlGetTermios)
+	if err != nil {
+		return nil, err
+	}
+	raw := *saved
+	raw.Iflag &^= unix.IGNBRK | unix.BRKINT | unix.PARMRK | unix.ISTRIP | unix.INLCR | unix.IGNCR | unix.ICRNL | unix.IXON
+	raw.Oflag &^= unix.OPOST
+	raw.Lflag &^= unix.ECHO | unix.ECHONL | unix.ICANON | unix.ISIG | unix.IEXTEN
+	raw.Cflag &^= unix.CSIZE | unix.PARENB
+	raw.Cflag |= unix.CS8
+	raw.Cc[unix.VMIN] = 1
+	raw.Cc[unix.VTIME] = 0
+	if err := unix.IoctlSetTermios(fd, ioctlSetTermios, &raw); err != nil {
+		return nil, err
+	}
+	return func() error { return unix.IoctlSetTermios(fd, ioctlSetTermios, saved) }, nil
+}
+
+// terminalSize returns the width and height of the terminal on fd.
+func terminalSize(fd int) (int, int, error) {
+	ws, err := unix.IoctlGetWinsize(fd, unix.TIOCGWINSZ)
+	if err != nil {
+		return 0, 0, err
+	}
+	return int(ws.Col), int(ws.Row), nil
+}
@@ -0,0 +1,105 @@
+// depcache/depcache.go
+package depcache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/mod/module"
+
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// formatVersion is bumped whenever the cached representation changes, invalidating old entries.
+const formatVersion = "v2"
+
+// Cache stores per-package analysis results of dependency modules on disk. Module
+// versions are immutable, so entries keyed by module@version never go stale.
+//
+// Layout: <Dir>/<formatVersion>/<escaped module>@<escaped version>/<escaped package path>.json
+type Cache struct {
+	Dir string
+}
+
+// NewCache creates a cache in dir, or in go-mcp/deps under os.UserCacheDir if dir is empty.
+func NewCache(dir string) (*Cache, error) {
+	if dir == "" {
+		userDir, err := os.UserCacheDir()
+		if err != nil {
+			return nil, fmt.Errorf("locating user cache directory: %w", err)
+		}
+		dir = filepath.Join(userDir, "go-mcp", "deps")
+	}
+	return &Cache{Dir: dir}, nil
+}
+
+// entryPath returns the file holding the entry for pkgPath in module@version.
+func (c *Cache) entryPath(modulePath, version, pkgPath string) (string, error) {
+	escMod, err := module.EscapePath(modulePath)
+	if err != nil {
+		return "", fmt.Errorf("escaping module path %s: %w", modulePath, err)
+	}
+	escVer, err := module.EscapeVersion(version)
+	if err != nil {
+		return "", fmt.Errorf("escaping version %s: %w", version, err)
+	}
+	escPkg, err := module.EscapePath(pkgPath)
+	if err != nil {
+		return "", fmt.Errorf("escaping package path %s: %w", pkgPath, err)
+	}
+	return filepath.Join(c.Dir, formatVersion, escMod+"@"+escVer, filepath.FromSlash(escPkg)+".json"), nil
+}
+
+// Load returns the cached entry for pkgPath in module@version. The boolean is false on a miss.
+func (c *Cache) Load(modulePath, version, pkgPath string) (*datamodel.DependencyPackage, bool, error) {
+	path, err := c.entryPath(modulePath, version, pkgPath)
+	if err != nil {
+		return nil, false, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("reading cache entry %s: %w", path, err)
+	}
+	var entry datamodel.DependencyPackage
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false, fmt.Errorf("decoding cache entry %s: %w", path, err)
+	}
+	return &entry, true, nil
+}
+
+// Store writes the entry, replacing any existing one. The file is written to a
+// temporary name first so concurrent runs never observe partial entries.
+func (c *Cache) Store(entry *datamodel.DependencyPackage) error {
+	path, err := c.entryPath(entry.Module, entry.Version, entry.Path)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating cache directory: %w", err)
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("encoding cache entry for %s: %w", entry.Path, err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("creating cache entry: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("writing cache entry: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("writing cache entry: %w", err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,103 @@
+// diff/api.go
+package diff
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/namikmesic/go-mcp/internal/apisurface"
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// CompareAPI reports the changes to the exported API of the analyzed packages from one
+// analysis to another. Removing a symbol, changing its type or signature, and adding a
+// method to an interface that other packages can implement are incompatible; adding
+// symbols is compatible. Members of added and removed types are not listed separately.
+// The API is that extracted by apisurface.Extract.
+func CompareAPI(from, to *datamodel.ProjectAnalysis) *datamodel.APIDiff {
+	d := &datamodel.APIDiff{}
+	oldAPI, newAPI := apisurface.Extract(from), apisurface.Extract(to)
+	paths := make(map[string]bool)
+	for path := range oldAPI {
+		paths[path] = true
+	}
+	for path := range newAPI {
+		paths[path] = true
+	}
+	for _, path := range sortedKeys(paths) {
+		oldPkg, newPkg := oldAPI[path], newAPI[path]
+		switch {
+		case oldPkg == nil:
+			d.Compatible = append(d.Compatible, datamodel.APIChange{Package: path, Change: "package added"})
+			continue
+		case newPkg == nil:
+			d.Incompatible = append(d.Incompatible, datamodel.APIChange{Package: path, Change: "package removed"})
+			continue
+		}
+		for _, name := range sortedKeys(oldPkg.Symbols) {
+			old := oldPkg.Symbols[name]
+			sym, ok := newPkg.Symbols[name]
+			switch {
+			case !ok:
+				if _, parentKept := newPkg.Symbols[old.Parent]; old.Parent == "" || parentKept {
+					d.Incompatible = append(d.Incompatible, datamodel.APIChange{Package: path, Symbol: name, Change: "removed"})
+				}
+			case sym.Kind != old.Kind || sym.Desc != old.Desc:
+				d.Incompatible = append(d.Incompatible, datamodel.APIChange{
+					Package: path,
+					Symbol:  name,
+					Change:  fmt.Sprintf("changed from %s to %s", describe(old), describe(sym)),
+				})
+			}
+		}
+		for _, name := range sortedKeys(newPkg.Symbols) {
+			sym := newPkg.Symbols[name]
+			if _, existed := oldPkg.Symbols[name]; existed {
+				continue
+			}
+			if _, parentExisted := oldPkg.Symbols[sym.Parent]; sym.Parent != "" && !parentExisted {
+				continue // Part of an added type
+			}
+			change := datamodel.APIChange{Package: path, Symbol: name, Change: "added"}
+			if sym.Kind == apisurface.KindInterfaceMethod && !oldPkg.Sealed[sym.Parent] {
+				change.Change = "added to interface, breaking its implementations outside the package"
+				d.Incompatible = append(d.Incompatible, change)
+				continue
+			}
+			d.Compatible = append(d.Compatible, change)
+		}
+	}
+	return d
+}
+
+func describe(sym apisurface.Symbol) string {
+	switch sym.Kind {
+	case apisurface.KindField, apisurface.KindConst, apisurface.KindVar:
+		return sym.Kind + " " + sym.Desc
+	}
+	return sym.Desc
+}
+
+// WriteAPIText writes d in the style of apidiff: the incompatible changes, then the
+// compatible ones, one "- pkg/path.Symbol: change" line each.
+func WriteAPIText(w io.Writer, d *datamodel.APIDiff) error {
+	var buf bytes.Buffer
+	section := func(title string, changes []datamodel.APIChange) {
+		if len(changes) == 0 {
+			return
+		}
+		fmt.Fprintf(&buf, "%s:\n", title)
+		for _, c := range changes {
+			name := c.Package
+			if c.Symbol != "" {
+				name += "." + c.Symbol
+			}
+			fmt.Fprintf(&buf, "- %s: %s\n", name, c.Change)
+		}
+	}
+	section("Incompatible changes", d.Incompatible)
+	section("Compatible changes", d.Compatible)
+	_, err := w.Write(buf.Bytes())
+	return err
+}
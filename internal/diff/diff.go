@@ -0,0 +1,254 @@
+// diff/diff.go
+package diff
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// Load reads an analysis written by the JSON renderer. Anything before the JSON
+// document, such as the "===== ANALYSIS RESULTS (JSON) =====" header of standard
+// output, is skipped.
+func Load(path string) (*datamodel.ProjectAnalysis, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading analysis %s: %w", path, err)
+	}
+	if start := bytes.IndexByte(data, '{'); start > 0 {
+		data = data[start:]
+	}
+	var analysis datamodel.ProjectAnalysis
+	if err := json.Unmarshal(data, &analysis); err != nil {
+		return nil, fmt.Errorf("decoding analysis %s: %w", path, err)
+	}
+	if !datamodel.SchemaCompatible(analysis.SchemaVersion) {
+		return nil, fmt.Errorf("analysis %s has schema version %s, which this build (%s) cannot read", path, analysis.SchemaVersion, datamodel.SchemaVersion)
+	}
+	return &analysis, nil
+}
+
+// Compare returns the structural difference from one analysis to another.
+func Compare(from, to *datamodel.ProjectAnalysis) *datamodel.AnalysisDiff {
+	d := &datamodel.AnalysisDiff{}
+
+	oldIfaces, newIfaces := interfacesByKey(from), interfacesByKey(to)
+	for _, key := range sortedKeys(newIfaces) {
+		oldIface, existed := oldIfaces[key]
+		if !existed {
+			d.AddedInterfaces = append(d.AddedInterfaces, key)
+			continue
+		}
+		if change, changed := compareInterface(key, oldIface, newIfaces[key]); changed {
+			d.ChangedInterfaces = append(d.ChangedInterfaces, change)
+		}
+	}
+	for _, key := range sortedKeys(oldIfaces) {
+		if _, ok := newIfaces[key]; !ok {
+			d.RemovedInterfaces = append(d.RemovedInterfaces, key)
+		}
+	}
+
+	oldCalls, newCalls := CallEdges(from), CallEdges(to)
+	for edge := range newCalls {
+		if !oldCalls[edge] {
+			d.AddedCalls = append(d.AddedCalls, edge)
+		}
+	}
+	for edge := range oldCalls {
+		if !newCalls[edge] {
+			d.RemovedCalls = append(d.RemovedCalls, edge)
+		}
+	}
+	sortCallEdges(d.AddedCalls)
+	sortCallEdges(d.RemovedCalls)
+	return d
+}
+
+// compareInterface compares the method sets and implementations of two versions of
+// an interface.
+func compareInterface(key string, from, to *datamodel.Interface) (datamodel.InterfaceChange, bool) {
+	change := datamodel.InterfaceChange{Interface: key}
+
+	oldMethods, newMethods := methodSet(from), methodSet(to)
+	for _, name := range sortedKeys(newMethods) {
+		oldMethod, existed := oldMethods[name]
+		switch {
+		case !existed:
+			change.AddedMethods = append(change.AddedMethods, name)
+		case oldMethod.Signature != newMethods[name].Signature:
+			change.ChangedMethods = append(change.ChangedMethods, datamodel.MethodChange{
+				Name:         name,
+				OldSignature: oldMethod.Signature,
+				NewSignature: newMethods[name].Signature,
+			})
+		}
+	}
+	for _, name := range sortedKeys(oldMethods) {
+		if _, ok := newMethods[name]; !ok {
+			change.RemovedMethods = append(change.RemovedMethods, name)
+		}
+	}
+
+	oldImpls, newImpls := implementations(from), implementations(to)
+	for _, impl := range sortedKeys(newImpls) {
+		if !oldImpls[impl] {
+			change.AddedImplementations = append(change.AddedImplementations, impl)
+		}
+	}
+	for _, impl := range sortedKeys(oldImpls) {
+		if !newImpls[impl] {
+			change.RemovedImplementations = append(change.RemovedImplementations, impl)
+		}
+	}
+
+	changed := len(change.AddedMethods) > 0 || len(change.RemovedMethods) > 0 || len(change.ChangedMethods) > 0 ||
+		len(change.AddedImplementations) > 0 || len(change.RemovedImplementations) > 0
+	return change, changed
+}
+
+func interfacesByKey(analysis *datamodel.ProjectAnalysis) map[string]*datamodel.Interface {
+	result := make(map[string]*datamodel.Interface)
+	if analysis == nil {
+		return result
+	}
+	for _, pkg := range analysis.Packages {
+		if pkg == nil {
+			continue
+		}
+		for i := range pkg.Interfaces {
+			iface := &pkg.Interfaces[i]
+			result[iface.PackagePath+"."+iface.Name] = iface
+		}
+	}
+	return result
+}
+
+// methodSet returns the complete method set of iface by name, so methods gained or
+// lost through embedded interfaces count as changes too.
+func methodSet(iface *datamodel.Interface) map[string]datamodel.Method {
+	methods := iface.Methods
+	if len(iface.MethodSet) > 0 {
+		methods = iface.MethodSet
+	}
+	result := make(map[string]datamodel.Method, len(methods))
+	for _, m := range methods {
+		result[m.Name] = m
+	}
+	return result
+}
+
+func implementations(iface *datamodel.Interface) map[string]bool {
+	result := make(map[string]bool, len(iface.Implementations))
+	for _, impl := range iface.Implementations {
+		key := impl.PackagePath + "." + impl.TypeName
+		if impl.IsPointer {
+			key = "*" + key
+		}
+		result[key] = true
+	}
+	return result
+}
+
+// CallEdges returns the distinct call edges of an analysis.
+func CallEdges(analysis *datamodel.ProjectAnalysis) map[datamodel.CallEdge]bool {
+	result := make(map[datamodel.CallEdge]bool)
+	if analysis == nil {
+		return result
+	}
+	for _, pkg := range analysis.Packages {
+		if pkg == nil {
+			continue
+		}
+		for _, call := range pkg.Calls {
+			edge := datamodel.CallEdge{Caller: call.CallerID, Callee: call.CalleeID, CallType: call.CallType}
+			if edge.Caller == "" {
+				edge.Caller = call.CallerFuncDesc
+			}
+			if edge.Callee == "" {
+				edge.Callee = stableCallee(call.CalleeDesc)
+			}
+			result[edge] = true
+		}
+	}
+	return result
+}
+
+// stableCallee drops the function value from a dynamic call description ("Dynamic
+// via t3 (func())"): SSA value names change with unrelated edits to the caller.
+func stableCallee(desc string) string {
+	rest, ok := strings.CutPrefix(desc, "Dynamic via ")
+	if !ok {
+		return desc
+	}
+	if _, typ, ok := strings.Cut(rest, " "); ok {
+		return "Dynamic " + typ
+	}
+	return desc
+}
+
+func sortCallEdges(edges []datamodel.CallEdge) {
+	sort.Slice(edges, func(i, j int) bool {
+		a, b := edges[i], edges[j]
+		if a.Caller != b.Caller {
+			return a.Caller < b.Caller
+		}
+		if a.Callee != b.Callee {
+			return a.Callee < b.Callee
+		}
+		return a.CallType < b.CallType
+	})
+}
+
+// WriteText writes d as a line-oriented summary: "+" marks additions, "-" removals
+// and "~" changed signatures.
+func WriteText(w io.Writer, d *datamodel.AnalysisDiff) error {
+	var buf bytes.Buffer
+	for _, key := range d.AddedInterfaces {
+		fmt.Fprintf(&buf, "+ interface %s\n", key)
+	}
+	for _, key := range d.RemovedInterfaces {
+		fmt.Fprintf(&buf, "- interface %s\n", key)
+	}
+	for _, c := range d.ChangedInterfaces {
+		fmt.Fprintf(&buf, "  interface %s\n", c.Interface)
+		for _, name := range c.AddedMethods {
+			fmt.Fprintf(&buf, "    + method %s\n", name)
+		}
+		for _, name := range c.RemovedMethods {
+			fmt.Fprintf(&buf, "    - method %s\n", name)
+		}
+		for _, m := range c.ChangedMethods {
+			fmt.Fprintf(&buf, "    ~ method %s: %s -> %s\n", m.Name, m.OldSignature, m.NewSignature)
+		}
+		for _, impl := range c.AddedImplementations {
+			fmt.Fprintf(&buf, "    + implementation %s\n", impl)
+		}
+		for _, impl := range c.RemovedImplementations {
+			fmt.Fprintf(&buf, "    - implementation %s\n", impl)
+		}
+	}
+	for _, e := range d.AddedCalls {
+		fmt.Fprintf(&buf, "+ call %s -> %s (%s)\n", e.Caller, e.Callee, e.CallType)
+	}
+	for _, e := range d.RemovedCalls {
+		fmt.Fprintf(&buf, "- call %s -> %s (%s)\n", e.Caller, e.Callee, e.CallType)
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
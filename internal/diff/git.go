@@ -0,0 +1,69 @@
+// diff/git.go
+package diff
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Worktree checks out ref of the git repository containing dir into a temporary
+// worktree, detached from any branch, and returns the directory corresponding to dir
+// in it. The returned remove function deletes the worktree again.
+func Worktree(ctx context.Context, dir, ref string) (string, func() error, error) {
+	dir, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return "", nil, err
+	}
+	top, err := git(ctx, dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", nil, fmt.Errorf("%s is not in a git repository: %w", dir, err)
+	}
+	rel, err := filepath.Rel(top, dir)
+	if err != nil {
+		return "", nil, err
+	}
+	commit, err := git(ctx, top, "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	if err != nil {
+		return "", nil, fmt.Errorf("unknown git ref %q", ref)
+	}
+
+	tmp, err := os.MkdirTemp("", "go-mcp-base-")
+	if err != nil {
+		return "", nil, fmt.Errorf("creating worktree directory: %w", err)
+	}
+	if _, err := git(ctx, top, "worktree", "add", "--detach", "--quiet", tmp, commit); err != nil {
+		os.RemoveAll(tmp)
+		return "", nil, fmt.Errorf("checking out %s: %w", ref, err)
+	}
+	remove := func() error {
+		// Not tied to ctx, so an interrupted run still cleans up
+		_, err := git(context.Background(), top, "worktree", "remove", "--force", tmp)
+		if err != nil {
+			os.RemoveAll(tmp)
+			git(context.Background(), top, "worktree", "prune")
+		}
+		return err
+	}
+	return filepath.Join(tmp, rel), remove, nil
+}
+
+// git runs a git command in dir and returns its trimmed standard output. Errors carry
+// git's standard error.
+func git(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
@@ -0,0 +1,363 @@
+// graphql/analysis.go
+package graphql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// Root is the root value of the analysis schema: an analysis with the indexes its
+// nested resolvers need.
+type Root struct {
+	analysis      *datamodel.ProjectAnalysis
+	packages      map[string]*datamodel.PackageAnalysis
+	interfaces    map[string]*datamodel.Interface  // By "pkgpath.Name"
+	functions     map[string]*datamodel.Function   // By Function.ID
+	callsByCaller map[string][]*datamodel.CallSite // By CallerID, closures under their enclosing function
+	callsByCallee map[string][]*datamodel.CallSite // By CalleeID
+	implementedBy map[string][]*datamodel.Interface
+}
+
+// NewRoot indexes analysis for the analysis schema.
+func NewRoot(analysis *datamodel.ProjectAnalysis) *Root {
+	r := &Root{
+		analysis:      analysis,
+		packages:      make(map[string]*datamodel.PackageAnalysis),
+		interfaces:    make(map[string]*datamodel.Interface),
+		functions:     make(map[string]*datamodel.Function),
+		callsByCaller: make(map[string][]*datamodel.CallSite),
+		callsByCallee: make(map[string][]*datamodel.CallSite),
+		implementedBy: make(map[string][]*datamodel.Interface),
+	}
+	for _, pkg := range analysis.Packages {
+		if pkg == nil {
+			continue
+		}
+		r.packages[pkg.Path] = pkg
+		for i := range pkg.Interfaces {
+			iface := &pkg.Interfaces[i]
+			r.interfaces[interfaceID(iface)] = iface
+			for _, impl := range iface.Implementations {
+				id := impl.PackagePath + "." + impl.TypeName
+				r.implementedBy[id] = append(r.implementedBy[id], iface)
+			}
+		}
+		for i := range pkg.Functions {
+			r.functions[pkg.Functions[i].ID] = &pkg.Functions[i]
+		}
+		for i := range pkg.Calls {
+			call := &pkg.Calls[i]
+			if call.CallerID != "" {
+				caller, _, _ := strings.Cut(call.CallerID, "$")
+				r.callsByCaller[caller] = append(r.callsByCaller[caller], call)
+			}
+			if call.CalleeID != "" {
+				r.callsByCallee[call.CalleeID] = append(r.callsByCallee[call.CalleeID], call)
+			}
+		}
+	}
+	return r
+}
+
+func interfaceID(iface *datamodel.Interface) string {
+	return iface.PackagePath + "." + iface.Name
+}
+
+// implementation is the source value of Implementation objects.
+type implementation struct {
+	*datamodel.Implementation
+}
+
+func (impl implementation) id() string { return impl.PackagePath + "." + impl.TypeName }
+
+// NewAnalysisSchema returns the schema exposing a Root: packages, interfaces and their
+// implementations, functions, and calls, with nested resolvers linking them.
+func NewAnalysisSchema() *Schema {
+	query := &Object{Name: "Query"}
+	pkgType := &Object{Name: "Package", Description: "An analyzed package"}
+	ifaceType := &Object{Name: "Interface", Description: "An interface declared in an analyzed package"}
+	methodType := &Object{Name: "Method", Description: "A method declared by an interface"}
+	implType := &Object{Name: "Implementation", Description: "A concrete type implementing an interface"}
+	funcType := &Object{Name: "Function", Description: "A function or method declared in an analyzed package"}
+	callType := &Object{Name: "Call", Description: "A call site"}
+
+	root := func(p ResolveParams) *Root { return p.Root.(*Root) }
+	str := func(p ResolveParams, name string) string {
+		s, _ := p.Args[name].(string)
+		return s
+	}
+
+	query.Fields = []*Field{
+		{Name: "schemaVersion", Type: &NonNull{Of: String}, Resolve: func(p ResolveParams) (any, error) {
+			return root(p).analysis.SchemaVersion, nil
+		}},
+		{Name: "modulePath", Type: &NonNull{Of: String}, Resolve: func(p ResolveParams) (any, error) {
+			return root(p).analysis.ModulePath, nil
+		}},
+		{Name: "packages", Type: ListOf(pkgType),
+			Args: []*Arg{{Name: "path", Type: String, Description: "Only the package with this import path"}},
+			Resolve: func(p ResolveParams) (any, error) {
+				var pkgs []*datamodel.PackageAnalysis
+				for _, pkg := range root(p).analysis.Packages {
+					if pkg != nil && (str(p, "path") == "" || pkg.Path == str(p, "path")) {
+						pkgs = append(pkgs, pkg)
+					}
+				}
+				return pkgs, nil
+			}},
+		{Name: "package", Type: pkgType, Args: []*Arg{{Name: "path", Type: &NonNull{Of: String}}},
+			Resolve: func(p ResolveParams) (any, error) {
+				return root(p).packages[str(p, "path")], nil
+			}},
+		{Name: "interfaces", Type: ListOf(ifaceType),
+			Args: []*Arg{
+				{Name: "package", Type: String, Description: "Only interfaces declared in this package"},
+				{Name: "name", Type: String, Description: "Only interfaces with this name"},
+			},
+			Resolve: func(p ResolveParams) (any, error) {
+				var ifaces []*datamodel.Interface
+				for _, pkg := range root(p).analysis.Packages {
+					if pkg == nil || str(p, "package") != "" && pkg.Path != str(p, "package") {
+						continue
+					}
+					for i := range pkg.Interfaces {
+						if str(p, "name") == "" || pkg.Interfaces[i].Name == str(p, "name") {
+							ifaces = append(ifaces, &pkg.Interfaces[i])
+						}
+					}
+				}
+				return ifaces, nil
+			}},
+		{Name: "interface", Type: ifaceType, Args: []*Arg{{Name: "id", Type: &NonNull{Of: String}, Description: "Qualified name, pkg/path.Name"}},
+			Resolve: func(p ResolveParams) (any, error) {
+				return root(p).interfaces[str(p, "id")], nil
+			}},
+		{Name: "functions", Type: ListOf(funcType),
+			Args: []*Arg{
+				{Name: "package", Type: String, Description: "Only functions declared in this package"},
+				{Name: "name", Type: String, Description: "Only functions and methods with this name"},
+				{Name: "exported", Type: Boolean},
+			},
+			Resolve: func(p ResolveParams) (any, error) {
+				exported, filterExported := p.Args["exported"].(bool)
+				var fns []*datamodel.Function
+				for _, pkg := range root(p).analysis.Packages {
+					if pkg == nil || str(p, "package") != "" && pkg.Path != str(p, "package") {
+						continue
+					}
+					for i := range pkg.Functions {
+						fn := &pkg.Functions[i]
+						if (str(p, "name") == "" || fn.Name == str(p, "name")) && (!filterExported || fn.Exported == exported) {
+							fns = append(fns, fn)
+						}
+					}
+				}
+				return fns, nil
+			}},
+		{Name: "function", Type: funcType, Args: []*Arg{{Name: "id", Type: &NonNull{Of: String}, Description: "Function.id"}},
+			Resolve: func(p ResolveParams) (any, error) {
+				return root(p).functions[str(p, "id")], nil
+			}},
+		{Name: "calls", Type: ListOf(callType),
+			Args: []*Arg{
+				{Name: "caller", Type: String, Description: "Substring of the calling function"},
+				{Name: "callee", Type: String, Description: "Substring of the called function"},
+				{Name: "first", Type: Int, Description: "At most this many calls"},
+			},
+			Resolve: func(p ResolveParams) (any, error) {
+				var calls []*datamodel.CallSite
+				for _, pkg := range root(p).analysis.Packages {
+					if pkg == nil {
+						continue
+					}
+					for i := range pkg.Calls {
+						call := &pkg.Calls[i]
+						if strings.Contains(call.CallerFuncDesc, str(p, "caller")) && strings.Contains(call.CalleeDesc, str(p, "callee")) {
+							calls = append(calls, call)
+						}
+					}
+				}
+				return first(calls, p)
+			}},
+	}
+
+	pkgType.Fields = []*Field{
+		{Name: "path", Type: &NonNull{Of: String}, Resolve: fieldOf(func(pkg *datamodel.PackageAnalysis) any { return pkg.Path })},
+		{Name: "name", Type: &NonNull{Of: String}, Resolve: fieldOf(func(pkg *datamodel.PackageAnalysis) any { return pkg.Name })},
+		{Name: "synopsis", Type: String, Resolve: fieldOf(func(pkg *datamodel.PackageAnalysis) any { return pkg.Synopsis })},
+		{Name: "files", Type: ListOf(String), Resolve: fieldOf(func(pkg *datamodel.PackageAnalysis) any { return pkg.Files })},
+		{Name: "layer", Type: &NonNull{Of: Int}, Resolve: fieldOf(func(pkg *datamodel.PackageAnalysis) any { return pkg.Layer })},
+		{Name: "imports", Type: ListOf(String), Description: "Import paths",
+			Resolve: fieldOf(func(pkg *datamodel.PackageAnalysis) any { return pkg.Imports })},
+		{Name: "importedPackages", Type: ListOf(pkgType), Description: "Imported packages that were analyzed",
+			Resolve: func(p ResolveParams) (any, error) {
+				var pkgs []*datamodel.PackageAnalysis
+				for _, path := range p.Source.(*datamodel.PackageAnalysis).Imports {
+					if pkg := root(p).packages[path]; pkg != nil {
+						pkgs = append(pkgs, pkg)
+					}
+				}
+				return pkgs, nil
+			}},
+		{Name: "interfaces", Type: ListOf(ifaceType), Resolve: func(p ResolveParams) (any, error) {
+			pkg := p.Source.(*datamodel.PackageAnalysis)
+			ifaces := make([]*datamodel.Interface, len(pkg.Interfaces))
+			for i := range pkg.Interfaces {
+				ifaces[i] = &pkg.Interfaces[i]
+			}
+			return ifaces, nil
+		}},
+		{Name: "functions", Type: ListOf(funcType), Resolve: func(p ResolveParams) (any, error) {
+			pkg := p.Source.(*datamodel.PackageAnalysis)
+			fns := make([]*datamodel.Function, len(pkg.Functions))
+			for i := range pkg.Functions {
+				fns[i] = &pkg.Functions[i]
+			}
+			return fns, nil
+		}},
+		{Name: "calls", Type: ListOf(callType), Description: "Calls made in the package",
+			Args: []*Arg{{Name: "first", Type: Int, Description: "At most this many calls"}},
+			Resolve: func(p ResolveParams) (any, error) {
+				pkg := p.Source.(*datamodel.PackageAnalysis)
+				calls := make([]*datamodel.CallSite, len(pkg.Calls))
+				for i := range pkg.Calls {
+					calls[i] = &pkg.Calls[i]
+				}
+				return first(calls, p)
+			}},
+	}
+
+	ifaceType.Fields = []*Field{
+		{Name: "id", Type: &NonNull{Of: String}, Description: "Qualified name, pkg/path.Name",
+			Resolve: fieldOf(func(iface *datamodel.Interface) any { return interfaceID(iface) })},
+		{Name: "name", Type: &NonNull{Of: String}, Resolve: fieldOf(func(iface *datamodel.Interface) any { return iface.Name })},
+		{Name: "packagePath", Type: &NonNull{Of: String}, Resolve: fieldOf(func(iface *datamodel.Interface) any { return iface.PackagePath })},
+		{Name: "package", Type: pkgType, Resolve: func(p ResolveParams) (any, error) {
+			return root(p).packages[p.Source.(*datamodel.Interface).PackagePath], nil
+		}},
+		{Name: "docComment", Type: String, Resolve: fieldOf(func(iface *datamodel.Interface) any { return iface.DocComment })},
+		{Name: "file", Type: String, Resolve: fieldOf(func(iface *datamodel.Interface) any { return iface.Location.Filename })},
+		{Name: "line", Type: Int, Resolve: fieldOf(func(iface *datamodel.Interface) any { return iface.Location.Line })},
+		{Name: "endLine", Type: Int, Resolve: fieldOf(func(iface *datamodel.Interface) any { return iface.Location.EndLine })},
+		{Name: "stability", Type: String, Resolve: fieldOf(func(iface *datamodel.Interface) any { return iface.Stability })},
+		{Name: "methods", Type: ListOf(methodType), Resolve: func(p ResolveParams) (any, error) {
+			iface := p.Source.(*datamodel.Interface)
+			methods := make([]*datamodel.Method, len(iface.Methods))
+			for i := range iface.Methods {
+				methods[i] = &iface.Methods[i]
+			}
+			return methods, nil
+		}},
+		{Name: "embeds", Type: ListOf(String), Description: "Qualified names of embedded interfaces",
+			Resolve: fieldOf(func(iface *datamodel.Interface) any { return iface.Embeds })},
+		{Name: "embeddedInterfaces", Type: ListOf(ifaceType), Description: "Embedded interfaces that were analyzed",
+			Resolve: func(p ResolveParams) (any, error) {
+				var ifaces []*datamodel.Interface
+				for _, id := range p.Source.(*datamodel.Interface).Embeds {
+					if iface := root(p).interfaces[id]; iface != nil {
+						ifaces = append(ifaces, iface)
+					}
+				}
+				return ifaces, nil
+			}},
+		{Name: "implementations", Type: ListOf(implType), Resolve: func(p ResolveParams) (any, error) {
+			iface := p.Source.(*datamodel.Interface)
+			impls := make([]implementation, len(iface.Implementations))
+			for i := range iface.Implementations {
+				impls[i] = implementation{&iface.Implementations[i]}
+			}
+			return impls, nil
+		}},
+	}
+
+	methodType.Fields = []*Field{
+		{Name: "name", Type: &NonNull{Of: String}, Resolve: fieldOf(func(m *datamodel.Method) any { return m.Name })},
+		{Name: "signature", Type: &NonNull{Of: String}, Resolve: fieldOf(func(m *datamodel.Method) any { return m.Signature })},
+		{Name: "docComment", Type: String, Resolve: fieldOf(func(m *datamodel.Method) any { return m.DocComment })},
+		{Name: "file", Type: String, Resolve: fieldOf(func(m *datamodel.Method) any { return m.Location.Filename })},
+		{Name: "line", Type: Int, Resolve: fieldOf(func(m *datamodel.Method) any { return m.Location.Line })},
+		{Name: "endLine", Type: Int, Resolve: fieldOf(func(m *datamodel.Method) any { return m.Location.EndLine })},
+	}
+
+	implType.Fields = []*Field{
+		{Name: "id", Type: &NonNull{Of: String}, Description: "Qualified name, pkg/path.Type",
+			Resolve: fieldOf(func(impl implementation) any { return impl.id() })},
+		{Name: "typeName", Type: &NonNull{Of: String}, Resolve: fieldOf(func(impl implementation) any { return impl.TypeName })},
+		{Name: "packagePath", Type: &NonNull{Of: String}, Resolve: fieldOf(func(impl implementation) any { return impl.PackagePath })},
+		{Name: "isPointer", Type: &NonNull{Of: Boolean}, Description: "Only the pointer type implements the interface",
+			Resolve: fieldOf(func(impl implementation) any { return impl.IsPointer })},
+		{Name: "file", Type: String, Resolve: fieldOf(func(impl implementation) any { return impl.Location.Filename })},
+		{Name: "line", Type: Int, Resolve: fieldOf(func(impl implementation) any { return impl.Location.Line })},
+		{Name: "package", Type: pkgType, Resolve: func(p ResolveParams) (any, error) {
+			return root(p).packages[p.Source.(implementation).PackagePath], nil
+		}},
+		{Name: "interfaces", Type: ListOf(ifaceType), Description: "Analyzed interfaces the type implements",
+			Resolve: func(p ResolveParams) (any, error) {
+				return root(p).implementedBy[p.Source.(implementation).id()], nil
+			}},
+	}
+
+	funcType.Fields = []*Field{
+		{Name: "id", Type: &NonNull{Of: String}, Resolve: fieldOf(func(fn *datamodel.Function) any { return fn.ID })},
+		{Name: "name", Type: &NonNull{Of: String}, Resolve: fieldOf(func(fn *datamodel.Function) any { return fn.Name })},
+		{Name: "fullName", Type: &NonNull{Of: String}, Description: "As in Call.caller and Call.callee",
+			Resolve: fieldOf(func(fn *datamodel.Function) any { return fn.FullName })},
+		{Name: "receiver", Type: String, Resolve: fieldOf(func(fn *datamodel.Function) any { return fn.Receiver })},
+		{Name: "signature", Type: &NonNull{Of: String}, Resolve: fieldOf(func(fn *datamodel.Function) any { return fn.Signature })},
+		{Name: "docComment", Type: String, Resolve: fieldOf(func(fn *datamodel.Function) any { return fn.DocComment })},
+		{Name: "exported", Type: &NonNull{Of: Boolean}, Resolve: fieldOf(func(fn *datamodel.Function) any { return fn.Exported })},
+		{Name: "file", Type: String, Resolve: fieldOf(func(fn *datamodel.Function) any { return fn.Location.Filename })},
+		{Name: "line", Type: Int, Resolve: fieldOf(func(fn *datamodel.Function) any { return fn.Location.Line })},
+		{Name: "endLine", Type: Int, Resolve: fieldOf(func(fn *datamodel.Function) any { return fn.Location.EndLine })},
+		{Name: "calls", Type: ListOf(callType), Description: "Calls made by the function and its closures",
+			Args: []*Arg{{Name: "first", Type: Int, Description: "At most this many calls"}},
+			Resolve: func(p ResolveParams) (any, error) {
+				return first(root(p).callsByCaller[p.Source.(*datamodel.Function).ID], p)
+			}},
+		{Name: "callers", Type: ListOf(callType), Description: "Calls to the function",
+			Args: []*Arg{{Name: "first", Type: Int, Description: "At most this many calls"}},
+			Resolve: func(p ResolveParams) (any, error) {
+				return first(root(p).callsByCallee[p.Source.(*datamodel.Function).ID], p)
+			}},
+	}
+
+	callType.Fields = []*Field{
+		{Name: "caller", Type: &NonNull{Of: String}, Description: "Description of the calling function",
+			Resolve: fieldOf(func(c *datamodel.CallSite) any { return c.CallerFuncDesc })},
+		{Name: "callee", Type: &NonNull{Of: String}, Description: "Description of the called function",
+			Resolve: fieldOf(func(c *datamodel.CallSite) any { return c.CalleeDesc })},
+		{Name: "callType", Type: &NonNull{Of: String}, Resolve: fieldOf(func(c *datamodel.CallSite) any { return c.CallType })},
+		{Name: "file", Type: String, Resolve: fieldOf(func(c *datamodel.CallSite) any { return c.Location.Filename })},
+		{Name: "line", Type: Int, Resolve: fieldOf(func(c *datamodel.CallSite) any { return c.Location.Line })},
+		{Name: "targets", Type: ListOf(String), Description: "IDs of the methods an interface call may dispatch to",
+			Resolve: fieldOf(func(c *datamodel.CallSite) any { return c.Targets })},
+		{Name: "callerFunction", Type: funcType, Resolve: func(p ResolveParams) (any, error) {
+			id, _, _ := strings.Cut(p.Source.(*datamodel.CallSite).CallerID, "$")
+			return root(p).functions[id], nil
+		}},
+		{Name: "calleeFunction", Type: funcType, Description: "Null for functions outside the analysis and interface methods",
+			Resolve: func(p ResolveParams) (any, error) {
+				return root(p).functions[p.Source.(*datamodel.CallSite).CalleeID], nil
+			}},
+	}
+
+	return &Schema{Query: query}
+}
+
+// first applies the "first" argument to list.
+func first[T any](list []T, p ResolveParams) (any, error) {
+	if n, ok := p.Args["first"].(int); ok {
+		if n < 0 {
+			return nil, fmt.Errorf("first must not be negative")
+		}
+		list = list[:min(n, len(list))]
+	}
+	return list, nil
+}
+
+// fieldOf resolves a field from the parent's source value of type S.
+func fieldOf[S any](get func(S) any) func(ResolveParams) (any, error) {
+	return func(p ResolveParams) (any, error) { return get(p.Source.(S)), nil }
+}
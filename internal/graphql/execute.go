@@ -0,0 +1,488 @@
+// graphql/execute.go
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// MaxDepth bounds the nesting of selections in a query, since cyclic types (a package
+// and its imported packages) would otherwise allow responses of unbounded size.
+const MaxDepth = 12
+
+// Request is a GraphQL request as sent over HTTP.
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// Response is the result of a request. Data is absent when the request could not be
+// executed at all, or when a non-null root field could not be resolved.
+type Response struct {
+	Data   *OrderedMap `json:"data,omitempty"`
+	Errors []*Error    `json:"errors,omitempty"`
+}
+
+// Error is a request or field error. Path locates the field in Data.
+type Error struct {
+	Message string `json:"message"`
+	Path    []any  `json:"path,omitempty"`
+}
+
+// OrderedMap is a response object, which keeps its fields in selection order.
+type OrderedMap struct {
+	keys   []string
+	values map[string]any
+}
+
+func newOrderedMap() *OrderedMap {
+	return &OrderedMap{values: make(map[string]any)}
+}
+
+func (m *OrderedMap) set(key string, v any) {
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = v
+}
+
+// Get returns the value of a field.
+func (m *OrderedMap) Get(key string) (any, bool) {
+	v, ok := m.values[key]
+	return v, ok
+}
+
+// MarshalJSON implements json.Marshaler.
+func (m *OrderedMap) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, key := range m.keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		k, _ := json.Marshal(key)
+		b.Write(k)
+		b.WriteByte(':')
+		v, err := json.Marshal(m.values[key])
+		if err != nil {
+			return nil, err
+		}
+		b.Write(v)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// Execute runs the query operation of req against the schema, passing root to the
+// resolvers. Errors raised by a field null it and are reported in the response; a
+// null in a non-null field nulls the parent instead.
+func (s *Schema) Execute(ctx context.Context, root any, req Request) *Response {
+	doc, err := parse(req.Query)
+	if err != nil {
+		return &Response{Errors: []*Error{{Message: err.Error()}}}
+	}
+	op, err := selectOperation(doc, req.OperationName)
+	if err != nil {
+		return &Response{Errors: []*Error{{Message: err.Error()}}}
+	}
+	if op.kind != "query" {
+		return &Response{Errors: []*Error{{Message: op.kind + " operations are not supported"}}}
+	}
+	if err := validate(s, doc, op); err != nil {
+		return &Response{Errors: []*Error{{Message: err.Error()}}}
+	}
+	vars, err := variableValues(op, req.Variables)
+	if err != nil {
+		return &Response{Errors: []*Error{{Message: err.Error()}}}
+	}
+	ex := &executor{ctx: ctx, root: root, doc: doc, vars: vars}
+	data, ok := ex.selectionSet(s.queryType(), root, op.selections, nil)
+	if !ok {
+		data = nil
+	}
+	return &Response{Data: data, Errors: ex.errors}
+}
+
+func selectOperation(doc *document, name string) (*operation, error) {
+	if name == "" {
+		if len(doc.operations) > 1 {
+			return nil, fmt.Errorf("operationName is required for a document with several operations")
+		}
+		return doc.operations[0], nil
+	}
+	for _, op := range doc.operations {
+		if op.name == name {
+			return op, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown operation %q", name)
+}
+
+// variableValues applies the defaults of the operation's variables and checks that
+// non-null variables are given. Values are coerced where they are used.
+func variableValues(op *operation, given map[string]any) (map[string]any, error) {
+	vars := make(map[string]any, len(op.vars))
+	for _, def := range op.vars {
+		v, ok := given[def.name]
+		if !ok && def.def != nil {
+			var err error
+			if v, err = literalValue(*def.def, nil); err != nil {
+				return nil, err
+			}
+			ok = true
+		}
+		if def.nonNull && v == nil {
+			return nil, fmt.Errorf("variable $%s of type %s is required", def.name, def.typ)
+		}
+		if ok {
+			vars[def.name] = v
+		}
+	}
+	return vars, nil
+}
+
+// literalValue converts a parsed value to the JSON representation of variables, so
+// literals and variables are coerced alike.
+func literalValue(v value, vars map[string]any) (any, error) {
+	switch v.kind {
+	case valVariable:
+		val, ok := vars[v.raw]
+		if !ok {
+			return nil, nil
+		}
+		return val, nil
+	case valInt, valFloat:
+		return json.Number(v.raw), nil
+	case valString, valEnum:
+		return v.raw, nil
+	case valBool:
+		return v.raw == "true", nil
+	case valList:
+		list := make([]any, len(v.list))
+		for i, item := range v.list {
+			var err error
+			if list[i], err = literalValue(item, vars); err != nil {
+				return nil, err
+			}
+		}
+		return list, nil
+	case valObject:
+		obj := make(map[string]any, len(v.fields))
+		for _, f := range v.fields {
+			var err error
+			if obj[f.name], err = literalValue(f.val, vars); err != nil {
+				return nil, err
+			}
+		}
+		return obj, nil
+	}
+	return nil, nil
+}
+
+// coerce converts an input value to the Go representation of t.
+func coerce(t Type, v any) (any, error) {
+	if nn, ok := t.(*NonNull); ok {
+		if v == nil {
+			return nil, fmt.Errorf("expected a non-null %s", nn.Of)
+		}
+		return coerce(nn.Of, v)
+	}
+	if v == nil {
+		return nil, nil
+	}
+	switch t := t.(type) {
+	case *List:
+		items, ok := v.([]any)
+		if !ok {
+			items = []any{v} // A single value stands for a list of one
+		}
+		list := make([]any, len(items))
+		for i, item := range items {
+			var err error
+			if list[i], err = coerce(t.Of, item); err != nil {
+				return nil, err
+			}
+		}
+		return list, nil
+	case *Scalar:
+		switch t {
+		case Int:
+			var f float64
+			switch n := v.(type) {
+			case json.Number:
+				i, err := strconv.ParseInt(string(n), 10, 32)
+				if err != nil {
+					return nil, fmt.Errorf("expected an Int, got %s", n)
+				}
+				return int(i), nil
+			case float64:
+				f = n
+			case int:
+				return n, nil
+			default:
+				return nil, fmt.Errorf("expected an Int, got %v", v)
+			}
+			if f != math.Trunc(f) || math.Abs(f) > math.MaxInt32 {
+				return nil, fmt.Errorf("expected an Int, got %v", f)
+			}
+			return int(f), nil
+		case Float:
+			switch n := v.(type) {
+			case json.Number:
+				return n.Float64()
+			case float64:
+				return n, nil
+			case int:
+				return float64(n), nil
+			}
+			return nil, fmt.Errorf("expected a Float, got %v", v)
+		case Boolean:
+			if b, ok := v.(bool); ok {
+				return b, nil
+			}
+			return nil, fmt.Errorf("expected a Boolean, got %v", v)
+		case ID:
+			if n, ok := v.(json.Number); ok {
+				return string(n), nil
+			}
+			fallthrough
+		default:
+			if s, ok := v.(string); ok {
+				return s, nil
+			}
+			return nil, fmt.Errorf("expected a %s, got %v", t.Name, v)
+		}
+	}
+	return nil, fmt.Errorf("%s is not an input type", t)
+}
+
+type executor struct {
+	ctx    context.Context
+	root   any
+	doc    *document
+	vars   map[string]any
+	errors []*Error
+}
+
+func (ex *executor) fail(path []any, format string, args ...any) {
+	ex.errors = append(ex.errors, &Error{Message: fmt.Sprintf(format, args...), Path: append([]any{}, path...)})
+}
+
+// fieldGroup is the selections of one response key, merged across fragments.
+type fieldGroup struct {
+	key        string
+	selections []*selection
+}
+
+// collectFields flattens fragments and applies @skip and @include.
+func (ex *executor) collectFields(obj *Object, sels []*selection, groups []*fieldGroup, visited map[string]bool) ([]*fieldGroup, error) {
+	for _, sel := range sels {
+		include, err := ex.included(sel.directives)
+		if err != nil {
+			return nil, err
+		}
+		if !include {
+			continue
+		}
+		switch {
+		case sel.fragment != "":
+			frag := ex.doc.fragments[sel.fragment]
+			if frag == nil {
+				return nil, fmt.Errorf("unknown fragment %q", sel.fragment)
+			}
+			if visited[sel.fragment] || frag.typeCond != obj.Name {
+				continue
+			}
+			visited[sel.fragment] = true
+			if groups, err = ex.collectFields(obj, frag.selections, groups, visited); err != nil {
+				return nil, err
+			}
+		case sel.inline:
+			if sel.typeCond != "" && sel.typeCond != obj.Name {
+				continue
+			}
+			if groups, err = ex.collectFields(obj, sel.selections, groups, visited); err != nil {
+				return nil, err
+			}
+		default:
+			key := sel.responseKey()
+			var group *fieldGroup
+			for _, g := range groups {
+				if g.key == key {
+					group = g
+				}
+			}
+			if group == nil {
+				group = &fieldGroup{key: key}
+				groups = append(groups, group)
+			}
+			group.selections = append(group.selections, sel)
+		}
+	}
+	return groups, nil
+}
+
+func (ex *executor) included(dirs []directive) (bool, error) {
+	for _, d := range dirs {
+		var cond any
+		for _, a := range d.args {
+			if a.name == "if" {
+				v, err := literalValue(a.val, ex.vars)
+				if err != nil {
+					return false, err
+				}
+				cond = v
+			}
+		}
+		b, ok := cond.(bool)
+		if !ok {
+			return false, fmt.Errorf("@%s requires a Boolean argument if", d.name)
+		}
+		if b == (d.name == "skip") {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// selectionSet resolves the selected fields of obj on source. It returns false when a
+// non-null field is null because of an error, in which case the object becomes null.
+func (ex *executor) selectionSet(obj *Object, source any, sels []*selection, path []any) (*OrderedMap, bool) {
+	groups, err := ex.collectFields(obj, sels, nil, map[string]bool{})
+	if err != nil {
+		ex.fail(path, "%v", err)
+		return nil, false
+	}
+	result := newOrderedMap()
+	for _, g := range groups {
+		sel := g.selections[0]
+		if sel.name == "__typename" {
+			result.set(g.key, obj.Name)
+			continue
+		}
+		field := obj.field(sel.name) // Checked by validate
+		v, ok := ex.field(field, source, g, append(path[:len(path):len(path)], g.key))
+		if !ok && !isNullable(field.Type) {
+			return nil, false
+		}
+		result.set(g.key, v)
+	}
+	return result, true
+}
+
+// field resolves and completes one field. It returns false when the field is null
+// because of an error.
+func (ex *executor) field(field *Field, source any, g *fieldGroup, path []any) (any, bool) {
+	args, err := ex.arguments(field, g.selections[0].args)
+	if err != nil {
+		ex.fail(path, "%v", err)
+		return nil, false
+	}
+	resolved, err := field.Resolve(ResolveParams{Context: ex.ctx, Root: ex.root, Source: source, Args: args})
+	if err != nil {
+		ex.fail(path, "%v", err)
+		return nil, false
+	}
+	var sub []*selection
+	for _, s := range g.selections {
+		sub = append(sub, s.selections...)
+	}
+	return ex.complete(field.Type, resolved, sub, path)
+}
+
+// arguments coerces the given arguments of field, applying defaults.
+func (ex *executor) arguments(field *Field, given []argument) (map[string]any, error) {
+	args := make(map[string]any, len(field.Args))
+	for _, def := range field.Args {
+		raw, present := def.Default, false
+		for _, a := range given {
+			if a.name != def.Name {
+				continue
+			}
+			present = true
+			if a.val.kind == valVariable {
+				_, present = ex.vars[a.val.raw]
+			}
+			if present {
+				v, err := literalValue(a.val, ex.vars)
+				if err != nil {
+					return nil, err
+				}
+				raw = v
+			}
+		}
+		v, err := coerce(def.Type, raw)
+		if err != nil {
+			return nil, fmt.Errorf("argument %q: %v", def.Name, err)
+		}
+		args[def.Name] = v
+	}
+	return args, nil
+}
+
+// complete converts a resolved value to its response value according to t. It returns
+// false when the value is null because of an error, to be absorbed by the nearest
+// nullable position.
+func (ex *executor) complete(t Type, v any, sels []*selection, path []any) (any, bool) {
+	if nn, ok := t.(*NonNull); ok {
+		completed, ok := ex.complete(nn.Of, v, sels, path)
+		if !ok {
+			return nil, false
+		}
+		if completed == nil {
+			ex.fail(path, "non-null field resolved to null")
+			return nil, false
+		}
+		return completed, true
+	}
+	if isNil(v) {
+		return nil, true
+	}
+	switch t := t.(type) {
+	case *List:
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Slice {
+			ex.fail(path, "list field resolved to %T", v)
+			return nil, false
+		}
+		list := make([]any, rv.Len())
+		for i := range list {
+			item, ok := ex.complete(t.Of, rv.Index(i).Interface(), sels, append(path[:len(path):len(path)], i))
+			if !ok && !isNullable(t.Of) {
+				return nil, false
+			}
+			list[i] = item
+		}
+		return list, true
+	case *Object:
+		obj, ok := ex.selectionSet(t, v, sels, path)
+		if !ok {
+			return nil, false
+		}
+		return obj, true
+	}
+	return v, true // Scalar
+}
+
+func isNullable(t Type) bool {
+	_, nonNull := t.(*NonNull)
+	return !nonNull
+}
+
+// isNil reports whether v is nil or a nil pointer or map; nil slices are empty lists.
+func isNil(v any) bool {
+	if v == nil {
+		return true
+	}
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.Pointer, reflect.Map:
+		return rv.IsNil()
+	}
+	return false
+}
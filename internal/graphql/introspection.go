@@ -0,0 +1,241 @@
+// graphql/introspection.go
+package graphql
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// The introspection types answer the __schema and __type fields of the query type, so
+// GraphQL clients and tools can discover the schema. Enums are not supported, so the
+// enum-valued fields (__Type.kind, __Directive.locations) are strings, which encode the
+// same in responses. The introspection types are not listed in __Schema.types.
+var introspection = newIntrospectionTypes()
+
+type introspectionTypes struct {
+	schema, typ, field, inputValue, enumValue, directive *Object
+}
+
+// directiveDef describes a directive supported by the executor.
+type directiveDef struct {
+	name        string
+	description string
+	locations   []string
+	args        []*Arg
+}
+
+// directives are the directives the executor supports: @skip and @include.
+var directives = []*directiveDef{
+	{
+		name:        "skip",
+		description: "Directs the executor to skip this field or fragment when the `if` argument is true.",
+		locations:   []string{"FIELD", "FRAGMENT_SPREAD", "INLINE_FRAGMENT"},
+		args:        []*Arg{{Name: "if", Description: "Skipped when true.", Type: &NonNull{Of: Boolean}}},
+	},
+	{
+		name:        "include",
+		description: "Directs the executor to include this field or fragment only when the `if` argument is true.",
+		locations:   []string{"FIELD", "FRAGMENT_SPREAD", "INLINE_FRAGMENT"},
+		args:        []*Arg{{Name: "if", Description: "Included when true.", Type: &NonNull{Of: Boolean}}},
+	},
+}
+
+func newIntrospectionTypes() *introspectionTypes {
+	t := &introspectionTypes{
+		schema:     &Object{Name: "__Schema"},
+		typ:        &Object{Name: "__Type"},
+		field:      &Object{Name: "__Field"},
+		inputValue: &Object{Name: "__InputValue"},
+		enumValue:  &Object{Name: "__EnumValue"},
+		directive:  &Object{Name: "__Directive"},
+	}
+	includeDeprecated := []*Arg{{Name: "includeDeprecated", Type: Boolean, Default: false}}
+	none := func(ResolveParams) (any, error) { return nil, nil }
+	notDeprecated := func(ResolveParams) (any, error) { return false, nil }
+	listOf := func(o *Object) Type { return &List{Of: &NonNull{Of: o}} }
+
+	t.schema.Fields = []*Field{
+		{Name: "description", Type: String, Resolve: none},
+		{Name: "types", Type: ListOf(t.typ), Resolve: func(p ResolveParams) (any, error) {
+			return p.Source.(*Schema).namedTypes(), nil
+		}},
+		{Name: "queryType", Type: &NonNull{Of: t.typ}, Resolve: func(p ResolveParams) (any, error) {
+			return Type(p.Source.(*Schema).Query), nil
+		}},
+		{Name: "mutationType", Type: t.typ, Resolve: none},
+		{Name: "subscriptionType", Type: t.typ, Resolve: none},
+		{Name: "directives", Type: ListOf(t.directive), Resolve: func(ResolveParams) (any, error) {
+			return directives, nil
+		}},
+	}
+
+	t.typ.Fields = []*Field{
+		{Name: "kind", Type: &NonNull{Of: String}, Resolve: func(p ResolveParams) (any, error) {
+			switch p.Source.(type) {
+			case *Scalar:
+				return "SCALAR", nil
+			case *Object:
+				return "OBJECT", nil
+			case *List:
+				return "LIST", nil
+			default:
+				return "NON_NULL", nil
+			}
+		}},
+		{Name: "name", Type: String, Resolve: func(p ResolveParams) (any, error) {
+			switch s := p.Source.(type) {
+			case *Scalar:
+				return s.Name, nil
+			case *Object:
+				return s.Name, nil
+			}
+			return nil, nil
+		}},
+		{Name: "description", Type: String, Resolve: func(p ResolveParams) (any, error) {
+			if o, ok := p.Source.(*Object); ok {
+				return optional(o.Description), nil
+			}
+			return nil, nil
+		}},
+		{Name: "specifiedByURL", Type: String, Resolve: none},
+		{Name: "fields", Type: listOf(t.field), Args: includeDeprecated, Resolve: func(p ResolveParams) (any, error) {
+			o, ok := p.Source.(*Object)
+			if !ok {
+				return nil, nil
+			}
+			fields := []*Field{}
+			for _, f := range o.Fields {
+				if !strings.HasPrefix(f.Name, "__") {
+					fields = append(fields, f)
+				}
+			}
+			return fields, nil
+		}},
+		{Name: "interfaces", Type: listOf(t.typ), Resolve: func(p ResolveParams) (any, error) {
+			if _, ok := p.Source.(*Object); ok {
+				return []Type{}, nil
+			}
+			return nil, nil
+		}},
+		{Name: "possibleTypes", Type: listOf(t.typ), Resolve: none},
+		{Name: "enumValues", Type: listOf(t.enumValue), Args: includeDeprecated, Resolve: none},
+		{Name: "inputFields", Type: listOf(t.inputValue), Args: includeDeprecated, Resolve: none},
+		{Name: "ofType", Type: t.typ, Resolve: func(p ResolveParams) (any, error) {
+			switch w := p.Source.(type) {
+			case *List:
+				return w.Of, nil
+			case *NonNull:
+				return w.Of, nil
+			}
+			return nil, nil
+		}},
+		{Name: "isOneOf", Type: Boolean, Resolve: none},
+	}
+
+	t.field.Fields = []*Field{
+		{Name: "name", Type: &NonNull{Of: String}, Resolve: fieldOf(func(f *Field) any { return f.Name })},
+		{Name: "description", Type: String, Resolve: fieldOf(func(f *Field) any { return optional(f.Description) })},
+		{Name: "args", Type: ListOf(t.inputValue), Args: includeDeprecated, Resolve: fieldOf(func(f *Field) any { return f.Args })},
+		{Name: "type", Type: &NonNull{Of: t.typ}, Resolve: fieldOf(func(f *Field) any { return f.Type })},
+		{Name: "isDeprecated", Type: &NonNull{Of: Boolean}, Resolve: notDeprecated},
+		{Name: "deprecationReason", Type: String, Resolve: none},
+	}
+
+	t.inputValue.Fields = []*Field{
+		{Name: "name", Type: &NonNull{Of: String}, Resolve: fieldOf(func(a *Arg) any { return a.Name })},
+		{Name: "description", Type: String, Resolve: fieldOf(func(a *Arg) any { return optional(a.Description) })},
+		{Name: "type", Type: &NonNull{Of: t.typ}, Resolve: fieldOf(func(a *Arg) any { return a.Type })},
+		{Name: "defaultValue", Type: String, Resolve: fieldOf(func(a *Arg) any { return defaultLiteral(a.Default) })},
+		{Name: "isDeprecated", Type: &NonNull{Of: Boolean}, Resolve: notDeprecated},
+		{Name: "deprecationReason", Type: String, Resolve: none},
+	}
+
+	// No type has enum values, but clients select these fields
+	t.enumValue.Fields = []*Field{
+		{Name: "name", Type: &NonNull{Of: String}, Resolve: none},
+		{Name: "description", Type: String, Resolve: none},
+		{Name: "isDeprecated", Type: &NonNull{Of: Boolean}, Resolve: notDeprecated},
+		{Name: "deprecationReason", Type: String, Resolve: none},
+	}
+
+	t.directive.Fields = []*Field{
+		{Name: "name", Type: &NonNull{Of: String}, Resolve: fieldOf(func(d *directiveDef) any { return d.name })},
+		{Name: "description", Type: String, Resolve: fieldOf(func(d *directiveDef) any { return optional(d.description) })},
+		{Name: "locations", Type: ListOf(String), Resolve: fieldOf(func(d *directiveDef) any { return d.locations })},
+		{Name: "args", Type: ListOf(t.inputValue), Args: includeDeprecated, Resolve: fieldOf(func(d *directiveDef) any { return d.args })},
+		{Name: "isRepeatable", Type: &NonNull{Of: Boolean}, Resolve: notDeprecated},
+	}
+	return t
+}
+
+// queryType returns the query type extended with the introspection fields __schema
+// and __type.
+func (s *Schema) queryType() *Object {
+	query := *s.Query
+	query.Fields = append(s.Query.Fields[:len(s.Query.Fields):len(s.Query.Fields)],
+		&Field{Name: "__schema", Type: &NonNull{Of: introspection.schema}, Resolve: func(ResolveParams) (any, error) {
+			return s, nil
+		}},
+		&Field{Name: "__type", Type: introspection.typ, Args: []*Arg{{Name: "name", Type: &NonNull{Of: String}}},
+			Resolve: func(p ResolveParams) (any, error) {
+				name, _ := p.Args["name"].(string)
+				for _, t := range s.namedTypes() {
+					if t.String() == name {
+						return t, nil
+					}
+				}
+				return nil, nil
+			}},
+	)
+	return &query
+}
+
+// namedTypes returns the object and scalar types of the schema, in the order they are
+// reached from the query type, followed by the scalars introspection uses.
+func (s *Schema) namedTypes() []Type {
+	var types []Type
+	seen := map[Type]bool{}
+	add := func(t Type) {
+		if t = namedType(t); !seen[t] {
+			seen[t] = true
+			types = append(types, t)
+		}
+	}
+	add(s.Query)
+	for i := 0; i < len(types); i++ {
+		obj, ok := types[i].(*Object)
+		if !ok {
+			continue
+		}
+		for _, f := range obj.Fields {
+			add(f.Type)
+			for _, a := range f.Args {
+				add(a.Type)
+			}
+		}
+	}
+	add(String)
+	add(Boolean)
+	return types
+}
+
+// optional returns s, or nil if it is empty.
+func optional(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// defaultLiteral writes a default argument value as a GraphQL literal, or returns nil
+// without a default. The JSON encoding of scalars and lists is valid GraphQL.
+func defaultLiteral(v any) any {
+	if v == nil {
+		return nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	return string(data)
+}
@@ -0,0 +1,633 @@
+// graphql/parse.go
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// document is a parsed GraphQL request document.
+type document struct {
+	operations []*operation
+	fragments  map[string]*fragment
+}
+
+type operation struct {
+	kind       string // query, mutation or subscription
+	name       string
+	vars       []*varDef
+	selections []*selection
+}
+
+type varDef struct {
+	name    string
+	typ     string // As written, e.g. "[String!]!"
+	nonNull bool
+	def     *value
+}
+
+type fragment struct {
+	name       string
+	typeCond   string
+	selections []*selection
+}
+
+// selection is a field, a fragment spread (fragment set) or an inline fragment
+// (inline set).
+type selection struct {
+	alias      string
+	name       string
+	args       []argument
+	directives []directive
+	selections []*selection
+	fragment   string
+	inline     bool
+	typeCond   string // Of an inline fragment; empty if omitted
+}
+
+// responseKey is the key of a field in the response: its alias or name.
+func (s *selection) responseKey() string {
+	if s.alias != "" {
+		return s.alias
+	}
+	return s.name
+}
+
+type argument struct {
+	name string
+	val  value
+}
+
+type directive struct {
+	name string
+	args []argument
+}
+
+type valueKind int
+
+const (
+	valVariable valueKind = iota
+	valInt
+	valFloat
+	valString
+	valBool
+	valNull
+	valEnum
+	valList
+	valObject
+)
+
+type value struct {
+	kind   valueKind
+	raw    string // Variable name, literal text or decoded string
+	list   []value
+	fields []argument // Object fields
+}
+
+// parse parses a request document. Type system definitions are rejected.
+func parse(src string) (*document, error) {
+	lx := &lexer{src: strings.TrimPrefix(src, "\ufeff")}
+	if err := lx.advance(); err != nil {
+		return nil, err
+	}
+	p := &parser{lx: lx, tok: lx.tok}
+	doc := &document{fragments: make(map[string]*fragment)}
+	for p.tok.kind != tokEOF {
+		switch {
+		case p.tok.kind == tokPunct && p.tok.text == "{":
+			sels, err := p.selectionSet()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, &operation{kind: "query", selections: sels})
+		case p.tok.kind == tokName && (p.tok.text == "query" || p.tok.text == "mutation" || p.tok.text == "subscription"):
+			op, err := p.operation()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, op)
+		case p.tok.kind == tokName && p.tok.text == "fragment":
+			frag, err := p.fragmentDefinition()
+			if err != nil {
+				return nil, err
+			}
+			if doc.fragments[frag.name] != nil {
+				return nil, fmt.Errorf("there can be only one fragment named %q", frag.name)
+			}
+			doc.fragments[frag.name] = frag
+		default:
+			return nil, p.unexpected()
+		}
+	}
+	if len(doc.operations) == 0 {
+		return nil, fmt.Errorf("the document contains no operation")
+	}
+	return doc, nil
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokPunct
+	tokName
+	tokInt
+	tokFloat
+	tokString
+)
+
+type token struct {
+	kind tokenKind
+	text string // Decoded for strings
+	line int
+	col  int
+}
+
+type lexer struct {
+	src  string
+	pos  int
+	line int // 0-based; reported 1-based
+	bol  int // Offset of the beginning of the current line
+	tok  token
+}
+
+func (lx *lexer) errorf(format string, args ...any) error {
+	return fmt.Errorf("syntax error at %d:%d: %s", lx.line+1, lx.pos-lx.bol+1, fmt.Sprintf(format, args...))
+}
+
+// advance reads the next token into lx.tok, skipping white space, commas and comments.
+func (lx *lexer) advance() error {
+	for lx.pos < len(lx.src) {
+		c := lx.src[lx.pos]
+		if c == '\n' {
+			lx.pos++
+			lx.line++
+			lx.bol = lx.pos
+		} else if c == ' ' || c == '\t' || c == '\r' || c == ',' {
+			lx.pos++
+		} else if c == '#' {
+			for lx.pos < len(lx.src) && lx.src[lx.pos] != '\n' {
+				lx.pos++
+			}
+		} else {
+			break
+		}
+	}
+	start := lx.pos
+	lx.tok = token{line: lx.line + 1, col: start - lx.bol + 1}
+	if lx.pos >= len(lx.src) {
+		lx.tok.kind = tokEOF
+		return nil
+	}
+	c := lx.src[lx.pos]
+	switch {
+	case strings.HasPrefix(lx.src[lx.pos:], "..."):
+		lx.pos += 3
+		lx.tok.kind, lx.tok.text = tokPunct, "..."
+	case strings.IndexByte("!$()&:=@[]{}|", c) >= 0:
+		lx.pos++
+		lx.tok.kind, lx.tok.text = tokPunct, string(c)
+	case c == '_' || isLetter(c):
+		for lx.pos < len(lx.src) && (lx.src[lx.pos] == '_' || isLetter(lx.src[lx.pos]) || isDigit(lx.src[lx.pos])) {
+			lx.pos++
+		}
+		lx.tok.kind, lx.tok.text = tokName, lx.src[start:lx.pos]
+	case c == '-' || isDigit(c):
+		return lx.number()
+	case c == '"':
+		return lx.string()
+	default:
+		return lx.errorf("unexpected character %q", c)
+	}
+	return nil
+}
+
+func (lx *lexer) number() error {
+	start := lx.pos
+	if lx.src[lx.pos] == '-' {
+		lx.pos++
+	}
+	digits := func() int {
+		n := 0
+		for lx.pos < len(lx.src) && isDigit(lx.src[lx.pos]) {
+			lx.pos++
+			n++
+		}
+		return n
+	}
+	if digits() == 0 {
+		return lx.errorf("invalid number")
+	}
+	lx.tok.kind = tokInt
+	if lx.pos < len(lx.src) && lx.src[lx.pos] == '.' {
+		lx.pos++
+		if digits() == 0 {
+			return lx.errorf("invalid number")
+		}
+		lx.tok.kind = tokFloat
+	}
+	if lx.pos < len(lx.src) && (lx.src[lx.pos] == 'e' || lx.src[lx.pos] == 'E') {
+		lx.pos++
+		if lx.pos < len(lx.src) && (lx.src[lx.pos] == '+' || lx.src[lx.pos] == '-') {
+			lx.pos++
+		}
+		if digits() == 0 {
+			return lx.errorf("invalid number")
+		}
+		lx.tok.kind = tokFloat
+	}
+	lx.tok.text = lx.src[start:lx.pos]
+	return nil
+}
+
+func (lx *lexer) string() error {
+	if strings.HasPrefix(lx.src[lx.pos:], `"""`) {
+		end := strings.Index(lx.src[lx.pos+3:], `"""`)
+		if end < 0 {
+			return lx.errorf("unterminated block string")
+		}
+		raw := lx.src[lx.pos+3 : lx.pos+3+end]
+		lx.line += strings.Count(raw, "\n")
+		lx.pos += end + 6
+		lx.tok.kind, lx.tok.text = tokString, strings.TrimSpace(raw)
+		return nil
+	}
+	end := lx.pos + 1
+	for ; end < len(lx.src) && lx.src[end] != '"'; end++ {
+		if lx.src[end] == '\\' {
+			end++
+		} else if lx.src[end] == '\n' {
+			break
+		}
+	}
+	if end >= len(lx.src) || lx.src[end] != '"' {
+		return lx.errorf("unterminated string")
+	}
+	text, err := strconv.Unquote(lx.src[lx.pos : end+1])
+	if err != nil {
+		return lx.errorf("invalid string: %v", err)
+	}
+	lx.pos = end + 1
+	lx.tok.kind, lx.tok.text = tokString, text
+	return nil
+}
+
+func isLetter(c byte) bool { return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' }
+func isDigit(c byte) bool  { return '0' <= c && c <= '9' }
+
+type parser struct {
+	lx  *lexer
+	tok token
+}
+
+func (p *parser) next() error {
+	if err := p.lx.advance(); err != nil {
+		return err
+	}
+	p.tok = p.lx.tok
+	return nil
+}
+
+func (p *parser) unexpected() error {
+	if p.tok.kind == tokEOF {
+		return fmt.Errorf("syntax error at %d:%d: unexpected end of document", p.tok.line, p.tok.col)
+	}
+	return fmt.Errorf("syntax error at %d:%d: unexpected %q", p.tok.line, p.tok.col, p.tok.text)
+}
+
+// punct consumes the punctuator s if it is next.
+func (p *parser) punct(s string) (bool, error) {
+	if p.tok.kind != tokPunct || p.tok.text != s {
+		return false, nil
+	}
+	return true, p.next()
+}
+
+func (p *parser) expect(s string) error {
+	ok, err := p.punct(s)
+	if err == nil && !ok {
+		err = p.unexpected()
+	}
+	return err
+}
+
+func (p *parser) name() (string, error) {
+	if p.tok.kind != tokName {
+		return "", p.unexpected()
+	}
+	name := p.tok.text
+	return name, p.next()
+}
+
+func (p *parser) operation() (*operation, error) {
+	op := &operation{kind: p.tok.text}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	if p.tok.kind == tokName {
+		op.name = p.tok.text
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+	}
+	if ok, err := p.punct("("); err != nil {
+		return nil, err
+	} else if ok {
+		for {
+			if ok, err := p.punct(")"); err != nil {
+				return nil, err
+			} else if ok {
+				break
+			}
+			v, err := p.varDef()
+			if err != nil {
+				return nil, err
+			}
+			op.vars = append(op.vars, v)
+		}
+	}
+	if _, err := p.directives(); err != nil {
+		return nil, err
+	}
+	sels, err := p.selectionSet()
+	if err != nil {
+		return nil, err
+	}
+	op.selections = sels
+	return op, nil
+}
+
+func (p *parser) varDef() (*varDef, error) {
+	if err := p.expect("$"); err != nil {
+		return nil, err
+	}
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(":"); err != nil {
+		return nil, err
+	}
+	typ, err := p.typeRef()
+	if err != nil {
+		return nil, err
+	}
+	v := &varDef{name: name, typ: typ, nonNull: strings.HasSuffix(typ, "!")}
+	if ok, err := p.punct("="); err != nil {
+		return nil, err
+	} else if ok {
+		def, err := p.value(true)
+		if err != nil {
+			return nil, err
+		}
+		v.def = &def
+	}
+	return v, nil
+}
+
+// typeRef parses a type reference, returned as written.
+func (p *parser) typeRef() (string, error) {
+	var typ string
+	if ok, err := p.punct("["); err != nil {
+		return "", err
+	} else if ok {
+		inner, err := p.typeRef()
+		if err != nil {
+			return "", err
+		}
+		if err := p.expect("]"); err != nil {
+			return "", err
+		}
+		typ = "[" + inner + "]"
+	} else if typ, err = p.name(); err != nil {
+		return "", err
+	}
+	if ok, err := p.punct("!"); err != nil {
+		return "", err
+	} else if ok {
+		typ += "!"
+	}
+	return typ, nil
+}
+
+func (p *parser) fragmentDefinition() (*fragment, error) {
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokName || p.tok.text != "on" {
+		return nil, p.unexpected()
+	}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	typeCond, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.directives(); err != nil {
+		return nil, err
+	}
+	sels, err := p.selectionSet()
+	if err != nil {
+		return nil, err
+	}
+	return &fragment{name: name, typeCond: typeCond, selections: sels}, nil
+}
+
+func (p *parser) selectionSet() ([]*selection, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	var sels []*selection
+	for {
+		if ok, err := p.punct("}"); err != nil {
+			return nil, err
+		} else if ok {
+			break
+		}
+		sel, err := p.selection()
+		if err != nil {
+			return nil, err
+		}
+		sels = append(sels, sel)
+	}
+	if len(sels) == 0 {
+		return nil, fmt.Errorf("syntax error at %d:%d: empty selection set", p.tok.line, p.tok.col)
+	}
+	return sels, nil
+}
+
+func (p *parser) selection() (*selection, error) {
+	sel := &selection{}
+	var err error
+	if ok, err := p.punct("..."); err != nil {
+		return nil, err
+	} else if ok {
+		if p.tok.kind == tokName && p.tok.text != "on" {
+			sel.fragment = p.tok.text
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			sel.directives, err = p.directives()
+			return sel, err
+		}
+		sel.inline = true
+		if p.tok.kind == tokName {
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			if sel.typeCond, err = p.name(); err != nil {
+				return nil, err
+			}
+		}
+		if sel.directives, err = p.directives(); err != nil {
+			return nil, err
+		}
+		sel.selections, err = p.selectionSet()
+		return sel, err
+	}
+
+	if sel.name, err = p.name(); err != nil {
+		return nil, err
+	}
+	if ok, err := p.punct(":"); err != nil {
+		return nil, err
+	} else if ok {
+		sel.alias = sel.name
+		if sel.name, err = p.name(); err != nil {
+			return nil, err
+		}
+	}
+	if sel.args, err = p.arguments(); err != nil {
+		return nil, err
+	}
+	if sel.directives, err = p.directives(); err != nil {
+		return nil, err
+	}
+	if p.tok.kind == tokPunct && p.tok.text == "{" {
+		sel.selections, err = p.selectionSet()
+	}
+	return sel, err
+}
+
+func (p *parser) arguments() ([]argument, error) {
+	if ok, err := p.punct("("); err != nil || !ok {
+		return nil, err
+	}
+	var args []argument
+	for {
+		if ok, err := p.punct(")"); err != nil {
+			return nil, err
+		} else if ok {
+			return args, nil
+		}
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		val, err := p.value(false)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, argument{name: name, val: val})
+	}
+}
+
+func (p *parser) directives() ([]directive, error) {
+	var dirs []directive
+	for {
+		if ok, err := p.punct("@"); err != nil || !ok {
+			return dirs, err
+		}
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		args, err := p.arguments()
+		if err != nil {
+			return nil, err
+		}
+		dirs = append(dirs, directive{name: name, args: args})
+	}
+}
+
+// value parses a value; constant values (variable defaults) cannot use variables.
+func (p *parser) value(constant bool) (value, error) {
+	t := p.tok
+	switch t.kind {
+	case tokInt, tokFloat, tokString:
+		kind := map[tokenKind]valueKind{tokInt: valInt, tokFloat: valFloat, tokString: valString}[t.kind]
+		return value{kind: kind, raw: t.text}, p.next()
+	case tokName:
+		kind := valEnum
+		switch t.text {
+		case "true", "false":
+			kind = valBool
+		case "null":
+			kind = valNull
+		}
+		return value{kind: kind, raw: t.text}, p.next()
+	case tokPunct:
+		switch t.text {
+		case "$":
+			if constant {
+				return value{}, p.unexpected()
+			}
+			if err := p.next(); err != nil {
+				return value{}, err
+			}
+			name, err := p.name()
+			return value{kind: valVariable, raw: name}, err
+		case "[":
+			if err := p.next(); err != nil {
+				return value{}, err
+			}
+			v := value{kind: valList}
+			for {
+				if ok, err := p.punct("]"); err != nil {
+					return value{}, err
+				} else if ok {
+					return v, nil
+				}
+				item, err := p.value(constant)
+				if err != nil {
+					return value{}, err
+				}
+				v.list = append(v.list, item)
+			}
+		case "{":
+			if err := p.next(); err != nil {
+				return value{}, err
+			}
+			v := value{kind: valObject}
+			for {
+				if ok, err := p.punct("}"); err != nil {
+					return value{}, err
+				} else if ok {
+					return v, nil
+				}
+				name, err := p.name()
+				if err != nil {
+					return value{}, err
+				}
+				if err := p.expect(":"); err != nil {
+					return value{}, err
+				}
+				field, err := p.value(constant)
+				if err != nil {
+					return value{}, err
+				}
+				v.fields = append(v.fields, argument{name: name, val: field})
+			}
+		}
+	}
+	return value{}, p.unexpected()
+}
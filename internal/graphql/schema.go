@@ -0,0 +1,160 @@
+// graphql/schema.go
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Type is a GraphQL output or input type: a *Scalar, an *Object, or a List or NonNull
+// wrapper.
+type Type interface {
+	String() string // As written in SDL, e.g. "[Package!]!"
+}
+
+// Scalar is a built-in scalar type. Resolvers return int for Int, float64 for Float,
+// string for String and ID, and bool for Boolean.
+type Scalar struct {
+	Name string
+}
+
+// The built-in scalar types.
+var (
+	Int     = &Scalar{Name: "Int"}
+	Float   = &Scalar{Name: "Float"}
+	String  = &Scalar{Name: "String"}
+	Boolean = &Scalar{Name: "Boolean"}
+	ID      = &Scalar{Name: "ID"}
+)
+
+func (s *Scalar) String() string { return s.Name }
+
+// Object is an object type. Fields may be set after creation so that types can refer
+// to each other.
+type Object struct {
+	Name        string
+	Description string
+	Fields      []*Field
+}
+
+func (o *Object) String() string { return o.Name }
+
+func (o *Object) field(name string) *Field {
+	for _, f := range o.Fields {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// List is a list of Of.
+type List struct {
+	Of Type
+}
+
+func (l *List) String() string { return "[" + l.Of.String() + "]" }
+
+// NonNull is a non-null Of.
+type NonNull struct {
+	Of Type
+}
+
+func (n *NonNull) String() string { return n.Of.String() + "!" }
+
+// ListOf returns the type [t!]!, the usual type of list fields.
+func ListOf(t Type) Type {
+	return &NonNull{Of: &List{Of: &NonNull{Of: t}}}
+}
+
+// Field is a field of an object type.
+type Field struct {
+	Name        string
+	Description string
+	Type        Type
+	Args        []*Arg
+	// Resolve returns the value of the field: a value of the Go type of a scalar, a
+	// source value for the fields of an object type, a slice for a list type, or nil.
+	Resolve func(p ResolveParams) (any, error)
+}
+
+// Arg is an argument of a field. Arguments that are absent and have no Default are
+// nil in ResolveParams.Args.
+type Arg struct {
+	Name        string
+	Description string
+	Type        Type // A scalar, possibly wrapped in List and NonNull
+	Default     any
+}
+
+// ResolveParams is passed to Field.Resolve.
+type ResolveParams struct {
+	Context context.Context
+	Root    any            // Root value passed to Execute
+	Source  any            // Value resolved for the parent object
+	Args    map[string]any // Coerced argument values
+}
+
+// Schema is an executable schema with a query root type. Mutations and subscriptions
+// are not supported.
+type Schema struct {
+	Query *Object
+}
+
+// SDL returns the schema in the GraphQL schema definition language, types in the order
+// they are reached from the query type.
+func (s *Schema) SDL() string {
+	var b strings.Builder
+	seen := map[*Object]bool{}
+	queue := []*Object{s.Query}
+	seen[s.Query] = true
+	for len(queue) > 0 {
+		o := queue[0]
+		queue = queue[1:]
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		if o.Description != "" {
+			fmt.Fprintf(&b, "%q\n", o.Description)
+		}
+		fmt.Fprintf(&b, "type %s {\n", o.Name)
+		for _, f := range o.Fields {
+			if f.Description != "" {
+				fmt.Fprintf(&b, "  %q\n", f.Description)
+			}
+			b.WriteString("  " + f.Name)
+			if len(f.Args) > 0 {
+				args := make([]string, len(f.Args))
+				for i, a := range f.Args {
+					args[i] = a.Name + ": " + a.Type.String()
+					if a.Default != nil {
+						args[i] += fmt.Sprintf(" = %#v", a.Default)
+					}
+				}
+				b.WriteString("(" + strings.Join(args, ", ") + ")")
+			}
+			b.WriteString(": " + f.Type.String() + "\n")
+			if obj, ok := namedType(f.Type).(*Object); ok && !seen[obj] {
+				seen[obj] = true
+				queue = append(queue, obj)
+			}
+		}
+		b.WriteString("}\n")
+	}
+	return b.String()
+}
+
+// namedType strips the List and NonNull wrappers of t.
+func namedType(t Type) Type {
+	for {
+		switch w := t.(type) {
+		case *List:
+			t = w.Of
+		case *NonNull:
+			t = w.Of
+		default:
+			return t
+		}
+	}
+}
@@ -0,0 +1,153 @@
+// graphql/validate.go
+package graphql
+
+import (
+	"fmt"
+)
+
+// validate checks the operation against the schema before it is executed: selected
+// fields and their arguments exist, objects and only objects have subselections,
+// fragments exist and apply to the type they are spread in, variables are defined,
+// and selections are nested at most MaxDepth levels.
+func validate(s *Schema, doc *document, op *operation) error {
+	v := &validator{doc: doc, vars: make(map[string]bool, len(op.vars))}
+	for _, def := range op.vars {
+		v.vars[def.name] = true
+	}
+	return v.selections(s.queryType(), op.selections, 1, map[string]bool{})
+}
+
+type validator struct {
+	doc  *document
+	vars map[string]bool
+}
+
+func (v *validator) selections(obj *Object, sels []*selection, depth int, spreading map[string]bool) error {
+	if depth > MaxDepth {
+		return fmt.Errorf("selections are nested deeper than %d levels", MaxDepth)
+	}
+	for _, sel := range sels {
+		if err := v.directives(sel.directives); err != nil {
+			return err
+		}
+		switch {
+		case sel.fragment != "":
+			frag := v.doc.fragments[sel.fragment]
+			if frag == nil {
+				return fmt.Errorf("unknown fragment %q", sel.fragment)
+			}
+			if spreading[frag.name] {
+				return fmt.Errorf("fragment %q spreads itself", frag.name)
+			}
+			if frag.typeCond != obj.Name {
+				return fmt.Errorf("fragment %q on %q cannot be spread within %q", frag.name, frag.typeCond, obj.Name)
+			}
+			spreading[frag.name] = true
+			if err := v.selections(obj, frag.selections, depth, spreading); err != nil {
+				return err
+			}
+			delete(spreading, frag.name)
+		case sel.inline:
+			if sel.typeCond != "" && sel.typeCond != obj.Name {
+				return fmt.Errorf("fragment on %q cannot be spread within %q", sel.typeCond, obj.Name)
+			}
+			if err := v.selections(obj, sel.selections, depth, spreading); err != nil {
+				return err
+			}
+		case sel.name == "__typename":
+			if len(sel.selections) > 0 {
+				return fmt.Errorf("field \"__typename\" cannot have a selection of subfields")
+			}
+		default:
+			if err := v.field(obj, sel, depth, spreading); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (v *validator) field(obj *Object, sel *selection, depth int, spreading map[string]bool) error {
+	field := obj.field(sel.name)
+	if field == nil {
+		return fmt.Errorf("cannot query field %q on type %q", sel.name, obj.Name)
+	}
+	for _, a := range sel.args {
+		found := false
+		for _, def := range field.Args {
+			found = found || def.Name == a.name
+		}
+		if !found {
+			return fmt.Errorf("unknown argument %q on field %q", a.name, field.Name)
+		}
+		if err := v.value(a.val); err != nil {
+			return err
+		}
+	}
+	for _, def := range field.Args {
+		if _, nonNull := def.Type.(*NonNull); !nonNull || def.Default != nil {
+			continue
+		}
+		given := false
+		for _, a := range sel.args {
+			given = given || a.name == def.Name
+		}
+		if !given {
+			return fmt.Errorf("field %q argument %q of type %s is required", field.Name, def.Name, def.Type)
+		}
+	}
+	if child, isObject := namedType(field.Type).(*Object); isObject {
+		if len(sel.selections) == 0 {
+			return fmt.Errorf("field %q of type %q must have a selection of subfields", field.Name, field.Type)
+		}
+		// A chain of __Type.ofType is as long as the nesting of list and non-null
+		// wrappers and does not fan out, so it does not count towards MaxDepth; the
+		// standard introspection query nests it deeper than MaxDepth allows.
+		if obj == introspection.typ && field.Name == "ofType" {
+			return v.selections(child, sel.selections, depth, spreading)
+		}
+		return v.selections(child, sel.selections, depth+1, spreading)
+	}
+	if len(sel.selections) > 0 {
+		return fmt.Errorf("field %q of type %q cannot have a selection of subfields", field.Name, field.Type)
+	}
+	return nil
+}
+
+func (v *validator) directives(dirs []directive) error {
+	for _, d := range dirs {
+		if d.name != "skip" && d.name != "include" {
+			return fmt.Errorf("unknown directive @%s", d.name)
+		}
+		if len(d.args) != 1 || d.args[0].name != "if" {
+			return fmt.Errorf("@%s takes one argument, if", d.name)
+		}
+		if err := v.value(d.args[0].val); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// value checks that the variables used in val are defined.
+func (v *validator) value(val value) error {
+	switch val.kind {
+	case valVariable:
+		if !v.vars[val.raw] {
+			return fmt.Errorf("variable $%s is not defined", val.raw)
+		}
+	case valList:
+		for _, item := range val.list {
+			if err := v.value(item); err != nil {
+				return err
+			}
+		}
+	case valObject:
+		for _, f := range val.fields {
+			if err := v.value(f.val); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
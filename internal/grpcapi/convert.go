@@ -0,0 +1,569 @@
+// grpcapi/convert.go
+package grpcapi
+
+import (
+	pb "github.com/namikmesic/go-mcp/internal/grpcapi/gomcpv1"
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// ToProtoProjectAnalysis converts the datamodel result into its protobuf form.
+func ToProtoProjectAnalysis(a *datamodel.ProjectAnalysis) *pb.ProjectAnalysis {
+	if a == nil {
+		return nil
+	}
+	out := &pb.ProjectAnalysis{
+		SchemaVersion: a.SchemaVersion,
+		ZeroBased:     a.ZeroBased,
+		ModulePath:    a.ModulePath,
+		ModuleDir:     a.ModuleDir,
+		Packages:      make([]*pb.PackageAnalysis, 0, len(a.Packages)),
+	}
+	for _, pkg := range a.Packages {
+		if pkg == nil {
+			continue
+		}
+		out.Packages = append(out.Packages, ToProtoPackage(pkg))
+	}
+	if a.Findings != nil {
+		out.Findings = toProtoFindings(a.Findings)
+	}
+	for _, dep := range a.Dependencies {
+		pd := &pb.DependencyPackage{
+			Name:     dep.Name,
+			Path:     dep.Path,
+			Module:   dep.Module,
+			Version:  dep.Version,
+			Vendored: dep.Vendored,
+		}
+		for i := range dep.Interfaces {
+			pd.Interfaces = append(pd.Interfaces, ToProtoInterface(&dep.Interfaces[i]))
+		}
+		out.Dependencies = append(out.Dependencies, pd)
+	}
+	for i := range a.StdlibInterfaces {
+		out.StdlibInterfaces = append(out.StdlibInterfaces, ToProtoInterface(&a.StdlibInterfaces[i]))
+	}
+	for _, e := range a.CrossModuleImplementations {
+		out.CrossModuleImplementations = append(out.CrossModuleImplementations, toProtoExternalImplementation(e))
+	}
+	out.ReachabilityRoots = a.ReachabilityRoots
+	out.InitOrder = a.InitOrder
+	for _, t := range a.Tests {
+		out.Tests = append(out.Tests, &pb.TestFunction{
+			FunctionId:  t.FunctionID,
+			Kind:        t.Kind,
+			PackagePath: t.PackagePath,
+			Location:    toProtoLocation(t.Location),
+			Exercises:   t.Exercises,
+		})
+	}
+	if a.ModuleGraph != nil {
+		out.ModuleGraph = &pb.ModuleGraph{}
+		for _, node := range a.ModuleGraph.Modules {
+			out.ModuleGraph.Modules = append(out.ModuleGraph.Modules, &pb.ModuleNode{
+				Path:       node.Path,
+				Version:    node.Version,
+				Main:       node.Main,
+				GoVersion:  node.GoVersion,
+				Replace:    node.Replace,
+				Indirect:   node.Indirect,
+				RequiredBy: node.RequiredBy,
+				Imports:    node.Imports,
+				Importers:  node.Importers,
+			})
+		}
+	}
+	return out
+}
+
+func toProtoExternalImplementation(e datamodel.ExternalImplementation) *pb.ExternalImplementation {
+	return &pb.ExternalImplementation{
+		TypeName:        e.TypeName,
+		PackagePath:     e.PackagePath,
+		Module:          e.Module,
+		Interface:       e.Interface,
+		InterfaceModule: e.InterfaceModule,
+		IsPointer:       e.IsPointer,
+		Location:        toProtoLocation(e.Location),
+	}
+}
+
+func toProtoFindings(f *datamodel.Findings) *pb.Findings {
+	out := &pb.Findings{}
+	for _, group := range f.Clones {
+		pg := &pb.CloneGroup{
+			Fingerprint: group.Fingerprint,
+			NodeCount:   int32(group.NodeCount),
+		}
+		for _, m := range group.Functions {
+			pg.Functions = append(pg.Functions, &pb.CloneMember{
+				Function:    m.Function,
+				PackagePath: m.PackagePath,
+				Location:    toProtoLocation(m.Location),
+			})
+		}
+		out.Clones = append(out.Clones, pg)
+	}
+	for _, v := range f.RuleViolations {
+		out.RuleViolations = append(out.RuleViolations, &pb.RuleViolation{
+			Rule:     v.Rule,
+			Kind:     v.Kind,
+			From:     v.From,
+			To:       v.To,
+			Detail:   v.Detail,
+			Location: toProtoLocation(v.Location),
+		})
+	}
+	if f.AdapterGaps != nil {
+		gaps := &pb.AdapterGaps{}
+		for _, u := range f.AdapterGaps.UnimplementedInterfaces {
+			gaps.UnimplementedInterfaces = append(gaps.UnimplementedInterfaces, &pb.UnimplementedInterface{
+				Interface: u.Interface,
+				Module:    u.Module,
+				Location:  toProtoLocation(u.Location),
+			})
+		}
+		for _, e := range f.AdapterGaps.ExternalImplementations {
+			gaps.ExternalImplementations = append(gaps.ExternalImplementations, toProtoExternalImplementation(e))
+		}
+		out.AdapterGaps = gaps
+	}
+	for _, nm := range f.NearMisses {
+		miss := &pb.NearMiss{
+			Interface:   nm.Interface,
+			TypeName:    nm.TypeName,
+			PackagePath: nm.PackagePath,
+			Location:    toProtoLocation(nm.Location),
+		}
+		for _, m := range nm.Missing {
+			miss.Missing = append(miss.Missing, &pb.MethodMismatch{
+				Name:     m.Name,
+				Want:     m.Want,
+				Have:     m.Have,
+				Reason:   m.Reason,
+				Location: toProtoLocation(m.Location),
+			})
+		}
+		out.NearMisses = append(out.NearMisses, miss)
+	}
+	for _, c := range f.ImportCycles {
+		cycle := &pb.ImportCycle{Packages: c.Packages, TestOnly: c.TestOnly}
+		for _, imp := range c.Imports {
+			cycle.Imports = append(cycle.Imports, &pb.CycleImport{
+				From:     imp.From,
+				To:       imp.To,
+				Test:     imp.Test,
+				Location: toProtoLocation(imp.Location),
+			})
+		}
+		out.ImportCycles = append(out.ImportCycles, cycle)
+	}
+	out.FatInterfaces = f.FatInterfaces
+	for _, c := range f.DuplicateInterfaces {
+		cluster := &pb.InterfaceCluster{Interfaces: c.Interfaces}
+		for _, rel := range c.Relations {
+			cluster.Relations = append(cluster.Relations, &pb.InterfaceRelation{
+				Interface: rel.Interface,
+				Other:     rel.Other,
+				Relation:  rel.Relation,
+			})
+		}
+		out.DuplicateInterfaces = append(out.DuplicateInterfaces, cluster)
+	}
+	out.UntestedFunctions = f.UntestedFunctions
+	for _, u := range f.UnrecoveredPanics {
+		pu := &pb.UnrecoveredPanic{FunctionId: u.FunctionID, Panic: toProtoPanicSite(u.Panic)}
+		for _, step := range u.Steps {
+			pu.Steps = append(pu.Steps, &pb.CallStep{
+				CallerId: step.CallerID,
+				CalleeId: step.CalleeID,
+				CallType: step.CallType,
+				Location: toProtoLocation(step.Location),
+			})
+		}
+		out.UnrecoveredPanics = append(out.UnrecoveredPanics, pu)
+	}
+	return out
+}
+
+func toProtoPanicSite(p datamodel.PanicSite) *pb.PanicSite {
+	return &pb.PanicSite{Value: p.Value, Location: toProtoLocation(p.Location)}
+}
+
+// ToProtoPackage converts a single package analysis.
+func ToProtoPackage(p *datamodel.PackageAnalysis) *pb.PackageAnalysis {
+	out := &pb.PackageAnalysis{
+		Name:          p.Name,
+		Path:          p.Path,
+		Uri:           p.URI,
+		Files:         p.Files,
+		Imports:       p.Imports,
+		EmbedFiles:    p.EmbedFiles,
+		EmbedPatterns: p.EmbedPatterns,
+		Layer:         int32(p.Layer),
+		Doc:           p.Doc,
+		Synopsis:      p.Synopsis,
+		Vendored:      p.Vendored,
+		Interfaces:    make([]*pb.Interface, 0, len(p.Interfaces)),
+		Calls:         make([]*pb.CallSite, 0, len(p.Calls)),
+	}
+	for i := range p.Interfaces {
+		out.Interfaces = append(out.Interfaces, ToProtoInterface(&p.Interfaces[i]))
+	}
+	for i := range p.Calls {
+		out.Calls = append(out.Calls, ToProtoCallSite(&p.Calls[i]))
+	}
+	for _, e := range p.ImportEdges {
+		out.ImportEdges = append(out.ImportEdges, &pb.ImportEdge{
+			Importer: e.Importer,
+			Imported: e.Imported,
+			TestOnly: e.TestOnly,
+			Location: toProtoLocation(e.Location),
+		})
+	}
+	for _, s := range p.Structs {
+		ps := &pb.Struct{
+			Name:        s.Name,
+			PackageName: s.PackageName,
+			PackagePath: s.PackagePath,
+			Uri:         s.URI,
+			DocComment:  s.DocComment,
+			TypeParams:  toProtoTypeParams(s.TypeParams),
+			Location:    toProtoLocation(s.Location),
+		}
+		for _, f := range s.Fields {
+			ps.Fields = append(ps.Fields, &pb.Field{
+				Name:       f.Name,
+				Type:       f.Type,
+				Tag:        f.Tag,
+				Embedded:   f.Embedded,
+				Exported:   f.Exported,
+				DocComment: f.DocComment,
+				Location:   toProtoLocation(f.Location),
+				Sync:       f.Sync,
+			})
+		}
+		out.Structs = append(out.Structs, ps)
+	}
+	for _, t := range p.Types {
+		out.Types = append(out.Types, &pb.NamedType{
+			Name:        t.Name,
+			PackageName: t.PackageName,
+			PackagePath: t.PackagePath,
+			Uri:         t.URI,
+			Kind:        t.Kind,
+			Underlying:  t.Underlying,
+			Target:      t.Target,
+			TypeParams:  toProtoTypeParams(t.TypeParams),
+			DocComment:  t.DocComment,
+			Exported:    t.Exported,
+			Location:    toProtoLocation(t.Location),
+		})
+	}
+	for _, fn := range p.Functions {
+		pf := &pb.Function{
+			Id:         fn.ID,
+			Uri:        fn.URI,
+			Name:       fn.Name,
+			FullName:   fn.FullName,
+			Receiver:   fn.Receiver,
+			Signature:  fn.Signature,
+			TypeParams: toProtoTypeParams(fn.TypeParams),
+			DocComment: fn.DocComment,
+			Exported:   fn.Exported,
+			Location:   toProtoLocation(fn.Location),
+			Reachable:  fn.Reachable,
+		}
+		if c := fn.Centrality; c != nil {
+			pf.Centrality = &pb.FunctionCentrality{
+				InDegree:    int32(c.InDegree),
+				OutDegree:   int32(c.OutDegree),
+				PageRank:    c.PageRank,
+				Betweenness: c.Betweenness,
+			}
+		}
+		out.Functions = append(out.Functions, pf)
+	}
+	out.Constants = toProtoValues(p.Constants)
+	out.Variables = toProtoValues(p.Variables)
+	for _, ext := range p.ExternalFunctions {
+		out.ExternalFunctions = append(out.ExternalFunctions, &pb.ExternalFunction{
+			Name:      ext.Name,
+			FullName:  ext.FullName,
+			Receiver:  ext.Receiver,
+			Signature: ext.Signature,
+			Kind:      ext.Kind,
+			Location:  toProtoLocation(ext.Location),
+		})
+	}
+	for _, g := range p.Goroutines {
+		out.Goroutines = append(out.Goroutines, &pb.Goroutine{
+			SpawnerId: g.SpawnerID,
+			Target:    g.Target,
+			TargetId:  g.TargetID,
+			Closure:   g.Closure,
+			Location:  toProtoLocation(g.Location),
+		})
+	}
+	for _, op := range p.ChannelOps {
+		pop := &pb.ChannelOp{
+			Op:         op.Op,
+			FunctionId: op.FunctionID,
+			Function:   op.Function,
+			Channel:    op.Channel,
+			ChanType:   op.ChanType,
+			Blocking:   op.Blocking,
+			Location:   toProtoLocation(op.Location),
+		}
+		for _, c := range op.Cases {
+			pop.Cases = append(pop.Cases, &pb.SelectCase{Op: c.Op, Channel: c.Channel, ChanType: c.ChanType})
+		}
+		out.ChannelOps = append(out.ChannelOps, pop)
+	}
+	for _, u := range p.Locking {
+		pu := &pb.LockUsage{
+			FunctionId: u.FunctionID,
+			Function:   u.Function,
+			Lock:       u.Lock,
+			Kind:       u.Kind,
+			Unpaired:   u.Unpaired,
+		}
+		for _, op := range u.Ops {
+			pu.Ops = append(pu.Ops, &pb.LockOp{Method: op.Method, Deferred: op.Deferred, Location: toProtoLocation(op.Location)})
+		}
+		out.Locking = append(out.Locking, pu)
+	}
+	for _, u := range p.Unsafe {
+		out.Unsafe = append(out.Unsafe, &pb.UnsafeUse{
+			Name:       u.Name,
+			FunctionId: u.FunctionID,
+			From:       u.From,
+			Arithmetic: u.Arithmetic,
+			Location:   toProtoLocation(u.Location),
+		})
+	}
+	if c := p.Cgo; c != nil {
+		out.Cgo = &pb.Cgo{GoFiles: c.GoFiles, CFiles: c.CFiles, Directives: c.Directives}
+		for _, e := range c.Exports {
+			out.Cgo.Exports = append(out.Cgo.Exports, &pb.CgoExport{Name: e.Name, FunctionId: e.FunctionID, Location: toProtoLocation(e.Location)})
+		}
+	}
+	for _, e := range p.EmbedMetadata {
+		out.EmbedMetadata = append(out.EmbedMetadata, &pb.EmbedFile{Path: e.Path, Size: e.Size, Sha256: e.SHA256, ContentType: e.ContentType})
+	}
+	if init := p.Init; init != nil {
+		out.Init = &pb.PackageInit{Funcs: init.Funcs}
+		for _, v := range init.Variables {
+			out.Init.Variables = append(out.Init.Variables, &pb.VarInit{
+				Names:    v.Names,
+				Expr:     v.Expr,
+				Calls:    v.Calls,
+				Location: toProtoLocation(v.Location),
+			})
+		}
+	}
+	for _, h := range p.PanicHandling {
+		ph := &pb.PanicHandling{FunctionId: h.FunctionID, Function: h.Function}
+		for _, d := range h.Defers {
+			ph.Defers = append(ph.Defers, &pb.DeferStmt{
+				Callee:   d.Callee,
+				CalleeId: d.CalleeID,
+				Closure:  d.Closure,
+				Recovers: d.Recovers,
+				Location: toProtoLocation(d.Location),
+			})
+		}
+		for _, site := range h.Panics {
+			ph.Panics = append(ph.Panics, toProtoPanicSite(site))
+		}
+		for _, loc := range h.Recovers {
+			ph.Recovers = append(ph.Recovers, toProtoLocation(loc))
+		}
+		out.PanicHandling = append(out.PanicHandling, ph)
+	}
+	if m := p.Metrics; m != nil {
+		out.Metrics = &pb.PackageMetrics{
+			AfferentCoupling: int32(m.AfferentCoupling),
+			EfferentCoupling: int32(m.EfferentCoupling),
+			Instability:      m.Instability,
+			AbstractTypes:    int32(m.AbstractTypes),
+			ConcreteTypes:    int32(m.ConcreteTypes),
+			Abstractness:     m.Abstractness,
+			Distance:         m.Distance,
+			Lcom:             int32(m.LCOM),
+			Cohesion:         m.Cohesion,
+			Lines:            int32(m.Lines),
+			CodeLines:        int32(m.CodeLines),
+			Functions:        int32(m.Functions),
+			Statements:       int32(m.Statements),
+		}
+		for _, f := range m.Files {
+			out.Metrics.Files = append(out.Metrics.Files, &pb.FileMetrics{
+				File:       f.File,
+				Lines:      int32(f.Lines),
+				CodeLines:  int32(f.CodeLines),
+				Functions:  int32(f.Functions),
+				Statements: int32(f.Statements),
+			})
+		}
+	}
+	return out
+}
+
+// ToProtoInterface converts an interface together with its methods and implementations.
+func ToProtoInterface(iface *datamodel.Interface) *pb.Interface {
+	out := &pb.Interface{
+		Name:            iface.Name,
+		PackageName:     iface.PackageName,
+		PackagePath:     iface.PackagePath,
+		Uri:             iface.URI,
+		Location:        toProtoLocation(iface.Location),
+		DocComment:      iface.DocComment,
+		Embeds:          iface.Embeds,
+		Stability:       iface.Stability,
+		ConsumerCount:   int32(iface.ConsumerCount),
+		TypeParams:      toProtoTypeParams(iface.TypeParams),
+		Methods:         make([]*pb.Method, 0, len(iface.Methods)),
+		Implementations: make([]*pb.Implementation, 0, len(iface.Implementations)),
+	}
+	for _, m := range iface.Methods {
+		out.Methods = append(out.Methods, toProtoMethod(m))
+	}
+	for _, m := range iface.MethodSet {
+		out.MethodSet = append(out.MethodSet, toProtoMethod(m))
+	}
+	if u := iface.Usage; u != nil {
+		out.Usage = &pb.InterfaceUsage{
+			MethodCount:     int32(u.MethodCount),
+			Implementations: int32(u.Implementations),
+			CallSites:       int32(u.CallSites),
+			Consumers:       int32(u.Consumers),
+			MaxMethodsUsed:  int32(u.MaxMethodsUsed),
+			Fat:             u.Fat,
+		}
+		for _, m := range u.Methods {
+			out.Usage.Methods = append(out.Usage.Methods, &pb.MethodUsage{
+				Name:      m.Name,
+				CallSites: int32(m.CallSites),
+				Consumers: int32(m.Consumers),
+			})
+		}
+	}
+	for _, impl := range iface.Implementations {
+		out.Implementations = append(out.Implementations, &pb.Implementation{
+			TypeName:    impl.TypeName,
+			PackagePath: impl.PackagePath,
+			PackageName: impl.PackageName,
+			Uri:         impl.URI,
+			IsPointer:   impl.IsPointer,
+			Location:    toProtoLocation(impl.Location),
+			Methods:     toProtoMethodMatches(impl.Methods),
+		})
+	}
+	return out
+}
+
+func toProtoMethodMatches(matches []datamodel.MethodMatch) []*pb.MethodMatch {
+	if len(matches) == 0 {
+		return nil
+	}
+	out := make([]*pb.MethodMatch, 0, len(matches))
+	for _, m := range matches {
+		out = append(out, &pb.MethodMatch{
+			Name:            m.Name,
+			Signature:       m.Signature,
+			PointerReceiver: m.PointerReceiver,
+			Promoted:        m.Promoted,
+			Location:        toProtoLocation(m.Location),
+			Id:              m.ID,
+		})
+	}
+	return out
+}
+
+func toProtoMethod(m datamodel.Method) *pb.Method {
+	method := &pb.Method{
+		Name:        m.Name,
+		Uri:         m.URI,
+		Signature:   m.Signature,
+		ReturnTypes: m.ReturnTypes,
+		DocComment:  m.DocComment,
+		Location:    toProtoLocation(m.Location),
+		TypeParams:  toProtoTypeParams(m.TypeParams),
+		DeclaredBy:  m.DeclaredBy,
+		Parameters:  make([]*pb.Parameter, 0, len(m.Parameters)),
+	}
+	for _, p := range m.Parameters {
+		method.Parameters = append(method.Parameters, &pb.Parameter{
+			Name:      p.Name,
+			Type:      p.Type,
+			IsPointer: p.IsPointer,
+		})
+	}
+	return method
+}
+
+// ToProtoCallSite converts a single call site.
+func ToProtoCallSite(c *datamodel.CallSite) *pb.CallSite {
+	return &pb.CallSite{
+		CallerFuncDesc: c.CallerFuncDesc,
+		CalleeDesc:     c.CalleeDesc,
+		CallType:       c.CallType,
+		Uri:            c.URI,
+		Location:       toProtoLocation(c.Location),
+		CalleeOpaque:   c.CalleeOpaque,
+		Callees:        c.Callees,
+		Algorithm:      c.Algorithm,
+		CallerId:       c.CallerID,
+		CalleeId:       c.CalleeID,
+		Targets:        c.Targets,
+	}
+}
+
+func ToProtoSymbolMatch(m *datamodel.SymbolMatch) *pb.SymbolMatch {
+	return &pb.SymbolMatch{
+		Id:       m.ID,
+		Name:     m.Name,
+		Kind:     m.Kind,
+		Package:  m.Package,
+		Score:    int32(m.Score),
+		Location: toProtoLocation(m.Location),
+	}
+}
+
+func toProtoLocation(loc datamodel.Location) *pb.Location {
+	return &pb.Location{
+		Filename:  loc.Filename,
+		Line:      int32(loc.Line),
+		Column:    int32(loc.Column),
+		EndLine:   int32(loc.EndLine),
+		EndColumn: int32(loc.EndColumn),
+		Offset:    int32(loc.Offset),
+		EndOffset: int32(loc.EndOffset),
+	}
+}
+
+func toProtoValues(values []datamodel.Value) []*pb.Value {
+	var out []*pb.Value
+	for _, v := range values {
+		out = append(out, &pb.Value{
+			Name:       v.Name,
+			Uri:        v.URI,
+			Type:       v.Type,
+			Value:      v.Value,
+			Constant:   v.Constant,
+			DocComment: v.DocComment,
+			Exported:   v.Exported,
+			Location:   toProtoLocation(v.Location),
+		})
+	}
+	return out
+}
+
+func toProtoTypeParams(params []datamodel.TypeParam) []*pb.TypeParam {
+	var out []*pb.TypeParam
+	for _, tp := range params {
+		out = append(out, &pb.TypeParam{Name: tp.Name, Constraint: tp.Constraint})
+	}
+	return out
+}
@@ -0,0 +1,5842 @@
+// Protobuf schema mirroring pkg/datamodel.
+// Regenerate the Go bindings with `go generate ./internal/grpcapi/...`.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: gomcp/v1/analysis.proto
+
+package gomcpv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Location represents a file:line:column position.
+type Location struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filename      string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	Line          int32                  `protobuf:"varint,2,opt,name=line,proto3" json:"line,omitempty"`
+	Column        int32                  `protobuf:"varint,3,opt,name=column,proto3" json:"column,omitempty"`
+	EndLine       int32                  `protobuf:"varint,4,opt,name=end_line,json=endLine,proto3" json:"end_line,omitempty"`       // Last line of the declaration, for spans
+	EndColumn     int32                  `protobuf:"varint,5,opt,name=end_column,json=endColumn,proto3" json:"end_column,omitempty"` // Column just past the end of the declaration
+	Offset        int32                  `protobuf:"varint,6,opt,name=offset,proto3" json:"offset,omitempty"`                        // Byte offset of the position in the file
+	EndOffset     int32                  `protobuf:"varint,7,opt,name=end_offset,json=endOffset,proto3" json:"end_offset,omitempty"` // Byte offset just past the end of the declaration
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Location) Reset() {
+	*x = Location{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Location) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Location) ProtoMessage() {}
+
+func (x *Location) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Location.ProtoReflect.Descriptor instead.
+func (*Location) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Location) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *Location) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *Location) GetColumn() int32 {
+	if x != nil {
+		return x.Column
+	}
+	return 0
+}
+
+func (x *Location) GetEndLine() int32 {
+	if x != nil {
+		return x.EndLine
+	}
+	return 0
+}
+
+func (x *Location) GetEndColumn() int32 {
+	if x != nil {
+		return x.EndColumn
+	}
+	return 0
+}
+
+func (x *Location) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *Location) GetEndOffset() int32 {
+	if x != nil {
+		return x.EndOffset
+	}
+	return 0
+}
+
+// Parameter represents information about a function/method parameter.
+type Parameter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	IsPointer     bool                   `protobuf:"varint,3,opt,name=is_pointer,json=isPointer,proto3" json:"is_pointer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Parameter) Reset() {
+	*x = Parameter{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Parameter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Parameter) ProtoMessage() {}
+
+func (x *Parameter) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Parameter.ProtoReflect.Descriptor instead.
+func (*Parameter) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Parameter) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Parameter) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Parameter) GetIsPointer() bool {
+	if x != nil {
+		return x.IsPointer
+	}
+	return false
+}
+
+// TypeParam represents a type parameter and its constraint.
+type TypeParam struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Constraint    string                 `protobuf:"bytes,2,opt,name=constraint,proto3" json:"constraint,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TypeParam) Reset() {
+	*x = TypeParam{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TypeParam) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TypeParam) ProtoMessage() {}
+
+func (x *TypeParam) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TypeParam.ProtoReflect.Descriptor instead.
+func (*TypeParam) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TypeParam) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TypeParam) GetConstraint() string {
+	if x != nil {
+		return x.Constraint
+	}
+	return ""
+}
+
+// Method represents detailed information about an interface method.
+type Method struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Name        string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Signature   string                 `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	Parameters  []*Parameter           `protobuf:"bytes,3,rep,name=parameters,proto3" json:"parameters,omitempty"`
+	ReturnTypes []string               `protobuf:"bytes,4,rep,name=return_types,json=returnTypes,proto3" json:"return_types,omitempty"`
+	DocComment  string                 `protobuf:"bytes,5,opt,name=doc_comment,json=docComment,proto3" json:"doc_comment,omitempty"`
+	Location    *Location              `protobuf:"bytes,6,opt,name=location,proto3" json:"location,omitempty"`
+	TypeParams  []*TypeParam           `protobuf:"bytes,7,rep,name=type_params,json=typeParams,proto3" json:"type_params,omitempty"`
+	// Qualified name of the declaring interface; set on method_set entries only.
+	DeclaredBy string `protobuf:"bytes,8,opt,name=declared_by,json=declaredBy,proto3" json:"declared_by,omitempty"`
+	// Canonical URI, e.g. go://example.com/mod/pkg#Type.Method.
+	Uri           string `protobuf:"bytes,9,opt,name=uri,proto3" json:"uri,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Method) Reset() {
+	*x = Method{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Method) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Method) ProtoMessage() {}
+
+func (x *Method) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Method.ProtoReflect.Descriptor instead.
+func (*Method) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Method) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Method) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+func (x *Method) GetParameters() []*Parameter {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+func (x *Method) GetReturnTypes() []string {
+	if x != nil {
+		return x.ReturnTypes
+	}
+	return nil
+}
+
+func (x *Method) GetDocComment() string {
+	if x != nil {
+		return x.DocComment
+	}
+	return ""
+}
+
+func (x *Method) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *Method) GetTypeParams() []*TypeParam {
+	if x != nil {
+		return x.TypeParams
+	}
+	return nil
+}
+
+func (x *Method) GetDeclaredBy() string {
+	if x != nil {
+		return x.DeclaredBy
+	}
+	return ""
+}
+
+func (x *Method) GetUri() string {
+	if x != nil {
+		return x.Uri
+	}
+	return ""
+}
+
+// Implementation represents a concrete type that implements an interface.
+type Implementation struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	TypeName    string                 `protobuf:"bytes,1,opt,name=type_name,json=typeName,proto3" json:"type_name,omitempty"`
+	PackagePath string                 `protobuf:"bytes,2,opt,name=package_path,json=packagePath,proto3" json:"package_path,omitempty"`
+	PackageName string                 `protobuf:"bytes,3,opt,name=package_name,json=packageName,proto3" json:"package_name,omitempty"`
+	IsPointer   bool                   `protobuf:"varint,4,opt,name=is_pointer,json=isPointer,proto3" json:"is_pointer,omitempty"`
+	Location    *Location              `protobuf:"bytes,5,opt,name=location,proto3" json:"location,omitempty"`
+	// Concrete method satisfying each interface method, in the interface's method order.
+	Methods []*MethodMatch `protobuf:"bytes,6,rep,name=methods,proto3" json:"methods,omitempty"`
+	// Canonical URI, e.g. go://example.com/mod/pkg#Type.Method.
+	Uri           string `protobuf:"bytes,7,opt,name=uri,proto3" json:"uri,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Implementation) Reset() {
+	*x = Implementation{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Implementation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Implementation) ProtoMessage() {}
+
+func (x *Implementation) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Implementation.ProtoReflect.Descriptor instead.
+func (*Implementation) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Implementation) GetTypeName() string {
+	if x != nil {
+		return x.TypeName
+	}
+	return ""
+}
+
+func (x *Implementation) GetPackagePath() string {
+	if x != nil {
+		return x.PackagePath
+	}
+	return ""
+}
+
+func (x *Implementation) GetPackageName() string {
+	if x != nil {
+		return x.PackageName
+	}
+	return ""
+}
+
+func (x *Implementation) GetIsPointer() bool {
+	if x != nil {
+		return x.IsPointer
+	}
+	return false
+}
+
+func (x *Implementation) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *Implementation) GetMethods() []*MethodMatch {
+	if x != nil {
+		return x.Methods
+	}
+	return nil
+}
+
+func (x *Implementation) GetUri() string {
+	if x != nil {
+		return x.Uri
+	}
+	return ""
+}
+
+// MethodMatch is an interface method satisfied by a concrete method.
+type MethodMatch struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Name      string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Signature string                 `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	// Only the pointer type has the method.
+	PointerReceiver bool `protobuf:"varint,3,opt,name=pointer_receiver,json=pointerReceiver,proto3" json:"pointer_receiver,omitempty"`
+	// Provided through an embedded field.
+	Promoted bool      `protobuf:"varint,4,opt,name=promoted,proto3" json:"promoted,omitempty"`
+	Location *Location `protobuf:"bytes,5,opt,name=location,proto3" json:"location,omitempty"`
+	// Stable ID of the concrete method, matching Function.id.
+	Id            string `protobuf:"bytes,6,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MethodMatch) Reset() {
+	*x = MethodMatch{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MethodMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MethodMatch) ProtoMessage() {}
+
+func (x *MethodMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MethodMatch.ProtoReflect.Descriptor instead.
+func (*MethodMatch) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *MethodMatch) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MethodMatch) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+func (x *MethodMatch) GetPointerReceiver() bool {
+	if x != nil {
+		return x.PointerReceiver
+	}
+	return false
+}
+
+func (x *MethodMatch) GetPromoted() bool {
+	if x != nil {
+		return x.Promoted
+	}
+	return false
+}
+
+func (x *MethodMatch) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *MethodMatch) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// Interface represents information about a found interface.
+type Interface struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Name            string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	PackageName     string                 `protobuf:"bytes,2,opt,name=package_name,json=packageName,proto3" json:"package_name,omitempty"`
+	PackagePath     string                 `protobuf:"bytes,3,opt,name=package_path,json=packagePath,proto3" json:"package_path,omitempty"`
+	Location        *Location              `protobuf:"bytes,4,opt,name=location,proto3" json:"location,omitempty"`
+	DocComment      string                 `protobuf:"bytes,5,opt,name=doc_comment,json=docComment,proto3" json:"doc_comment,omitempty"`
+	Methods         []*Method              `protobuf:"bytes,6,rep,name=methods,proto3" json:"methods,omitempty"`
+	Embeds          []string               `protobuf:"bytes,7,rep,name=embeds,proto3" json:"embeds,omitempty"`
+	Implementations []*Implementation      `protobuf:"bytes,8,rep,name=implementations,proto3" json:"implementations,omitempty"`
+	// One of "Internal", "Public", "Core".
+	Stability     string       `protobuf:"bytes,9,opt,name=stability,proto3" json:"stability,omitempty"`
+	ConsumerCount int32        `protobuf:"varint,10,opt,name=consumer_count,json=consumerCount,proto3" json:"consumer_count,omitempty"`
+	TypeParams    []*TypeParam `protobuf:"bytes,11,rep,name=type_params,json=typeParams,proto3" json:"type_params,omitempty"`
+	// Complete method set including embedded methods; only set when embeds is non-empty.
+	MethodSet []*Method       `protobuf:"bytes,12,rep,name=method_set,json=methodSet,proto3" json:"method_set,omitempty"`
+	Usage     *InterfaceUsage `protobuf:"bytes,13,opt,name=usage,proto3" json:"usage,omitempty"`
+	// Canonical URI, e.g. go://example.com/mod/pkg#Type.Method.
+	Uri           string `protobuf:"bytes,14,opt,name=uri,proto3" json:"uri,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Interface) Reset() {
+	*x = Interface{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Interface) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Interface) ProtoMessage() {}
+
+func (x *Interface) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Interface.ProtoReflect.Descriptor instead.
+func (*Interface) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Interface) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Interface) GetPackageName() string {
+	if x != nil {
+		return x.PackageName
+	}
+	return ""
+}
+
+func (x *Interface) GetPackagePath() string {
+	if x != nil {
+		return x.PackagePath
+	}
+	return ""
+}
+
+func (x *Interface) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *Interface) GetDocComment() string {
+	if x != nil {
+		return x.DocComment
+	}
+	return ""
+}
+
+func (x *Interface) GetMethods() []*Method {
+	if x != nil {
+		return x.Methods
+	}
+	return nil
+}
+
+func (x *Interface) GetEmbeds() []string {
+	if x != nil {
+		return x.Embeds
+	}
+	return nil
+}
+
+func (x *Interface) GetImplementations() []*Implementation {
+	if x != nil {
+		return x.Implementations
+	}
+	return nil
+}
+
+func (x *Interface) GetStability() string {
+	if x != nil {
+		return x.Stability
+	}
+	return ""
+}
+
+func (x *Interface) GetConsumerCount() int32 {
+	if x != nil {
+		return x.ConsumerCount
+	}
+	return 0
+}
+
+func (x *Interface) GetTypeParams() []*TypeParam {
+	if x != nil {
+		return x.TypeParams
+	}
+	return nil
+}
+
+func (x *Interface) GetMethodSet() []*Method {
+	if x != nil {
+		return x.MethodSet
+	}
+	return nil
+}
+
+func (x *Interface) GetUsage() *InterfaceUsage {
+	if x != nil {
+		return x.Usage
+	}
+	return nil
+}
+
+func (x *Interface) GetUri() string {
+	if x != nil {
+		return x.Uri
+	}
+	return ""
+}
+
+// InterfaceUsage reports how the methods of an interface are called through it.
+type InterfaceUsage struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	MethodCount     int32                  `protobuf:"varint,1,opt,name=method_count,json=methodCount,proto3" json:"method_count,omitempty"`
+	Implementations int32                  `protobuf:"varint,2,opt,name=implementations,proto3" json:"implementations,omitempty"`
+	CallSites       int32                  `protobuf:"varint,3,opt,name=call_sites,json=callSites,proto3" json:"call_sites,omitempty"`
+	Consumers       int32                  `protobuf:"varint,4,opt,name=consumers,proto3" json:"consumers,omitempty"`
+	// Most distinct methods called by a single consuming package.
+	MaxMethodsUsed int32 `protobuf:"varint,5,opt,name=max_methods_used,json=maxMethodsUsed,proto3" json:"max_methods_used,omitempty"`
+	// In method set order.
+	Methods []*MethodUsage `protobuf:"bytes,6,rep,name=methods,proto3" json:"methods,omitempty"`
+	// Every consumer calls at most half of a large method set.
+	Fat           bool `protobuf:"varint,7,opt,name=fat,proto3" json:"fat,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InterfaceUsage) Reset() {
+	*x = InterfaceUsage{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InterfaceUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InterfaceUsage) ProtoMessage() {}
+
+func (x *InterfaceUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InterfaceUsage.ProtoReflect.Descriptor instead.
+func (*InterfaceUsage) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *InterfaceUsage) GetMethodCount() int32 {
+	if x != nil {
+		return x.MethodCount
+	}
+	return 0
+}
+
+func (x *InterfaceUsage) GetImplementations() int32 {
+	if x != nil {
+		return x.Implementations
+	}
+	return 0
+}
+
+func (x *InterfaceUsage) GetCallSites() int32 {
+	if x != nil {
+		return x.CallSites
+	}
+	return 0
+}
+
+func (x *InterfaceUsage) GetConsumers() int32 {
+	if x != nil {
+		return x.Consumers
+	}
+	return 0
+}
+
+func (x *InterfaceUsage) GetMaxMethodsUsed() int32 {
+	if x != nil {
+		return x.MaxMethodsUsed
+	}
+	return 0
+}
+
+func (x *InterfaceUsage) GetMethods() []*MethodUsage {
+	if x != nil {
+		return x.Methods
+	}
+	return nil
+}
+
+func (x *InterfaceUsage) GetFat() bool {
+	if x != nil {
+		return x.Fat
+	}
+	return false
+}
+
+// MethodUsage counts the interface calls of one method of an interface.
+type MethodUsage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	CallSites     int32                  `protobuf:"varint,2,opt,name=call_sites,json=callSites,proto3" json:"call_sites,omitempty"`
+	Consumers     int32                  `protobuf:"varint,3,opt,name=consumers,proto3" json:"consumers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MethodUsage) Reset() {
+	*x = MethodUsage{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MethodUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MethodUsage) ProtoMessage() {}
+
+func (x *MethodUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MethodUsage.ProtoReflect.Descriptor instead.
+func (*MethodUsage) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *MethodUsage) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MethodUsage) GetCallSites() int32 {
+	if x != nil {
+		return x.CallSites
+	}
+	return 0
+}
+
+func (x *MethodUsage) GetConsumers() int32 {
+	if x != nil {
+		return x.Consumers
+	}
+	return 0
+}
+
+// CallSite represents information about a single call site.
+type CallSite struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	CallerFuncDesc string                 `protobuf:"bytes,1,opt,name=caller_func_desc,json=callerFuncDesc,proto3" json:"caller_func_desc,omitempty"`
+	CalleeDesc     string                 `protobuf:"bytes,2,opt,name=callee_desc,json=calleeDesc,proto3" json:"callee_desc,omitempty"`
+	CallType       string                 `protobuf:"bytes,3,opt,name=call_type,json=callType,proto3" json:"call_type,omitempty"`
+	Location       *Location              `protobuf:"bytes,4,opt,name=location,proto3" json:"location,omitempty"`
+	// Set when the callee has no Go body visible to the analysis.
+	CalleeOpaque bool `protobuf:"varint,5,opt,name=callee_opaque,json=calleeOpaque,proto3" json:"callee_opaque,omitempty"`
+	// Candidate targets of a dynamic or interface call, from the selected call graph algorithm.
+	Callees []string `protobuf:"bytes,6,rep,name=callees,proto3" json:"callees,omitempty"`
+	// Call graph algorithm that resolved callees (cha, rta or vta).
+	Algorithm string `protobuf:"bytes,7,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	// Stable IDs of the caller and callee, matching Function.id.
+	CallerId string `protobuf:"bytes,8,opt,name=caller_id,json=callerId,proto3" json:"caller_id,omitempty"`
+	CalleeId string `protobuf:"bytes,9,opt,name=callee_id,json=calleeId,proto3" json:"callee_id,omitempty"`
+	// IDs of the concrete methods an interface call may dispatch to.
+	Targets []string `protobuf:"bytes,10,rep,name=targets,proto3" json:"targets,omitempty"`
+	// Canonical URI, e.g. go://example.com/mod/pkg#Type.Method.
+	Uri           string `protobuf:"bytes,11,opt,name=uri,proto3" json:"uri,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CallSite) Reset() {
+	*x = CallSite{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CallSite) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CallSite) ProtoMessage() {}
+
+func (x *CallSite) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CallSite.ProtoReflect.Descriptor instead.
+func (*CallSite) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CallSite) GetCallerFuncDesc() string {
+	if x != nil {
+		return x.CallerFuncDesc
+	}
+	return ""
+}
+
+func (x *CallSite) GetCalleeDesc() string {
+	if x != nil {
+		return x.CalleeDesc
+	}
+	return ""
+}
+
+func (x *CallSite) GetCallType() string {
+	if x != nil {
+		return x.CallType
+	}
+	return ""
+}
+
+func (x *CallSite) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *CallSite) GetCalleeOpaque() bool {
+	if x != nil {
+		return x.CalleeOpaque
+	}
+	return false
+}
+
+func (x *CallSite) GetCallees() []string {
+	if x != nil {
+		return x.Callees
+	}
+	return nil
+}
+
+func (x *CallSite) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
+func (x *CallSite) GetCallerId() string {
+	if x != nil {
+		return x.CallerId
+	}
+	return ""
+}
+
+func (x *CallSite) GetCalleeId() string {
+	if x != nil {
+		return x.CalleeId
+	}
+	return ""
+}
+
+func (x *CallSite) GetTargets() []string {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+func (x *CallSite) GetUri() string {
+	if x != nil {
+		return x.Uri
+	}
+	return ""
+}
+
+// ExternalFunction is a function implemented outside Go (assembly, linkname, cgo).
+type ExternalFunction struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Name      string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	FullName  string                 `protobuf:"bytes,2,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
+	Receiver  string                 `protobuf:"bytes,3,opt,name=receiver,proto3" json:"receiver,omitempty"`
+	Signature string                 `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+	// One of "Assembly", "Linkname", "Cgo", "Unknown".
+	Kind          string    `protobuf:"bytes,5,opt,name=kind,proto3" json:"kind,omitempty"`
+	Location      *Location `protobuf:"bytes,6,opt,name=location,proto3" json:"location,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExternalFunction) Reset() {
+	*x = ExternalFunction{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExternalFunction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExternalFunction) ProtoMessage() {}
+
+func (x *ExternalFunction) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExternalFunction.ProtoReflect.Descriptor instead.
+func (*ExternalFunction) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ExternalFunction) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ExternalFunction) GetFullName() string {
+	if x != nil {
+		return x.FullName
+	}
+	return ""
+}
+
+func (x *ExternalFunction) GetReceiver() string {
+	if x != nil {
+		return x.Receiver
+	}
+	return ""
+}
+
+func (x *ExternalFunction) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+func (x *ExternalFunction) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ExternalFunction) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+// PackageMetrics holds architecture metrics for a package.
+type PackageMetrics struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	AfferentCoupling int32                  `protobuf:"varint,1,opt,name=afferent_coupling,json=afferentCoupling,proto3" json:"afferent_coupling,omitempty"`
+	EfferentCoupling int32                  `protobuf:"varint,2,opt,name=efferent_coupling,json=efferentCoupling,proto3" json:"efferent_coupling,omitempty"`
+	Instability      float64                `protobuf:"fixed64,3,opt,name=instability,proto3" json:"instability,omitempty"`
+	AbstractTypes    int32                  `protobuf:"varint,4,opt,name=abstract_types,json=abstractTypes,proto3" json:"abstract_types,omitempty"`
+	ConcreteTypes    int32                  `protobuf:"varint,5,opt,name=concrete_types,json=concreteTypes,proto3" json:"concrete_types,omitempty"`
+	Abstractness     float64                `protobuf:"fixed64,6,opt,name=abstractness,proto3" json:"abstractness,omitempty"`
+	Distance         float64                `protobuf:"fixed64,7,opt,name=distance,proto3" json:"distance,omitempty"`
+	Lcom             int32                  `protobuf:"varint,8,opt,name=lcom,proto3" json:"lcom,omitempty"`
+	Cohesion         float64                `protobuf:"fixed64,9,opt,name=cohesion,proto3" json:"cohesion,omitempty"`
+	Lines            int32                  `protobuf:"varint,10,opt,name=lines,proto3" json:"lines,omitempty"`
+	CodeLines        int32                  `protobuf:"varint,11,opt,name=code_lines,json=codeLines,proto3" json:"code_lines,omitempty"`
+	Functions        int32                  `protobuf:"varint,12,opt,name=functions,proto3" json:"functions,omitempty"`
+	Statements       int32                  `protobuf:"varint,13,opt,name=statements,proto3" json:"statements,omitempty"`
+	Files            []*FileMetrics         `protobuf:"bytes,14,rep,name=files,proto3" json:"files,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *PackageMetrics) Reset() {
+	*x = PackageMetrics{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PackageMetrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PackageMetrics) ProtoMessage() {}
+
+func (x *PackageMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PackageMetrics.ProtoReflect.Descriptor instead.
+func (*PackageMetrics) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *PackageMetrics) GetAfferentCoupling() int32 {
+	if x != nil {
+		return x.AfferentCoupling
+	}
+	return 0
+}
+
+func (x *PackageMetrics) GetEfferentCoupling() int32 {
+	if x != nil {
+		return x.EfferentCoupling
+	}
+	return 0
+}
+
+func (x *PackageMetrics) GetInstability() float64 {
+	if x != nil {
+		return x.Instability
+	}
+	return 0
+}
+
+func (x *PackageMetrics) GetAbstractTypes() int32 {
+	if x != nil {
+		return x.AbstractTypes
+	}
+	return 0
+}
+
+func (x *PackageMetrics) GetConcreteTypes() int32 {
+	if x != nil {
+		return x.ConcreteTypes
+	}
+	return 0
+}
+
+func (x *PackageMetrics) GetAbstractness() float64 {
+	if x != nil {
+		return x.Abstractness
+	}
+	return 0
+}
+
+func (x *PackageMetrics) GetDistance() float64 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+func (x *PackageMetrics) GetLcom() int32 {
+	if x != nil {
+		return x.Lcom
+	}
+	return 0
+}
+
+func (x *PackageMetrics) GetCohesion() float64 {
+	if x != nil {
+		return x.Cohesion
+	}
+	return 0
+}
+
+func (x *PackageMetrics) GetLines() int32 {
+	if x != nil {
+		return x.Lines
+	}
+	return 0
+}
+
+func (x *PackageMetrics) GetCodeLines() int32 {
+	if x != nil {
+		return x.CodeLines
+	}
+	return 0
+}
+
+func (x *PackageMetrics) GetFunctions() int32 {
+	if x != nil {
+		return x.Functions
+	}
+	return 0
+}
+
+func (x *PackageMetrics) GetStatements() int32 {
+	if x != nil {
+		return x.Statements
+	}
+	return 0
+}
+
+func (x *PackageMetrics) GetFiles() []*FileMetrics {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+// FileMetrics holds the size of one source file of a package.
+type FileMetrics struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	File          string                 `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	Lines         int32                  `protobuf:"varint,2,opt,name=lines,proto3" json:"lines,omitempty"`
+	CodeLines     int32                  `protobuf:"varint,3,opt,name=code_lines,json=codeLines,proto3" json:"code_lines,omitempty"`
+	Functions     int32                  `protobuf:"varint,4,opt,name=functions,proto3" json:"functions,omitempty"`
+	Statements    int32                  `protobuf:"varint,5,opt,name=statements,proto3" json:"statements,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileMetrics) Reset() {
+	*x = FileMetrics{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileMetrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileMetrics) ProtoMessage() {}
+
+func (x *FileMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileMetrics.ProtoReflect.Descriptor instead.
+func (*FileMetrics) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *FileMetrics) GetFile() string {
+	if x != nil {
+		return x.File
+	}
+	return ""
+}
+
+func (x *FileMetrics) GetLines() int32 {
+	if x != nil {
+		return x.Lines
+	}
+	return 0
+}
+
+func (x *FileMetrics) GetCodeLines() int32 {
+	if x != nil {
+		return x.CodeLines
+	}
+	return 0
+}
+
+func (x *FileMetrics) GetFunctions() int32 {
+	if x != nil {
+		return x.Functions
+	}
+	return 0
+}
+
+func (x *FileMetrics) GetStatements() int32 {
+	if x != nil {
+		return x.Statements
+	}
+	return 0
+}
+
+// PackageAnalysis holds all analyzed information for a single Go package.
+type PackageAnalysis struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Name              string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Path              string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Files             []string               `protobuf:"bytes,3,rep,name=files,proto3" json:"files,omitempty"`
+	Imports           []string               `protobuf:"bytes,4,rep,name=imports,proto3" json:"imports,omitempty"`
+	EmbedFiles        []string               `protobuf:"bytes,5,rep,name=embed_files,json=embedFiles,proto3" json:"embed_files,omitempty"`
+	EmbedPatterns     []string               `protobuf:"bytes,6,rep,name=embed_patterns,json=embedPatterns,proto3" json:"embed_patterns,omitempty"`
+	Interfaces        []*Interface           `protobuf:"bytes,7,rep,name=interfaces,proto3" json:"interfaces,omitempty"`
+	Calls             []*CallSite            `protobuf:"bytes,8,rep,name=calls,proto3" json:"calls,omitempty"`
+	ExternalFunctions []*ExternalFunction    `protobuf:"bytes,9,rep,name=external_functions,json=externalFunctions,proto3" json:"external_functions,omitempty"`
+	Metrics           *PackageMetrics        `protobuf:"bytes,10,opt,name=metrics,proto3" json:"metrics,omitempty"`
+	Layer             int32                  `protobuf:"varint,11,opt,name=layer,proto3" json:"layer,omitempty"`
+	Structs           []*Struct              `protobuf:"bytes,12,rep,name=structs,proto3" json:"structs,omitempty"`
+	Functions         []*Function            `protobuf:"bytes,13,rep,name=functions,proto3" json:"functions,omitempty"`
+	Constants         []*Value               `protobuf:"bytes,14,rep,name=constants,proto3" json:"constants,omitempty"`
+	Variables         []*Value               `protobuf:"bytes,15,rep,name=variables,proto3" json:"variables,omitempty"`
+	Doc               string                 `protobuf:"bytes,16,opt,name=doc,proto3" json:"doc,omitempty"`
+	Synopsis          string                 `protobuf:"bytes,17,opt,name=synopsis,proto3" json:"synopsis,omitempty"`
+	Types             []*NamedType           `protobuf:"bytes,18,rep,name=types,proto3" json:"types,omitempty"`
+	Vendored          bool                   `protobuf:"varint,19,opt,name=vendored,proto3" json:"vendored,omitempty"`
+	ImportEdges       []*ImportEdge          `protobuf:"bytes,20,rep,name=import_edges,json=importEdges,proto3" json:"import_edges,omitempty"`
+	Goroutines        []*Goroutine           `protobuf:"bytes,21,rep,name=goroutines,proto3" json:"goroutines,omitempty"`
+	ChannelOps        []*ChannelOp           `protobuf:"bytes,22,rep,name=channel_ops,json=channelOps,proto3" json:"channel_ops,omitempty"`
+	Locking           []*LockUsage           `protobuf:"bytes,23,rep,name=locking,proto3" json:"locking,omitempty"`
+	PanicHandling     []*PanicHandling       `protobuf:"bytes,24,rep,name=panic_handling,json=panicHandling,proto3" json:"panic_handling,omitempty"`
+	Unsafe            []*UnsafeUse           `protobuf:"bytes,25,rep,name=unsafe,proto3" json:"unsafe,omitempty"`
+	// Set for packages using cgo.
+	Cgo *Cgo `protobuf:"bytes,26,opt,name=cgo,proto3" json:"cgo,omitempty"`
+	// Variable initializers and init functions.
+	Init *PackageInit `protobuf:"bytes,27,opt,name=init,proto3" json:"init,omitempty"`
+	// Size, hash and content type of each embedded file (--embed-metadata).
+	EmbedMetadata []*EmbedFile `protobuf:"bytes,28,rep,name=embed_metadata,json=embedMetadata,proto3" json:"embed_metadata,omitempty"`
+	// Canonical URI, e.g. go://example.com/mod/pkg#Type.Method.
+	Uri           string `protobuf:"bytes,29,opt,name=uri,proto3" json:"uri,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PackageAnalysis) Reset() {
+	*x = PackageAnalysis{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PackageAnalysis) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PackageAnalysis) ProtoMessage() {}
+
+func (x *PackageAnalysis) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PackageAnalysis.ProtoReflect.Descriptor instead.
+func (*PackageAnalysis) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PackageAnalysis) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PackageAnalysis) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *PackageAnalysis) GetFiles() []string {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *PackageAnalysis) GetImports() []string {
+	if x != nil {
+		return x.Imports
+	}
+	return nil
+}
+
+func (x *PackageAnalysis) GetEmbedFiles() []string {
+	if x != nil {
+		return x.EmbedFiles
+	}
+	return nil
+}
+
+func (x *PackageAnalysis) GetEmbedPatterns() []string {
+	if x != nil {
+		return x.EmbedPatterns
+	}
+	return nil
+}
+
+func (x *PackageAnalysis) GetInterfaces() []*Interface {
+	if x != nil {
+		return x.Interfaces
+	}
+	return nil
+}
+
+func (x *PackageAnalysis) GetCalls() []*CallSite {
+	if x != nil {
+		return x.Calls
+	}
+	return nil
+}
+
+func (x *PackageAnalysis) GetExternalFunctions() []*ExternalFunction {
+	if x != nil {
+		return x.ExternalFunctions
+	}
+	return nil
+}
+
+func (x *PackageAnalysis) GetMetrics() *PackageMetrics {
+	if x != nil {
+		return x.Metrics
+	}
+	return nil
+}
+
+func (x *PackageAnalysis) GetLayer() int32 {
+	if x != nil {
+		return x.Layer
+	}
+	return 0
+}
+
+func (x *PackageAnalysis) GetStructs() []*Struct {
+	if x != nil {
+		return x.Structs
+	}
+	return nil
+}
+
+func (x *PackageAnalysis) GetFunctions() []*Function {
+	if x != nil {
+		return x.Functions
+	}
+	return nil
+}
+
+func (x *PackageAnalysis) GetConstants() []*Value {
+	if x != nil {
+		return x.Constants
+	}
+	return nil
+}
+
+func (x *PackageAnalysis) GetVariables() []*Value {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+func (x *PackageAnalysis) GetDoc() string {
+	if x != nil {
+		return x.Doc
+	}
+	return ""
+}
+
+func (x *PackageAnalysis) GetSynopsis() string {
+	if x != nil {
+		return x.Synopsis
+	}
+	return ""
+}
+
+func (x *PackageAnalysis) GetTypes() []*NamedType {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *PackageAnalysis) GetVendored() bool {
+	if x != nil {
+		return x.Vendored
+	}
+	return false
+}
+
+func (x *PackageAnalysis) GetImportEdges() []*ImportEdge {
+	if x != nil {
+		return x.ImportEdges
+	}
+	return nil
+}
+
+func (x *PackageAnalysis) GetGoroutines() []*Goroutine {
+	if x != nil {
+		return x.Goroutines
+	}
+	return nil
+}
+
+func (x *PackageAnalysis) GetChannelOps() []*ChannelOp {
+	if x != nil {
+		return x.ChannelOps
+	}
+	return nil
+}
+
+func (x *PackageAnalysis) GetLocking() []*LockUsage {
+	if x != nil {
+		return x.Locking
+	}
+	return nil
+}
+
+func (x *PackageAnalysis) GetPanicHandling() []*PanicHandling {
+	if x != nil {
+		return x.PanicHandling
+	}
+	return nil
+}
+
+func (x *PackageAnalysis) GetUnsafe() []*UnsafeUse {
+	if x != nil {
+		return x.Unsafe
+	}
+	return nil
+}
+
+func (x *PackageAnalysis) GetCgo() *Cgo {
+	if x != nil {
+		return x.Cgo
+	}
+	return nil
+}
+
+func (x *PackageAnalysis) GetInit() *PackageInit {
+	if x != nil {
+		return x.Init
+	}
+	return nil
+}
+
+func (x *PackageAnalysis) GetEmbedMetadata() []*EmbedFile {
+	if x != nil {
+		return x.EmbedMetadata
+	}
+	return nil
+}
+
+func (x *PackageAnalysis) GetUri() string {
+	if x != nil {
+		return x.Uri
+	}
+	return ""
+}
+
+// EmbedFile describes a file embedded with //go:embed.
+type EmbedFile struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Size          int64                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Sha256        string                 `protobuf:"bytes,3,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	ContentType   string                 `protobuf:"bytes,4,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmbedFile) Reset() {
+	*x = EmbedFile{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmbedFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmbedFile) ProtoMessage() {}
+
+func (x *EmbedFile) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmbedFile.ProtoReflect.Descriptor instead.
+func (*EmbedFile) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *EmbedFile) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *EmbedFile) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *EmbedFile) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *EmbedFile) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+// PackageInit lists what runs when a package is initialized, in order.
+type PackageInit struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Variables []*VarInit             `protobuf:"bytes,1,rep,name=variables,proto3" json:"variables,omitempty"`
+	// Function IDs of the init functions.
+	Funcs         []string `protobuf:"bytes,2,rep,name=funcs,proto3" json:"funcs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PackageInit) Reset() {
+	*x = PackageInit{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PackageInit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PackageInit) ProtoMessage() {}
+
+func (x *PackageInit) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PackageInit.ProtoReflect.Descriptor instead.
+func (*PackageInit) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *PackageInit) GetVariables() []*VarInit {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+func (x *PackageInit) GetFuncs() []string {
+	if x != nil {
+		return x.Funcs
+	}
+	return nil
+}
+
+// VarInit is the initializer of package-level variables.
+type VarInit struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Names []string               `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
+	Expr  string                 `protobuf:"bytes,2,opt,name=expr,proto3" json:"expr,omitempty"`
+	// IDs of the functions the initializer calls directly.
+	Calls         []string  `protobuf:"bytes,3,rep,name=calls,proto3" json:"calls,omitempty"`
+	Location      *Location `protobuf:"bytes,4,opt,name=location,proto3" json:"location,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VarInit) Reset() {
+	*x = VarInit{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VarInit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VarInit) ProtoMessage() {}
+
+func (x *VarInit) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VarInit.ProtoReflect.Descriptor instead.
+func (*VarInit) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *VarInit) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+func (x *VarInit) GetExpr() string {
+	if x != nil {
+		return x.Expr
+	}
+	return ""
+}
+
+func (x *VarInit) GetCalls() []string {
+	if x != nil {
+		return x.Calls
+	}
+	return nil
+}
+
+func (x *VarInit) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+// Cgo describes the cgo usage of a package.
+type Cgo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Go files importing "C".
+	GoFiles []string `protobuf:"bytes,1,rep,name=go_files,json=goFiles,proto3" json:"go_files,omitempty"`
+	// C, C++, Objective-C and Fortran sources and headers.
+	CFiles []string `protobuf:"bytes,2,rep,name=c_files,json=cFiles,proto3" json:"c_files,omitempty"`
+	// #cgo directives of the preambles.
+	Directives    []string     `protobuf:"bytes,3,rep,name=directives,proto3" json:"directives,omitempty"`
+	Exports       []*CgoExport `protobuf:"bytes,4,rep,name=exports,proto3" json:"exports,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Cgo) Reset() {
+	*x = Cgo{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Cgo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cgo) ProtoMessage() {}
+
+func (x *Cgo) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cgo.ProtoReflect.Descriptor instead.
+func (*Cgo) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Cgo) GetGoFiles() []string {
+	if x != nil {
+		return x.GoFiles
+	}
+	return nil
+}
+
+func (x *Cgo) GetCFiles() []string {
+	if x != nil {
+		return x.CFiles
+	}
+	return nil
+}
+
+func (x *Cgo) GetDirectives() []string {
+	if x != nil {
+		return x.Directives
+	}
+	return nil
+}
+
+func (x *Cgo) GetExports() []*CgoExport {
+	if x != nil {
+		return x.Exports
+	}
+	return nil
+}
+
+// CgoExport is a Go function exported to C with an //export directive.
+type CgoExport struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	FunctionId    string                 `protobuf:"bytes,2,opt,name=function_id,json=functionId,proto3" json:"function_id,omitempty"`
+	Location      *Location              `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CgoExport) Reset() {
+	*x = CgoExport{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CgoExport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CgoExport) ProtoMessage() {}
+
+func (x *CgoExport) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CgoExport.ProtoReflect.Descriptor instead.
+func (*CgoExport) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CgoExport) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CgoExport) GetFunctionId() string {
+	if x != nil {
+		return x.FunctionId
+	}
+	return ""
+}
+
+func (x *CgoExport) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+// UnsafeUse is a reference to a member of package unsafe.
+type UnsafeUse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Member of package unsafe: "Pointer", "Sizeof", "Add", ...
+	Name       string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	FunctionId string `protobuf:"bytes,2,opt,name=function_id,json=functionId,proto3" json:"function_id,omitempty"`
+	// Type converted by a conversion to unsafe.Pointer.
+	From string `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	// unsafe.Add, or a conversion to unsafe.Pointer of a computed uintptr.
+	Arithmetic    bool      `protobuf:"varint,4,opt,name=arithmetic,proto3" json:"arithmetic,omitempty"`
+	Location      *Location `protobuf:"bytes,5,opt,name=location,proto3" json:"location,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnsafeUse) Reset() {
+	*x = UnsafeUse{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnsafeUse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnsafeUse) ProtoMessage() {}
+
+func (x *UnsafeUse) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnsafeUse.ProtoReflect.Descriptor instead.
+func (*UnsafeUse) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UnsafeUse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UnsafeUse) GetFunctionId() string {
+	if x != nil {
+		return x.FunctionId
+	}
+	return ""
+}
+
+func (x *UnsafeUse) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *UnsafeUse) GetArithmetic() bool {
+	if x != nil {
+		return x.Arithmetic
+	}
+	return false
+}
+
+func (x *UnsafeUse) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+// PanicHandling lists the defer statements, panics and recover calls of a function.
+type PanicHandling struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FunctionId    string                 `protobuf:"bytes,1,opt,name=function_id,json=functionId,proto3" json:"function_id,omitempty"`
+	Function      string                 `protobuf:"bytes,2,opt,name=function,proto3" json:"function,omitempty"`
+	Defers        []*DeferStmt           `protobuf:"bytes,3,rep,name=defers,proto3" json:"defers,omitempty"`
+	Panics        []*PanicSite           `protobuf:"bytes,4,rep,name=panics,proto3" json:"panics,omitempty"`
+	Recovers      []*Location            `protobuf:"bytes,5,rep,name=recovers,proto3" json:"recovers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PanicHandling) Reset() {
+	*x = PanicHandling{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PanicHandling) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PanicHandling) ProtoMessage() {}
+
+func (x *PanicHandling) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PanicHandling.ProtoReflect.Descriptor instead.
+func (*PanicHandling) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *PanicHandling) GetFunctionId() string {
+	if x != nil {
+		return x.FunctionId
+	}
+	return ""
+}
+
+func (x *PanicHandling) GetFunction() string {
+	if x != nil {
+		return x.Function
+	}
+	return ""
+}
+
+func (x *PanicHandling) GetDefers() []*DeferStmt {
+	if x != nil {
+		return x.Defers
+	}
+	return nil
+}
+
+func (x *PanicHandling) GetPanics() []*PanicSite {
+	if x != nil {
+		return x.Panics
+	}
+	return nil
+}
+
+func (x *PanicHandling) GetRecovers() []*Location {
+	if x != nil {
+		return x.Recovers
+	}
+	return nil
+}
+
+// DeferStmt is a defer statement.
+type DeferStmt struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Callee   string                 `protobuf:"bytes,1,opt,name=callee,proto3" json:"callee,omitempty"`
+	CalleeId string                 `protobuf:"bytes,2,opt,name=callee_id,json=calleeId,proto3" json:"callee_id,omitempty"`
+	Closure  bool                   `protobuf:"varint,3,opt,name=closure,proto3" json:"closure,omitempty"`
+	// The deferred function calls recover.
+	Recovers      bool      `protobuf:"varint,4,opt,name=recovers,proto3" json:"recovers,omitempty"`
+	Location      *Location `protobuf:"bytes,5,opt,name=location,proto3" json:"location,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeferStmt) Reset() {
+	*x = DeferStmt{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeferStmt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeferStmt) ProtoMessage() {}
+
+func (x *DeferStmt) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeferStmt.ProtoReflect.Descriptor instead.
+func (*DeferStmt) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DeferStmt) GetCallee() string {
+	if x != nil {
+		return x.Callee
+	}
+	return ""
+}
+
+func (x *DeferStmt) GetCalleeId() string {
+	if x != nil {
+		return x.CalleeId
+	}
+	return ""
+}
+
+func (x *DeferStmt) GetClosure() bool {
+	if x != nil {
+		return x.Closure
+	}
+	return false
+}
+
+func (x *DeferStmt) GetRecovers() bool {
+	if x != nil {
+		return x.Recovers
+	}
+	return false
+}
+
+func (x *DeferStmt) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+// PanicSite is a call to panic.
+type PanicSite struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Type of the value passed to panic.
+	Value         string    `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Location      *Location `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PanicSite) Reset() {
+	*x = PanicSite{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PanicSite) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PanicSite) ProtoMessage() {}
+
+func (x *PanicSite) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PanicSite.ProtoReflect.Descriptor instead.
+func (*PanicSite) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *PanicSite) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *PanicSite) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+// LockUsage is the use of one mutex or sync.Map by a function.
+type LockUsage struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	FunctionId string                 `protobuf:"bytes,1,opt,name=function_id,json=functionId,proto3" json:"function_id,omitempty"`
+	Function   string                 `protobuf:"bytes,2,opt,name=function,proto3" json:"function,omitempty"`
+	// Identified like ChannelOp.channel; empty when unknown.
+	Lock string `protobuf:"bytes,3,opt,name=lock,proto3" json:"lock,omitempty"`
+	// "Mutex", "RWMutex" or "Map".
+	Kind string    `protobuf:"bytes,4,opt,name=kind,proto3" json:"kind,omitempty"`
+	Ops  []*LockOp `protobuf:"bytes,5,rep,name=ops,proto3" json:"ops,omitempty"`
+	// Mutex methods called without their counterpart in the function.
+	Unpaired      []string `protobuf:"bytes,6,rep,name=unpaired,proto3" json:"unpaired,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LockUsage) Reset() {
+	*x = LockUsage{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LockUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LockUsage) ProtoMessage() {}
+
+func (x *LockUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LockUsage.ProtoReflect.Descriptor instead.
+func (*LockUsage) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *LockUsage) GetFunctionId() string {
+	if x != nil {
+		return x.FunctionId
+	}
+	return ""
+}
+
+func (x *LockUsage) GetFunction() string {
+	if x != nil {
+		return x.Function
+	}
+	return ""
+}
+
+func (x *LockUsage) GetLock() string {
+	if x != nil {
+		return x.Lock
+	}
+	return ""
+}
+
+func (x *LockUsage) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *LockUsage) GetOps() []*LockOp {
+	if x != nil {
+		return x.Ops
+	}
+	return nil
+}
+
+func (x *LockUsage) GetUnpaired() []string {
+	if x != nil {
+		return x.Unpaired
+	}
+	return nil
+}
+
+// LockOp is a method call on a mutex or sync.Map.
+type LockOp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Method        string                 `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	Deferred      bool                   `protobuf:"varint,2,opt,name=deferred,proto3" json:"deferred,omitempty"`
+	Location      *Location              `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LockOp) Reset() {
+	*x = LockOp{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LockOp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LockOp) ProtoMessage() {}
+
+func (x *LockOp) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LockOp.ProtoReflect.Descriptor instead.
+func (*LockOp) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *LockOp) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *LockOp) GetDeferred() bool {
+	if x != nil {
+		return x.Deferred
+	}
+	return false
+}
+
+func (x *LockOp) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+// ChannelOp is an operation on a channel inside a function.
+type ChannelOp struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// "Make", "Send", "Receive", "Close" or "Select".
+	Op         string `protobuf:"bytes,1,opt,name=op,proto3" json:"op,omitempty"`
+	FunctionId string `protobuf:"bytes,2,opt,name=function_id,json=functionId,proto3" json:"function_id,omitempty"`
+	Function   string `protobuf:"bytes,3,opt,name=function,proto3" json:"function,omitempty"`
+	// "pkg/path.Type.field", "pkg/path.name", "FunctionID(param)" or "FunctionID@line";
+	// empty when unknown and for selects.
+	Channel  string        `protobuf:"bytes,4,opt,name=channel,proto3" json:"channel,omitempty"`
+	ChanType string        `protobuf:"bytes,5,opt,name=chan_type,json=chanType,proto3" json:"chan_type,omitempty"`
+	Cases    []*SelectCase `protobuf:"bytes,6,rep,name=cases,proto3" json:"cases,omitempty"`
+	// A select without a default case.
+	Blocking      bool      `protobuf:"varint,7,opt,name=blocking,proto3" json:"blocking,omitempty"`
+	Location      *Location `protobuf:"bytes,8,opt,name=location,proto3" json:"location,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChannelOp) Reset() {
+	*x = ChannelOp{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChannelOp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelOp) ProtoMessage() {}
+
+func (x *ChannelOp) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelOp.ProtoReflect.Descriptor instead.
+func (*ChannelOp) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ChannelOp) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *ChannelOp) GetFunctionId() string {
+	if x != nil {
+		return x.FunctionId
+	}
+	return ""
+}
+
+func (x *ChannelOp) GetFunction() string {
+	if x != nil {
+		return x.Function
+	}
+	return ""
+}
+
+func (x *ChannelOp) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *ChannelOp) GetChanType() string {
+	if x != nil {
+		return x.ChanType
+	}
+	return ""
+}
+
+func (x *ChannelOp) GetCases() []*SelectCase {
+	if x != nil {
+		return x.Cases
+	}
+	return nil
+}
+
+func (x *ChannelOp) GetBlocking() bool {
+	if x != nil {
+		return x.Blocking
+	}
+	return false
+}
+
+func (x *ChannelOp) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+// SelectCase is a communication case of a select statement.
+type SelectCase struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// "Send" or "Receive".
+	Op            string `protobuf:"bytes,1,opt,name=op,proto3" json:"op,omitempty"`
+	Channel       string `protobuf:"bytes,2,opt,name=channel,proto3" json:"channel,omitempty"`
+	ChanType      string `protobuf:"bytes,3,opt,name=chan_type,json=chanType,proto3" json:"chan_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SelectCase) Reset() {
+	*x = SelectCase{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SelectCase) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SelectCase) ProtoMessage() {}
+
+func (x *SelectCase) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SelectCase.ProtoReflect.Descriptor instead.
+func (*SelectCase) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *SelectCase) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *SelectCase) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *SelectCase) GetChanType() string {
+	if x != nil {
+		return x.ChanType
+	}
+	return ""
+}
+
+// Goroutine is a go statement: the spawn site of a goroutine.
+type Goroutine struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Function.id of the function containing the go statement.
+	SpawnerId string `protobuf:"bytes,1,opt,name=spawner_id,json=spawnerId,proto3" json:"spawner_id,omitempty"`
+	// Function run by the goroutine, described like CallSite.callee_desc.
+	Target   string `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	TargetId string `protobuf:"bytes,3,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	// The target is a function literal.
+	Closure       bool      `protobuf:"varint,4,opt,name=closure,proto3" json:"closure,omitempty"`
+	Location      *Location `protobuf:"bytes,5,opt,name=location,proto3" json:"location,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Goroutine) Reset() {
+	*x = Goroutine{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Goroutine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Goroutine) ProtoMessage() {}
+
+func (x *Goroutine) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Goroutine.ProtoReflect.Descriptor instead.
+func (*Goroutine) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *Goroutine) GetSpawnerId() string {
+	if x != nil {
+		return x.SpawnerId
+	}
+	return ""
+}
+
+func (x *Goroutine) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *Goroutine) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+func (x *Goroutine) GetClosure() bool {
+	if x != nil {
+		return x.Closure
+	}
+	return false
+}
+
+func (x *Goroutine) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+// Function represents a package-level function or a method declared in Go source.
+type Function struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Name       string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	FullName   string                 `protobuf:"bytes,2,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
+	Receiver   string                 `protobuf:"bytes,3,opt,name=receiver,proto3" json:"receiver,omitempty"`
+	Signature  string                 `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+	DocComment string                 `protobuf:"bytes,5,opt,name=doc_comment,json=docComment,proto3" json:"doc_comment,omitempty"`
+	Exported   bool                   `protobuf:"varint,6,opt,name=exported,proto3" json:"exported,omitempty"`
+	Location   *Location              `protobuf:"bytes,7,opt,name=location,proto3" json:"location,omitempty"`
+	TypeParams []*TypeParam           `protobuf:"bytes,8,rep,name=type_params,json=typeParams,proto3" json:"type_params,omitempty"`
+	// Stable ID "pkgpath.Func" or "pkgpath.Type.Method", matching CallSite caller_id/callee_id.
+	Id string `protobuf:"bytes,9,opt,name=id,proto3" json:"id,omitempty"`
+	// Reachable from ProjectAnalysis.reachability_roots (--reachability).
+	Reachable bool `protobuf:"varint,10,opt,name=reachable,proto3" json:"reachable,omitempty"`
+	// Unset for functions without calls.
+	Centrality *FunctionCentrality `protobuf:"bytes,11,opt,name=centrality,proto3" json:"centrality,omitempty"`
+	// Canonical URI, e.g. go://example.com/mod/pkg#Type.Method.
+	Uri           string `protobuf:"bytes,12,opt,name=uri,proto3" json:"uri,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Function) Reset() {
+	*x = Function{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Function) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Function) ProtoMessage() {}
+
+func (x *Function) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Function.ProtoReflect.Descriptor instead.
+func (*Function) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *Function) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Function) GetFullName() string {
+	if x != nil {
+		return x.FullName
+	}
+	return ""
+}
+
+func (x *Function) GetReceiver() string {
+	if x != nil {
+		return x.Receiver
+	}
+	return ""
+}
+
+func (x *Function) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+func (x *Function) GetDocComment() string {
+	if x != nil {
+		return x.DocComment
+	}
+	return ""
+}
+
+func (x *Function) GetExported() bool {
+	if x != nil {
+		return x.Exported
+	}
+	return false
+}
+
+func (x *Function) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *Function) GetTypeParams() []*TypeParam {
+	if x != nil {
+		return x.TypeParams
+	}
+	return nil
+}
+
+func (x *Function) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Function) GetReachable() bool {
+	if x != nil {
+		return x.Reachable
+	}
+	return false
+}
+
+func (x *Function) GetCentrality() *FunctionCentrality {
+	if x != nil {
+		return x.Centrality
+	}
+	return nil
+}
+
+func (x *Function) GetUri() string {
+	if x != nil {
+		return x.Uri
+	}
+	return ""
+}
+
+// FunctionCentrality holds centrality measures of a function in the call graph.
+type FunctionCentrality struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	InDegree  int32                  `protobuf:"varint,1,opt,name=in_degree,json=inDegree,proto3" json:"in_degree,omitempty"`
+	OutDegree int32                  `protobuf:"varint,2,opt,name=out_degree,json=outDegree,proto3" json:"out_degree,omitempty"`
+	// 1 is the average over all functions; with --centrality=pagerank.
+	PageRank float64 `protobuf:"fixed64,3,opt,name=page_rank,json=pageRank,proto3" json:"page_rank,omitempty"`
+	// Shortest call paths through the function; with --centrality=betweenness.
+	Betweenness   float64 `protobuf:"fixed64,4,opt,name=betweenness,proto3" json:"betweenness,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FunctionCentrality) Reset() {
+	*x = FunctionCentrality{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FunctionCentrality) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FunctionCentrality) ProtoMessage() {}
+
+func (x *FunctionCentrality) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FunctionCentrality.ProtoReflect.Descriptor instead.
+func (*FunctionCentrality) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *FunctionCentrality) GetInDegree() int32 {
+	if x != nil {
+		return x.InDegree
+	}
+	return 0
+}
+
+func (x *FunctionCentrality) GetOutDegree() int32 {
+	if x != nil {
+		return x.OutDegree
+	}
+	return 0
+}
+
+func (x *FunctionCentrality) GetPageRank() float64 {
+	if x != nil {
+		return x.PageRank
+	}
+	return 0
+}
+
+func (x *FunctionCentrality) GetBetweenness() float64 {
+	if x != nil {
+		return x.Betweenness
+	}
+	return 0
+}
+
+// Value represents a package-level constant or variable.
+type Value struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Name       string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type       string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Value      string                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	Constant   string                 `protobuf:"bytes,4,opt,name=constant,proto3" json:"constant,omitempty"`
+	DocComment string                 `protobuf:"bytes,5,opt,name=doc_comment,json=docComment,proto3" json:"doc_comment,omitempty"`
+	Exported   bool                   `protobuf:"varint,6,opt,name=exported,proto3" json:"exported,omitempty"`
+	Location   *Location              `protobuf:"bytes,7,opt,name=location,proto3" json:"location,omitempty"`
+	// Canonical URI, e.g. go://example.com/mod/pkg#Type.Method.
+	Uri           string `protobuf:"bytes,8,opt,name=uri,proto3" json:"uri,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Value) Reset() {
+	*x = Value{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Value) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Value) ProtoMessage() {}
+
+func (x *Value) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Value.ProtoReflect.Descriptor instead.
+func (*Value) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *Value) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Value) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Value) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *Value) GetConstant() string {
+	if x != nil {
+		return x.Constant
+	}
+	return ""
+}
+
+func (x *Value) GetDocComment() string {
+	if x != nil {
+		return x.DocComment
+	}
+	return ""
+}
+
+func (x *Value) GetExported() bool {
+	if x != nil {
+		return x.Exported
+	}
+	return false
+}
+
+func (x *Value) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *Value) GetUri() string {
+	if x != nil {
+		return x.Uri
+	}
+	return ""
+}
+
+// NamedType represents a type alias or a defined non-struct, non-interface type.
+type NamedType struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Name        string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	PackageName string                 `protobuf:"bytes,2,opt,name=package_name,json=packageName,proto3" json:"package_name,omitempty"`
+	PackagePath string                 `protobuf:"bytes,3,opt,name=package_path,json=packagePath,proto3" json:"package_path,omitempty"`
+	Kind        string                 `protobuf:"bytes,4,opt,name=kind,proto3" json:"kind,omitempty"`
+	Underlying  string                 `protobuf:"bytes,5,opt,name=underlying,proto3" json:"underlying,omitempty"`
+	Target      string                 `protobuf:"bytes,6,opt,name=target,proto3" json:"target,omitempty"`
+	DocComment  string                 `protobuf:"bytes,7,opt,name=doc_comment,json=docComment,proto3" json:"doc_comment,omitempty"`
+	Exported    bool                   `protobuf:"varint,8,opt,name=exported,proto3" json:"exported,omitempty"`
+	Location    *Location              `protobuf:"bytes,9,opt,name=location,proto3" json:"location,omitempty"`
+	TypeParams  []*TypeParam           `protobuf:"bytes,10,rep,name=type_params,json=typeParams,proto3" json:"type_params,omitempty"`
+	// Canonical URI, e.g. go://example.com/mod/pkg#Type.Method.
+	Uri           string `protobuf:"bytes,11,opt,name=uri,proto3" json:"uri,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NamedType) Reset() {
+	*x = NamedType{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NamedType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NamedType) ProtoMessage() {}
+
+func (x *NamedType) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NamedType.ProtoReflect.Descriptor instead.
+func (*NamedType) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *NamedType) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *NamedType) GetPackageName() string {
+	if x != nil {
+		return x.PackageName
+	}
+	return ""
+}
+
+func (x *NamedType) GetPackagePath() string {
+	if x != nil {
+		return x.PackagePath
+	}
+	return ""
+}
+
+func (x *NamedType) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *NamedType) GetUnderlying() string {
+	if x != nil {
+		return x.Underlying
+	}
+	return ""
+}
+
+func (x *NamedType) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *NamedType) GetDocComment() string {
+	if x != nil {
+		return x.DocComment
+	}
+	return ""
+}
+
+func (x *NamedType) GetExported() bool {
+	if x != nil {
+		return x.Exported
+	}
+	return false
+}
+
+func (x *NamedType) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *NamedType) GetTypeParams() []*TypeParam {
+	if x != nil {
+		return x.TypeParams
+	}
+	return nil
+}
+
+func (x *NamedType) GetUri() string {
+	if x != nil {
+		return x.Uri
+	}
+	return ""
+}
+
+// Field represents one field of a struct type.
+type Field struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Name       string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type       string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Tag        string                 `protobuf:"bytes,3,opt,name=tag,proto3" json:"tag,omitempty"`
+	Embedded   bool                   `protobuf:"varint,4,opt,name=embedded,proto3" json:"embedded,omitempty"`
+	Exported   bool                   `protobuf:"varint,5,opt,name=exported,proto3" json:"exported,omitempty"`
+	DocComment string                 `protobuf:"bytes,6,opt,name=doc_comment,json=docComment,proto3" json:"doc_comment,omitempty"`
+	Location   *Location              `protobuf:"bytes,7,opt,name=location,proto3" json:"location,omitempty"`
+	// "Mutex", "RWMutex" or "Map" for sync.Mutex, sync.RWMutex and sync.Map fields.
+	Sync          string `protobuf:"bytes,8,opt,name=sync,proto3" json:"sync,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Field) Reset() {
+	*x = Field{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Field) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Field) ProtoMessage() {}
+
+func (x *Field) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Field.ProtoReflect.Descriptor instead.
+func (*Field) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *Field) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Field) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Field) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *Field) GetEmbedded() bool {
+	if x != nil {
+		return x.Embedded
+	}
+	return false
+}
+
+func (x *Field) GetExported() bool {
+	if x != nil {
+		return x.Exported
+	}
+	return false
+}
+
+func (x *Field) GetDocComment() string {
+	if x != nil {
+		return x.DocComment
+	}
+	return ""
+}
+
+func (x *Field) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *Field) GetSync() string {
+	if x != nil {
+		return x.Sync
+	}
+	return ""
+}
+
+// Struct represents a package-level struct type declaration.
+type Struct struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Name        string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	PackageName string                 `protobuf:"bytes,2,opt,name=package_name,json=packageName,proto3" json:"package_name,omitempty"`
+	PackagePath string                 `protobuf:"bytes,3,opt,name=package_path,json=packagePath,proto3" json:"package_path,omitempty"`
+	DocComment  string                 `protobuf:"bytes,4,opt,name=doc_comment,json=docComment,proto3" json:"doc_comment,omitempty"`
+	Fields      []*Field               `protobuf:"bytes,5,rep,name=fields,proto3" json:"fields,omitempty"`
+	Location    *Location              `protobuf:"bytes,6,opt,name=location,proto3" json:"location,omitempty"`
+	TypeParams  []*TypeParam           `protobuf:"bytes,7,rep,name=type_params,json=typeParams,proto3" json:"type_params,omitempty"`
+	// Canonical URI, e.g. go://example.com/mod/pkg#Type.Method.
+	Uri           string `protobuf:"bytes,8,opt,name=uri,proto3" json:"uri,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Struct) Reset() {
+	*x = Struct{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Struct) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Struct) ProtoMessage() {}
+
+func (x *Struct) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Struct.ProtoReflect.Descriptor instead.
+func (*Struct) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *Struct) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Struct) GetPackageName() string {
+	if x != nil {
+		return x.PackageName
+	}
+	return ""
+}
+
+func (x *Struct) GetPackagePath() string {
+	if x != nil {
+		return x.PackagePath
+	}
+	return ""
+}
+
+func (x *Struct) GetDocComment() string {
+	if x != nil {
+		return x.DocComment
+	}
+	return ""
+}
+
+func (x *Struct) GetFields() []*Field {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *Struct) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *Struct) GetTypeParams() []*TypeParam {
+	if x != nil {
+		return x.TypeParams
+	}
+	return nil
+}
+
+func (x *Struct) GetUri() string {
+	if x != nil {
+		return x.Uri
+	}
+	return ""
+}
+
+// CloneMember is one function participating in a clone group.
+type CloneMember struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Function      string                 `protobuf:"bytes,1,opt,name=function,proto3" json:"function,omitempty"`
+	PackagePath   string                 `protobuf:"bytes,2,opt,name=package_path,json=packagePath,proto3" json:"package_path,omitempty"`
+	Location      *Location              `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloneMember) Reset() {
+	*x = CloneMember{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloneMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloneMember) ProtoMessage() {}
+
+func (x *CloneMember) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloneMember.ProtoReflect.Descriptor instead.
+func (*CloneMember) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *CloneMember) GetFunction() string {
+	if x != nil {
+		return x.Function
+	}
+	return ""
+}
+
+func (x *CloneMember) GetPackagePath() string {
+	if x != nil {
+		return x.PackagePath
+	}
+	return ""
+}
+
+func (x *CloneMember) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+// CloneGroup is a set of structurally identical functions.
+type CloneGroup struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Fingerprint   string                 `protobuf:"bytes,1,opt,name=fingerprint,proto3" json:"fingerprint,omitempty"`
+	NodeCount     int32                  `protobuf:"varint,2,opt,name=node_count,json=nodeCount,proto3" json:"node_count,omitempty"`
+	Functions     []*CloneMember         `protobuf:"bytes,3,rep,name=functions,proto3" json:"functions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloneGroup) Reset() {
+	*x = CloneGroup{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloneGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloneGroup) ProtoMessage() {}
+
+func (x *CloneGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloneGroup.ProtoReflect.Descriptor instead.
+func (*CloneGroup) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *CloneGroup) GetFingerprint() string {
+	if x != nil {
+		return x.Fingerprint
+	}
+	return ""
+}
+
+func (x *CloneGroup) GetNodeCount() int32 {
+	if x != nil {
+		return x.NodeCount
+	}
+	return 0
+}
+
+func (x *CloneGroup) GetFunctions() []*CloneMember {
+	if x != nil {
+		return x.Functions
+	}
+	return nil
+}
+
+// RuleViolation is a dependency that breaks a declared architecture rule.
+type RuleViolation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rule          string                 `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"` // "Import" or "Call"
+	From          string                 `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	Detail        string                 `protobuf:"bytes,5,opt,name=detail,proto3" json:"detail,omitempty"`
+	Location      *Location              `protobuf:"bytes,6,opt,name=location,proto3" json:"location,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RuleViolation) Reset() {
+	*x = RuleViolation{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RuleViolation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RuleViolation) ProtoMessage() {}
+
+func (x *RuleViolation) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RuleViolation.ProtoReflect.Descriptor instead.
+func (*RuleViolation) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *RuleViolation) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *RuleViolation) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *RuleViolation) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *RuleViolation) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *RuleViolation) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+func (x *RuleViolation) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+// UnimplementedInterface is an interface without implementations in any loaded module.
+type UnimplementedInterface struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Interface     string                 `protobuf:"bytes,1,opt,name=interface,proto3" json:"interface,omitempty"`
+	Module        string                 `protobuf:"bytes,2,opt,name=module,proto3" json:"module,omitempty"`
+	Location      *Location              `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnimplementedInterface) Reset() {
+	*x = UnimplementedInterface{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnimplementedInterface) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnimplementedInterface) ProtoMessage() {}
+
+func (x *UnimplementedInterface) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnimplementedInterface.ProtoReflect.Descriptor instead.
+func (*UnimplementedInterface) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *UnimplementedInterface) GetInterface() string {
+	if x != nil {
+		return x.Interface
+	}
+	return ""
+}
+
+func (x *UnimplementedInterface) GetModule() string {
+	if x != nil {
+		return x.Module
+	}
+	return ""
+}
+
+func (x *UnimplementedInterface) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+// ExternalImplementation is a loaded type implementing an interface from a module that is not loaded.
+type ExternalImplementation struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TypeName        string                 `protobuf:"bytes,1,opt,name=type_name,json=typeName,proto3" json:"type_name,omitempty"`
+	PackagePath     string                 `protobuf:"bytes,2,opt,name=package_path,json=packagePath,proto3" json:"package_path,omitempty"`
+	Module          string                 `protobuf:"bytes,3,opt,name=module,proto3" json:"module,omitempty"`
+	Interface       string                 `protobuf:"bytes,4,opt,name=interface,proto3" json:"interface,omitempty"`
+	InterfaceModule string                 `protobuf:"bytes,5,opt,name=interface_module,json=interfaceModule,proto3" json:"interface_module,omitempty"`
+	IsPointer       bool                   `protobuf:"varint,6,opt,name=is_pointer,json=isPointer,proto3" json:"is_pointer,omitempty"`
+	Location        *Location              `protobuf:"bytes,7,opt,name=location,proto3" json:"location,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ExternalImplementation) Reset() {
+	*x = ExternalImplementation{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExternalImplementation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExternalImplementation) ProtoMessage() {}
+
+func (x *ExternalImplementation) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExternalImplementation.ProtoReflect.Descriptor instead.
+func (*ExternalImplementation) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ExternalImplementation) GetTypeName() string {
+	if x != nil {
+		return x.TypeName
+	}
+	return ""
+}
+
+func (x *ExternalImplementation) GetPackagePath() string {
+	if x != nil {
+		return x.PackagePath
+	}
+	return ""
+}
+
+func (x *ExternalImplementation) GetModule() string {
+	if x != nil {
+		return x.Module
+	}
+	return ""
+}
+
+func (x *ExternalImplementation) GetInterface() string {
+	if x != nil {
+		return x.Interface
+	}
+	return ""
+}
+
+func (x *ExternalImplementation) GetInterfaceModule() string {
+	if x != nil {
+		return x.InterfaceModule
+	}
+	return ""
+}
+
+func (x *ExternalImplementation) GetIsPointer() bool {
+	if x != nil {
+		return x.IsPointer
+	}
+	return false
+}
+
+func (x *ExternalImplementation) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+// AdapterGaps lists interface/implementation pairs split across module boundaries.
+type AdapterGaps struct {
+	state                   protoimpl.MessageState    `protogen:"open.v1"`
+	UnimplementedInterfaces []*UnimplementedInterface `protobuf:"bytes,1,rep,name=unimplemented_interfaces,json=unimplementedInterfaces,proto3" json:"unimplemented_interfaces,omitempty"`
+	ExternalImplementations []*ExternalImplementation `protobuf:"bytes,2,rep,name=external_implementations,json=externalImplementations,proto3" json:"external_implementations,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *AdapterGaps) Reset() {
+	*x = AdapterGaps{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdapterGaps) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdapterGaps) ProtoMessage() {}
+
+func (x *AdapterGaps) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdapterGaps.ProtoReflect.Descriptor instead.
+func (*AdapterGaps) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *AdapterGaps) GetUnimplementedInterfaces() []*UnimplementedInterface {
+	if x != nil {
+		return x.UnimplementedInterfaces
+	}
+	return nil
+}
+
+func (x *AdapterGaps) GetExternalImplementations() []*ExternalImplementation {
+	if x != nil {
+		return x.ExternalImplementations
+	}
+	return nil
+}
+
+// MethodMismatch is an interface method a type fails to provide.
+type MethodMismatch struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Signature required by the interface.
+	Want string `protobuf:"bytes,2,opt,name=want,proto3" json:"want,omitempty"`
+	// Type of the conflicting method or field, if any.
+	Have string `protobuf:"bytes,3,opt,name=have,proto3" json:"have,omitempty"`
+	// One of "Missing", "Signature", "NotMethod".
+	Reason        string    `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	Location      *Location `protobuf:"bytes,5,opt,name=location,proto3" json:"location,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MethodMismatch) Reset() {
+	*x = MethodMismatch{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MethodMismatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MethodMismatch) ProtoMessage() {}
+
+func (x *MethodMismatch) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MethodMismatch.ProtoReflect.Descriptor instead.
+func (*MethodMismatch) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *MethodMismatch) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MethodMismatch) GetWant() string {
+	if x != nil {
+		return x.Want
+	}
+	return ""
+}
+
+func (x *MethodMismatch) GetHave() string {
+	if x != nil {
+		return x.Have
+	}
+	return ""
+}
+
+func (x *MethodMismatch) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *MethodMismatch) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+// NearMiss is a type that lacks only a few methods of an interface.
+type NearMiss struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Interface     string                 `protobuf:"bytes,1,opt,name=interface,proto3" json:"interface,omitempty"`
+	TypeName      string                 `protobuf:"bytes,2,opt,name=type_name,json=typeName,proto3" json:"type_name,omitempty"`
+	PackagePath   string                 `protobuf:"bytes,3,opt,name=package_path,json=packagePath,proto3" json:"package_path,omitempty"`
+	Missing       []*MethodMismatch      `protobuf:"bytes,4,rep,name=missing,proto3" json:"missing,omitempty"`
+	Location      *Location              `protobuf:"bytes,5,opt,name=location,proto3" json:"location,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NearMiss) Reset() {
+	*x = NearMiss{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NearMiss) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NearMiss) ProtoMessage() {}
+
+func (x *NearMiss) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NearMiss.ProtoReflect.Descriptor instead.
+func (*NearMiss) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *NearMiss) GetInterface() string {
+	if x != nil {
+		return x.Interface
+	}
+	return ""
+}
+
+func (x *NearMiss) GetTypeName() string {
+	if x != nil {
+		return x.TypeName
+	}
+	return ""
+}
+
+func (x *NearMiss) GetPackagePath() string {
+	if x != nil {
+		return x.PackagePath
+	}
+	return ""
+}
+
+func (x *NearMiss) GetMissing() []*MethodMismatch {
+	if x != nil {
+		return x.Missing
+	}
+	return nil
+}
+
+func (x *NearMiss) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+// Findings holds project-wide observations meant to guide refactoring.
+type Findings struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Clones         []*CloneGroup          `protobuf:"bytes,1,rep,name=clones,proto3" json:"clones,omitempty"`
+	RuleViolations []*RuleViolation       `protobuf:"bytes,2,rep,name=rule_violations,json=ruleViolations,proto3" json:"rule_violations,omitempty"`
+	AdapterGaps    *AdapterGaps           `protobuf:"bytes,3,opt,name=adapter_gaps,json=adapterGaps,proto3" json:"adapter_gaps,omitempty"`
+	NearMisses     []*NearMiss            `protobuf:"bytes,4,rep,name=near_misses,json=nearMisses,proto3" json:"near_misses,omitempty"`
+	ImportCycles   []*ImportCycle         `protobuf:"bytes,5,rep,name=import_cycles,json=importCycles,proto3" json:"import_cycles,omitempty"`
+	// Interfaces ("pkg/path.Name") whose usage is fat.
+	FatInterfaces []string `protobuf:"bytes,6,rep,name=fat_interfaces,json=fatInterfaces,proto3" json:"fat_interfaces,omitempty"`
+	// Groups of interfaces in different packages with identical or nested method sets.
+	DuplicateInterfaces []*InterfaceCluster `protobuf:"bytes,7,rep,name=duplicate_interfaces,json=duplicateInterfaces,proto3" json:"duplicate_interfaces,omitempty"`
+	// Exported functions that can panic without recovering.
+	UnrecoveredPanics []*UnrecoveredPanic `protobuf:"bytes,8,rep,name=unrecovered_panics,json=unrecoveredPanics,proto3" json:"unrecovered_panics,omitempty"`
+	// Exported functions no test or fuzz target exercises.
+	UntestedFunctions []string `protobuf:"bytes,9,rep,name=untested_functions,json=untestedFunctions,proto3" json:"untested_functions,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Findings) Reset() {
+	*x = Findings{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Findings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Findings) ProtoMessage() {}
+
+func (x *Findings) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Findings.ProtoReflect.Descriptor instead.
+func (*Findings) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *Findings) GetClones() []*CloneGroup {
+	if x != nil {
+		return x.Clones
+	}
+	return nil
+}
+
+func (x *Findings) GetRuleViolations() []*RuleViolation {
+	if x != nil {
+		return x.RuleViolations
+	}
+	return nil
+}
+
+func (x *Findings) GetAdapterGaps() *AdapterGaps {
+	if x != nil {
+		return x.AdapterGaps
+	}
+	return nil
+}
+
+func (x *Findings) GetNearMisses() []*NearMiss {
+	if x != nil {
+		return x.NearMisses
+	}
+	return nil
+}
+
+func (x *Findings) GetImportCycles() []*ImportCycle {
+	if x != nil {
+		return x.ImportCycles
+	}
+	return nil
+}
+
+func (x *Findings) GetFatInterfaces() []string {
+	if x != nil {
+		return x.FatInterfaces
+	}
+	return nil
+}
+
+func (x *Findings) GetDuplicateInterfaces() []*InterfaceCluster {
+	if x != nil {
+		return x.DuplicateInterfaces
+	}
+	return nil
+}
+
+func (x *Findings) GetUnrecoveredPanics() []*UnrecoveredPanic {
+	if x != nil {
+		return x.UnrecoveredPanics
+	}
+	return nil
+}
+
+func (x *Findings) GetUntestedFunctions() []string {
+	if x != nil {
+		return x.UntestedFunctions
+	}
+	return nil
+}
+
+// UnrecoveredPanic is an exported function that can panic without recovering.
+type UnrecoveredPanic struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	FunctionId string                 `protobuf:"bytes,1,opt,name=function_id,json=functionId,proto3" json:"function_id,omitempty"`
+	// Calls leading to the function calling panic; empty if it calls panic itself.
+	Steps         []*CallStep `protobuf:"bytes,2,rep,name=steps,proto3" json:"steps,omitempty"`
+	Panic         *PanicSite  `protobuf:"bytes,3,opt,name=panic,proto3" json:"panic,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnrecoveredPanic) Reset() {
+	*x = UnrecoveredPanic{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnrecoveredPanic) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnrecoveredPanic) ProtoMessage() {}
+
+func (x *UnrecoveredPanic) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnrecoveredPanic.ProtoReflect.Descriptor instead.
+func (*UnrecoveredPanic) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *UnrecoveredPanic) GetFunctionId() string {
+	if x != nil {
+		return x.FunctionId
+	}
+	return ""
+}
+
+func (x *UnrecoveredPanic) GetSteps() []*CallStep {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *UnrecoveredPanic) GetPanic() *PanicSite {
+	if x != nil {
+		return x.Panic
+	}
+	return nil
+}
+
+// CallStep is one call of a call path.
+type CallStep struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	CallerId string                 `protobuf:"bytes,1,opt,name=caller_id,json=callerId,proto3" json:"caller_id,omitempty"`
+	CalleeId string                 `protobuf:"bytes,2,opt,name=callee_id,json=calleeId,proto3" json:"callee_id,omitempty"`
+	// The call site's call type, or "Closure" from a function to a closure it creates.
+	CallType      string    `protobuf:"bytes,3,opt,name=call_type,json=callType,proto3" json:"call_type,omitempty"`
+	Location      *Location `protobuf:"bytes,4,opt,name=location,proto3" json:"location,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CallStep) Reset() {
+	*x = CallStep{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CallStep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CallStep) ProtoMessage() {}
+
+func (x *CallStep) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CallStep.ProtoReflect.Descriptor instead.
+func (*CallStep) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *CallStep) GetCallerId() string {
+	if x != nil {
+		return x.CallerId
+	}
+	return ""
+}
+
+func (x *CallStep) GetCalleeId() string {
+	if x != nil {
+		return x.CalleeId
+	}
+	return ""
+}
+
+func (x *CallStep) GetCallType() string {
+	if x != nil {
+		return x.CallType
+	}
+	return ""
+}
+
+func (x *CallStep) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+// InterfaceCluster is a group of interfaces linked by identical or nested method sets.
+type InterfaceCluster struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Interfaces    []string               `protobuf:"bytes,1,rep,name=interfaces,proto3" json:"interfaces,omitempty"`
+	Relations     []*InterfaceRelation   `protobuf:"bytes,2,rep,name=relations,proto3" json:"relations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InterfaceCluster) Reset() {
+	*x = InterfaceCluster{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InterfaceCluster) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InterfaceCluster) ProtoMessage() {}
+
+func (x *InterfaceCluster) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InterfaceCluster.ProtoReflect.Descriptor instead.
+func (*InterfaceCluster) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *InterfaceCluster) GetInterfaces() []string {
+	if x != nil {
+		return x.Interfaces
+	}
+	return nil
+}
+
+func (x *InterfaceCluster) GetRelations() []*InterfaceRelation {
+	if x != nil {
+		return x.Relations
+	}
+	return nil
+}
+
+// InterfaceRelation links two interfaces of an InterfaceCluster.
+type InterfaceRelation struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Interface string                 `protobuf:"bytes,1,opt,name=interface,proto3" json:"interface,omitempty"`
+	Other     string                 `protobuf:"bytes,2,opt,name=other,proto3" json:"other,omitempty"`
+	// "Identical", or "Subset" when every method of interface is a method of other.
+	Relation      string `protobuf:"bytes,3,opt,name=relation,proto3" json:"relation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InterfaceRelation) Reset() {
+	*x = InterfaceRelation{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InterfaceRelation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InterfaceRelation) ProtoMessage() {}
+
+func (x *InterfaceRelation) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InterfaceRelation.ProtoReflect.Descriptor instead.
+func (*InterfaceRelation) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *InterfaceRelation) GetInterface() string {
+	if x != nil {
+		return x.Interface
+	}
+	return ""
+}
+
+func (x *InterfaceRelation) GetOther() string {
+	if x != nil {
+		return x.Other
+	}
+	return ""
+}
+
+func (x *InterfaceRelation) GetRelation() string {
+	if x != nil {
+		return x.Relation
+	}
+	return ""
+}
+
+// ImportEdge is one import declared by a package's files.
+type ImportEdge struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Importer string                 `protobuf:"bytes,1,opt,name=importer,proto3" json:"importer,omitempty"`
+	Imported string                 `protobuf:"bytes,2,opt,name=imported,proto3" json:"imported,omitempty"`
+	// Only imported by _test.go files.
+	TestOnly      bool      `protobuf:"varint,3,opt,name=test_only,json=testOnly,proto3" json:"test_only,omitempty"`
+	Location      *Location `protobuf:"bytes,4,opt,name=location,proto3" json:"location,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportEdge) Reset() {
+	*x = ImportEdge{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportEdge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportEdge) ProtoMessage() {}
+
+func (x *ImportEdge) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportEdge.ProtoReflect.Descriptor instead.
+func (*ImportEdge) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ImportEdge) GetImporter() string {
+	if x != nil {
+		return x.Importer
+	}
+	return ""
+}
+
+func (x *ImportEdge) GetImported() string {
+	if x != nil {
+		return x.Imported
+	}
+	return ""
+}
+
+func (x *ImportEdge) GetTestOnly() bool {
+	if x != nil {
+		return x.TestOnly
+	}
+	return false
+}
+
+func (x *ImportEdge) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+// ImportCycle is a set of packages that import each other, directly or indirectly.
+type ImportCycle struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Packages []string               `protobuf:"bytes,1,rep,name=packages,proto3" json:"packages,omitempty"`
+	// One shortest cycle through the packages.
+	Imports []*CycleImport `protobuf:"bytes,2,rep,name=imports,proto3" json:"imports,omitempty"`
+	// Only imports of _test.go files close the cycle.
+	TestOnly      bool `protobuf:"varint,3,opt,name=test_only,json=testOnly,proto3" json:"test_only,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportCycle) Reset() {
+	*x = ImportCycle{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportCycle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportCycle) ProtoMessage() {}
+
+func (x *ImportCycle) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportCycle.ProtoReflect.Descriptor instead.
+func (*ImportCycle) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ImportCycle) GetPackages() []string {
+	if x != nil {
+		return x.Packages
+	}
+	return nil
+}
+
+func (x *ImportCycle) GetImports() []*CycleImport {
+	if x != nil {
+		return x.Imports
+	}
+	return nil
+}
+
+func (x *ImportCycle) GetTestOnly() bool {
+	if x != nil {
+		return x.TestOnly
+	}
+	return false
+}
+
+// CycleImport is one import of an ImportCycle.
+type CycleImport struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	From  string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To    string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	// Declared in _test.go files only.
+	Test          bool      `protobuf:"varint,3,opt,name=test,proto3" json:"test,omitempty"`
+	Location      *Location `protobuf:"bytes,4,opt,name=location,proto3" json:"location,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CycleImport) Reset() {
+	*x = CycleImport{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CycleImport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CycleImport) ProtoMessage() {}
+
+func (x *CycleImport) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CycleImport.ProtoReflect.Descriptor instead.
+func (*CycleImport) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *CycleImport) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *CycleImport) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *CycleImport) GetTest() bool {
+	if x != nil {
+		return x.Test
+	}
+	return false
+}
+
+func (x *CycleImport) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+// ProjectAnalysis holds the analysis results for all packages in the project.
+type ProjectAnalysis struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	ModulePath   string                 `protobuf:"bytes,1,opt,name=module_path,json=modulePath,proto3" json:"module_path,omitempty"`
+	ModuleDir    string                 `protobuf:"bytes,2,opt,name=module_dir,json=moduleDir,proto3" json:"module_dir,omitempty"`
+	Packages     []*PackageAnalysis     `protobuf:"bytes,3,rep,name=packages,proto3" json:"packages,omitempty"`
+	Findings     *Findings              `protobuf:"bytes,4,opt,name=findings,proto3" json:"findings,omitempty"`
+	Dependencies []*DependencyPackage   `protobuf:"bytes,5,rep,name=dependencies,proto3" json:"dependencies,omitempty"`
+	// Standard library interfaces checked with --std-interfaces.
+	StdlibInterfaces []*Interface `protobuf:"bytes,6,rep,name=stdlib_interfaces,json=stdlibInterfaces,proto3" json:"stdlib_interfaces,omitempty"`
+	// Implementations linking the analyzed modules and their dependencies (--cross-module).
+	CrossModuleImplementations []*ExternalImplementation `protobuf:"bytes,7,rep,name=cross_module_implementations,json=crossModuleImplementations,proto3" json:"cross_module_implementations,omitempty"`
+	// Root kinds Function.reachable was computed from (--reachability).
+	ReachabilityRoots []string `protobuf:"bytes,8,rep,name=reachability_roots,json=reachabilityRoots,proto3" json:"reachability_roots,omitempty"`
+	// Modules of the analyzed packages and the modules they depend on.
+	ModuleGraph *ModuleGraph `protobuf:"bytes,9,opt,name=module_graph,json=moduleGraph,proto3" json:"module_graph,omitempty"`
+	// Analyzed packages in the order a program importing them all initializes them.
+	InitOrder []string `protobuf:"bytes,10,rep,name=init_order,json=initOrder,proto3" json:"init_order,omitempty"`
+	// Tests, benchmarks, fuzz targets and examples of the analyzed packages.
+	Tests []*TestFunction `protobuf:"bytes,11,rep,name=tests,proto3" json:"tests,omitempty"`
+	// Version of the datamodel the result follows.
+	SchemaVersion string `protobuf:"bytes,12,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	// Lines and columns count from 0 (--zero-based).
+	ZeroBased     bool `protobuf:"varint,13,opt,name=zero_based,json=zeroBased,proto3" json:"zero_based,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProjectAnalysis) Reset() {
+	*x = ProjectAnalysis{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProjectAnalysis) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProjectAnalysis) ProtoMessage() {}
+
+func (x *ProjectAnalysis) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProjectAnalysis.ProtoReflect.Descriptor instead.
+func (*ProjectAnalysis) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ProjectAnalysis) GetModulePath() string {
+	if x != nil {
+		return x.ModulePath
+	}
+	return ""
+}
+
+func (x *ProjectAnalysis) GetModuleDir() string {
+	if x != nil {
+		return x.ModuleDir
+	}
+	return ""
+}
+
+func (x *ProjectAnalysis) GetPackages() []*PackageAnalysis {
+	if x != nil {
+		return x.Packages
+	}
+	return nil
+}
+
+func (x *ProjectAnalysis) GetFindings() *Findings {
+	if x != nil {
+		return x.Findings
+	}
+	return nil
+}
+
+func (x *ProjectAnalysis) GetDependencies() []*DependencyPackage {
+	if x != nil {
+		return x.Dependencies
+	}
+	return nil
+}
+
+func (x *ProjectAnalysis) GetStdlibInterfaces() []*Interface {
+	if x != nil {
+		return x.StdlibInterfaces
+	}
+	return nil
+}
+
+func (x *ProjectAnalysis) GetCrossModuleImplementations() []*ExternalImplementation {
+	if x != nil {
+		return x.CrossModuleImplementations
+	}
+	return nil
+}
+
+func (x *ProjectAnalysis) GetReachabilityRoots() []string {
+	if x != nil {
+		return x.ReachabilityRoots
+	}
+	return nil
+}
+
+func (x *ProjectAnalysis) GetModuleGraph() *ModuleGraph {
+	if x != nil {
+		return x.ModuleGraph
+	}
+	return nil
+}
+
+func (x *ProjectAnalysis) GetInitOrder() []string {
+	if x != nil {
+		return x.InitOrder
+	}
+	return nil
+}
+
+func (x *ProjectAnalysis) GetTests() []*TestFunction {
+	if x != nil {
+		return x.Tests
+	}
+	return nil
+}
+
+func (x *ProjectAnalysis) GetSchemaVersion() string {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return ""
+}
+
+func (x *ProjectAnalysis) GetZeroBased() bool {
+	if x != nil {
+		return x.ZeroBased
+	}
+	return false
+}
+
+// TestFunction is a test, benchmark, fuzz target or example declared in a _test.go file.
+type TestFunction struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	FunctionId string                 `protobuf:"bytes,1,opt,name=function_id,json=functionId,proto3" json:"function_id,omitempty"`
+	// "Test", "Benchmark", "Fuzz" or "Example".
+	Kind        string    `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	PackagePath string    `protobuf:"bytes,3,opt,name=package_path,json=packagePath,proto3" json:"package_path,omitempty"`
+	Location    *Location `protobuf:"bytes,4,opt,name=location,proto3" json:"location,omitempty"`
+	// IDs of the functions outside _test.go files the test reaches in the call graph.
+	Exercises     []string `protobuf:"bytes,5,rep,name=exercises,proto3" json:"exercises,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TestFunction) Reset() {
+	*x = TestFunction{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestFunction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestFunction) ProtoMessage() {}
+
+func (x *TestFunction) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestFunction.ProtoReflect.Descriptor instead.
+func (*TestFunction) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *TestFunction) GetFunctionId() string {
+	if x != nil {
+		return x.FunctionId
+	}
+	return ""
+}
+
+func (x *TestFunction) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *TestFunction) GetPackagePath() string {
+	if x != nil {
+		return x.PackagePath
+	}
+	return ""
+}
+
+func (x *TestFunction) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *TestFunction) GetExercises() []string {
+	if x != nil {
+		return x.Exercises
+	}
+	return nil
+}
+
+// ModuleGraph describes the analyzed (main) modules and the modules they depend on.
+type ModuleGraph struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Modules       []*ModuleNode          `protobuf:"bytes,1,rep,name=modules,proto3" json:"modules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModuleGraph) Reset() {
+	*x = ModuleGraph{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModuleGraph) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModuleGraph) ProtoMessage() {}
+
+func (x *ModuleGraph) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModuleGraph.ProtoReflect.Descriptor instead.
+func (*ModuleGraph) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ModuleGraph) GetModules() []*ModuleNode {
+	if x != nil {
+		return x.Modules
+	}
+	return nil
+}
+
+// ModuleNode is a module of the ModuleGraph.
+type ModuleNode struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Path  string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// Selected version; empty for main modules.
+	Version   string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Main      bool   `protobuf:"varint,3,opt,name=main,proto3" json:"main,omitempty"`
+	GoVersion string `protobuf:"bytes,4,opt,name=go_version,json=goVersion,proto3" json:"go_version,omitempty"`
+	// Replacement: "path@version" or a local directory.
+	Replace string `protobuf:"bytes,5,opt,name=replace,proto3" json:"replace,omitempty"`
+	// Every main module requiring it marks it "// indirect".
+	Indirect bool `protobuf:"varint,6,opt,name=indirect,proto3" json:"indirect,omitempty"`
+	// Main modules whose go.mod requires it.
+	RequiredBy []string `protobuf:"bytes,7,rep,name=required_by,json=requiredBy,proto3" json:"required_by,omitempty"`
+	// Modules whose packages its packages import.
+	Imports []string `protobuf:"bytes,8,rep,name=imports,proto3" json:"imports,omitempty"`
+	// Analyzed packages importing packages of the module directly.
+	Importers     []string `protobuf:"bytes,9,rep,name=importers,proto3" json:"importers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModuleNode) Reset() {
+	*x = ModuleNode{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModuleNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModuleNode) ProtoMessage() {}
+
+func (x *ModuleNode) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModuleNode.ProtoReflect.Descriptor instead.
+func (*ModuleNode) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ModuleNode) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ModuleNode) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *ModuleNode) GetMain() bool {
+	if x != nil {
+		return x.Main
+	}
+	return false
+}
+
+func (x *ModuleNode) GetGoVersion() string {
+	if x != nil {
+		return x.GoVersion
+	}
+	return ""
+}
+
+func (x *ModuleNode) GetReplace() string {
+	if x != nil {
+		return x.Replace
+	}
+	return ""
+}
+
+func (x *ModuleNode) GetIndirect() bool {
+	if x != nil {
+		return x.Indirect
+	}
+	return false
+}
+
+func (x *ModuleNode) GetRequiredBy() []string {
+	if x != nil {
+		return x.RequiredBy
+	}
+	return nil
+}
+
+func (x *ModuleNode) GetImports() []string {
+	if x != nil {
+		return x.Imports
+	}
+	return nil
+}
+
+func (x *ModuleNode) GetImporters() []string {
+	if x != nil {
+		return x.Importers
+	}
+	return nil
+}
+
+// DependencyPackage holds the exported interfaces of a package from a dependency module.
+type DependencyPackage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Module        string                 `protobuf:"bytes,3,opt,name=module,proto3" json:"module,omitempty"`
+	Version       string                 `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	Interfaces    []*Interface           `protobuf:"bytes,5,rep,name=interfaces,proto3" json:"interfaces,omitempty"`
+	Vendored      bool                   `protobuf:"varint,6,opt,name=vendored,proto3" json:"vendored,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DependencyPackage) Reset() {
+	*x = DependencyPackage{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DependencyPackage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DependencyPackage) ProtoMessage() {}
+
+func (x *DependencyPackage) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DependencyPackage.ProtoReflect.Descriptor instead.
+func (*DependencyPackage) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *DependencyPackage) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DependencyPackage) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *DependencyPackage) GetModule() string {
+	if x != nil {
+		return x.Module
+	}
+	return ""
+}
+
+func (x *DependencyPackage) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *DependencyPackage) GetInterfaces() []*Interface {
+	if x != nil {
+		return x.Interfaces
+	}
+	return nil
+}
+
+func (x *DependencyPackage) GetVendored() bool {
+	if x != nil {
+		return x.Vendored
+	}
+	return false
+}
+
+type GetProjectAnalysisRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProjectAnalysisRequest) Reset() {
+	*x = GetProjectAnalysisRequest{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProjectAnalysisRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProjectAnalysisRequest) ProtoMessage() {}
+
+func (x *GetProjectAnalysisRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProjectAnalysisRequest.ProtoReflect.Descriptor instead.
+func (*GetProjectAnalysisRequest) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{55}
+}
+
+type StreamPackagesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional import path filter; empty streams every package.
+	Path          string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamPackagesRequest) Reset() {
+	*x = StreamPackagesRequest{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamPackagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamPackagesRequest) ProtoMessage() {}
+
+func (x *StreamPackagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamPackagesRequest.ProtoReflect.Descriptor instead.
+func (*StreamPackagesRequest) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *StreamPackagesRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type StreamCallsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional substring filters on CallSite.caller_func_desc and callee_desc.
+	Caller        string `protobuf:"bytes,1,opt,name=caller,proto3" json:"caller,omitempty"`
+	Callee        string `protobuf:"bytes,2,opt,name=callee,proto3" json:"callee,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamCallsRequest) Reset() {
+	*x = StreamCallsRequest{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamCallsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamCallsRequest) ProtoMessage() {}
+
+func (x *StreamCallsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamCallsRequest.ProtoReflect.Descriptor instead.
+func (*StreamCallsRequest) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *StreamCallsRequest) GetCaller() string {
+	if x != nil {
+		return x.Caller
+	}
+	return ""
+}
+
+func (x *StreamCallsRequest) GetCallee() string {
+	if x != nil {
+		return x.Callee
+	}
+	return ""
+}
+
+type SearchSymbolsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Fuzzy query; its characters must appear in order in the symbol name.
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// Optional kinds to restrict to: Func, Method, Type or Interface.
+	Kinds []string `protobuf:"bytes,2,rep,name=kinds,proto3" json:"kinds,omitempty"`
+	// Maximum number of matches; 0 means the default of 20.
+	Limit         int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchSymbolsRequest) Reset() {
+	*x = SearchSymbolsRequest{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchSymbolsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchSymbolsRequest) ProtoMessage() {}
+
+func (x *SearchSymbolsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchSymbolsRequest.ProtoReflect.Descriptor instead.
+func (*SearchSymbolsRequest) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *SearchSymbolsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchSymbolsRequest) GetKinds() []string {
+	if x != nil {
+		return x.Kinds
+	}
+	return nil
+}
+
+func (x *SearchSymbolsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type SymbolMatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Kind          string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	Package       string                 `protobuf:"bytes,4,opt,name=package,proto3" json:"package,omitempty"`
+	Score         int32                  `protobuf:"varint,5,opt,name=score,proto3" json:"score,omitempty"`
+	Location      *Location              `protobuf:"bytes,6,opt,name=location,proto3" json:"location,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SymbolMatch) Reset() {
+	*x = SymbolMatch{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SymbolMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SymbolMatch) ProtoMessage() {}
+
+func (x *SymbolMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SymbolMatch.ProtoReflect.Descriptor instead.
+func (*SymbolMatch) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *SymbolMatch) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SymbolMatch) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SymbolMatch) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *SymbolMatch) GetPackage() string {
+	if x != nil {
+		return x.Package
+	}
+	return ""
+}
+
+func (x *SymbolMatch) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *SymbolMatch) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+type SearchSymbolsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Best match first.
+	Matches       []*SymbolMatch `protobuf:"bytes,1,rep,name=matches,proto3" json:"matches,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchSymbolsResponse) Reset() {
+	*x = SearchSymbolsResponse{}
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchSymbolsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchSymbolsResponse) ProtoMessage() {}
+
+func (x *SearchSymbolsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gomcp_v1_analysis_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchSymbolsResponse.ProtoReflect.Descriptor instead.
+func (*SearchSymbolsResponse) Descriptor() ([]byte, []int) {
+	return file_gomcp_v1_analysis_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *SearchSymbolsResponse) GetMatches() []*SymbolMatch {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+var File_gomcp_v1_analysis_proto protoreflect.FileDescriptor
+
+const file_gomcp_v1_analysis_proto_rawDesc = "" +
+	"\n" +
+	"\x17gomcp/v1/analysis.proto\x12\bgomcp.v1\"\xc3\x01\n" +
+	"\bLocation\x12\x1a\n" +
+	"\bfilename\x18\x01 \x01(\tR\bfilename\x12\x12\n" +
+	"\x04line\x18\x02 \x01(\x05R\x04line\x12\x16\n" +
+	"\x06column\x18\x03 \x01(\x05R\x06column\x12\x19\n" +
+	"\bend_line\x18\x04 \x01(\x05R\aendLine\x12\x1d\n" +
+	"\n" +
+	"end_column\x18\x05 \x01(\x05R\tendColumn\x12\x16\n" +
+	"\x06offset\x18\x06 \x01(\x05R\x06offset\x12\x1d\n" +
+	"\n" +
+	"end_offset\x18\a \x01(\x05R\tendOffset\"R\n" +
+	"\tParameter\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x1d\n" +
+	"\n" +
+	"is_pointer\x18\x03 \x01(\bR\tisPointer\"?\n" +
+	"\tTypeParam\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1e\n" +
+	"\n" +
+	"constraint\x18\x02 \x01(\tR\n" +
+	"constraint\"\xcc\x02\n" +
+	"\x06Method\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tsignature\x18\x02 \x01(\tR\tsignature\x123\n" +
+	"\n" +
+	"parameters\x18\x03 \x03(\v2\x13.gomcp.v1.ParameterR\n" +
+	"parameters\x12!\n" +
+	"\freturn_types\x18\x04 \x03(\tR\vreturnTypes\x12\x1f\n" +
+	"\vdoc_comment\x18\x05 \x01(\tR\n" +
+	"docComment\x12.\n" +
+	"\blocation\x18\x06 \x01(\v2\x12.gomcp.v1.LocationR\blocation\x124\n" +
+	"\vtype_params\x18\a \x03(\v2\x13.gomcp.v1.TypeParamR\n" +
+	"typeParams\x12\x1f\n" +
+	"\vdeclared_by\x18\b \x01(\tR\n" +
+	"declaredBy\x12\x10\n" +
+	"\x03uri\x18\t \x01(\tR\x03uri\"\x85\x02\n" +
+	"\x0eImplementation\x12\x1b\n" +
+	"\ttype_name\x18\x01 \x01(\tR\btypeName\x12!\n" +
+	"\fpackage_path\x18\x02 \x01(\tR\vpackagePath\x12!\n" +
+	"\fpackage_name\x18\x03 \x01(\tR\vpackageName\x12\x1d\n" +
+	"\n" +
+	"is_pointer\x18\x04 \x01(\bR\tisPointer\x12.\n" +
+	"\blocation\x18\x05 \x01(\v2\x12.gomcp.v1.LocationR\blocation\x12/\n" +
+	"\amethods\x18\x06 \x03(\v2\x15.gomcp.v1.MethodMatchR\amethods\x12\x10\n" +
+	"\x03uri\x18\a \x01(\tR\x03uri\"\xc6\x01\n" +
+	"\vMethodMatch\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tsignature\x18\x02 \x01(\tR\tsignature\x12)\n" +
+	"\x10pointer_receiver\x18\x03 \x01(\bR\x0fpointerReceiver\x12\x1a\n" +
+	"\bpromoted\x18\x04 \x01(\bR\bpromoted\x12.\n" +
+	"\blocation\x18\x05 \x01(\v2\x12.gomcp.v1.LocationR\blocation\x12\x0e\n" +
+	"\x02id\x18\x06 \x01(\tR\x02id\"\xac\x04\n" +
+	"\tInterface\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12!\n" +
+	"\fpackage_name\x18\x02 \x01(\tR\vpackageName\x12!\n" +
+	"\fpackage_path\x18\x03 \x01(\tR\vpackagePath\x12.\n" +
+	"\blocation\x18\x04 \x01(\v2\x12.gomcp.v1.LocationR\blocation\x12\x1f\n" +
+	"\vdoc_comment\x18\x05 \x01(\tR\n" +
+	"docComment\x12*\n" +
+	"\amethods\x18\x06 \x03(\v2\x10.gomcp.v1.MethodR\amethods\x12\x16\n" +
+	"\x06embeds\x18\a \x03(\tR\x06embeds\x12B\n" +
+	"\x0fimplementations\x18\b \x03(\v2\x18.gomcp.v1.ImplementationR\x0fimplementations\x12\x1c\n" +
+	"\tstability\x18\t \x01(\tR\tstability\x12%\n" +
+	"\x0econsumer_count\x18\n" +
+	" \x01(\x05R\rconsumerCount\x124\n" +
+	"\vtype_params\x18\v \x03(\v2\x13.gomcp.v1.TypeParamR\n" +
+	"typeParams\x12/\n" +
+	"\n" +
+	"method_set\x18\f \x03(\v2\x10.gomcp.v1.MethodR\tmethodSet\x12.\n" +
+	"\x05usage\x18\r \x01(\v2\x18.gomcp.v1.InterfaceUsageR\x05usage\x12\x10\n" +
+	"\x03uri\x18\x0e \x01(\tR\x03uri\"\x87\x02\n" +
+	"\x0eInterfaceUsage\x12!\n" +
+	"\fmethod_count\x18\x01 \x01(\x05R\vmethodCount\x12(\n" +
+	"\x0fimplementations\x18\x02 \x01(\x05R\x0fimplementations\x12\x1d\n" +
+	"\n" +
+	"call_sites\x18\x03 \x01(\x05R\tcallSites\x12\x1c\n" +
+	"\tconsumers\x18\x04 \x01(\x05R\tconsumers\x12(\n" +
+	"\x10max_methods_used\x18\x05 \x01(\x05R\x0emaxMethodsUsed\x12/\n" +
+	"\amethods\x18\x06 \x03(\v2\x15.gomcp.v1.MethodUsageR\amethods\x12\x10\n" +
+	"\x03fat\x18\a \x01(\bR\x03fat\"^\n" +
+	"\vMethodUsage\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"call_sites\x18\x02 \x01(\x05R\tcallSites\x12\x1c\n" +
+	"\tconsumers\x18\x03 \x01(\x05R\tconsumers\"\xe5\x02\n" +
+	"\bCallSite\x12(\n" +
+	"\x10caller_func_desc\x18\x01 \x01(\tR\x0ecallerFuncDesc\x12\x1f\n" +
+	"\vcallee_desc\x18\x02 \x01(\tR\n" +
+	"calleeDesc\x12\x1b\n" +
+	"\tcall_type\x18\x03 \x01(\tR\bcallType\x12.\n" +
+	"\blocation\x18\x04 \x01(\v2\x12.gomcp.v1.LocationR\blocation\x12#\n" +
+	"\rcallee_opaque\x18\x05 \x01(\bR\fcalleeOpaque\x12\x18\n" +
+	"\acallees\x18\x06 \x03(\tR\acallees\x12\x1c\n" +
+	"\talgorithm\x18\a \x01(\tR\talgorithm\x12\x1b\n" +
+	"\tcaller_id\x18\b \x01(\tR\bcallerId\x12\x1b\n" +
+	"\tcallee_id\x18\t \x01(\tR\bcalleeId\x12\x18\n" +
+	"\atargets\x18\n" +
+	" \x03(\tR\atargets\x12\x10\n" +
+	"\x03uri\x18\v \x01(\tR\x03uri\"\xc1\x01\n" +
+	"\x10ExternalFunction\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1b\n" +
+	"\tfull_name\x18\x02 \x01(\tR\bfullName\x12\x1a\n" +
+	"\breceiver\x18\x03 \x01(\tR\breceiver\x12\x1c\n" +
+	"\tsignature\x18\x04 \x01(\tR\tsignature\x12\x12\n" +
+	"\x04kind\x18\x05 \x01(\tR\x04kind\x12.\n" +
+	"\blocation\x18\x06 \x01(\v2\x12.gomcp.v1.LocationR\blocation\"\xea\x03\n" +
+	"\x0ePackageMetrics\x12+\n" +
+	"\x11afferent_coupling\x18\x01 \x01(\x05R\x10afferentCoupling\x12+\n" +
+	"\x11efferent_coupling\x18\x02 \x01(\x05R\x10efferentCoupling\x12 \n" +
+	"\vinstability\x18\x03 \x01(\x01R\vinstability\x12%\n" +
+	"\x0eabstract_types\x18\x04 \x01(\x05R\rabstractTypes\x12%\n" +
+	"\x0econcrete_types\x18\x05 \x01(\x05R\rconcreteTypes\x12\"\n" +
+	"\fabstractness\x18\x06 \x01(\x01R\fabstractness\x12\x1a\n" +
+	"\bdistance\x18\a \x01(\x01R\bdistance\x12\x12\n" +
+	"\x04lcom\x18\b \x01(\x05R\x04lcom\x12\x1a\n" +
+	"\bcohesion\x18\t \x01(\x01R\bcohesion\x12\x14\n" +
+	"\x05lines\x18\n" +
+	" \x01(\x05R\x05lines\x12\x1d\n" +
+	"\n" +
+	"code_lines\x18\v \x01(\x05R\tcodeLines\x12\x1c\n" +
+	"\tfunctions\x18\f \x01(\x05R\tfunctions\x12\x1e\n" +
+	"\n" +
+	"statements\x18\r \x01(\x05R\n" +
+	"statements\x12+\n" +
+	"\x05files\x18\x0e \x03(\v2\x15.gomcp.v1.FileMetricsR\x05files\"\x94\x01\n" +
+	"\vFileMetrics\x12\x12\n" +
+	"\x04file\x18\x01 \x01(\tR\x04file\x12\x14\n" +
+	"\x05lines\x18\x02 \x01(\x05R\x05lines\x12\x1d\n" +
+	"\n" +
+	"code_lines\x18\x03 \x01(\x05R\tcodeLines\x12\x1c\n" +
+	"\tfunctions\x18\x04 \x01(\x05R\tfunctions\x12\x1e\n" +
+	"\n" +
+	"statements\x18\x05 \x01(\x05R\n" +
+	"statements\"\xb0\t\n" +
+	"\x0fPackageAnalysis\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x14\n" +
+	"\x05files\x18\x03 \x03(\tR\x05files\x12\x18\n" +
+	"\aimports\x18\x04 \x03(\tR\aimports\x12\x1f\n" +
+	"\vembed_files\x18\x05 \x03(\tR\n" +
+	"embedFiles\x12%\n" +
+	"\x0eembed_patterns\x18\x06 \x03(\tR\rembedPatterns\x123\n" +
+	"\n" +
+	"interfaces\x18\a \x03(\v2\x13.gomcp.v1.InterfaceR\n" +
+	"interfaces\x12(\n" +
+	"\x05calls\x18\b \x03(\v2\x12.gomcp.v1.CallSiteR\x05calls\x12I\n" +
+	"\x12external_functions\x18\t \x03(\v2\x1a.gomcp.v1.ExternalFunctionR\x11externalFunctions\x122\n" +
+	"\ametrics\x18\n" +
+	" \x01(\v2\x18.gomcp.v1.PackageMetricsR\ametrics\x12\x14\n" +
+	"\x05layer\x18\v \x01(\x05R\x05layer\x12*\n" +
+	"\astructs\x18\f \x03(\v2\x10.gomcp.v1.StructR\astructs\x120\n" +
+	"\tfunctions\x18\r \x03(\v2\x12.gomcp.v1.FunctionR\tfunctions\x12-\n" +
+	"\tconstants\x18\x0e \x03(\v2\x0f.gomcp.v1.ValueR\tconstants\x12-\n" +
+	"\tvariables\x18\x0f \x03(\v2\x0f.gomcp.v1.ValueR\tvariables\x12\x10\n" +
+	"\x03doc\x18\x10 \x01(\tR\x03doc\x12\x1a\n" +
+	"\bsynopsis\x18\x11 \x01(\tR\bsynopsis\x12)\n" +
+	"\x05types\x18\x12 \x03(\v2\x13.gomcp.v1.NamedTypeR\x05types\x12\x1a\n" +
+	"\bvendored\x18\x13 \x01(\bR\bvendored\x127\n" +
+	"\fimport_edges\x18\x14 \x03(\v2\x14.gomcp.v1.ImportEdgeR\vimportEdges\x123\n" +
+	"\n" +
+	"goroutines\x18\x15 \x03(\v2\x13.gomcp.v1.GoroutineR\n" +
+	"goroutines\x124\n" +
+	"\vchannel_ops\x18\x16 \x03(\v2\x13.gomcp.v1.ChannelOpR\n" +
+	"channelOps\x12-\n" +
+	"\alocking\x18\x17 \x03(\v2\x13.gomcp.v1.LockUsageR\alocking\x12>\n" +
+	"\x0epanic_handling\x18\x18 \x03(\v2\x17.gomcp.v1.PanicHandlingR\rpanicHandling\x12+\n" +
+	"\x06unsafe\x18\x19 \x03(\v2\x13.gomcp.v1.UnsafeUseR\x06unsafe\x12\x1f\n" +
+	"\x03cgo\x18\x1a \x01(\v2\r.gomcp.v1.CgoR\x03cgo\x12)\n" +
+	"\x04init\x18\x1b \x01(\v2\x15.gomcp.v1.PackageInitR\x04init\x12:\n" +
+	"\x0eembed_metadata\x18\x1c \x03(\v2\x13.gomcp.v1.EmbedFileR\rembedMetadata\x12\x10\n" +
+	"\x03uri\x18\x1d \x01(\tR\x03uri\"n\n" +
+	"\tEmbedFile\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\x12\x16\n" +
+	"\x06sha256\x18\x03 \x01(\tR\x06sha256\x12!\n" +
+	"\fcontent_type\x18\x04 \x01(\tR\vcontentType\"T\n" +
+	"\vPackageInit\x12/\n" +
+	"\tvariables\x18\x01 \x03(\v2\x11.gomcp.v1.VarInitR\tvariables\x12\x14\n" +
+	"\x05funcs\x18\x02 \x03(\tR\x05funcs\"y\n" +
+	"\aVarInit\x12\x14\n" +
+	"\x05names\x18\x01 \x03(\tR\x05names\x12\x12\n" +
+	"\x04expr\x18\x02 \x01(\tR\x04expr\x12\x14\n" +
+	"\x05calls\x18\x03 \x03(\tR\x05calls\x12.\n" +
+	"\blocation\x18\x04 \x01(\v2\x12.gomcp.v1.LocationR\blocation\"\x88\x01\n" +
+	"\x03Cgo\x12\x19\n" +
+	"\bgo_files\x18\x01 \x03(\tR\agoFiles\x12\x17\n" +
+	"\ac_files\x18\x02 \x03(\tR\x06cFiles\x12\x1e\n" +
+	"\n" +
+	"directives\x18\x03 \x03(\tR\n" +
+	"directives\x12-\n" +
+	"\aexports\x18\x04 \x03(\v2\x13.gomcp.v1.CgoExportR\aexports\"p\n" +
+	"\tCgoExport\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1f\n" +
+	"\vfunction_id\x18\x02 \x01(\tR\n" +
+	"functionId\x12.\n" +
+	"\blocation\x18\x03 \x01(\v2\x12.gomcp.v1.LocationR\blocation\"\xa4\x01\n" +
+	"\tUnsafeUse\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1f\n" +
+	"\vfunction_id\x18\x02 \x01(\tR\n" +
+	"functionId\x12\x12\n" +
+	"\x04from\x18\x03 \x01(\tR\x04from\x12\x1e\n" +
+	"\n" +
+	"arithmetic\x18\x04 \x01(\bR\n" +
+	"arithmetic\x12.\n" +
+	"\blocation\x18\x05 \x01(\v2\x12.gomcp.v1.LocationR\blocation\"\xd6\x01\n" +
+	"\rPanicHandling\x12\x1f\n" +
+	"\vfunction_id\x18\x01 \x01(\tR\n" +
+	"functionId\x12\x1a\n" +
+	"\bfunction\x18\x02 \x01(\tR\bfunction\x12+\n" +
+	"\x06defers\x18\x03 \x03(\v2\x13.gomcp.v1.DeferStmtR\x06defers\x12+\n" +
+	"\x06panics\x18\x04 \x03(\v2\x13.gomcp.v1.PanicSiteR\x06panics\x12.\n" +
+	"\brecovers\x18\x05 \x03(\v2\x12.gomcp.v1.LocationR\brecovers\"\xa6\x01\n" +
+	"\tDeferStmt\x12\x16\n" +
+	"\x06callee\x18\x01 \x01(\tR\x06callee\x12\x1b\n" +
+	"\tcallee_id\x18\x02 \x01(\tR\bcalleeId\x12\x18\n" +
+	"\aclosure\x18\x03 \x01(\bR\aclosure\x12\x1a\n" +
+	"\brecovers\x18\x04 \x01(\bR\brecovers\x12.\n" +
+	"\blocation\x18\x05 \x01(\v2\x12.gomcp.v1.LocationR\blocation\"Q\n" +
+	"\tPanicSite\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12.\n" +
+	"\blocation\x18\x02 \x01(\v2\x12.gomcp.v1.LocationR\blocation\"\xb0\x01\n" +
+	"\tLockUsage\x12\x1f\n" +
+	"\vfunction_id\x18\x01 \x01(\tR\n" +
+	"functionId\x12\x1a\n" +
+	"\bfunction\x18\x02 \x01(\tR\bfunction\x12\x12\n" +
+	"\x04lock\x18\x03 \x01(\tR\x04lock\x12\x12\n" +
+	"\x04kind\x18\x04 \x01(\tR\x04kind\x12\"\n" +
+	"\x03ops\x18\x05 \x03(\v2\x10.gomcp.v1.LockOpR\x03ops\x12\x1a\n" +
+	"\bunpaired\x18\x06 \x03(\tR\bunpaired\"l\n" +
+	"\x06LockOp\x12\x16\n" +
+	"\x06method\x18\x01 \x01(\tR\x06method\x12\x1a\n" +
+	"\bdeferred\x18\x02 \x01(\bR\bdeferred\x12.\n" +
+	"\blocation\x18\x03 \x01(\v2\x12.gomcp.v1.LocationR\blocation\"\x87\x02\n" +
+	"\tChannelOp\x12\x0e\n" +
+	"\x02op\x18\x01 \x01(\tR\x02op\x12\x1f\n" +
+	"\vfunction_id\x18\x02 \x01(\tR\n" +
+	"functionId\x12\x1a\n" +
+	"\bfunction\x18\x03 \x01(\tR\bfunction\x12\x18\n" +
+	"\achannel\x18\x04 \x01(\tR\achannel\x12\x1b\n" +
+	"\tchan_type\x18\x05 \x01(\tR\bchanType\x12*\n" +
+	"\x05cases\x18\x06 \x03(\v2\x14.gomcp.v1.SelectCaseR\x05cases\x12\x1a\n" +
+	"\bblocking\x18\a \x01(\bR\bblocking\x12.\n" +
+	"\blocation\x18\b \x01(\v2\x12.gomcp.v1.LocationR\blocation\"S\n" +
+	"\n" +
+	"SelectCase\x12\x0e\n" +
+	"\x02op\x18\x01 \x01(\tR\x02op\x12\x18\n" +
+	"\achannel\x18\x02 \x01(\tR\achannel\x12\x1b\n" +
+	"\tchan_type\x18\x03 \x01(\tR\bchanType\"\xa9\x01\n" +
+	"\tGoroutine\x12\x1d\n" +
+	"\n" +
+	"spawner_id\x18\x01 \x01(\tR\tspawnerId\x12\x16\n" +
+	"\x06target\x18\x02 \x01(\tR\x06target\x12\x1b\n" +
+	"\ttarget_id\x18\x03 \x01(\tR\btargetId\x12\x18\n" +
+	"\aclosure\x18\x04 \x01(\bR\aclosure\x12.\n" +
+	"\blocation\x18\x05 \x01(\v2\x12.gomcp.v1.LocationR\blocation\"\x96\x03\n" +
+	"\bFunction\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1b\n" +
+	"\tfull_name\x18\x02 \x01(\tR\bfullName\x12\x1a\n" +
+	"\breceiver\x18\x03 \x01(\tR\breceiver\x12\x1c\n" +
+	"\tsignature\x18\x04 \x01(\tR\tsignature\x12\x1f\n" +
+	"\vdoc_comment\x18\x05 \x01(\tR\n" +
+	"docComment\x12\x1a\n" +
+	"\bexported\x18\x06 \x01(\bR\bexported\x12.\n" +
+	"\blocation\x18\a \x01(\v2\x12.gomcp.v1.LocationR\blocation\x124\n" +
+	"\vtype_params\x18\b \x03(\v2\x13.gomcp.v1.TypeParamR\n" +
+	"typeParams\x12\x0e\n" +
+	"\x02id\x18\t \x01(\tR\x02id\x12\x1c\n" +
+	"\treachable\x18\n" +
+	" \x01(\bR\treachable\x12<\n" +
+	"\n" +
+	"centrality\x18\v \x01(\v2\x1c.gomcp.v1.FunctionCentralityR\n" +
+	"centrality\x12\x10\n" +
+	"\x03uri\x18\f \x01(\tR\x03uri\"\x8f\x01\n" +
+	"\x12FunctionCentrality\x12\x1b\n" +
+	"\tin_degree\x18\x01 \x01(\x05R\binDegree\x12\x1d\n" +
+	"\n" +
+	"out_degree\x18\x02 \x01(\x05R\toutDegree\x12\x1b\n" +
+	"\tpage_rank\x18\x03 \x01(\x01R\bpageRank\x12 \n" +
+	"\vbetweenness\x18\x04 \x01(\x01R\vbetweenness\"\xe0\x01\n" +
+	"\x05Value\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\x12\x1a\n" +
+	"\bconstant\x18\x04 \x01(\tR\bconstant\x12\x1f\n" +
+	"\vdoc_comment\x18\x05 \x01(\tR\n" +
+	"docComment\x12\x1a\n" +
+	"\bexported\x18\x06 \x01(\bR\bexported\x12.\n" +
+	"\blocation\x18\a \x01(\v2\x12.gomcp.v1.LocationR\blocation\x12\x10\n" +
+	"\x03uri\x18\b \x01(\tR\x03uri\"\xe6\x02\n" +
+	"\tNamedType\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12!\n" +
+	"\fpackage_name\x18\x02 \x01(\tR\vpackageName\x12!\n" +
+	"\fpackage_path\x18\x03 \x01(\tR\vpackagePath\x12\x12\n" +
+	"\x04kind\x18\x04 \x01(\tR\x04kind\x12\x1e\n" +
+	"\n" +
+	"underlying\x18\x05 \x01(\tR\n" +
+	"underlying\x12\x16\n" +
+	"\x06target\x18\x06 \x01(\tR\x06target\x12\x1f\n" +
+	"\vdoc_comment\x18\a \x01(\tR\n" +
+	"docComment\x12\x1a\n" +
+	"\bexported\x18\b \x01(\bR\bexported\x12.\n" +
+	"\blocation\x18\t \x01(\v2\x12.gomcp.v1.LocationR\blocation\x124\n" +
+	"\vtype_params\x18\n" +
+	" \x03(\v2\x13.gomcp.v1.TypeParamR\n" +
+	"typeParams\x12\x10\n" +
+	"\x03uri\x18\v \x01(\tR\x03uri\"\xde\x01\n" +
+	"\x05Field\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x10\n" +
+	"\x03tag\x18\x03 \x01(\tR\x03tag\x12\x1a\n" +
+	"\bembedded\x18\x04 \x01(\bR\bembedded\x12\x1a\n" +
+	"\bexported\x18\x05 \x01(\bR\bexported\x12\x1f\n" +
+	"\vdoc_comment\x18\x06 \x01(\tR\n" +
+	"docComment\x12.\n" +
+	"\blocation\x18\a \x01(\v2\x12.gomcp.v1.LocationR\blocation\x12\x12\n" +
+	"\x04sync\x18\b \x01(\tR\x04sync\"\xa4\x02\n" +
+	"\x06Struct\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12!\n" +
+	"\fpackage_name\x18\x02 \x01(\tR\vpackageName\x12!\n" +
+	"\fpackage_path\x18\x03 \x01(\tR\vpackagePath\x12\x1f\n" +
+	"\vdoc_comment\x18\x04 \x01(\tR\n" +
+	"docComment\x12'\n" +
+	"\x06fields\x18\x05 \x03(\v2\x0f.gomcp.v1.FieldR\x06fields\x12.\n" +
+	"\blocation\x18\x06 \x01(\v2\x12.gomcp.v1.LocationR\blocation\x124\n" +
+	"\vtype_params\x18\a \x03(\v2\x13.gomcp.v1.TypeParamR\n" +
+	"typeParams\x12\x10\n" +
+	"\x03uri\x18\b \x01(\tR\x03uri\"|\n" +
+	"\vCloneMember\x12\x1a\n" +
+	"\bfunction\x18\x01 \x01(\tR\bfunction\x12!\n" +
+	"\fpackage_path\x18\x02 \x01(\tR\vpackagePath\x12.\n" +
+	"\blocation\x18\x03 \x01(\v2\x12.gomcp.v1.LocationR\blocation\"\x82\x01\n" +
+	"\n" +
+	"CloneGroup\x12 \n" +
+	"\vfingerprint\x18\x01 \x01(\tR\vfingerprint\x12\x1d\n" +
+	"\n" +
+	"node_count\x18\x02 \x01(\x05R\tnodeCount\x123\n" +
+	"\tfunctions\x18\x03 \x03(\v2\x15.gomcp.v1.CloneMemberR\tfunctions\"\xa3\x01\n" +
+	"\rRuleViolation\x12\x12\n" +
+	"\x04rule\x18\x01 \x01(\tR\x04rule\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x12\n" +
+	"\x04from\x18\x03 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x04 \x01(\tR\x02to\x12\x16\n" +
+	"\x06detail\x18\x05 \x01(\tR\x06detail\x12.\n" +
+	"\blocation\x18\x06 \x01(\v2\x12.gomcp.v1.LocationR\blocation\"~\n" +
+	"\x16UnimplementedInterface\x12\x1c\n" +
+	"\tinterface\x18\x01 \x01(\tR\tinterface\x12\x16\n" +
+	"\x06module\x18\x02 \x01(\tR\x06module\x12.\n" +
+	"\blocation\x18\x03 \x01(\v2\x12.gomcp.v1.LocationR\blocation\"\x88\x02\n" +
+	"\x16ExternalImplementation\x12\x1b\n" +
+	"\ttype_name\x18\x01 \x01(\tR\btypeName\x12!\n" +
+	"\fpackage_path\x18\x02 \x01(\tR\vpackagePath\x12\x16\n" +
+	"\x06module\x18\x03 \x01(\tR\x06module\x12\x1c\n" +
+	"\tinterface\x18\x04 \x01(\tR\tinterface\x12)\n" +
+	"\x10interface_module\x18\x05 \x01(\tR\x0finterfaceModule\x12\x1d\n" +
+	"\n" +
+	"is_pointer\x18\x06 \x01(\bR\tisPointer\x12.\n" +
+	"\blocation\x18\a \x01(\v2\x12.gomcp.v1.LocationR\blocation\"\xc7\x01\n" +
+	"\vAdapterGaps\x12[\n" +
+	"\x18unimplemented_interfaces\x18\x01 \x03(\v2 .gomcp.v1.UnimplementedInterfaceR\x17unimplementedInterfaces\x12[\n" +
+	"\x18external_implementations\x18\x02 \x03(\v2 .gomcp.v1.ExternalImplementationR\x17externalImplementations\"\x94\x01\n" +
+	"\x0eMethodMismatch\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04want\x18\x02 \x01(\tR\x04want\x12\x12\n" +
+	"\x04have\x18\x03 \x01(\tR\x04have\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12.\n" +
+	"\blocation\x18\x05 \x01(\v2\x12.gomcp.v1.LocationR\blocation\"\xcc\x01\n" +
+	"\bNearMiss\x12\x1c\n" +
+	"\tinterface\x18\x01 \x01(\tR\tinterface\x12\x1b\n" +
+	"\ttype_name\x18\x02 \x01(\tR\btypeName\x12!\n" +
+	"\fpackage_path\x18\x03 \x01(\tR\vpackagePath\x122\n" +
+	"\amissing\x18\x04 \x03(\v2\x18.gomcp.v1.MethodMismatchR\amissing\x12.\n" +
+	"\blocation\x18\x05 \x01(\v2\x12.gomcp.v1.LocationR\blocation\"\x95\x04\n" +
+	"\bFindings\x12,\n" +
+	"\x06clones\x18\x01 \x03(\v2\x14.gomcp.v1.CloneGroupR\x06clones\x12@\n" +
+	"\x0frule_violations\x18\x02 \x03(\v2\x17.gomcp.v1.RuleViolationR\x0eruleViolations\x128\n" +
+	"\fadapter_gaps\x18\x03 \x01(\v2\x15.gomcp.v1.AdapterGapsR\vadapterGaps\x123\n" +
+	"\vnear_misses\x18\x04 \x03(\v2\x12.gomcp.v1.NearMissR\n" +
+	"nearMisses\x12:\n" +
+	"\rimport_cycles\x18\x05 \x03(\v2\x15.gomcp.v1.ImportCycleR\fimportCycles\x12%\n" +
+	"\x0efat_interfaces\x18\x06 \x03(\tR\rfatInterfaces\x12M\n" +
+	"\x14duplicate_interfaces\x18\a \x03(\v2\x1a.gomcp.v1.InterfaceClusterR\x13duplicateInterfaces\x12I\n" +
+	"\x12unrecovered_panics\x18\b \x03(\v2\x1a.gomcp.v1.UnrecoveredPanicR\x11unrecoveredPanics\x12-\n" +
+	"\x12untested_functions\x18\t \x03(\tR\x11untestedFunctions\"\x88\x01\n" +
+	"\x10UnrecoveredPanic\x12\x1f\n" +
+	"\vfunction_id\x18\x01 \x01(\tR\n" +
+	"functionId\x12(\n" +
+	"\x05steps\x18\x02 \x03(\v2\x12.gomcp.v1.CallStepR\x05steps\x12)\n" +
+	"\x05panic\x18\x03 \x01(\v2\x13.gomcp.v1.PanicSiteR\x05panic\"\x91\x01\n" +
+	"\bCallStep\x12\x1b\n" +
+	"\tcaller_id\x18\x01 \x01(\tR\bcallerId\x12\x1b\n" +
+	"\tcallee_id\x18\x02 \x01(\tR\bcalleeId\x12\x1b\n" +
+	"\tcall_type\x18\x03 \x01(\tR\bcallType\x12.\n" +
+	"\blocation\x18\x04 \x01(\v2\x12.gomcp.v1.LocationR\blocation\"m\n" +
+	"\x10InterfaceCluster\x12\x1e\n" +
+	"\n" +
+	"interfaces\x18\x01 \x03(\tR\n" +
+	"interfaces\x129\n" +
+	"\trelations\x18\x02 \x03(\v2\x1b.gomcp.v1.InterfaceRelationR\trelations\"c\n" +
+	"\x11InterfaceRelation\x12\x1c\n" +
+	"\tinterface\x18\x01 \x01(\tR\tinterface\x12\x14\n" +
+	"\x05other\x18\x02 \x01(\tR\x05other\x12\x1a\n" +
+	"\brelation\x18\x03 \x01(\tR\brelation\"\x91\x01\n" +
+	"\n" +
+	"ImportEdge\x12\x1a\n" +
+	"\bimporter\x18\x01 \x01(\tR\bimporter\x12\x1a\n" +
+	"\bimported\x18\x02 \x01(\tR\bimported\x12\x1b\n" +
+	"\ttest_only\x18\x03 \x01(\bR\btestOnly\x12.\n" +
+	"\blocation\x18\x04 \x01(\v2\x12.gomcp.v1.LocationR\blocation\"w\n" +
+	"\vImportCycle\x12\x1a\n" +
+	"\bpackages\x18\x01 \x03(\tR\bpackages\x12/\n" +
+	"\aimports\x18\x02 \x03(\v2\x15.gomcp.v1.CycleImportR\aimports\x12\x1b\n" +
+	"\ttest_only\x18\x03 \x01(\bR\btestOnly\"u\n" +
+	"\vCycleImport\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\x12\x12\n" +
+	"\x04test\x18\x03 \x01(\bR\x04test\x12.\n" +
+	"\blocation\x18\x04 \x01(\v2\x12.gomcp.v1.LocationR\blocation\"\x9b\x05\n" +
+	"\x0fProjectAnalysis\x12\x1f\n" +
+	"\vmodule_path\x18\x01 \x01(\tR\n" +
+	"modulePath\x12\x1d\n" +
+	"\n" +
+	"module_dir\x18\x02 \x01(\tR\tmoduleDir\x125\n" +
+	"\bpackages\x18\x03 \x03(\v2\x19.gomcp.v1.PackageAnalysisR\bpackages\x12.\n" +
+	"\bfindings\x18\x04 \x01(\v2\x12.gomcp.v1.FindingsR\bfindings\x12?\n" +
+	"\fdependencies\x18\x05 \x03(\v2\x1b.gomcp.v1.DependencyPackageR\fdependencies\x12@\n" +
+	"\x11stdlib_interfaces\x18\x06 \x03(\v2\x13.gomcp.v1.InterfaceR\x10stdlibInterfaces\x12b\n" +
+	"\x1ccross_module_implementations\x18\a \x03(\v2 .gomcp.v1.ExternalImplementationR\x1acrossModuleImplementations\x12-\n" +
+	"\x12reachability_roots\x18\b \x03(\tR\x11reachabilityRoots\x128\n" +
+	"\fmodule_graph\x18\t \x01(\v2\x15.gomcp.v1.ModuleGraphR\vmoduleGraph\x12\x1d\n" +
+	"\n" +
+	"init_order\x18\n" +
+	" \x03(\tR\tinitOrder\x12,\n" +
+	"\x05tests\x18\v \x03(\v2\x16.gomcp.v1.TestFunctionR\x05tests\x12%\n" +
+	"\x0eschema_version\x18\f \x01(\tR\rschemaVersion\x12\x1d\n" +
+	"\n" +
+	"zero_based\x18\r \x01(\bR\tzeroBased\"\xb4\x01\n" +
+	"\fTestFunction\x12\x1f\n" +
+	"\vfunction_id\x18\x01 \x01(\tR\n" +
+	"functionId\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12!\n" +
+	"\fpackage_path\x18\x03 \x01(\tR\vpackagePath\x12.\n" +
+	"\blocation\x18\x04 \x01(\v2\x12.gomcp.v1.LocationR\blocation\x12\x1c\n" +
+	"\texercises\x18\x05 \x03(\tR\texercises\"=\n" +
+	"\vModuleGraph\x12.\n" +
+	"\amodules\x18\x01 \x03(\v2\x14.gomcp.v1.ModuleNodeR\amodules\"\xfc\x01\n" +
+	"\n" +
+	"ModuleNode\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12\x12\n" +
+	"\x04main\x18\x03 \x01(\bR\x04main\x12\x1d\n" +
+	"\n" +
+	"go_version\x18\x04 \x01(\tR\tgoVersion\x12\x18\n" +
+	"\areplace\x18\x05 \x01(\tR\areplace\x12\x1a\n" +
+	"\bindirect\x18\x06 \x01(\bR\bindirect\x12\x1f\n" +
+	"\vrequired_by\x18\a \x03(\tR\n" +
+	"requiredBy\x12\x18\n" +
+	"\aimports\x18\b \x03(\tR\aimports\x12\x1c\n" +
+	"\timporters\x18\t \x03(\tR\timporters\"\xbe\x01\n" +
+	"\x11DependencyPackage\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x16\n" +
+	"\x06module\x18\x03 \x01(\tR\x06module\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\tR\aversion\x123\n" +
+	"\n" +
+	"interfaces\x18\x05 \x03(\v2\x13.gomcp.v1.InterfaceR\n" +
+	"interfaces\x12\x1a\n" +
+	"\bvendored\x18\x06 \x01(\bR\bvendored\"\x1b\n" +
+	"\x19GetProjectAnalysisRequest\"+\n" +
+	"\x15StreamPackagesRequest\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\"D\n" +
+	"\x12StreamCallsRequest\x12\x16\n" +
+	"\x06caller\x18\x01 \x01(\tR\x06caller\x12\x16\n" +
+	"\x06callee\x18\x02 \x01(\tR\x06callee\"X\n" +
+	"\x14SearchSymbolsRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x14\n" +
+	"\x05kinds\x18\x02 \x03(\tR\x05kinds\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"\xa5\x01\n" +
+	"\vSymbolMatch\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12\x18\n" +
+	"\apackage\x18\x04 \x01(\tR\apackage\x12\x14\n" +
+	"\x05score\x18\x05 \x01(\x05R\x05score\x12.\n" +
+	"\blocation\x18\x06 \x01(\v2\x12.gomcp.v1.LocationR\blocation\"H\n" +
+	"\x15SearchSymbolsResponse\x12/\n" +
+	"\amatches\x18\x01 \x03(\v2\x15.gomcp.v1.SymbolMatchR\amatches2\xcc\x02\n" +
+	"\x0fAnalysisService\x12T\n" +
+	"\x12GetProjectAnalysis\x12#.gomcp.v1.GetProjectAnalysisRequest\x1a\x19.gomcp.v1.ProjectAnalysis\x12N\n" +
+	"\x0eStreamPackages\x12\x1f.gomcp.v1.StreamPackagesRequest\x1a\x19.gomcp.v1.PackageAnalysis0\x01\x12A\n" +
+	"\vStreamCalls\x12\x1c.gomcp.v1.StreamCallsRequest\x1a\x12.gomcp.v1.CallSite0\x01\x12P\n" +
+	"\rSearchSymbols\x12\x1e.gomcp.v1.SearchSymbolsRequest\x1a\x1f.gomcp.v1.SearchSymbolsResponseB?Z=github.com/namikmesic/go-mcp/internal/grpcapi/gomcpv1;gomcpv1b\x06proto3"
+
+var (
+	file_gomcp_v1_analysis_proto_rawDescOnce sync.Once
+	file_gomcp_v1_analysis_proto_rawDescData []byte
+)
+
+func file_gomcp_v1_analysis_proto_rawDescGZIP() []byte {
+	file_gomcp_v1_analysis_proto_rawDescOnce.Do(func() {
+		file_gomcp_v1_analysis_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_gomcp_v1_analysis_proto_rawDesc), len(file_gomcp_v1_analysis_proto_rawDesc)))
+	})
+	return file_gomcp_v1_analysis_proto_rawDescData
+}
+
+var file_gomcp_v1_analysis_proto_msgTypes = make([]protoimpl.MessageInfo, 61)
+var file_gomcp_v1_analysis_proto_goTypes = []any{
+	(*Location)(nil),                  // 0: gomcp.v1.Location
+	(*Parameter)(nil),                 // 1: gomcp.v1.Parameter
+	(*TypeParam)(nil),                 // 2: gomcp.v1.TypeParam
+	(*Method)(nil),                    // 3: gomcp.v1.Method
+	(*Implementation)(nil),            // 4: gomcp.v1.Implementation
+	(*MethodMatch)(nil),               // 5: gomcp.v1.MethodMatch
+	(*Interface)(nil),                 // 6: gomcp.v1.Interface
+	(*InterfaceUsage)(nil),            // 7: gomcp.v1.InterfaceUsage
+	(*MethodUsage)(nil),               // 8: gomcp.v1.MethodUsage
+	(*CallSite)(nil),                  // 9: gomcp.v1.CallSite
+	(*ExternalFunction)(nil),          // 10: gomcp.v1.ExternalFunction
+	(*PackageMetrics)(nil),            // 11: gomcp.v1.PackageMetrics
+	(*FileMetrics)(nil),               // 12: gomcp.v1.FileMetrics
+	(*PackageAnalysis)(nil),           // 13: gomcp.v1.PackageAnalysis
+	(*EmbedFile)(nil),                 // 14: gomcp.v1.EmbedFile
+	(*PackageInit)(nil),               // 15: gomcp.v1.PackageInit
+	(*VarInit)(nil),                   // 16: gomcp.v1.VarInit
+	(*Cgo)(nil),                       // 17: gomcp.v1.Cgo
+	(*CgoExport)(nil),                 // 18: gomcp.v1.CgoExport
+	(*UnsafeUse)(nil),                 // 19: gomcp.v1.UnsafeUse
+	(*PanicHandling)(nil),             // 20: gomcp.v1.PanicHandling
+	(*DeferStmt)(nil),                 // 21: gomcp.v1.DeferStmt
+	(*PanicSite)(nil),                 // 22: gomcp.v1.PanicSite
+	(*LockUsage)(nil),                 // 23: gomcp.v1.LockUsage
+	(*LockOp)(nil),                    // 24: gomcp.v1.LockOp
+	(*ChannelOp)(nil),                 // 25: gomcp.v1.ChannelOp
+	(*SelectCase)(nil),                // 26: gomcp.v1.SelectCase
+	(*Goroutine)(nil),                 // 27: gomcp.v1.Goroutine
+	(*Function)(nil),                  // 28: gomcp.v1.Function
+	(*FunctionCentrality)(nil),        // 29: gomcp.v1.FunctionCentrality
+	(*Value)(nil),                     // 30: gomcp.v1.Value
+	(*NamedType)(nil),                 // 31: gomcp.v1.NamedType
+	(*Field)(nil),                     // 32: gomcp.v1.Field
+	(*Struct)(nil),                    // 33: gomcp.v1.Struct
+	(*CloneMember)(nil),               // 34: gomcp.v1.CloneMember
+	(*CloneGroup)(nil),                // 35: gomcp.v1.CloneGroup
+	(*RuleViolation)(nil),             // 36: gomcp.v1.RuleViolation
+	(*UnimplementedInterface)(nil),    // 37: gomcp.v1.UnimplementedInterface
+	(*ExternalImplementation)(nil),    // 38: gomcp.v1.ExternalImplementation
+	(*AdapterGaps)(nil),               // 39: gomcp.v1.AdapterGaps
+	(*MethodMismatch)(nil),            // 40: gomcp.v1.MethodMismatch
+	(*NearMiss)(nil),                  // 41: gomcp.v1.NearMiss
+	(*Findings)(nil),                  // 42: gomcp.v1.Findings
+	(*UnrecoveredPanic)(nil),          // 43: gomcp.v1.UnrecoveredPanic
+	(*CallStep)(nil),                  // 44: gomcp.v1.CallStep
+	(*InterfaceCluster)(nil),          // 45: gomcp.v1.InterfaceCluster
+	(*InterfaceRelation)(nil),         // 46: gomcp.v1.InterfaceRelation
+	(*ImportEdge)(nil),                // 47: gomcp.v1.ImportEdge
+	(*ImportCycle)(nil),               // 48: gomcp.v1.ImportCycle
+	(*CycleImport)(nil),               // 49: gomcp.v1.CycleImport
+	(*ProjectAnalysis)(nil),           // 50: gomcp.v1.ProjectAnalysis
+	(*TestFunction)(nil),              // 51: gomcp.v1.TestFunction
+	(*ModuleGraph)(nil),               // 52: gomcp.v1.ModuleGraph
+	(*ModuleNode)(nil),                // 53: gomcp.v1.ModuleNode
+	(*DependencyPackage)(nil),         // 54: gomcp.v1.DependencyPackage
+	(*GetProjectAnalysisRequest)(nil), // 55: gomcp.v1.GetProjectAnalysisRequest
+	(*StreamPackagesRequest)(nil),     // 56: gomcp.v1.StreamPackagesRequest
+	(*StreamCallsRequest)(nil),        // 57: gomcp.v1.StreamCallsRequest
+	(*SearchSymbolsRequest)(nil),      // 58: gomcp.v1.SearchSymbolsRequest
+	(*SymbolMatch)(nil),               // 59: gomcp.v1.SymbolMatch
+	(*SearchSymbolsResponse)(nil),     // 60: gomcp.v1.SearchSymbolsResponse
+}
+var file_gomcp_v1_analysis_proto_depIdxs = []int32{
+	1,  // 0: gomcp.v1.Method.parameters:type_name -> gomcp.v1.Parameter
+	0,  // 1: gomcp.v1.Method.location:type_name -> gomcp.v1.Location
+	2,  // 2: gomcp.v1.Method.type_params:type_name -> gomcp.v1.TypeParam
+	0,  // 3: gomcp.v1.Implementation.location:type_name -> gomcp.v1.Location
+	5,  // 4: gomcp.v1.Implementation.methods:type_name -> gomcp.v1.MethodMatch
+	0,  // 5: gomcp.v1.MethodMatch.location:type_name -> gomcp.v1.Location
+	0,  // 6: gomcp.v1.Interface.location:type_name -> gomcp.v1.Location
+	3,  // 7: gomcp.v1.Interface.methods:type_name -> gomcp.v1.Method
+	4,  // 8: gomcp.v1.Interface.implementations:type_name -> gomcp.v1.Implementation
+	2,  // 9: gomcp.v1.Interface.type_params:type_name -> gomcp.v1.TypeParam
+	3,  // 10: gomcp.v1.Interface.method_set:type_name -> gomcp.v1.Method
+	7,  // 11: gomcp.v1.Interface.usage:type_name -> gomcp.v1.InterfaceUsage
+	8,  // 12: gomcp.v1.InterfaceUsage.methods:type_name -> gomcp.v1.MethodUsage
+	0,  // 13: gomcp.v1.CallSite.location:type_name -> gomcp.v1.Location
+	0,  // 14: gomcp.v1.ExternalFunction.location:type_name -> gomcp.v1.Location
+	12, // 15: gomcp.v1.PackageMetrics.files:type_name -> gomcp.v1.FileMetrics
+	6,  // 16: gomcp.v1.PackageAnalysis.interfaces:type_name -> gomcp.v1.Interface
+	9,  // 17: gomcp.v1.PackageAnalysis.calls:type_name -> gomcp.v1.CallSite
+	10, // 18: gomcp.v1.PackageAnalysis.external_functions:type_name -> gomcp.v1.ExternalFunction
+	11, // 19: gomcp.v1.PackageAnalysis.metrics:type_name -> gomcp.v1.PackageMetrics
+	33, // 20: gomcp.v1.PackageAnalysis.structs:type_name -> gomcp.v1.Struct
+	28, // 21: gomcp.v1.PackageAnalysis.functions:type_name -> gomcp.v1.Function
+	30, // 22: gomcp.v1.PackageAnalysis.constants:type_name -> gomcp.v1.Value
+	30, // 23: gomcp.v1.PackageAnalysis.variables:type_name -> gomcp.v1.Value
+	31, // 24: gomcp.v1.PackageAnalysis.types:type_name -> gomcp.v1.NamedType
+	47, // 25: gomcp.v1.PackageAnalysis.import_edges:type_name -> gomcp.v1.ImportEdge
+	27, // 26: gomcp.v1.PackageAnalysis.goroutines:type_name -> gomcp.v1.Goroutine
+	25, // 27: gomcp.v1.PackageAnalysis.channel_ops:type_name -> gomcp.v1.ChannelOp
+	23, // 28: gomcp.v1.PackageAnalysis.locking:type_name -> gomcp.v1.LockUsage
+	20, // 29: gomcp.v1.PackageAnalysis.panic_handling:type_name -> gomcp.v1.PanicHandling
+	19, // 30: gomcp.v1.PackageAnalysis.unsafe:type_name -> gomcp.v1.UnsafeUse
+	17, // 31: gomcp.v1.PackageAnalysis.cgo:type_name -> gomcp.v1.Cgo
+	15, // 32: gomcp.v1.PackageAnalysis.init:type_name -> gomcp.v1.PackageInit
+	14, // 33: gomcp.v1.PackageAnalysis.embed_metadata:type_name -> gomcp.v1.EmbedFile
+	16, // 34: gomcp.v1.PackageInit.variables:type_name -> gomcp.v1.VarInit
+	0,  // 35: gomcp.v1.VarInit.location:type_name -> gomcp.v1.Location
+	18, // 36: gomcp.v1.Cgo.exports:type_name -> gomcp.v1.CgoExport
+	0,  // 37: gomcp.v1.CgoExport.location:type_name -> gomcp.v1.Location
+	0,  // 38: gomcp.v1.UnsafeUse.location:type_name -> gomcp.v1.Location
+	21, // 39: gomcp.v1.PanicHandling.defers:type_name -> gomcp.v1.DeferStmt
+	22, // 40: gomcp.v1.PanicHandling.panics:type_name -> gomcp.v1.PanicSite
+	0,  // 41: gomcp.v1.PanicHandling.recovers:type_name -> gomcp.v1.Location
+	0,  // 42: gomcp.v1.DeferStmt.location:type_name -> gomcp.v1.Location
+	0,  // 43: gomcp.v1.PanicSite.location:type_name -> gomcp.v1.Location
+	24, // 44: gomcp.v1.LockUsage.ops:type_name -> gomcp.v1.LockOp
+	0,  // 45: gomcp.v1.LockOp.location:type_name -> gomcp.v1.Location
+	26, // 46: gomcp.v1.ChannelOp.cases:type_name -> gomcp.v1.SelectCase
+	0,  // 47: gomcp.v1.ChannelOp.location:type_name -> gomcp.v1.Location
+	0,  // 48: gomcp.v1.Goroutine.location:type_name -> gomcp.v1.Location
+	0,  // 49: gomcp.v1.Function.location:type_name -> gomcp.v1.Location
+	2,  // 50: gomcp.v1.Function.type_params:type_name -> gomcp.v1.TypeParam
+	29, // 51: gomcp.v1.Function.centrality:type_name -> gomcp.v1.FunctionCentrality
+	0,  // 52: gomcp.v1.Value.location:type_name -> gomcp.v1.Location
+	0,  // 53: gomcp.v1.NamedType.location:type_name -> gomcp.v1.Location
+	2,  // 54: gomcp.v1.NamedType.type_params:type_name -> gomcp.v1.TypeParam
+	0,  // 55: gomcp.v1.Field.location:type_name -> gomcp.v1.Location
+	32, // 56: gomcp.v1.Struct.fields:type_name -> gomcp.v1.Field
+	0,  // 57: gomcp.v1.Struct.location:type_name -> gomcp.v1.Location
+	2,  // 58: gomcp.v1.Struct.type_params:type_name -> gomcp.v1.TypeParam
+	0,  // 59: gomcp.v1.CloneMember.location:type_name -> gomcp.v1.Location
+	34, // 60: gomcp.v1.CloneGroup.functions:type_name -> gomcp.v1.CloneMember
+	0,  // 61: gomcp.v1.RuleViolation.location:type_name -> gomcp.v1.Location
+	0,  // 62: gomcp.v1.UnimplementedInterface.location:type_name -> gomcp.v1.Location
+	0,  // 63: gomcp.v1.ExternalImplementation.location:type_name -> gomcp.v1.Location
+	37, // 64: gomcp.v1.AdapterGaps.unimplemented_interfaces:type_name -> gomcp.v1.UnimplementedInterface
+	38, // 65: gomcp.v1.AdapterGaps.external_implementations:type_name -> gomcp.v1.ExternalImplementation
+	0,  // 66: gomcp.v1.MethodMismatch.location:type_name -> gomcp.v1.Location
+	40, // 67: gomcp.v1.NearMiss.missing:type_name -> gomcp.v1.MethodMismatch
+	0,  // 68: gomcp.v1.NearMiss.location:type_name -> gomcp.v1.Location
+	35, // 69: gomcp.v1.Findings.clones:type_name -> gomcp.v1.CloneGroup
+	36, // 70: gomcp.v1.Findings.rule_violations:type_name -> gomcp.v1.RuleViolation
+	39, // 71: gomcp.v1.Findings.adapter_gaps:type_name -> gomcp.v1.AdapterGaps
+	41, // 72: gomcp.v1.Findings.near_misses:type_name -> gomcp.v1.NearMiss
+	48, // 73: gomcp.v1.Findings.import_cycles:type_name -> gomcp.v1.ImportCycle
+	45, // 74: gomcp.v1.Findings.duplicate_interfaces:type_name -> gomcp.v1.InterfaceCluster
+	43, // 75: gomcp.v1.Findings.unrecovered_panics:type_name -> gomcp.v1.UnrecoveredPanic
+	44, // 76: gomcp.v1.UnrecoveredPanic.steps:type_name -> gomcp.v1.CallStep
+	22, // 77: gomcp.v1.UnrecoveredPanic.panic:type_name -> gomcp.v1.PanicSite
+	0,  // 78: gomcp.v1.CallStep.location:type_name -> gomcp.v1.Location
+	46, // 79: gomcp.v1.InterfaceCluster.relations:type_name -> gomcp.v1.InterfaceRelation
+	0,  // 80: gomcp.v1.ImportEdge.location:type_name -> gomcp.v1.Location
+	49, // 81: gomcp.v1.ImportCycle.imports:type_name -> gomcp.v1.CycleImport
+	0,  // 82: gomcp.v1.CycleImport.location:type_name -> gomcp.v1.Location
+	13, // 83: gomcp.v1.ProjectAnalysis.packages:type_name -> gomcp.v1.PackageAnalysis
+	42, // 84: gomcp.v1.ProjectAnalysis.findings:type_name -> gomcp.v1.Findings
+	54, // 85: gomcp.v1.ProjectAnalysis.dependencies:type_name -> gomcp.v1.DependencyPackage
+	6,  // 86: gomcp.v1.ProjectAnalysis.stdlib_interfaces:type_name -> gomcp.v1.Interface
+	38, // 87: gomcp.v1.ProjectAnalysis.cross_module_implementations:type_name -> gomcp.v1.ExternalImplementation
+	52, // 88: gomcp.v1.ProjectAnalysis.module_graph:type_name -> gomcp.v1.ModuleGraph
+	51, // 89: gomcp.v1.ProjectAnalysis.tests:type_name -> gomcp.v1.TestFunction
+	0,  // 90: gomcp.v1.TestFunction.location:type_name -> gomcp.v1.Location
+	53, // 91: gomcp.v1.ModuleGraph.modules:type_name -> gomcp.v1.ModuleNode
+	6,  // 92: gomcp.v1.DependencyPackage.interfaces:type_name -> gomcp.v1.Interface
+	0,  // 93: gomcp.v1.SymbolMatch.location:type_name -> gomcp.v1.Location
+	59, // 94: gomcp.v1.SearchSymbolsResponse.matches:type_name -> gomcp.v1.SymbolMatch
+	55, // 95: gomcp.v1.AnalysisService.GetProjectAnalysis:input_type -> gomcp.v1.GetProjectAnalysisRequest
+	56, // 96: gomcp.v1.AnalysisService.StreamPackages:input_type -> gomcp.v1.StreamPackagesRequest
+	57, // 97: gomcp.v1.AnalysisService.StreamCalls:input_type -> gomcp.v1.StreamCallsRequest
+	58, // 98: gomcp.v1.AnalysisService.SearchSymbols:input_type -> gomcp.v1.SearchSymbolsRequest
+	50, // 99: gomcp.v1.AnalysisService.GetProjectAnalysis:output_type -> gomcp.v1.ProjectAnalysis
+	13, // 100: gomcp.v1.AnalysisService.StreamPackages:output_type -> gomcp.v1.PackageAnalysis
+	9,  // 101: gomcp.v1.AnalysisService.StreamCalls:output_type -> gomcp.v1.CallSite
+	60, // 102: gomcp.v1.AnalysisService.SearchSymbols:output_type -> gomcp.v1.SearchSymbolsResponse
+	99, // [99:103] is the sub-list for method output_type
+	95, // [95:99] is the sub-list for method input_type
+	95, // [95:95] is the sub-list for extension type_name
+	95, // [95:95] is the sub-list for extension extendee
+	0,  // [0:95] is the sub-list for field type_name
+}
+
+func init() { file_gomcp_v1_analysis_proto_init() }
+func file_gomcp_v1_analysis_proto_init() {
+	if File_gomcp_v1_analysis_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_gomcp_v1_analysis_proto_rawDesc), len(file_gomcp_v1_analysis_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   61,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_gomcp_v1_analysis_proto_goTypes,
+		DependencyIndexes: file_gomcp_v1_analysis_proto_depIdxs,
+		MessageInfos:      file_gomcp_v1_analysis_proto_msgTypes,
+	}.Build()
+	File_gomcp_v1_analysis_proto = out.File
+	file_gomcp_v1_analysis_proto_goTypes = nil
+	file_gomcp_v1_analysis_proto_depIdxs = nil
+}
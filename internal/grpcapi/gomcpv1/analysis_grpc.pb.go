@@ -0,0 +1,257 @@
+// Protobuf schema mirroring pkg/datamodel.
+// Regenerate the Go bindings with `go generate ./internal/grpcapi/...`.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: gomcp/v1/analysis.proto
+
+package gomcpv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AnalysisService_GetProjectAnalysis_FullMethodName = "/gomcp.v1.AnalysisService/GetProjectAnalysis"
+	AnalysisService_StreamPackages_FullMethodName     = "/gomcp.v1.AnalysisService/StreamPackages"
+	AnalysisService_StreamCalls_FullMethodName        = "/gomcp.v1.AnalysisService/StreamCalls"
+	AnalysisService_SearchSymbols_FullMethodName      = "/gomcp.v1.AnalysisService/SearchSymbols"
+)
+
+// AnalysisServiceClient is the client API for AnalysisService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// AnalysisService serves the results of a completed analysis run.
+type AnalysisServiceClient interface {
+	// GetProjectAnalysis returns the complete analysis in a single message.
+	GetProjectAnalysis(ctx context.Context, in *GetProjectAnalysisRequest, opts ...grpc.CallOption) (*ProjectAnalysis, error)
+	// StreamPackages streams packages one at a time.
+	StreamPackages(ctx context.Context, in *StreamPackagesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PackageAnalysis], error)
+	// StreamCalls streams call sites one at a time.
+	StreamCalls(ctx context.Context, in *StreamCallsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CallSite], error)
+	// SearchSymbols finds declared functions, methods, types and interfaces by fuzzy name.
+	SearchSymbols(ctx context.Context, in *SearchSymbolsRequest, opts ...grpc.CallOption) (*SearchSymbolsResponse, error)
+}
+
+type analysisServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAnalysisServiceClient(cc grpc.ClientConnInterface) AnalysisServiceClient {
+	return &analysisServiceClient{cc}
+}
+
+func (c *analysisServiceClient) GetProjectAnalysis(ctx context.Context, in *GetProjectAnalysisRequest, opts ...grpc.CallOption) (*ProjectAnalysis, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProjectAnalysis)
+	err := c.cc.Invoke(ctx, AnalysisService_GetProjectAnalysis_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analysisServiceClient) StreamPackages(ctx context.Context, in *StreamPackagesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PackageAnalysis], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AnalysisService_ServiceDesc.Streams[0], AnalysisService_StreamPackages_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamPackagesRequest, PackageAnalysis]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AnalysisService_StreamPackagesClient = grpc.ServerStreamingClient[PackageAnalysis]
+
+func (c *analysisServiceClient) StreamCalls(ctx context.Context, in *StreamCallsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CallSite], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AnalysisService_ServiceDesc.Streams[1], AnalysisService_StreamCalls_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamCallsRequest, CallSite]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AnalysisService_StreamCallsClient = grpc.ServerStreamingClient[CallSite]
+
+func (c *analysisServiceClient) SearchSymbols(ctx context.Context, in *SearchSymbolsRequest, opts ...grpc.CallOption) (*SearchSymbolsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchSymbolsResponse)
+	err := c.cc.Invoke(ctx, AnalysisService_SearchSymbols_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AnalysisServiceServer is the server API for AnalysisService service.
+// All implementations must embed UnimplementedAnalysisServiceServer
+// for forward compatibility.
+//
+// AnalysisService serves the results of a completed analysis run.
+type AnalysisServiceServer interface {
+	// GetProjectAnalysis returns the complete analysis in a single message.
+	GetProjectAnalysis(context.Context, *GetProjectAnalysisRequest) (*ProjectAnalysis, error)
+	// StreamPackages streams packages one at a time.
+	StreamPackages(*StreamPackagesRequest, grpc.ServerStreamingServer[PackageAnalysis]) error
+	// StreamCalls streams call sites one at a time.
+	StreamCalls(*StreamCallsRequest, grpc.ServerStreamingServer[CallSite]) error
+	// SearchSymbols finds declared functions, methods, types and interfaces by fuzzy name.
+	SearchSymbols(context.Context, *SearchSymbolsRequest) (*SearchSymbolsResponse, error)
+	mustEmbedUnimplementedAnalysisServiceServer()
+}
+
+// UnimplementedAnalysisServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAnalysisServiceServer struct{}
+
+func (UnimplementedAnalysisServiceServer) GetProjectAnalysis(context.Context, *GetProjectAnalysisRequest) (*ProjectAnalysis, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProjectAnalysis not implemented")
+}
+func (UnimplementedAnalysisServiceServer) StreamPackages(*StreamPackagesRequest, grpc.ServerStreamingServer[PackageAnalysis]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamPackages not implemented")
+}
+func (UnimplementedAnalysisServiceServer) StreamCalls(*StreamCallsRequest, grpc.ServerStreamingServer[CallSite]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamCalls not implemented")
+}
+func (UnimplementedAnalysisServiceServer) SearchSymbols(context.Context, *SearchSymbolsRequest) (*SearchSymbolsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchSymbols not implemented")
+}
+func (UnimplementedAnalysisServiceServer) mustEmbedUnimplementedAnalysisServiceServer() {}
+func (UnimplementedAnalysisServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeAnalysisServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AnalysisServiceServer will
+// result in compilation errors.
+type UnsafeAnalysisServiceServer interface {
+	mustEmbedUnimplementedAnalysisServiceServer()
+}
+
+func RegisterAnalysisServiceServer(s grpc.ServiceRegistrar, srv AnalysisServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAnalysisServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AnalysisService_ServiceDesc, srv)
+}
+
+func _AnalysisService_GetProjectAnalysis_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProjectAnalysisRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalysisServiceServer).GetProjectAnalysis(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalysisService_GetProjectAnalysis_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalysisServiceServer).GetProjectAnalysis(ctx, req.(*GetProjectAnalysisRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalysisService_StreamPackages_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamPackagesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AnalysisServiceServer).StreamPackages(m, &grpc.GenericServerStream[StreamPackagesRequest, PackageAnalysis]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AnalysisService_StreamPackagesServer = grpc.ServerStreamingServer[PackageAnalysis]
+
+func _AnalysisService_StreamCalls_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamCallsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AnalysisServiceServer).StreamCalls(m, &grpc.GenericServerStream[StreamCallsRequest, CallSite]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AnalysisService_StreamCallsServer = grpc.ServerStreamingServer[CallSite]
+
+func _AnalysisService_SearchSymbols_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchSymbolsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalysisServiceServer).SearchSymbols(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalysisService_SearchSymbols_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalysisServiceServer).SearchSymbols(ctx, req.(*SearchSymbolsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AnalysisService_ServiceDesc is the grpc.ServiceDesc for AnalysisService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AnalysisService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gomcp.v1.AnalysisService",
+	HandlerType: (*AnalysisServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetProjectAnalysis",
+			Handler:    _AnalysisService_GetProjectAnalysis_Handler,
+		},
+		{
+			MethodName: "SearchSymbols",
+			Handler:    _AnalysisService_SearchSymbols_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamPackages",
+			Handler:       _AnalysisService_StreamPackages_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamCalls",
+			Handler:       _AnalysisService_StreamCalls_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "gomcp/v1/analysis.proto",
+}
@@ -0,0 +1,141 @@
+// grpcapi/server.go
+package grpcapi
+
+//go:generate protoc -I ../../proto --go_out=../.. --go_opt=module=github.com/namikmesic/go-mcp --go-grpc_out=../.. --go-grpc_opt=module=github.com/namikmesic/go-mcp gomcp/v1/analysis.proto
+
+import (
+	"context"
+	"log"
+	"log/slog"
+	"net"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/namikmesic/go-mcp/internal/grpcapi/gomcpv1"
+	"github.com/namikmesic/go-mcp/internal/symbols"
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// Server implements the gomcp.v1.AnalysisService gRPC service over a ProjectAnalysis.
+type Server struct {
+	pb.UnimplementedAnalysisServiceServer
+	mu         sync.RWMutex
+	analysis   *datamodel.ProjectAnalysis
+	symbols    *symbols.Index
+	grpcServer *grpc.Server // Set by ListenAndServe
+}
+
+// Compile-time check to ensure Server implements the generated service interface.
+var _ pb.AnalysisServiceServer = (*Server)(nil)
+
+// NewServer creates a gRPC service for the given analysis results.
+func NewServer(analysis *datamodel.ProjectAnalysis) *Server {
+	if analysis == nil {
+		log.Panicln("Error: Cannot create gRPC Server with nil analysis.")
+	}
+	return &Server{analysis: analysis, symbols: symbols.NewIndex(analysis)}
+}
+
+// Update replaces the served analysis, e.g. after re-analyzing changed files.
+func (s *Server) Update(analysis *datamodel.ProjectAnalysis) {
+	if analysis == nil {
+		return
+	}
+	index := symbols.NewIndex(analysis)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.analysis = analysis
+	s.symbols = index
+}
+
+// current returns the served analysis, which is immutable once published.
+func (s *Server) current() *datamodel.ProjectAnalysis {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.analysis
+}
+
+// currentSymbols returns the symbol index of the served analysis.
+func (s *Server) currentSymbols() *symbols.Index {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.symbols
+}
+
+// ListenAndServe registers the service on a new grpc.Server and serves on addr
+// until the listener fails or Stop is called, in which case it returns nil.
+func (s *Server) ListenAndServe(addr string) error {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	grpcServer := grpc.NewServer()
+	pb.RegisterAnalysisServiceServer(grpcServer, s)
+	s.mu.Lock()
+	s.grpcServer = grpcServer
+	s.mu.Unlock()
+	slog.Info("Serving analysis gRPC API", "addr", addr)
+	return grpcServer.Serve(lis)
+}
+
+// Stop stops accepting connections and waits for in-flight RPCs to finish.
+func (s *Server) Stop() {
+	s.mu.RLock()
+	grpcServer := s.grpcServer
+	s.mu.RUnlock()
+	if grpcServer != nil {
+		grpcServer.GracefulStop()
+	}
+}
+
+func (s *Server) GetProjectAnalysis(ctx context.Context, req *pb.GetProjectAnalysisRequest) (*pb.ProjectAnalysis, error) {
+	return ToProtoProjectAnalysis(s.current()), nil
+}
+
+func (s *Server) StreamPackages(req *pb.StreamPackagesRequest, stream grpc.ServerStreamingServer[pb.PackageAnalysis]) error {
+	for _, pkg := range s.current().Packages {
+		if pkg == nil || (req.GetPath() != "" && pkg.Path != req.GetPath()) {
+			continue
+		}
+		if err := stream.Send(ToProtoPackage(pkg)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Server) StreamCalls(req *pb.StreamCallsRequest, stream grpc.ServerStreamingServer[pb.CallSite]) error {
+	for _, pkg := range s.current().Packages {
+		if pkg == nil {
+			continue
+		}
+		for i := range pkg.Calls {
+			call := &pkg.Calls[i]
+			if req.GetCaller() != "" && !strings.Contains(call.CallerFuncDesc, req.GetCaller()) {
+				continue
+			}
+			if req.GetCallee() != "" && !strings.Contains(call.CalleeDesc, req.GetCallee()) {
+				continue
+			}
+			if err := stream.Send(ToProtoCallSite(call)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Server) SearchSymbols(ctx context.Context, req *pb.SearchSymbolsRequest) (*pb.SearchSymbolsResponse, error) {
+	if strings.TrimSpace(req.GetQuery()) == "" {
+		return nil, status.Error(codes.InvalidArgument, "query is required")
+	}
+	resp := &pb.SearchSymbolsResponse{}
+	for _, m := range s.currentSymbols().Search(req.GetQuery(), req.GetKinds(), int(req.GetLimit())) {
+		resp.Matches = append(resp.Matches, ToProtoSymbolMatch(&m))
+	}
+	return resp, nil
+}
@@ -2,8 +2,11 @@
 package loader
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"go/build"
+	"log/slog"
+	"os"
 	"path/filepath"
 	"strings"
 
@@ -14,6 +17,14 @@ import (
 type GoPackagesLoader struct {
 	// Config allows customizing the packages.Load behavior.
 	Config packages.Config
+	// GOPATH, when set, loads in GOPATH mode with this GOPATH. Otherwise directories
+	// outside any module are loaded in GOPATH mode automatically (see gopathEnv).
+	GOPATH string
+	// Logger receives loading diagnostics; nil logs to slog.Default().
+	Logger *slog.Logger
+	// IncludeVendor also loads the packages of the vendor directory as analyzed packages
+	// instead of only as dependencies.
+	IncludeVendor bool
 }
 
 // NewGoPackagesLoader creates a loader with default configuration for analysis.
@@ -32,44 +43,91 @@ func NewGoPackagesLoader() *GoPackagesLoader {
 				packages.NeedTypesSizes |
 				packages.NeedModule |
 				packages.NeedEmbedFiles |
-				packages.NeedEmbedPatterns,
-			Tests: true, // Include test files
+				packages.NeedEmbedPatterns |
+				packages.NeedForTest,
+			Tests: true, // Include test files; see SupersededVariants for de-duplicating the results
 			// Consider adding BuildFlags if needed, e.g., "-tags=yourtag"
 		},
 	}
 }
 
-func (l *GoPackagesLoader) Load(path string) ([]*packages.Package, error) {
-	// Normalize path by removing trailing separator if present
-	normalizedPath := path
-	if len(normalizedPath) > 0 && normalizedPath[len(normalizedPath)-1] == filepath.Separator {
-		normalizedPath = normalizedPath[:len(normalizedPath)-1]
+// SplitPattern splits a load pattern into the directory it names and whether it ends in
+// "/..." (or "\..." on Windows), matching every package below the directory. Both
+// separators are accepted on every platform, and trailing separators are ignored.
+func SplitPattern(path string) (dir string, recursive bool) {
+	for _, sep := range []string{"/", string(filepath.Separator)} {
+		if trimmed, ok := strings.CutSuffix(path, sep+"..."); ok {
+			path, recursive = trimmed, true
+			break
+		}
+	}
+	if path == "" {
+		return "", recursive
 	}
+	return filepath.Clean(path), recursive
+}
 
-	cfg := l.Config          // Copy base config
-	cfg.Dir = normalizedPath // Set the directory for the current load operation
+func (l *GoPackagesLoader) Load(ctx context.Context, path string) ([]*packages.Package, error) {
+	dir, recursive := SplitPattern(path)
+	cfg := l.Config   // Copy base config
+	cfg.Context = ctx // Cancels the underlying go list invocation
+	cfg.Dir = dir     // Set the directory for the current load operation
 
-	// For a path ending with "...", strip the "..." suffix for the directory setting
-	// Use platform-specific path handling for better cross-platform compatibility
-	pattern := normalizedPath
-	recursiveSuffix := string(filepath.Separator) + "..."
+	// Patterns are relative to cfg.Dir; go patterns use forward slashes on every platform
+	pattern := "."
+	if recursive {
+		pattern = "./..."
+	}
+
+	env := l.gopathEnv(cfg.Dir)
+	if env != nil {
+		base := cfg.Env
+		if base == nil {
+			base = os.Environ()
+		}
+		cfg.Env = append(append([]string{}, base...), env...)
+		l.log().Info("Loading in GOPATH mode", "dir", cfg.Dir, "env", strings.Join(env, " "))
+	}
 
-	if strings.HasSuffix(pattern, recursiveSuffix) {
-		// Remove the /... suffix to get the actual directory
-		cfg.Dir = pattern[:len(pattern)-len(recursiveSuffix)]
-		// Use "." + separator + "..." as the standard recursive pattern
-		pattern = "." + recursiveSuffix
+	patterns := []string{pattern}
+	if l.IncludeVendor {
+		vendorDir := filepath.Join(cfg.Dir, "vendor")
+		goMod := ""
+		if env == nil {
+			goMod = findGoMod(cfg.Dir)
+		}
+		if goMod != "" {
+			vendorDir = filepath.Join(filepath.Dir(goMod), "vendor")
+		}
+		if info, err := os.Stat(vendorDir); err == nil && info.IsDir() {
+			if goMod != "" {
+				// Vendored packages only have import paths when resolved from vendor/
+				cfg.BuildFlags = append(append([]string{}, cfg.BuildFlags...), "-mod=vendor")
+			}
+			patterns = append(patterns, vendorDir+string(filepath.Separator)+"...")
+			l.log().Info("Including vendored packages", "dir", vendorDir)
+		}
 	}
 
-	pkgs, err := packages.Load(&cfg, pattern) // Load using the adjusted pattern
+	pkgs, err := packages.Load(&cfg, patterns...) // Load using the adjusted pattern
 	if err != nil {
 		return nil, fmt.Errorf("loading packages from %s: %w", path, err)
 	}
+	if err := ctx.Err(); err != nil {
+		return nil, err // Type-checking is not interruptible; discard its results
+	}
 
 	// It's good practice to report errors but not necessarily fail entirely
 	// if some packages loaded successfully. The caller can decide.
-	if packages.PrintErrors(pkgs) > 0 {
-		log.Printf("Warning: Encountered errors during package loading from %s, analysis might be incomplete.", path)
+	errorCount := 0
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		for _, err := range pkg.Errors {
+			l.log().Warn("Package loading error", "package", pkg.ID, "error", err)
+			errorCount++
+		}
+	})
+	if errorCount > 0 {
+		l.log().Warn("Encountered errors during package loading; analysis might be incomplete", "path", path, "errors", errorCount)
 	}
 
 	// Filter out packages that completely failed to load types (essential for analysis)
@@ -78,13 +136,108 @@ func (l *GoPackagesLoader) Load(path string) ([]*packages.Package, error) {
 		if pkg.Types != nil || len(pkg.Errors) == 0 { // Keep packages with types or no errors
 			validPkgs = append(validPkgs, pkg)
 		} else {
-			log.Printf("Skipping package %s due to critical loading errors (no types/syntax).", pkg.ID)
+			l.log().Warn("Skipping package due to critical loading errors (no types/syntax)", "package", pkg.ID)
 		}
 	}
 
 	if len(validPkgs) == 0 && len(pkgs) > 0 {
 		return nil, fmt.Errorf("no valid packages could be loaded from %s", path)
 	}
+	if cfg.Tests {
+		validPkgs = dropTestMains(validPkgs)
+	}
 
 	return validPkgs, nil
 }
+
+// SetLogger implements analyzer.LoggerAware.
+func (l *GoPackagesLoader) SetLogger(logger *slog.Logger) {
+	l.Logger = logger
+}
+
+func (l *GoPackagesLoader) log() *slog.Logger {
+	if l.Logger != nil {
+		return l.Logger
+	}
+	return slog.Default()
+}
+
+// gopathEnv returns the environment overrides for loading dir in GOPATH mode, or nil
+// when dir belongs to a module. Without an explicit GOPATH, dir must lie below the src
+// directory of a GOPATH entry or of an ancestor directory named src, which is then
+// prepended to GOPATH.
+func (l *GoPackagesLoader) gopathEnv(dir string) []string {
+	if l.GOPATH != "" {
+		return []string{"GO111MODULE=off", "GOPATH=" + l.GOPATH}
+	}
+	if findGoMod(dir) != "" {
+		return nil
+	}
+	for _, root := range filepath.SplitList(build.Default.GOPATH) {
+		if root != "" && within(filepath.Join(root, "src"), dir) {
+			return []string{"GO111MODULE=off"}
+		}
+	}
+	for d := filepath.Clean(dir); filepath.Dir(d) != d; d = filepath.Dir(d) {
+		if filepath.Base(d) == "src" {
+			gopath := filepath.Dir(d)
+			if build.Default.GOPATH != "" {
+				gopath += string(filepath.ListSeparator) + build.Default.GOPATH
+			}
+			return []string{"GO111MODULE=off", "GOPATH=" + gopath}
+		}
+	}
+	return nil
+}
+
+// findGoMod returns the go.mod file governing dir, or "" if there is none.
+func findGoMod(dir string) string {
+	for d := filepath.Clean(dir); ; d = filepath.Dir(d) {
+		if info, err := os.Stat(filepath.Join(d, "go.mod")); err == nil && !info.IsDir() {
+			return filepath.Join(d, "go.mod")
+		}
+		if filepath.Dir(d) == d {
+			return ""
+		}
+	}
+}
+
+// within reports whether path equals root or lies below it.
+func within(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) && !filepath.IsAbs(rel)
+}
+
+// dropTestMains removes the synthesized test binaries ("p.test") that loading with
+// Tests adds; they contain only generated code.
+func dropTestMains(pkgs []*packages.Package) []*packages.Package {
+	kept := make([]*packages.Package, 0, len(pkgs))
+	for _, pkg := range pkgs {
+		if pkg.ForTest == "" && pkg.Name == "main" && strings.HasSuffix(pkg.ID, ".test") {
+			continue
+		}
+		kept = append(kept, pkg)
+	}
+	return kept
+}
+
+// SupersededVariants returns the plain packages whose test variant "p [p.test]" was also
+// loaded. The test variant has the same declarations plus the in-package _test.go files,
+// so results should be reported for it alone. The plain package must still be analyzed,
+// because its importers (including external "p_test" packages of other packages) refer
+// to its types rather than the test variant's.
+func SupersededVariants(pkgs []*packages.Package) map[*packages.Package]bool {
+	hasTestVariant := make(map[string]bool)
+	for _, pkg := range pkgs {
+		if pkg.ForTest != "" && pkg.ForTest == pkg.PkgPath {
+			hasTestVariant[pkg.PkgPath] = true
+		}
+	}
+	superseded := make(map[*packages.Package]bool)
+	for _, pkg := range pkgs {
+		if pkg.ForTest == "" && hasTestVariant[pkg.PkgPath] {
+			superseded[pkg] = true
+		}
+	}
+	return superseded
+}
@@ -1,10 +1,15 @@
 // loader/loader.go
 package loader
 
-import "golang.org/x/tools/go/packages"
+import (
+	"context"
+
+	"golang.org/x/tools/go/packages"
+)
 
 // Loader defines the interface for loading Go packages.
 type Loader interface {
-	// Load loads packages based on the provided path pattern (e.g., "./...").
-	Load(path string) ([]*packages.Package, error)
+	// Load loads packages based on the provided path pattern (e.g., "./..."). Loading
+	// stops early with ctx.Err() when ctx is cancelled.
+	Load(ctx context.Context, path string) ([]*packages.Package, error)
 }
@@ -0,0 +1,218 @@
+// memstore/build.go
+package memstore
+
+import (
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// Node labels, matching those used by the Neo4j store.
+const (
+	LabelPackage        = "Package"
+	LabelInterface      = "Interface"
+	LabelMethod         = "Method"
+	LabelImplementation = "Implementation"
+	LabelFunction       = "Function"
+	LabelChannel        = "Channel" // In-memory graph only, like the channel edges
+)
+
+// Edge labels, matching those used by the Neo4j store.
+const (
+	EdgeImports    = "IMPORTS"    // Package -> Package, with a "testOnly" prop when recorded
+	EdgeDeclares   = "DECLARES"   // Package -> Interface
+	EdgeHasMethod  = "HAS_METHOD" // Interface -> Method
+	EdgeEmbeds     = "EMBEDS"     // Interface -> Interface
+	EdgeImplements = "IMPLEMENTS" // Implementation -> Interface
+	EdgeContains   = "CONTAINS"   // Package -> Function
+	EdgeCalls      = "CALLS"      // Function -> Function, one edge per call site
+	// Function -> Channel, one edge per operation; select cases have a "select" prop
+	EdgeMakes    = "MAKES"
+	EdgeSends    = "SENDS"
+	EdgeReceives = "RECEIVES"
+	EdgeCloses   = "CLOSES"
+)
+
+// FromAnalysis builds a graph from the analysis results.
+//
+// Node IDs follow the repo-wide conventions: package import paths, packagePath + "." + name
+// for interfaces and implementations, interfaceID + "." + method for methods, and the
+// SSA function descriptions used in CallSite for functions. Imported packages and embedded
+// interfaces outside the analysis become nodes without properties. Channels use the
+// ChannelOp.Channel references; operations on unidentified channels are left out.
+func FromAnalysis(analysis *datamodel.ProjectAnalysis) *Graph {
+	g := New()
+	if analysis == nil {
+		return g
+	}
+
+	for _, pkg := range analysis.Packages {
+		if pkg == nil {
+			continue
+		}
+		g.AddNode(pkg.Path, LabelPackage, map[string]any{
+			"name":       pkg.Name,
+			"uri":        pkg.URI,
+			"files":      pkg.Files,
+			"layer":      pkg.Layer,
+			"unsafeUses": len(pkg.Unsafe),
+			"cgo":        pkg.Cgo != nil,
+		})
+		// Interfaces are added up front so that interfaces which also implement other
+		// interfaces keep the Interface label
+		for _, iface := range pkg.Interfaces {
+			g.AddNode(iface.PackagePath+"."+iface.Name, LabelInterface, map[string]any{
+				"name":        iface.Name,
+				"uri":         iface.URI,
+				"packagePath": iface.PackagePath,
+				"file":        iface.Location.Filename,
+				"line":        iface.Location.Line,
+				"endLine":     iface.Location.EndLine,
+				"docComment":  iface.DocComment,
+			})
+		}
+	}
+
+	contained := make(map[[2]string]bool) // (package, function) pairs already linked
+	for _, pkg := range analysis.Packages {
+		if pkg == nil {
+			continue
+		}
+		if len(pkg.ImportEdges) > 0 {
+			for _, edge := range pkg.ImportEdges {
+				g.AddNode(edge.Imported, LabelPackage, nil)
+				g.AddEdge(pkg.Path, edge.Imported, EdgeImports, map[string]any{"testOnly": edge.TestOnly})
+			}
+		} else {
+			for _, imp := range pkg.Imports {
+				g.AddNode(imp, LabelPackage, nil)
+				g.AddEdge(pkg.Path, imp, EdgeImports, nil)
+			}
+		}
+
+		for _, iface := range pkg.Interfaces {
+			ifaceID := iface.PackagePath + "." + iface.Name
+			g.AddEdge(pkg.Path, ifaceID, EdgeDeclares, nil)
+
+			for _, embed := range iface.Embeds {
+				g.AddNode(embed, LabelInterface, nil)
+				g.AddEdge(ifaceID, embed, EdgeEmbeds, nil)
+			}
+			for _, m := range iface.Methods {
+				methodID := ifaceID + "." + m.Name
+				g.AddNode(methodID, LabelMethod, map[string]any{
+					"name":      m.Name,
+					"uri":       m.URI,
+					"signature": m.Signature,
+					"file":      m.Location.Filename,
+					"line":      m.Location.Line,
+					"endLine":   m.Location.EndLine,
+				})
+				g.AddEdge(ifaceID, methodID, EdgeHasMethod, nil)
+			}
+			addImplementations(g, ifaceID, iface.Implementations)
+		}
+
+		unsafeFuncs := pkg.UnsafeFunctions()
+		for _, fn := range pkg.Functions {
+			g.AddNode(fn.FullName, LabelFunction, map[string]any{
+				"name":      fn.Name,
+				"uri":       fn.URI,
+				"receiver":  fn.Receiver,
+				"signature": fn.Signature,
+				"exported":  fn.Exported,
+				"unsafe":    unsafeFuncs[fn.ID],
+				"file":      fn.Location.Filename,
+				"line":      fn.Location.Line,
+				"endLine":   fn.Location.EndLine,
+			})
+			if key := [2]string{pkg.Path, fn.FullName}; !contained[key] {
+				contained[key] = true
+				g.AddEdge(pkg.Path, fn.FullName, EdgeContains, nil)
+			}
+		}
+
+		for _, call := range pkg.Calls {
+			g.AddNode(call.CallerFuncDesc, LabelFunction, nil)
+			if key := [2]string{pkg.Path, call.CallerFuncDesc}; !contained[key] {
+				contained[key] = true
+				g.AddEdge(pkg.Path, call.CallerFuncDesc, EdgeContains, nil)
+			}
+			g.AddNode(call.CalleeDesc, LabelFunction, nil)
+			g.AddEdge(call.CallerFuncDesc, call.CalleeDesc, EdgeCalls, map[string]any{
+				"uri":      call.URI,
+				"callType": call.CallType,
+				"file":     call.Location.Filename,
+				"line":     call.Location.Line,
+			})
+		}
+		for _, op := range pkg.ChannelOps {
+			if op.Op == datamodel.ChannelSelect {
+				for _, c := range op.Cases {
+					addChannelOp(g, op.Function, c.Channel, c.ChanType, c.Op, op.Location, true)
+				}
+				continue
+			}
+			addChannelOp(g, op.Function, op.Channel, op.ChanType, op.Op, op.Location, false)
+		}
+	}
+
+	// Standard library interfaces only contribute the project's IMPLEMENTS edges
+	for _, iface := range analysis.StdlibInterfaces {
+		ifaceID := iface.PackagePath + "." + iface.Name
+		if iface.PackagePath == "" {
+			ifaceID = iface.Name // Predeclared "error"
+		}
+		g.AddNode(ifaceID, LabelInterface, map[string]any{
+			"name":        iface.Name,
+			"packagePath": iface.PackagePath,
+		})
+		addImplementations(g, ifaceID, iface.Implementations)
+	}
+
+	for _, e := range analysis.CrossModuleImplementations {
+		implID := e.PackagePath + "." + e.TypeName
+		g.AddNode(implID, LabelImplementation, map[string]any{
+			"typeName":    e.TypeName,
+			"packagePath": e.PackagePath,
+			"module":      e.Module,
+			"file":        e.Location.Filename,
+			"line":        e.Location.Line,
+		})
+		g.AddNode(e.Interface, LabelInterface, nil)
+		g.AddEdge(implID, e.Interface, EdgeImplements, map[string]any{"isPointer": e.IsPointer})
+	}
+	return g
+}
+
+var channelEdges = map[string]string{
+	datamodel.ChannelMake:    EdgeMakes,
+	datamodel.ChannelSend:    EdgeSends,
+	datamodel.ChannelReceive: EdgeReceives,
+	datamodel.ChannelClose:   EdgeCloses,
+}
+
+func addChannelOp(g *Graph, fn, channel, chanType, op string, loc datamodel.Location, inSelect bool) {
+	if channel == "" {
+		return
+	}
+	g.AddNode(fn, LabelFunction, nil)
+	g.AddNode(channel, LabelChannel, map[string]any{"type": chanType})
+	props := map[string]any{"file": loc.Filename, "line": loc.Line}
+	if inSelect {
+		props["select"] = true
+	}
+	g.AddEdge(fn, channel, channelEdges[op], props)
+}
+
+func addImplementations(g *Graph, ifaceID string, impls []datamodel.Implementation) {
+	for _, impl := range impls {
+		implID := impl.PackagePath + "." + impl.TypeName
+		g.AddNode(implID, LabelImplementation, map[string]any{
+			"typeName":    impl.TypeName,
+			"uri":         impl.URI,
+			"packagePath": impl.PackagePath,
+			"file":        impl.Location.Filename,
+			"line":        impl.Location.Line,
+		})
+		g.AddEdge(implID, ifaceID, EdgeImplements, map[string]any{"isPointer": impl.IsPointer})
+	}
+}
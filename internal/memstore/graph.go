@@ -0,0 +1,214 @@
+// memstore/graph.go
+package memstore
+
+import (
+	"sort"
+)
+
+// Node is a labeled vertex with arbitrary properties.
+type Node struct {
+	ID    string         `json:"ID"`
+	Label string         `json:"Label"`
+	Props map[string]any `json:"Props,omitempty"`
+}
+
+// Edge is a labeled, directed relationship between two nodes.
+type Edge struct {
+	From  string         `json:"From"`
+	To    string         `json:"To"`
+	Label string         `json:"Label"`
+	Props map[string]any `json:"Props,omitempty"`
+}
+
+// Direction selects which edges of a node are followed.
+type Direction int
+
+const (
+	Outgoing Direction = iota
+	Incoming
+	Both
+)
+
+// Graph is an in-memory property graph with adjacency and label indexes.
+// It is safe for concurrent reads once construction has finished.
+type Graph struct {
+	nodes   map[string]*Node
+	out     map[string][]*Edge
+	in      map[string][]*Edge
+	byLabel map[string][]*Node
+}
+
+func New() *Graph {
+	return &Graph{
+		nodes:   make(map[string]*Node),
+		out:     make(map[string][]*Edge),
+		in:      make(map[string][]*Edge),
+		byLabel: make(map[string][]*Node),
+	}
+}
+
+// AddNode adds a node, or merges props into an existing node with the same ID.
+// The label of an existing node is kept.
+func (g *Graph) AddNode(id, label string, props map[string]any) *Node {
+	if n, ok := g.nodes[id]; ok {
+		if n.Props == nil && len(props) > 0 {
+			n.Props = make(map[string]any, len(props))
+		}
+		for k, v := range props {
+			n.Props[k] = v
+		}
+		return n
+	}
+	n := &Node{ID: id, Label: label, Props: props}
+	g.nodes[id] = n
+	g.byLabel[label] = append(g.byLabel[label], n)
+	return n
+}
+
+// AddEdge adds a directed edge. Both endpoints must already exist; otherwise the
+// edge is dropped and false is returned.
+func (g *Graph) AddEdge(from, to, label string, props map[string]any) bool {
+	if g.nodes[from] == nil || g.nodes[to] == nil {
+		return false
+	}
+	e := &Edge{From: from, To: to, Label: label, Props: props}
+	g.out[from] = append(g.out[from], e)
+	g.in[to] = append(g.in[to], e)
+	return true
+}
+
+// Node returns the node with the given ID.
+func (g *Graph) Node(id string) (*Node, bool) {
+	n, ok := g.nodes[id]
+	return n, ok
+}
+
+// NodeCount returns the number of nodes in the graph.
+func (g *Graph) NodeCount() int {
+	return len(g.nodes)
+}
+
+// NodesByLabel returns all nodes with the given label, sorted by ID.
+func (g *Graph) NodesByLabel(label string) []*Node {
+	return g.Filter(label, nil)
+}
+
+// Filter returns the nodes with the given label (any label if empty) for which keep
+// returns true (all if nil), sorted by ID.
+func (g *Graph) Filter(label string, keep func(*Node) bool) []*Node {
+	var candidates []*Node
+	if label != "" {
+		candidates = g.byLabel[label]
+	} else {
+		candidates = make([]*Node, 0, len(g.nodes))
+		for _, n := range g.nodes {
+			candidates = append(candidates, n)
+		}
+	}
+	result := []*Node{}
+	for _, n := range candidates {
+		if keep == nil || keep(n) {
+			result = append(result, n)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
+
+// Edges returns the edges of node id in the given direction, restricted to
+// edgeLabels when any are given.
+func (g *Graph) Edges(id string, dir Direction, edgeLabels ...string) []*Edge {
+	var result []*Edge
+	if dir == Outgoing || dir == Both {
+		result = appendMatching(result, g.out[id], edgeLabels)
+	}
+	if dir == Incoming || dir == Both {
+		result = appendMatching(result, g.in[id], edgeLabels)
+	}
+	return result
+}
+
+// Neighbors returns the distinct nodes adjacent to id in the given direction,
+// restricted to edgeLabels when any are given, sorted by ID.
+func (g *Graph) Neighbors(id string, dir Direction, edgeLabels ...string) []*Node {
+	seen := make(map[string]bool)
+	result := []*Node{}
+	for _, e := range g.Edges(id, dir, edgeLabels...) {
+		other := otherEnd(e, id)
+		if !seen[other] {
+			seen[other] = true
+			result = append(result, g.nodes[other])
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
+
+// ShortestPath returns the edges of a shortest path from -> to (breadth-first,
+// unweighted) following edges in the given direction and restricted to edgeLabels
+// when any are given. It returns false if no path exists.
+func (g *Graph) ShortestPath(from, to string, dir Direction, edgeLabels ...string) ([]*Edge, bool) {
+	if g.nodes[from] == nil || g.nodes[to] == nil {
+		return nil, false
+	}
+	if from == to {
+		return []*Edge{}, true
+	}
+	via := map[string]*Edge{from: nil} // Node -> edge used to reach it
+	queue := []string{from}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, e := range g.Edges(current, dir, edgeLabels...) {
+			next := otherEnd(e, current)
+			if _, visited := via[next]; visited {
+				continue
+			}
+			via[next] = e
+			if next == to {
+				return g.tracePath(via, from, to), true
+			}
+			queue = append(queue, next)
+		}
+	}
+	return nil, false
+}
+
+// tracePath walks the BFS predecessor edges back from to and returns them in order.
+func (g *Graph) tracePath(via map[string]*Edge, from, to string) []*Edge {
+	var path []*Edge
+	for node := to; node != from; {
+		e := via[node]
+		path = append(path, e)
+		node = otherEnd(e, node)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+func otherEnd(e *Edge, id string) string {
+	if e.From == id {
+		return e.To
+	}
+	return e.From
+}
+
+func appendMatching(dst, edges []*Edge, labels []string) []*Edge {
+	for _, e := range edges {
+		if len(labels) == 0 || containsString(labels, e.Label) {
+			dst = append(dst, e)
+		}
+	}
+	return dst
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,215 @@
+package neo4jstore
+
+// Graph schema written by StoreAnalysis:
+//
+//	(:Package)-[:IMPORTS {testOnly}]->(:Package)
+//	(:Package)-[:DECLARES]->(:Interface)-[:HAS_METHOD]->(:Method)
+//	(:Interface)-[:EMBEDS]->(:Interface)
+//	(:Implementation)-[:IMPLEMENTS {isPointer}]->(:Interface)
+//	(:Package)-[:CONTAINS]->(:CallSite)
+//	(:Package)-[:CONTAINS]->(:Function)
+//	(:Function)-[:HAS_CALLSITE]->(:CallSite)-[:CALLS]->(:Function)
+//
+// Every node created for the analyzed project carries a "module" property so a
+// project's data can be replaced without touching other projects in the same database.
+// Imported packages outside the project are shared and only keyed by path.
+// Project Package nodes also carry a "hash" of their analysis content, which the
+// incremental mode uses to skip unchanged packages.
+
+// schemaStatements create the uniqueness constraints backing the MERGE keys, and the
+// indexes for looking nodes up by their canonical URI.
+var schemaStatements = []string{
+	"CREATE CONSTRAINT package_path IF NOT EXISTS FOR (n:Package) REQUIRE n.path IS UNIQUE",
+	"CREATE CONSTRAINT interface_id IF NOT EXISTS FOR (n:Interface) REQUIRE n.id IS UNIQUE",
+	"CREATE CONSTRAINT method_id IF NOT EXISTS FOR (n:Method) REQUIRE n.id IS UNIQUE",
+	"CREATE CONSTRAINT implementation_id IF NOT EXISTS FOR (n:Implementation) REQUIRE n.id IS UNIQUE",
+	"CREATE CONSTRAINT callsite_id IF NOT EXISTS FOR (n:CallSite) REQUIRE n.id IS UNIQUE",
+	"CREATE CONSTRAINT function_id IF NOT EXISTS FOR (n:Function) REQUIRE n.id IS UNIQUE",
+	"CREATE INDEX package_uri IF NOT EXISTS FOR (n:Package) ON (n.uri)",
+	"CREATE INDEX interface_uri IF NOT EXISTS FOR (n:Interface) ON (n.uri)",
+	"CREATE INDEX method_uri IF NOT EXISTS FOR (n:Method) ON (n.uri)",
+	"CREATE INDEX implementation_uri IF NOT EXISTS FOR (n:Implementation) ON (n.uri)",
+	"CREATE INDEX callsite_uri IF NOT EXISTS FOR (n:CallSite) ON (n.uri)",
+	"CREATE INDEX function_uri IF NOT EXISTS FOR (n:Function) ON (n.uri)",
+}
+
+// projectLabels lists the labels of nodes owned by a project (carrying the module property).
+var projectLabels = []string{"CallSite", "Implementation", "Method", "Interface", "Package"}
+
+// deleteModuleNodesQuery removes one batch of a label's nodes belonging to $module.
+// The label is substituted with fmt.Sprintf since labels cannot be parameterized.
+const deleteModuleNodesQuery = `
+MATCH (n:%s {module: $module})
+WITH n LIMIT $limit
+DETACH DELETE n
+RETURN count(*) AS deleted`
+
+const mergePackagesQuery = `
+UNWIND $rows AS row
+MERGE (p:Package {path: row.path})
+SET p.name = row.name,
+    p.uri = row.uri,
+    p.module = $module,
+    p.hash = row.hash,
+    p.files = row.files,
+    p.embedFiles = row.embedFiles,
+    p.embedPatterns = row.embedPatterns,
+    p.layer = row.layer,
+    p.unsafeUses = row.unsafeUses,
+    p.cgo = row.cgo`
+
+const mergeImportsQuery = `
+UNWIND $rows AS row
+MATCH (p:Package {path: row.from})
+MERGE (dep:Package {path: row.to})
+MERGE (p)-[r:IMPORTS]->(dep)
+SET r.testOnly = row.testOnly`
+
+const mergeInterfacesQuery = `
+UNWIND $rows AS row
+MATCH (p:Package {path: row.packagePath})
+MERGE (i:Interface {id: row.id})
+SET i.name = row.name,
+    i.uri = row.uri,
+    i.packagePath = row.packagePath,
+    i.packageName = row.packageName,
+    i.file = row.file,
+    i.line = row.line,
+    i.endLine = row.endLine,
+    i.docComment = row.docComment,
+    i.module = $module
+MERGE (p)-[:DECLARES]->(i)`
+
+const mergeEmbedsQuery = `
+UNWIND $rows AS row
+MATCH (i:Interface {id: row.from})
+MERGE (e:Interface {id: row.to})
+MERGE (i)-[:EMBEDS]->(e)`
+
+const mergeMethodsQuery = `
+UNWIND $rows AS row
+MATCH (i:Interface {id: row.interfaceId})
+MERGE (m:Method {id: row.id})
+SET m.name = row.name,
+    m.uri = row.uri,
+    m.signature = row.signature,
+    m.parameters = row.parameters,
+    m.returnTypes = row.returnTypes,
+    m.docComment = row.docComment,
+    m.file = row.file,
+    m.line = row.line,
+    m.endLine = row.endLine,
+    m.module = $module
+MERGE (i)-[:HAS_METHOD]->(m)`
+
+const mergeImplementationsQuery = `
+UNWIND $rows AS row
+MATCH (i:Interface {id: row.interfaceId})
+MERGE (t:Implementation {id: row.id})
+SET t.typeName = row.typeName,
+    t.uri = row.uri,
+    t.packagePath = row.packagePath,
+    t.packageName = row.packageName,
+    t.file = row.file,
+    t.line = row.line,
+    t.module = $module
+MERGE (t)-[:IMPLEMENTS {isPointer: row.isPointer}]->(i)`
+
+// mergeFunctionsQuery records function declarations. Function nodes are shared across
+// modules like the call graph, so they carry no module property.
+const mergeFunctionsQuery = `
+UNWIND $rows AS row
+MATCH (p:Package {path: row.packagePath})
+MERGE (f:Function {id: row.id})
+SET f.name = row.name,
+    f.fullName = row.fullName,
+    f.uri = row.uri,
+    f.packagePath = row.packagePath,
+    f.receiver = row.receiver,
+    f.signature = row.signature,
+    f.docComment = row.docComment,
+    f.exported = row.exported,
+    f.unsafe = row.unsafe,
+    f.file = row.file,
+    f.line = row.line,
+    f.endLine = row.endLine
+MERGE (p)-[:CONTAINS]->(f)`
+
+// mergeCallSitesQuery records call sites and links them to the Function nodes of their
+// caller and callee by Function.ID. Calls without a CalleeID (through function values)
+// get no CALLS relationship and are marked resolved: false.
+const mergeCallSitesQuery = `
+UNWIND $rows AS row
+MATCH (p:Package {path: row.packagePath})
+MERGE (c:CallSite {id: row.id})
+SET c.uri = row.uri,
+    c.caller = row.caller,
+    c.callee = row.callee,
+    c.callerDesc = row.callerDesc,
+    c.calleeDesc = row.calleeDesc,
+    c.callType = row.callType,
+    c.resolved = row.callee <> '',
+    c.file = row.file,
+    c.line = row.line,
+    c.module = $module
+MERGE (p)-[:CONTAINS]->(c)
+FOREACH (_ IN CASE WHEN row.caller <> '' THEN [1] ELSE [] END |
+    MERGE (caller:Function {id: row.caller})
+    MERGE (caller)-[:HAS_CALLSITE]->(c))
+FOREACH (_ IN CASE WHEN row.callee <> '' THEN [1] ELSE [] END |
+    MERGE (callee:Function {id: row.callee})
+    MERGE (c)-[:CALLS]->(callee))`
+
+// deleteOrphanFunctionsQuery removes one batch of Function nodes left without any call sites.
+const deleteOrphanFunctionsQuery = `
+MATCH (f:Function)
+WHERE NOT (f)--()
+WITH f LIMIT $limit
+DELETE f
+RETURN count(*) AS deleted`
+
+// existingPackagesQuery reads the content hashes stored for $module's packages.
+const existingPackagesQuery = `
+MATCH (p:Package {module: $module})
+RETURN p.path AS path, p.hash AS hash`
+
+// deletePackageInterfacesQuery removes the interfaces (and their methods) declared by the given packages.
+const deletePackageInterfacesQuery = `
+UNWIND $rows AS row
+MATCH (:Package {path: row.path, module: $module})-[:DECLARES]->(i:Interface)
+OPTIONAL MATCH (i)-[:HAS_METHOD]->(m:Method)
+DETACH DELETE m, i`
+
+// deletePackageCallSitesQuery removes the call sites contained in the given packages.
+const deletePackageCallSitesQuery = `
+UNWIND $rows AS row
+MATCH (:Package {path: row.path, module: $module})-[:CONTAINS]->(c:CallSite)
+DETACH DELETE c`
+
+// deletePackageFunctionsQuery unlinks the functions declared by the given packages;
+// functions left without relationships are removed as orphans.
+const deletePackageFunctionsQuery = `
+UNWIND $rows AS row
+MATCH (:Package {path: row.path, module: $module})-[r:CONTAINS]->(:Function)
+DELETE r`
+
+// deletePackageImportsQuery removes the outgoing IMPORTS relationships of the given packages.
+const deletePackageImportsQuery = `
+UNWIND $rows AS row
+MATCH (:Package {path: row.path, module: $module})-[r:IMPORTS]->()
+DELETE r`
+
+// deletePackagesQuery removes the given package nodes entirely.
+const deletePackagesQuery = `
+UNWIND $rows AS row
+MATCH (p:Package {path: row.path, module: $module})
+DETACH DELETE p`
+
+// deleteOrphanImplementationsQuery removes one batch of $module's Implementation nodes
+// that no longer implement any interface.
+const deleteOrphanImplementationsQuery = `
+MATCH (t:Implementation {module: $module})
+WHERE NOT (t)-[:IMPLEMENTS]->()
+WITH t LIMIT $limit
+DETACH DELETE t
+RETURN count(*) AS deleted`
@@ -0,0 +1,165 @@
+package neo4jstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// storeFormat versions the rows written for a package. It is part of every package
+// hash, so changing how packages are stored rewrites them all on the next incremental
+// run instead of mixing old and new nodes. Version 2 keys Function nodes by Function.ID.
+const storeFormat = "2"
+
+// packageHashes computes a content hash for every package path in the analysis.
+// Slices whose order depends on map iteration are sorted first, and variants sharing
+// the same path (e.g. test variants) are combined, so unchanged code yields identical hashes.
+func packageHashes(analysis *datamodel.ProjectAnalysis) map[string]string {
+	variantHashes := make(map[string][]string)
+	for _, pkg := range analysis.Packages {
+		if pkg == nil {
+			continue
+		}
+		variantHashes[pkg.Path] = append(variantHashes[pkg.Path], hashPackage(pkg))
+	}
+
+	hashes := make(map[string]string, len(variantHashes))
+	for path, variants := range variantHashes {
+		sort.Strings(variants)
+		sum := sha256.New()
+		sum.Write([]byte(storeFormat))
+		for _, v := range variants {
+			sum.Write([]byte(v))
+		}
+		hashes[path] = hex.EncodeToString(sum.Sum(nil))
+	}
+	return hashes
+}
+
+// hashPackage hashes a canonical (sorted) copy of a single package analysis.
+func hashPackage(pkg *datamodel.PackageAnalysis) string {
+	canonical := *pkg
+	canonical.Imports = append([]string(nil), pkg.Imports...)
+	sort.Strings(canonical.Imports)
+
+	canonical.Interfaces = make([]datamodel.Interface, len(pkg.Interfaces))
+	for i, iface := range pkg.Interfaces {
+		iface.Implementations = append([]datamodel.Implementation(nil), iface.Implementations...)
+		sort.Slice(iface.Implementations, func(a, b int) bool {
+			x, y := iface.Implementations[a], iface.Implementations[b]
+			if x.PackagePath+"."+x.TypeName != y.PackagePath+"."+y.TypeName {
+				return x.PackagePath+"."+x.TypeName < y.PackagePath+"."+y.TypeName
+			}
+			return !x.IsPointer && y.IsPointer
+		})
+		canonical.Interfaces[i] = iface
+	}
+	sort.Slice(canonical.Interfaces, func(a, b int) bool {
+		return canonical.Interfaces[a].Name < canonical.Interfaces[b].Name
+	})
+
+	canonical.Calls = append([]datamodel.CallSite(nil), pkg.Calls...)
+	sort.Slice(canonical.Calls, func(a, b int) bool {
+		return callSiteID(canonical.Calls[a]) < callSiteID(canonical.Calls[b])
+	})
+
+	data, err := json.Marshal(canonical)
+	if err != nil {
+		// Should not happen for plain data; an empty hash forces the package to be rewritten
+		slog.Warn("Could not hash package", "package", pkg.Path, "error", err)
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// existingPackageHashes reads the package hashes previously stored for module.
+func (s *Neo4jStore) existingPackageHashes(ctx context.Context, session neo4j.SessionWithContext, module string) (map[string]string, error) {
+	return neo4j.ExecuteRead(ctx, session, func(tx neo4j.ManagedTransaction) (map[string]string, error) {
+		res, err := tx.Run(ctx, existingPackagesQuery, map[string]any{"module": module})
+		if err != nil {
+			return nil, err
+		}
+		records, err := res.Collect(ctx)
+		if err != nil {
+			return nil, err
+		}
+		hashes := make(map[string]string, len(records))
+		for _, record := range records {
+			path, _, err := neo4j.GetRecordValue[string](record, "path")
+			if err != nil {
+				return nil, err
+			}
+			// Packages stored before hashes existed have a null hash and are treated as changed
+			hash, _, _ := neo4j.GetRecordValue[string](record, "hash")
+			hashes[path] = hash
+		}
+		return hashes, nil
+	})
+}
+
+// diffPackageHashes returns the package paths that are new or modified, and those that disappeared.
+func diffPackageHashes(existing, current map[string]string) (changed, removed []string) {
+	for path, hash := range current {
+		if old, ok := existing[path]; !ok || old != hash || hash == "" {
+			changed = append(changed, path)
+		}
+	}
+	for path := range existing {
+		if _, ok := current[path]; !ok {
+			removed = append(removed, path)
+		}
+	}
+	sort.Strings(changed)
+	sort.Strings(removed)
+	return changed, removed
+}
+
+// clearPackages deletes the contents of changed packages and the removed packages themselves.
+// Package nodes of changed packages are kept so IMPORTS relationships from other packages survive.
+func (s *Neo4jStore) clearPackages(ctx context.Context, session neo4j.SessionWithContext, module string, changed, removed []string) error {
+	stale := pathRows(append(append([]string{}, changed...), removed...))
+	for _, step := range []struct {
+		name  string
+		query string
+		rows  []map[string]any
+	}{
+		{"interfaces", deletePackageInterfacesQuery, stale},
+		{"call sites", deletePackageCallSitesQuery, stale},
+		{"functions", deletePackageFunctionsQuery, stale},
+		{"imports", deletePackageImportsQuery, stale},
+		{"packages", deletePackagesQuery, pathRows(removed)},
+	} {
+		if err := s.writeBatches(ctx, session, step.query, module, step.rows); err != nil {
+			return fmt.Errorf("clearing stale %s: %w", step.name, err)
+		}
+	}
+	return nil
+}
+
+func pathRows(paths []string) []map[string]any {
+	rows := make([]map[string]any, len(paths))
+	for i, path := range paths {
+		rows[i] = map[string]any{"path": path}
+	}
+	return rows
+}
+
+// deleteOrphans removes Implementation and Function nodes left without relationships.
+func (s *Neo4jStore) deleteOrphans(ctx context.Context, session neo4j.SessionWithContext, module string) error {
+	if err := s.deleteInBatches(ctx, session, deleteOrphanImplementationsQuery, map[string]any{"module": module}); err != nil {
+		return fmt.Errorf("deleting orphaned Implementation nodes: %w", err)
+	}
+	if err := s.deleteInBatches(ctx, session, deleteOrphanFunctionsQuery, map[string]any{}); err != nil {
+		return fmt.Errorf("deleting orphaned Function nodes: %w", err)
+	}
+	return nil
+}
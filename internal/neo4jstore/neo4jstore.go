@@ -3,11 +3,11 @@ package neo4jstore
 import (
 	"context"
 	"fmt"
+	"log/slog"
 
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
-	// Assuming the analysis result struct is defined in a 'datamodel' package
-	// Adjust the import path if your datamodel package is located elsewhere.
-	"github.com/namikmesic/go-mcp/internal/datamodel"
+
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
 )
 
 // GraphStorer defines the interface for storing project analysis data in a graph database.
@@ -18,10 +18,17 @@ type GraphStorer interface {
 	Close(ctx context.Context) error
 }
 
+// DefaultBatchSize is the number of rows sent per UNWIND batch when BatchSize is unset.
+const DefaultBatchSize = 1000
+
 // Neo4jStore implements the GraphStorer interface using a Neo4j database.
 type Neo4jStore struct {
 	driver   neo4j.DriverWithContext
 	database string // Target database name (optional, for Neo4j 4.0+)
+	// BatchSize is the number of rows written per transaction. Zero means DefaultBatchSize.
+	BatchSize int
+	// Incremental rewrites only changed packages instead of replacing the whole module.
+	Incremental bool
 }
 
 // Compile-time check to ensure Neo4jStore implements GraphStorer.
@@ -45,46 +52,182 @@ func NewNeo4jStore(ctx context.Context, uri, username, password, database string
 		return nil, fmt.Errorf("could not verify Neo4j connection: %w", err)
 	}
 
-	fmt.Println("Neo4j connection established successfully.")
+	slog.Info("Neo4j connection established")
 
 	return &Neo4jStore{
-		driver:   driver,
-		database: database,
+		driver:    driver,
+		database:  database,
+		BatchSize: DefaultBatchSize,
 	}, nil
 }
 
 // Close closes the underlying Neo4j driver connection.
 func (s *Neo4jStore) Close(ctx context.Context) error {
 	if s.driver != nil {
-		fmt.Println("Closing Neo4j connection.")
+		slog.Info("Closing Neo4j connection")
 		return s.driver.Close(ctx)
 	}
 	return nil
 }
 
-// StoreAnalysis is the method to store the analysis results in Neo4j.
-// This is currently a stub implementation.
+// StoreAnalysis persists the analysis results in Neo4j.
+// By default any data previously stored for the same module is removed first. In
+// Incremental mode only packages whose content hash changed are rewritten, and
+// packages that no longer exist are deleted. Packages, interfaces, methods,
+// implementations and call sites are written using UNWIND-batched Cypher, one
+// managed transaction per batch.
 func (s *Neo4jStore) StoreAnalysis(ctx context.Context, analysis *datamodel.ProjectAnalysis) error {
-	// TODO: Implement the logic to store the analysis data in Neo4j.
-	// This will involve creating nodes and relationships based on the
-	// contents of the 'analysis' struct (Packages, Interfaces, Calls, etc.).
-	fmt.Printf("Stub: StoreAnalysis called. Would store analysis for %d packages.\n", len(analysis.Packages))
-	// Example: Accessing driver and database name
-	// fmt.Printf("Using database: %s\n", s.database)
-	// session := s.driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: s.database})
-	// defer session.Close(ctx)
-	// ... Neo4j write operations ...
-
-	return nil // Placeholder return
+	if analysis == nil {
+		return fmt.Errorf("cannot store nil analysis")
+	}
+
+	session := s.driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: s.database})
+	defer session.Close(ctx)
+
+	if err := s.ensureSchema(ctx, session); err != nil {
+		return err
+	}
+
+	hashes := packageHashes(analysis)
+	pkgs := analysis.Packages
+	if s.Incremental {
+		existing, err := s.existingPackageHashes(ctx, session, analysis.ModulePath)
+		if err != nil {
+			return fmt.Errorf("reading stored package hashes: %w", err)
+		}
+		changed, removed := diffPackageHashes(existing, hashes)
+		slog.Info("Incremental update", "changed", len(changed), "removed", len(removed), "unchanged", len(hashes)-len(changed))
+		if err := s.clearPackages(ctx, session, analysis.ModulePath, changed, removed); err != nil {
+			return err
+		}
+
+		changedSet := make(map[string]bool, len(changed))
+		for _, path := range changed {
+			changedSet[path] = true
+		}
+		pkgs = nil
+		for _, pkg := range analysis.Packages {
+			if pkg != nil && changedSet[pkg.Path] {
+				pkgs = append(pkgs, pkg)
+			}
+		}
+	} else if err := s.deleteModule(ctx, session, analysis.ModulePath); err != nil {
+		return err
+	}
+
+	rows := buildRows(pkgs, hashes)
+	steps := []struct {
+		name  string
+		query string
+		rows  []map[string]any
+	}{
+		{"packages", mergePackagesQuery, rows.packages},
+		{"imports", mergeImportsQuery, rows.imports},
+		{"interfaces", mergeInterfacesQuery, rows.interfaces},
+		{"embeds", mergeEmbedsQuery, rows.embeds},
+		{"methods", mergeMethodsQuery, rows.methods},
+		{"implementations", mergeImplementationsQuery, rows.implementations},
+		{"functions", mergeFunctionsQuery, rows.functions},
+		{"call sites", mergeCallSitesQuery, rows.callSites},
+	}
+	for _, step := range steps {
+		if err := s.writeBatches(ctx, session, step.query, analysis.ModulePath, step.rows); err != nil {
+			return fmt.Errorf("storing %s: %w", step.name, err)
+		}
+		slog.Info("Stored rows in Neo4j", "count", len(step.rows), "kind", step.name)
+	}
+
+	if s.Incremental {
+		// Implementations and functions may have lost all their relationships
+		return s.deleteOrphans(ctx, session, analysis.ModulePath)
+	}
+	return nil
 }
 
-// Placeholder for the ProjectAnalysis struct definition.
-// You should replace this with your actual datamodel package import
-// or define the struct properly if it doesn't exist yet.
-// namespace datamodel {
-// type ProjectAnalysis struct {
-// 	Packages []PackageInfo // Assuming PackageInfo is defined similarly to main.go
-// 	// Add other relevant fields from your analysis output
-// }
-// }
-// Note: The above placeholder is commented out as it should be in its own package.
+// ensureSchema creates the constraints used as MERGE keys.
+func (s *Neo4jStore) ensureSchema(ctx context.Context, session neo4j.SessionWithContext) error {
+	for _, stmt := range schemaStatements {
+		_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+			res, err := tx.Run(ctx, stmt, nil)
+			if err != nil {
+				return nil, err
+			}
+			return res.Consume(ctx)
+		})
+		if err != nil {
+			return fmt.Errorf("creating schema (%s): %w", stmt, err)
+		}
+	}
+	return nil
+}
+
+// deleteModule removes all nodes owned by module in batches, then drops orphaned Function nodes.
+func (s *Neo4jStore) deleteModule(ctx context.Context, session neo4j.SessionWithContext, module string) error {
+	for _, label := range projectLabels {
+		if err := s.deleteInBatches(ctx, session, fmt.Sprintf(deleteModuleNodesQuery, label), map[string]any{"module": module}); err != nil {
+			return fmt.Errorf("deleting existing %s nodes: %w", label, err)
+		}
+	}
+	if err := s.deleteInBatches(ctx, session, deleteOrphanFunctionsQuery, map[string]any{}); err != nil {
+		return fmt.Errorf("deleting orphaned Function nodes: %w", err)
+	}
+	return nil
+}
+
+// deleteInBatches runs a "... LIMIT $limit ... RETURN count(*) AS deleted" query until nothing is left.
+func (s *Neo4jStore) deleteInBatches(ctx context.Context, session neo4j.SessionWithContext, query string, params map[string]any) error {
+	params["limit"] = s.batchSize()
+	for {
+		deleted, err := neo4j.ExecuteWrite(ctx, session, func(tx neo4j.ManagedTransaction) (int64, error) {
+			res, err := tx.Run(ctx, query, params)
+			if err != nil {
+				return 0, err
+			}
+			record, err := res.Single(ctx)
+			if err != nil {
+				return 0, err
+			}
+			count, _, err := neo4j.GetRecordValue[int64](record, "deleted")
+			return count, err
+		})
+		if err != nil {
+			return err
+		}
+		if deleted == 0 {
+			return nil
+		}
+	}
+}
+
+// writeBatches runs query once per batch of rows, each in its own managed transaction.
+func (s *Neo4jStore) writeBatches(ctx context.Context, session neo4j.SessionWithContext, query, module string, rows []map[string]any) error {
+	size := s.batchSize()
+	for start := 0; start < len(rows); start += size {
+		end := start + size
+		if end > len(rows) {
+			end = len(rows)
+		}
+		params := map[string]any{
+			"module": module,
+			"rows":   rows[start:end],
+		}
+		_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+			res, err := tx.Run(ctx, query, params)
+			if err != nil {
+				return nil, err
+			}
+			return res.Consume(ctx)
+		})
+		if err != nil {
+			return fmt.Errorf("batch %d-%d: %w", start, end, err)
+		}
+	}
+	return nil
+}
+
+func (s *Neo4jStore) batchSize() int {
+	if s.BatchSize <= 0 {
+		return DefaultBatchSize
+	}
+	return s.BatchSize
+}
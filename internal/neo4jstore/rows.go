@@ -0,0 +1,152 @@
+package neo4jstore
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// graphRows holds the UNWIND parameter rows for each write step.
+type graphRows struct {
+	packages        []map[string]any
+	imports         []map[string]any
+	interfaces      []map[string]any
+	embeds          []map[string]any
+	methods         []map[string]any
+	implementations []map[string]any
+	callSites       []map[string]any
+	functions       []map[string]any
+}
+
+// buildRows flattens the given packages into parameter rows for the batched queries.
+// hashes maps package paths to the content hash stored on the Package node.
+func buildRows(pkgs []*datamodel.PackageAnalysis, hashes map[string]string) *graphRows {
+	rows := &graphRows{}
+	for _, pkg := range pkgs {
+		if pkg == nil {
+			continue
+		}
+		rows.packages = append(rows.packages, map[string]any{
+			"path":          pkg.Path,
+			"hash":          hashes[pkg.Path],
+			"name":          pkg.Name,
+			"uri":           pkg.URI,
+			"files":         pkg.Files,
+			"embedFiles":    pkg.EmbedFiles,
+			"embedPatterns": pkg.EmbedPatterns,
+			"layer":         pkg.Layer,
+			"unsafeUses":    len(pkg.Unsafe),
+			"cgo":           pkg.Cgo != nil,
+		})
+		if len(pkg.ImportEdges) > 0 {
+			for _, edge := range pkg.ImportEdges {
+				rows.imports = append(rows.imports, map[string]any{"from": pkg.Path, "to": edge.Imported, "testOnly": edge.TestOnly})
+			}
+		} else {
+			for _, imp := range pkg.Imports {
+				rows.imports = append(rows.imports, map[string]any{"from": pkg.Path, "to": imp, "testOnly": false})
+			}
+		}
+
+		for _, iface := range pkg.Interfaces {
+			ifaceID := iface.PackagePath + "." + iface.Name
+			rows.interfaces = append(rows.interfaces, map[string]any{
+				"id":          ifaceID,
+				"name":        iface.Name,
+				"uri":         iface.URI,
+				"packagePath": iface.PackagePath,
+				"packageName": iface.PackageName,
+				"file":        iface.Location.Filename,
+				"line":        iface.Location.Line,
+				"endLine":     iface.Location.EndLine,
+				"docComment":  iface.DocComment,
+			})
+			for _, embed := range iface.Embeds {
+				rows.embeds = append(rows.embeds, map[string]any{"from": ifaceID, "to": embed})
+			}
+			for _, m := range iface.Methods {
+				params := make([]string, 0, len(m.Parameters))
+				for _, p := range m.Parameters {
+					// Neo4j properties cannot hold maps, so parameters are stored as "name type" strings
+					typ := p.Type
+					if p.IsPointer {
+						typ = "*" + typ
+					}
+					params = append(params, strings.TrimSpace(p.Name+" "+typ))
+				}
+				rows.methods = append(rows.methods, map[string]any{
+					"id":          ifaceID + "." + m.Name,
+					"interfaceId": ifaceID,
+					"name":        m.Name,
+					"uri":         m.URI,
+					"signature":   m.Signature,
+					"parameters":  params,
+					"returnTypes": m.ReturnTypes,
+					"docComment":  m.DocComment,
+					"file":        m.Location.Filename,
+					"line":        m.Location.Line,
+					"endLine":     m.Location.EndLine,
+				})
+			}
+			for _, impl := range iface.Implementations {
+				rows.implementations = append(rows.implementations, map[string]any{
+					"id":          impl.PackagePath + "." + impl.TypeName,
+					"interfaceId": ifaceID,
+					"typeName":    impl.TypeName,
+					"uri":         impl.URI,
+					"packagePath": impl.PackagePath,
+					"packageName": impl.PackageName,
+					"isPointer":   impl.IsPointer,
+					"file":        impl.Location.Filename,
+					"line":        impl.Location.Line,
+				})
+			}
+		}
+
+		unsafeFuncs := pkg.UnsafeFunctions()
+		for _, fn := range pkg.Functions {
+			rows.functions = append(rows.functions, map[string]any{
+				"id":          fn.ID,
+				"packagePath": pkg.Path,
+				"name":        fn.Name,
+				"fullName":    fn.FullName,
+				"uri":         fn.URI,
+				"receiver":    fn.Receiver,
+				"signature":   fn.Signature,
+				"docComment":  fn.DocComment,
+				"exported":    fn.Exported,
+				"unsafe":      unsafeFuncs[fn.ID],
+				"file":        fn.Location.Filename,
+				"line":        fn.Location.Line,
+				"endLine":     fn.Location.EndLine,
+			})
+		}
+
+		for _, call := range pkg.Calls {
+			rows.callSites = append(rows.callSites, map[string]any{
+				"id":          callSiteID(call),
+				"packagePath": pkg.Path,
+				"uri":         call.URI,
+				"caller":      call.CallerID,
+				"callee":      call.CalleeID,
+				"callerDesc":  call.CallerFuncDesc,
+				"calleeDesc":  call.CalleeDesc,
+				"callType":    call.CallType,
+				"file":        call.Location.Filename,
+				"line":        call.Location.Line,
+			})
+		}
+	}
+	return rows
+}
+
+// callSiteID builds a key unique per call instruction: position, call type, caller and
+// callee. Calls without a CalleeID (dynamic calls) fall back to the callee description.
+func callSiteID(call datamodel.CallSite) string {
+	callee := call.CalleeID
+	if callee == "" {
+		callee = call.CalleeDesc
+	}
+	return fmt.Sprintf("%s:%d:%d|%s|%s|%s", call.Location.Filename, call.Location.Line, call.Location.Column, call.CallType, call.CallerID, callee)
+}
@@ -0,0 +1,30 @@
+// output/api.go
+package output
+
+import (
+	"bufio"
+	"io"
+
+	"github.com/namikmesic/go-mcp/internal/apisurface"
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// APIRenderer implements Renderer by writing only the exported API of the public
+// packages, one declaration per line in the format of the Go distribution's api
+// files ("pkg example.com/m/store, func New() *Mem"). Lines are sorted and signatures
+// omit parameter names, so the output only changes when the API does and can be
+// checked in as a golden file.
+type APIRenderer struct{}
+
+func NewAPIRenderer() *APIRenderer {
+	return &APIRenderer{}
+}
+
+func (r *APIRenderer) Render(w io.Writer, analysis *datamodel.ProjectAnalysis) error {
+	bw := bufio.NewWriter(w)
+	for _, line := range apisurface.Lines(analysis) {
+		bw.WriteString(line)
+		bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
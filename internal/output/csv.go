@@ -0,0 +1,196 @@
+// output/csv.go
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// CSV table headers. Columns are only ever appended, so consumers can rely on positions.
+var (
+	csvPackagesHeader        = []string{"path", "name", "layer", "files", "imports", "interfaces", "calls", "ca", "ce", "instability", "abstractness", "distance", "lines", "code_lines", "functions", "statements"}
+	csvInterfacesHeader      = []string{"id", "package", "name", "file", "line", "methods", "embeds", "implementations", "stability", "doc"}
+	csvImplementationsHeader = []string{"interface", "type", "package", "pointer", "file", "line"}
+	csvCallsHeader           = []string{"package", "caller", "callee", "call_type", "opaque", "file", "line"}
+	csvImportsHeader         = []string{"importer", "imported", "test_only", "file", "line"}
+)
+
+// CSVRenderer implements Renderer by writing packages.csv, interfaces.csv,
+// implementations.csv, calls.csv and imports.csv into Dir, one row per entity.
+// Multi-valued cells (imports, methods, embeds) are joined with ";". The names of the
+// written files are printed to the Render writer.
+type CSVRenderer struct {
+	Dir string
+}
+
+// NewCSVRenderer creates a renderer writing its tables into dir.
+func NewCSVRenderer(dir string) *CSVRenderer {
+	return &CSVRenderer{Dir: dir}
+}
+
+func (r *CSVRenderer) Render(w io.Writer, analysis *datamodel.ProjectAnalysis) error {
+	if err := os.MkdirAll(r.Dir, 0o755); err != nil {
+		return fmt.Errorf("creating %s: %w", r.Dir, err)
+	}
+
+	tables := []struct {
+		name string
+		rows [][]string
+	}{
+		{"packages.csv", csvPackages(analysis)},
+		{"interfaces.csv", csvInterfaces(analysis)},
+		{"implementations.csv", csvImplementations(analysis)},
+		{"calls.csv", csvCalls(analysis)},
+		{"imports.csv", csvImports(analysis)},
+	}
+	for _, t := range tables {
+		path := filepath.Join(r.Dir, t.name)
+		if err := writeCSVFile(path, t.rows); err != nil {
+			return err
+		}
+		fmt.Fprintln(w, path)
+	}
+	return nil
+}
+
+func writeCSVFile(path string, rows [][]string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating %s: %w", path, err)
+	}
+	cw := csv.NewWriter(f)
+	if err := cw.WriteAll(rows); err != nil {
+		f.Close()
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return f.Close()
+}
+
+func csvPackages(analysis *datamodel.ProjectAnalysis) [][]string {
+	rows := [][]string{csvPackagesHeader}
+	for _, pkg := range analysis.Packages {
+		if pkg == nil {
+			continue
+		}
+		m := pkg.Metrics
+		if m == nil {
+			m = &datamodel.PackageMetrics{}
+		}
+		rows = append(rows, []string{
+			pkg.Path,
+			pkg.Name,
+			strconv.Itoa(pkg.Layer),
+			strconv.Itoa(len(pkg.Files)),
+			strings.Join(pkg.Imports, ";"),
+			strconv.Itoa(len(pkg.Interfaces)),
+			strconv.Itoa(len(pkg.Calls)),
+			strconv.Itoa(m.AfferentCoupling),
+			strconv.Itoa(m.EfferentCoupling),
+			strconv.FormatFloat(m.Instability, 'f', -1, 64),
+			strconv.FormatFloat(m.Abstractness, 'f', -1, 64),
+			strconv.FormatFloat(m.Distance, 'f', -1, 64),
+			strconv.Itoa(m.Lines),
+			strconv.Itoa(m.CodeLines),
+			strconv.Itoa(m.Functions),
+			strconv.Itoa(m.Statements),
+		})
+	}
+	return rows
+}
+
+func csvInterfaces(analysis *datamodel.ProjectAnalysis) [][]string {
+	rows := [][]string{csvInterfacesHeader}
+	for _, pkg := range analysis.Packages {
+		if pkg == nil {
+			continue
+		}
+		for _, iface := range pkg.Interfaces {
+			methods := make([]string, 0, len(iface.Methods))
+			for _, m := range iface.Methods {
+				methods = append(methods, m.Name)
+			}
+			rows = append(rows, []string{
+				iface.PackagePath + "." + iface.Name,
+				iface.PackagePath,
+				iface.Name,
+				iface.Location.Filename,
+				strconv.Itoa(iface.Location.Line),
+				strings.Join(methods, ";"),
+				strings.Join(iface.Embeds, ";"),
+				strconv.Itoa(len(iface.Implementations)),
+				iface.Stability,
+				iface.DocComment,
+			})
+		}
+	}
+	return rows
+}
+
+func csvImplementations(analysis *datamodel.ProjectAnalysis) [][]string {
+	rows := [][]string{csvImplementationsHeader}
+	for _, pkg := range analysis.Packages {
+		if pkg == nil {
+			continue
+		}
+		for _, iface := range pkg.Interfaces {
+			for _, impl := range iface.Implementations {
+				rows = append(rows, []string{
+					iface.PackagePath + "." + iface.Name,
+					impl.TypeName,
+					impl.PackagePath,
+					strconv.FormatBool(impl.IsPointer),
+					impl.Location.Filename,
+					strconv.Itoa(impl.Location.Line),
+				})
+			}
+		}
+	}
+	return rows
+}
+
+func csvCalls(analysis *datamodel.ProjectAnalysis) [][]string {
+	rows := [][]string{csvCallsHeader}
+	for _, pkg := range analysis.Packages {
+		if pkg == nil {
+			continue
+		}
+		for _, call := range pkg.Calls {
+			rows = append(rows, []string{
+				pkg.Path,
+				call.CallerFuncDesc,
+				call.CalleeDesc,
+				call.CallType,
+				strconv.FormatBool(call.CalleeOpaque),
+				call.Location.Filename,
+				strconv.Itoa(call.Location.Line),
+			})
+		}
+	}
+	return rows
+}
+
+func csvImports(analysis *datamodel.ProjectAnalysis) [][]string {
+	rows := [][]string{csvImportsHeader}
+	for _, pkg := range analysis.Packages {
+		if pkg == nil {
+			continue
+		}
+		for _, edge := range pkg.ImportEdges {
+			rows = append(rows, []string{
+				edge.Importer,
+				edge.Imported,
+				strconv.FormatBool(edge.TestOnly),
+				edge.Location.Filename,
+				strconv.Itoa(edge.Location.Line),
+			})
+		}
+	}
+	return rows
+}
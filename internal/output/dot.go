@@ -0,0 +1,178 @@
+// output/dot.go
+package output
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// callTypeColors maps CallSite.CallType to the DOT edge color.
+var callTypeColors = map[string]string{
+	"Static":    "black",
+	"Interface": "blue",
+	"Dynamic":   "darkorange",
+	"Go":        "darkgreen",
+	"Defer":     "purple",
+}
+
+// DOTRenderer implements Renderer by writing the call graph as a Graphviz digraph.
+// Functions are nodes (grouped into one cluster per analyzed package) and each distinct
+// caller/callee/call type triple is one edge, colored by call type.
+type DOTRenderer struct {
+	// Layered ranks caller functions by their package's Layer, so the diagram reads
+	// from the top layer down to the foundation layer.
+	Layered bool
+}
+
+// NewDOTRenderer creates a renderer producing a layered call graph.
+func NewDOTRenderer() *DOTRenderer {
+	return &DOTRenderer{Layered: true}
+}
+
+type dotEdge struct {
+	from, to, callType string
+	count              int
+	opaque             bool
+}
+
+func (r *DOTRenderer) Render(w io.Writer, analysis *datamodel.ProjectAnalysis) error {
+	bw := bufio.NewWriter(w)
+	// Module-internal names are shown relative to the module to keep labels readable
+	shorten := func(desc string) string { return desc }
+	if analysis.ModulePath != "" {
+		replacer := strings.NewReplacer(analysis.ModulePath+"/", "", analysis.ModulePath+".", "")
+		shorten = replacer.Replace
+	}
+
+	clusters := make(map[string]map[string]bool) // package path -> caller functions
+	pkgLayers := make(map[string]int)
+	nodes := make(map[string]bool)
+	edges := make(map[[3]string]*dotEdge)
+	for _, pkg := range analysis.Packages {
+		if pkg == nil {
+			continue
+		}
+		for _, call := range pkg.Calls {
+			if clusters[pkg.Path] == nil {
+				clusters[pkg.Path] = make(map[string]bool)
+			}
+			clusters[pkg.Path][call.CallerFuncDesc] = true
+			pkgLayers[pkg.Path] = pkg.Layer
+			nodes[call.CallerFuncDesc] = true
+			nodes[call.CalleeDesc] = true
+			key := [3]string{call.CallerFuncDesc, call.CalleeDesc, call.CallType}
+			if e := edges[key]; e != nil {
+				e.count++
+				continue
+			}
+			edges[key] = &dotEdge{from: key[0], to: key[1], callType: key[2], count: 1, opaque: call.CalleeOpaque}
+		}
+	}
+
+	fmt.Fprintln(bw, "digraph callgraph {")
+	fmt.Fprintln(bw, "  rankdir=LR;")
+	fmt.Fprintln(bw, "  node [shape=box, fontname=\"Helvetica\", fontsize=10];")
+	fmt.Fprintln(bw, "  edge [fontname=\"Helvetica\", fontsize=8];")
+
+	// Clusters are ordered from the top layer down so Graphviz lays them out in that order
+	pkgPaths := sortedKeys(clusters)
+	sort.SliceStable(pkgPaths, func(i, j int) bool { return pkgLayers[pkgPaths[i]] > pkgLayers[pkgPaths[j]] })
+	layerMembers := make(map[int][]string)
+	placed := make(map[string]bool)
+	for i, pkgPath := range pkgPaths {
+		fmt.Fprintf(bw, "  subgraph cluster_%d {\n", i)
+		fmt.Fprintf(bw, "    label=%s;\n", dotQuote(fmt.Sprintf("%s (layer %d)", shorten(pkgPath), pkgLayers[pkgPath])))
+		for _, fn := range sortedKeys(clusters[pkgPath]) {
+			if placed[fn] {
+				continue // Test variants share functions with the package under test
+			}
+			placed[fn] = true
+			layerMembers[pkgLayers[pkgPath]] = append(layerMembers[pkgLayers[pkgPath]], fn)
+			fmt.Fprintf(bw, "    %s [label=%s];\n", dotQuote(fn), dotQuote(shorten(fn)))
+		}
+		fmt.Fprintln(bw, "  }")
+	}
+	if r.Layered && len(layerMembers) > 1 {
+		writeDOTLayers(bw, layerMembers)
+	}
+	for _, fn := range sortedKeys(nodes) {
+		if !placed[fn] {
+			fmt.Fprintf(bw, "  %s [label=%s];\n", dotQuote(fn), dotQuote(shorten(fn)))
+		}
+	}
+
+	sortedEdges := make([]*dotEdge, 0, len(edges))
+	for _, e := range edges {
+		sortedEdges = append(sortedEdges, e)
+	}
+	sort.Slice(sortedEdges, func(i, j int) bool {
+		a, b := sortedEdges[i], sortedEdges[j]
+		if a.from != b.from {
+			return a.from < b.from
+		}
+		if a.to != b.to {
+			return a.to < b.to
+		}
+		return a.callType < b.callType
+	})
+	for _, e := range sortedEdges {
+		color, ok := callTypeColors[e.callType]
+		if !ok {
+			color = "gray"
+		}
+		attrs := []string{"color=" + color, "tooltip=" + dotQuote(e.callType)}
+		if e.count > 1 {
+			attrs = append(attrs, fmt.Sprintf("label=\"x%d\"", e.count))
+		}
+		if e.opaque {
+			attrs = append(attrs, "style=dashed") // Callee implemented outside Go
+		}
+		fmt.Fprintf(bw, "  %s -> %s [%s];\n", dotQuote(e.from), dotQuote(e.to), strings.Join(attrs, ", "))
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+// writeDOTLayers pins each layer's functions to one rank, next to a "Layer N" marker.
+// The markers are chained from the top layer down to fix the order of the ranks.
+func writeDOTLayers(w io.Writer, layerMembers map[int][]string) {
+	layers := make([]int, 0, len(layerMembers))
+	for l := range layerMembers {
+		layers = append(layers, l)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(layers)))
+
+	fmt.Fprintln(w, "  newrank=true;")
+	for i, l := range layers {
+		marker := dotQuote(fmt.Sprintf("__layer_%d", l))
+		fmt.Fprintf(w, "  %s [shape=plaintext, label=\"Layer %d\"];\n", marker, l)
+		if i > 0 {
+			fmt.Fprintf(w, "  %s -> %s [style=invis];\n", dotQuote(fmt.Sprintf("__layer_%d", layers[i-1])), marker)
+		}
+		members := make([]string, 0, len(layerMembers[l])+1)
+		members = append(members, marker)
+		for _, fn := range layerMembers[l] {
+			members = append(members, dotQuote(fn))
+		}
+		fmt.Fprintf(w, "  { rank=same; %s; }\n", strings.Join(members, "; "))
+	}
+}
+
+// dotQuote returns s as a double-quoted DOT string.
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
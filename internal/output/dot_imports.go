@@ -0,0 +1,96 @@
+// output/dot_imports.go
+package output
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// ImportGraphRenderer implements Renderer by writing the package import graph as a
+// Graphviz digraph. Analyzed packages are nodes, and each ImportEdge between them is
+// an edge; imports only made by _test.go files are dashed.
+type ImportGraphRenderer struct {
+	// External also draws the imported packages outside the analysis, in gray.
+	External bool
+}
+
+// NewImportGraphRenderer creates a renderer drawing the imports between analyzed packages.
+func NewImportGraphRenderer() *ImportGraphRenderer {
+	return &ImportGraphRenderer{}
+}
+
+func (r *ImportGraphRenderer) Render(w io.Writer, analysis *datamodel.ProjectAnalysis) error {
+	bw := bufio.NewWriter(w)
+	shorten := func(path string) string { return path }
+	if analysis.ModulePath != "" {
+		shorten = func(path string) string {
+			if rel, ok := strings.CutPrefix(path, analysis.ModulePath+"/"); ok {
+				return rel
+			}
+			return path
+		}
+	}
+
+	analyzed := make(map[string]*datamodel.PackageAnalysis)
+	for _, pkg := range analysis.Packages {
+		if pkg != nil {
+			analyzed[pkg.Path] = pkg
+		}
+	}
+	external := make(map[string]bool)
+	edges := make(map[[2]string]bool) // (importer, imported) -> test only
+	for _, pkg := range analysis.Packages {
+		if pkg == nil {
+			continue
+		}
+		for _, edge := range pkg.ImportEdges {
+			if analyzed[edge.Imported] == nil {
+				if !r.External {
+					continue
+				}
+				external[edge.Imported] = true
+			}
+			key := [2]string{edge.Importer, edge.Imported}
+			testOnly, seen := edges[key]
+			edges[key] = edge.TestOnly && (!seen || testOnly)
+		}
+	}
+
+	fmt.Fprintln(bw, "digraph imports {")
+	fmt.Fprintln(bw, "  node [shape=box, fontname=\"Helvetica\", fontsize=10];")
+	for _, path := range sortedKeys(analyzed) {
+		label := fmt.Sprintf("%s\n(layer %d)", shorten(path), analyzed[path].Layer)
+		fmt.Fprintf(bw, "  %s [label=%s];\n", dotQuote(path), dotQuote(label))
+	}
+	for _, path := range sortedKeys(external) {
+		fmt.Fprintf(bw, "  %s [color=gray, fontcolor=gray];\n", dotQuote(path))
+	}
+	for _, key := range sortedEdgeKeys(edges) {
+		attrs := ""
+		if edges[key] {
+			attrs = " [style=dashed, tooltip=\"test only\"]"
+		}
+		fmt.Fprintf(bw, "  %s -> %s%s;\n", dotQuote(key[0]), dotQuote(key[1]), attrs)
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+func sortedEdgeKeys(m map[[2]string]bool) [][2]string {
+	keys := make([][2]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i][0] != keys[j][0] {
+			return keys[i][0] < keys[j][0]
+		}
+		return keys[i][1] < keys[j][1]
+	})
+	return keys
+}
@@ -0,0 +1,38 @@
+// output/json.go
+package output
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// JSONRenderer implements Renderer by encoding the analysis as JSON.
+type JSONRenderer struct {
+	// Indent is used for pretty printing. Empty means compact output.
+	Indent string
+}
+
+// NewJSONRenderer creates a renderer producing indented JSON.
+func NewJSONRenderer() *JSONRenderer {
+	return &JSONRenderer{Indent: "  "}
+}
+
+// Render encodes the analysis package by package, so the encoded output is never held in
+// memory as a whole; the analysis itself already is (see JSONStreamWriter).
+func (r *JSONRenderer) Render(w io.Writer, analysis *datamodel.ProjectAnalysis) error {
+	if analysis == nil {
+		return json.NewEncoder(w).Encode(analysis)
+	}
+	stream := NewJSONStreamWriter(w, r.Indent)
+	if err := stream.Begin(analysis); err != nil {
+		return err
+	}
+	for _, pkg := range analysis.Packages {
+		if err := stream.WritePackage(pkg); err != nil {
+			return err
+		}
+	}
+	return stream.End(analysis)
+}
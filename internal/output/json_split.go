@@ -0,0 +1,127 @@
+// output/json_split.go
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// SplitJSONRenderer implements Renderer by writing the analysis as one JSON file per
+// package into Dir, which suits incremental diffs and storing results in git:
+//
+//	Dir/project.json                  the analysis without its packages
+//	Dir/packages/<import path>.json   one PackageAnalysis each
+//
+// Files whose contents did not change are left untouched, and package files of
+// packages no longer in the analysis are removed. The names of the written files are
+// printed to the Render writer.
+type SplitJSONRenderer struct {
+	Dir string
+	// Indent is used for pretty printing. Empty means compact output.
+	Indent string
+}
+
+// NewSplitJSONRenderer creates a renderer writing indented JSON files into dir.
+func NewSplitJSONRenderer(dir string) *SplitJSONRenderer {
+	return &SplitJSONRenderer{Dir: dir, Indent: "  "}
+}
+
+func (r *SplitJSONRenderer) Render(w io.Writer, analysis *datamodel.ProjectAnalysis) error {
+	if analysis == nil {
+		return fmt.Errorf("no analysis to write to %s", r.Dir)
+	}
+	packagesDir := filepath.Join(r.Dir, "packages")
+	files := make(map[string]any, len(analysis.Packages)+1)
+	for _, pkg := range analysis.Packages {
+		if pkg == nil {
+			continue
+		}
+		name := filepath.FromSlash(pkg.Path) + ".json"
+		if !filepath.IsLocal(name) {
+			return fmt.Errorf("package path %q cannot be used as a file name", pkg.Path)
+		}
+		files[filepath.Join(packagesDir, name)] = pkg
+	}
+	shell := *analysis
+	shell.Packages = nil
+	files[filepath.Join(r.Dir, "project.json")] = &shell
+
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		written, err := r.writeFile(path, files[path])
+		if err != nil {
+			return err
+		}
+		if written {
+			fmt.Fprintln(w, path)
+		}
+	}
+	return removeStale(packagesDir, files)
+}
+
+// writeFile encodes v into the file at path unless it already holds the same contents,
+// and reports whether it wrote the file.
+func (r *SplitJSONRenderer) writeFile(path string, v any) (bool, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetIndent("", r.Indent)
+	if err := encoder.Encode(v); err != nil {
+		return false, fmt.Errorf("encoding %s: %w", path, err)
+	}
+	if existing, err := os.ReadFile(path); err == nil && bytes.Equal(existing, buf.Bytes()) {
+		return false, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return false, fmt.Errorf("creating %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		return false, fmt.Errorf("writing %s: %w", path, err)
+	}
+	return true, nil
+}
+
+// removeStale deletes the JSON files under dir that are not in keep, and the
+// directories left empty. Other files are never touched.
+func removeStale(dir string, keep map[string]any) error {
+	var dirs []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == dir {
+				return fs.SkipDir
+			}
+			return err
+		}
+		if d.IsDir() {
+			dirs = append(dirs, path)
+			return nil
+		}
+		if _, ok := keep[path]; ok || !strings.HasSuffix(path, ".json") {
+			return nil
+		}
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("removing stale %s: %w", path, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	// Deepest directories first; removing a non-empty directory fails and is ignored
+	for i := len(dirs) - 1; i > 0; i-- {
+		os.Remove(dirs[i])
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+// output/json_stream.go
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// packagesKey is the key of ProjectAnalysis.Packages as it appears in the encoded shell.
+const packagesKey = `"Packages":`
+
+// JSONStreamWriter writes a ProjectAnalysis as JSON one package at a time, so only one
+// package is encoded at once instead of the whole output being buffered. The output is
+// identical to JSONRenderer's.
+//
+// This bounds the encoder's buffer, not the analysis: the project passes update packages
+// after they are assembled, so the complete ProjectAnalysis is still built in memory
+// before anything is written. Begin writes the fields preceding Packages, WritePackage
+// appends one package and End writes the remaining fields.
+type JSONStreamWriter struct {
+	w        *bufio.Writer
+	indent   string // Empty means compact output
+	packages int    // Packages written so far
+	begun    bool
+}
+
+// NewJSONStreamWriter creates a streaming writer to w. An empty indent gives compact output.
+func NewJSONStreamWriter(w io.Writer, indent string) *JSONStreamWriter {
+	return &JSONStreamWriter{w: bufio.NewWriter(w), indent: indent}
+}
+
+// Begin writes the opening of the object: the fields preceding Packages.
+func (s *JSONStreamWriter) Begin(analysis *datamodel.ProjectAnalysis) error {
+	if s.begun {
+		return errors.New("JSON stream already begun")
+	}
+	head, _, err := s.shell(analysis)
+	if err != nil {
+		return err
+	}
+	s.begun = true
+	_, err = s.w.Write(head)
+	return err
+}
+
+// WritePackage appends pkg to the Packages list.
+func (s *JSONStreamWriter) WritePackage(pkg *datamodel.PackageAnalysis) error {
+	if !s.begun {
+		return errors.New("JSON stream not begun")
+	}
+	var data []byte
+	var err error
+	if s.indent != "" {
+		data, err = json.MarshalIndent(pkg, s.indent+s.indent, s.indent)
+	} else {
+		data, err = json.Marshal(pkg)
+	}
+	if err != nil {
+		return err
+	}
+	if s.packages == 0 {
+		s.w.WriteString("[")
+	} else {
+		s.w.WriteString(",")
+	}
+	s.newline(2)
+	s.packages++
+	_, err = s.w.Write(data)
+	return err
+}
+
+// End closes the Packages list, writes the fields following it and flushes the output.
+// An analysis without packages is written with an empty (or null, if nil) list.
+func (s *JSONStreamWriter) End(analysis *datamodel.ProjectAnalysis) error {
+	if !s.begun {
+		return errors.New("JSON stream not begun")
+	}
+	_, tail, err := s.shell(analysis)
+	if err != nil {
+		return err
+	}
+	switch {
+	case s.packages > 0:
+		s.newline(1)
+		s.w.WriteString("]")
+	case analysis.Packages == nil:
+		s.w.WriteString("null")
+	default:
+		s.w.WriteString("[]")
+	}
+	s.w.Write(tail)
+	return s.w.Flush()
+}
+
+// shell encodes analysis without its packages and splits the result around the
+// Packages value. The head ends with the Packages key, the tail starts after its value
+// and ends with the newline json.Encoder terminates values with.
+func (s *JSONStreamWriter) shell(analysis *datamodel.ProjectAnalysis) ([]byte, []byte, error) {
+	shell := *analysis
+	shell.Packages = nil
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	if s.indent != "" {
+		encoder.SetIndent("", s.indent)
+	}
+	if err := encoder.Encode(&shell); err != nil {
+		return nil, nil, err
+	}
+	data := buf.Bytes()
+	// Keys are not escaped inside strings, and Packages is the first field able to
+	// contain nested objects, so the first occurrence is the top-level key
+	i := bytes.Index(data, []byte(packagesKey))
+	if i < 0 {
+		return nil, nil, errors.New("encoded analysis has no Packages field")
+	}
+	head := data[:i+len(packagesKey)]
+	rest := data[i+len(packagesKey):]
+	if s.indent != "" {
+		head = append(head, ' ')
+		rest = bytes.TrimPrefix(rest, []byte(" "))
+	}
+	tail := bytes.TrimPrefix(rest, []byte("null"))
+	return head, tail, nil
+}
+
+// newline starts a new line indented to the given depth (indented output only).
+func (s *JSONStreamWriter) newline(depth int) {
+	if s.indent == "" {
+		return
+	}
+	s.w.WriteString("\n")
+	for i := 0; i < depth; i++ {
+		s.w.WriteString(s.indent)
+	}
+}
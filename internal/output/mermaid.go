@@ -0,0 +1,181 @@
+// output/mermaid.go
+package output
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// MermaidRenderer implements Renderer by writing a Mermaid classDiagram of the
+// interfaces, their methods and their implementing types, ready to paste into a
+// ```mermaid block in Markdown. Types are grouped into one namespace per package,
+// ordered from the top layer down.
+type MermaidRenderer struct{}
+
+func NewMermaidRenderer() *MermaidRenderer {
+	return &MermaidRenderer{}
+}
+
+// mermaidClass is one class box of the diagram.
+type mermaidClass struct {
+	id, label, pkgPath string
+	iface              *datamodel.Interface // nil for implementing types
+}
+
+var mermaidUnsafe = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+func (r *MermaidRenderer) Render(w io.Writer, analysis *datamodel.ProjectAnalysis) error {
+	bw := bufio.NewWriter(w)
+	classes := make(map[string]*mermaidClass) // packagePath.TypeName -> class
+	pkgLayers := make(map[string]int)
+	var relations []string
+	// Module-internal IDs are relative to the module to keep the source readable
+	idOf := func(qualified string) string {
+		if analysis.ModulePath != "" {
+			qualified = strings.TrimPrefix(qualified, analysis.ModulePath+"/")
+		}
+		return mermaidID(qualified)
+	}
+
+	addClass := func(pkgPath, name string, iface *datamodel.Interface) *mermaidClass {
+		key := pkgPath + "." + name
+		if c := classes[key]; c != nil {
+			if iface != nil {
+				c.iface = iface
+			}
+			return c
+		}
+		c := &mermaidClass{id: idOf(key), label: name, pkgPath: pkgPath, iface: iface}
+		classes[key] = c
+		return c
+	}
+
+	for _, pkg := range analysis.Packages {
+		if pkg == nil {
+			continue
+		}
+		pkgLayers[pkg.Path] = pkg.Layer
+		for i := range pkg.Interfaces {
+			iface := &pkg.Interfaces[i]
+			addClass(iface.PackagePath, iface.Name, iface)
+		}
+	}
+
+	seenRelations := make(map[string]bool)
+	addRelation := func(rel string) {
+		if !seenRelations[rel] {
+			seenRelations[rel] = true
+			relations = append(relations, rel)
+		}
+	}
+	for _, key := range sortedKeys(classes) {
+		c := classes[key]
+		if c.iface == nil {
+			continue
+		}
+		for _, embed := range c.iface.Embeds {
+			addRelation(fmt.Sprintf("%s <|-- %s : embeds", idOf(embed), c.id))
+			if classes[embed] == nil {
+				// Embedded interface from outside the analysis
+				pkgPath, name := splitQualified(embed)
+				addClass(pkgPath, name, nil)
+			}
+		}
+		for _, impl := range c.iface.Implementations {
+			implKey := impl.PackagePath + "." + impl.TypeName
+			if target := classes[implKey]; target != nil && target.iface != nil {
+				continue // Interfaces satisfying interfaces are shown through embeds
+			}
+			implClass := addClass(impl.PackagePath, impl.TypeName, nil)
+			rel := fmt.Sprintf("%s <|.. %s", c.id, implClass.id)
+			if impl.IsPointer {
+				rel += " : pointer"
+			}
+			addRelation(rel)
+		}
+	}
+
+	// Group classes by package, top layer first
+	byPkg := make(map[string][]*mermaidClass)
+	for _, key := range sortedKeys(classes) {
+		c := classes[key]
+		byPkg[c.pkgPath] = append(byPkg[c.pkgPath], c)
+	}
+	pkgPaths := sortedKeys(byPkg)
+	sort.SliceStable(pkgPaths, func(i, j int) bool { return pkgLayers[pkgPaths[i]] > pkgLayers[pkgPaths[j]] })
+
+	fmt.Fprintln(bw, "classDiagram")
+	for _, pkgPath := range pkgPaths {
+		fmt.Fprintf(bw, "  namespace %s {\n", idOf(pkgPath))
+		for _, c := range byPkg[pkgPath] {
+			writeMermaidClass(bw, c)
+		}
+		fmt.Fprintln(bw, "  }")
+	}
+	for _, rel := range relations {
+		fmt.Fprintf(bw, "  %s\n", rel)
+	}
+	return bw.Flush()
+}
+
+func writeMermaidClass(w io.Writer, c *mermaidClass) {
+	if c.iface == nil {
+		fmt.Fprintf(w, "    class %s[\"%s\"]\n", c.id, c.label)
+		return
+	}
+	fmt.Fprintf(w, "    class %s[\"%s\"] {\n", c.id, c.label)
+	fmt.Fprintln(w, "      <<interface>>")
+	for _, m := range c.iface.Methods {
+		params := make([]string, 0, len(m.Parameters))
+		for _, p := range m.Parameters {
+			typ := p.Type
+			if p.IsPointer {
+				typ = "*" + typ
+			}
+			params = append(params, strings.TrimSpace(p.Name+" "+typ))
+		}
+		member := fmt.Sprintf("+%s(%s)", m.Name, strings.Join(params, ", "))
+		switch len(m.ReturnTypes) {
+		case 0:
+		case 1:
+			member += " " + m.ReturnTypes[0]
+		default:
+			member += " (" + strings.Join(m.ReturnTypes, ", ") + ")"
+		}
+		fmt.Fprintf(w, "      %s\n", mermaidMember(member))
+	}
+	fmt.Fprintln(w, "    }")
+}
+
+// mermaidID turns a qualified name into a valid Mermaid identifier.
+func mermaidID(s string) string {
+	if s == "" {
+		return "_"
+	}
+	return mermaidUnsafe.ReplaceAllString(s, "_")
+}
+
+// mermaidMember neutralizes characters Mermaid treats as syntax in class members:
+// braces close the class body, "~" marks generics and a trailing "*" or "$" marks
+// abstract or static members.
+func mermaidMember(s string) string {
+	s = strings.NewReplacer("interface{}", "any", "struct{}", "struct", "{", "(", "}", ")", "~", "-").Replace(s)
+	if strings.HasSuffix(s, "*") || strings.HasSuffix(s, "$") {
+		s += " "
+	}
+	return s
+}
+
+// splitQualified splits "pkg/path.Name" into its package path and name.
+func splitQualified(qualified string) (string, string) {
+	if idx := strings.LastIndex(qualified, "."); idx > strings.LastIndex(qualified, "/") {
+		return qualified[:idx], qualified[idx+1:]
+	}
+	return "", qualified
+}
@@ -0,0 +1,14 @@
+// output/output.go
+package output
+
+import (
+	"io"
+
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// Renderer defines the interface for writing analysis results in a specific format.
+type Renderer interface {
+	// Render writes the project analysis to w.
+	Render(w io.Writer, analysis *datamodel.ProjectAnalysis) error
+}
@@ -0,0 +1,34 @@
+// output/protobuf.go
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/namikmesic/go-mcp/internal/grpcapi"
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// ProtobufRenderer implements Renderer by writing the analysis as a binary
+// gomcp.v1.ProjectAnalysis message (see proto/gomcp/v1/analysis.proto). The encoding
+// is much smaller and faster to decode than JSON for large projects.
+type ProtobufRenderer struct {
+	// Deterministic orders map entries so identical analyses encode identically.
+	Deterministic bool
+}
+
+// NewProtobufRenderer creates a renderer producing deterministic binary protobuf.
+func NewProtobufRenderer() *ProtobufRenderer {
+	return &ProtobufRenderer{Deterministic: true}
+}
+
+func (r *ProtobufRenderer) Render(w io.Writer, analysis *datamodel.ProjectAnalysis) error {
+	data, err := proto.MarshalOptions{Deterministic: r.Deterministic}.Marshal(grpcapi.ToProtoProjectAnalysis(analysis))
+	if err != nil {
+		return fmt.Errorf("encoding protobuf: %w", err)
+	}
+	_, err = w.Write(data)
+	return err
+}
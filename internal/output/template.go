@@ -0,0 +1,51 @@
+// output/template.go
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// TemplateRenderer implements Renderer using a user-supplied text/template file.
+// The template is executed with the *datamodel.ProjectAnalysis as its data (dot).
+type TemplateRenderer struct {
+	tmpl *template.Template
+}
+
+// NewTemplateRenderer parses the template file at path.
+func NewTemplateRenderer(path string) (*TemplateRenderer, error) {
+	tmpl, err := template.New(filepath.Base(path)).Funcs(templateFuncs()).ParseFiles(path)
+	if err != nil {
+		return nil, fmt.Errorf("parsing template %s: %w", path, err)
+	}
+	return &TemplateRenderer{tmpl: tmpl}, nil
+}
+
+func (r *TemplateRenderer) Render(w io.Writer, analysis *datamodel.ProjectAnalysis) error {
+	if err := r.tmpl.Execute(w, analysis); err != nil {
+		return fmt.Errorf("executing template %s: %w", r.tmpl.Name(), err)
+	}
+	return nil
+}
+
+// templateFuncs returns the helper functions available inside templates.
+func templateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"join":  strings.Join,
+		"lower": strings.ToLower,
+		"upper": strings.ToUpper,
+		"json": func(v interface{}) (string, error) {
+			b, err := json.MarshalIndent(v, "", "  ")
+			if err != nil {
+				return "", err
+			}
+			return string(b), nil
+		},
+	}
+}
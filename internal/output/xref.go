@@ -0,0 +1,99 @@
+// output/xref.go
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// Cross-reference kinds recorded in the index.
+const (
+	XRefDefinition     = "Definition"     // Where the symbol is declared
+	XRefCall           = "Call"           // A call site targeting the symbol
+	XRefImplementation = "Implementation" // A type implementing the (interface) symbol
+	XRefEmbed          = "Embed"          // An interface embedding the symbol
+)
+
+// XRef is a single reference to a symbol. It is intentionally terse so that
+// the index stays small even for large projects.
+type XRef struct {
+	Kind string `json:"Kind"`
+	From string `json:"From,omitempty"` // Referencing entity (caller, implementing type, embedding interface)
+	At   string `json:"At"`             // "file:line"
+}
+
+// XRefIndex maps a symbol ID to every location referencing it.
+// Symbol IDs are the same strings used elsewhere in the output:
+// packagePath + "." + name for interfaces, and CalleeDesc for call targets.
+type XRefIndex struct {
+	ModulePath string            `json:"ModulePath"`
+	Symbols    map[string][]XRef `json:"Symbols"`
+}
+
+// BuildXRefIndex collects all symbol references found in the analysis.
+func BuildXRefIndex(analysis *datamodel.ProjectAnalysis) *XRefIndex {
+	index := &XRefIndex{
+		ModulePath: analysis.ModulePath,
+		Symbols:    make(map[string][]XRef),
+	}
+	add := func(symbol string, ref XRef) {
+		index.Symbols[symbol] = append(index.Symbols[symbol], ref)
+	}
+
+	for _, pkg := range analysis.Packages {
+		if pkg == nil {
+			continue
+		}
+		for _, iface := range pkg.Interfaces {
+			ifaceID := iface.PackagePath + "." + iface.Name
+			add(ifaceID, XRef{Kind: XRefDefinition, At: formatAt(iface.Location)})
+			for _, impl := range iface.Implementations {
+				implName := impl.PackagePath + "." + impl.TypeName
+				if impl.IsPointer {
+					implName = "*" + implName
+				}
+				add(ifaceID, XRef{Kind: XRefImplementation, From: implName, At: formatAt(impl.Location)})
+			}
+			for _, embed := range iface.Embeds {
+				add(embed, XRef{Kind: XRefEmbed, From: ifaceID, At: formatAt(iface.Location)})
+			}
+		}
+		for _, call := range pkg.Calls {
+			add(call.CalleeDesc, XRef{Kind: XRefCall, From: call.CallerFuncDesc, At: formatAt(call.Location)})
+		}
+	}
+
+	// Sort references for deterministic output
+	for _, refs := range index.Symbols {
+		sort.Slice(refs, func(i, j int) bool {
+			if refs[i].Kind != refs[j].Kind {
+				return refs[i].Kind < refs[j].Kind
+			}
+			if refs[i].At != refs[j].At {
+				return refs[i].At < refs[j].At
+			}
+			return refs[i].From < refs[j].From
+		})
+	}
+	return index
+}
+
+func formatAt(loc datamodel.Location) string {
+	return fmt.Sprintf("%s:%d", loc.Filename, loc.Line)
+}
+
+// XRefRenderer implements Renderer by writing the compact cross-reference index.
+type XRefRenderer struct{}
+
+func NewXRefRenderer() *XRefRenderer {
+	return &XRefRenderer{}
+}
+
+func (r *XRefRenderer) Render(w io.Writer, analysis *datamodel.ProjectAnalysis) error {
+	// No indentation: the index is meant for machines, not humans
+	return json.NewEncoder(w).Encode(BuildXRefIndex(analysis))
+}
@@ -0,0 +1,318 @@
+// query/eval.go
+package query
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/namikmesic/go-mcp/internal/analyzer/utils"
+	"github.com/namikmesic/go-mcp/internal/memstore"
+)
+
+// Result is the answer to a query: one row per matching node or call, holding every
+// field of the entity by name.
+type Result struct {
+	Entity string `json:"Entity"`
+	Total  int    `json:"Total"` // Matches before the limit was applied
+	Rows   []Row  `json:"Rows"`
+}
+
+// Row holds the fields of one result.
+type Row map[string]any
+
+type fieldKind int
+
+const (
+	kindString fieldKind = iota
+	kindNumber
+	kindBool
+)
+
+type field struct {
+	kind fieldKind
+	node func(g *memstore.Graph, n *memstore.Node) any // For node entities
+	edge func(g *memstore.Graph, e *memstore.Edge) any // For calls
+}
+
+// entity is a queryable set of graph nodes with a label, or the CALLS edges.
+type entity struct {
+	name   string
+	label  string // Node label; empty for calls
+	fields map[string]field
+}
+
+func (e *entity) fieldNames() []string {
+	names := make([]string, 0, len(e.fields))
+	for name := range e.fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+var entities = map[string]*entity{
+	"packages": {name: "packages", label: memstore.LabelPackage, fields: map[string]field{
+		"id":    propField(kindString, ""),
+		"name":  propField(kindString, "name"),
+		"layer": propField(kindString, "layer"),
+		"files": {kind: kindNumber, node: func(g *memstore.Graph, n *memstore.Node) any {
+			files, _ := n.Props["files"].([]string)
+			return len(files)
+		}},
+		"imports":    countField(memstore.Outgoing, memstore.EdgeImports),
+		"importers":  countField(memstore.Incoming, memstore.EdgeImports),
+		"interfaces": countField(memstore.Outgoing, memstore.EdgeDeclares),
+		"functions":  countField(memstore.Outgoing, memstore.EdgeContains),
+	}},
+	"interfaces": {name: "interfaces", label: memstore.LabelInterface, fields: map[string]field{
+		"id":              propField(kindString, ""),
+		"name":            propField(kindString, "name"),
+		"package":         propField(kindString, "packagePath"),
+		"file":            propField(kindString, "file"),
+		"line":            propField(kindNumber, "line"),
+		"methods":         countField(memstore.Outgoing, memstore.EdgeHasMethod),
+		"implementations": countField(memstore.Incoming, memstore.EdgeImplements),
+		"embeds":          countField(memstore.Outgoing, memstore.EdgeEmbeds),
+		"embeddedBy":      countField(memstore.Incoming, memstore.EdgeEmbeds),
+	}},
+	"methods": {name: "methods", label: memstore.LabelMethod, fields: map[string]field{
+		"id":        propField(kindString, ""),
+		"name":      propField(kindString, "name"),
+		"signature": propField(kindString, "signature"),
+		"interface": {kind: kindString, node: func(g *memstore.Graph, n *memstore.Node) any {
+			if from := g.Edges(n.ID, memstore.Incoming, memstore.EdgeHasMethod); len(from) > 0 {
+				return from[0].From
+			}
+			return ""
+		}},
+		"file": propField(kindString, "file"),
+		"line": propField(kindNumber, "line"),
+	}},
+	"implementations": {name: "implementations", label: memstore.LabelImplementation, fields: map[string]field{
+		"id":         propField(kindString, ""),
+		"name":       propField(kindString, "typeName"),
+		"package":    propField(kindString, "packagePath"),
+		"module":     propField(kindString, "module"),
+		"file":       propField(kindString, "file"),
+		"line":       propField(kindNumber, "line"),
+		"interfaces": countField(memstore.Outgoing, memstore.EdgeImplements),
+	}},
+	"functions": {name: "functions", label: memstore.LabelFunction, fields: map[string]field{
+		"id":        propField(kindString, ""),
+		"name":      propField(kindString, "name"),
+		"receiver":  propField(kindString, "receiver"),
+		"signature": propField(kindString, "signature"),
+		"exported":  propField(kindBool, "exported"),
+		"package":   {kind: kindString, node: func(g *memstore.Graph, n *memstore.Node) any { return utils.PackageOfFuncDesc(n.ID) }},
+		"file":      propField(kindString, "file"),
+		"line":      propField(kindNumber, "line"),
+		"calls":     countField(memstore.Outgoing, memstore.EdgeCalls),
+		"callers":   countField(memstore.Incoming, memstore.EdgeCalls),
+	}},
+	"calls": {name: "calls", fields: map[string]field{
+		"caller":        {kind: kindString, edge: func(g *memstore.Graph, e *memstore.Edge) any { return e.From }},
+		"callee":        {kind: kindString, edge: func(g *memstore.Graph, e *memstore.Edge) any { return e.To }},
+		"callerPackage": {kind: kindString, edge: func(g *memstore.Graph, e *memstore.Edge) any { return utils.PackageOfFuncDesc(e.From) }},
+		"calleePackage": {kind: kindString, edge: func(g *memstore.Graph, e *memstore.Edge) any { return utils.PackageOfFuncDesc(e.To) }},
+		"callType":      propField(kindString, "callType"),
+		"file":          propField(kindString, "file"),
+		"line":          propField(kindNumber, "line"),
+	}},
+}
+
+func entityNames() []string {
+	names := make([]string, 0, len(entities))
+	for name := range entities {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// propField reads the property prop of a node or edge, or the node ID if prop is
+// empty. Missing properties read as the zero value of kind.
+func propField(kind fieldKind, prop string) field {
+	get := func(id string, props map[string]any) any {
+		if prop == "" {
+			return id
+		}
+		v := props[prop]
+		switch kind {
+		case kindNumber:
+			switch v := v.(type) {
+			case int:
+				return v
+			case float64:
+				return int(v)
+			}
+			return 0
+		case kindBool:
+			b, _ := v.(bool)
+			return b
+		}
+		s, _ := v.(string)
+		return s
+	}
+	return field{
+		kind: kind,
+		node: func(g *memstore.Graph, n *memstore.Node) any { return get(n.ID, n.Props) },
+		edge: func(g *memstore.Graph, e *memstore.Edge) any { return get("", e.Props) },
+	}
+}
+
+// countField counts the edges of a node with the given label and direction.
+func countField(dir memstore.Direction, label string) field {
+	return field{kind: kindNumber, node: func(g *memstore.Graph, n *memstore.Node) any {
+		return len(g.Edges(n.ID, dir, label))
+	}}
+}
+
+type condition interface {
+	eval(row Row) bool
+}
+
+type andCond struct{ left, right condition }
+type orCond struct{ left, right condition }
+type notCond struct{ inner condition }
+
+func (c andCond) eval(row Row) bool { return c.left.eval(row) && c.right.eval(row) }
+func (c orCond) eval(row Row) bool  { return c.left.eval(row) || c.right.eval(row) }
+func (c notCond) eval(row Row) bool { return !c.inner.eval(row) }
+
+// comparison compares a field with a value of the field's kind.
+type comparison struct {
+	field   string
+	op      string
+	str     string
+	num     float64
+	boolean bool
+	re      *regexp.Regexp
+}
+
+func (c *comparison) eval(row Row) bool {
+	switch v := row[c.field].(type) {
+	case int:
+		return compare(float64(v), c.num, c.op)
+	case bool:
+		return (v == c.boolean) == (c.op == "==")
+	case string:
+		switch c.op {
+		case "contains":
+			return strings.Contains(v, c.str)
+		case "matches":
+			return c.re.MatchString(v)
+		}
+		return (v == c.str) == (c.op == "==")
+	}
+	return false
+}
+
+func compare(a, b float64, op string) bool {
+	switch op {
+	case "==":
+		return a == b
+	case "!=":
+		return a != b
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	case ">":
+		return a > b
+	case ">=":
+		return a >= b
+	}
+	return false
+}
+
+// Eval runs the query against g. Node entities only include nodes of declarations in
+// the analysis, not the property-less nodes standing for imported packages or called
+// functions outside it; calls include calls to such functions.
+func (q *Query) Eval(g *memstore.Graph) *Result {
+	var rows []Row
+	if q.entity.label != "" {
+		for _, n := range g.NodesByLabel(q.entity.label) {
+			if len(n.Props) == 0 {
+				continue
+			}
+			row := make(Row, len(q.entity.fields))
+			for name, f := range q.entity.fields {
+				row[name] = f.node(g, n)
+			}
+			rows = q.appendMatching(rows, row)
+		}
+	} else {
+		for _, n := range g.NodesByLabel(memstore.LabelFunction) {
+			if q.from != "" && !matchesRef(n.ID, q.from) {
+				continue
+			}
+			for _, e := range g.Edges(n.ID, memstore.Outgoing, memstore.EdgeCalls) {
+				if q.to != "" && !matchesRef(e.To, q.to) {
+					continue
+				}
+				row := make(Row, len(q.entity.fields))
+				for name, f := range q.entity.fields {
+					row[name] = f.edge(g, e)
+				}
+				rows = q.appendMatching(rows, row)
+			}
+		}
+	}
+
+	if q.orderBy != "" {
+		sort.SliceStable(rows, func(i, j int) bool {
+			a, b := rows[i][q.orderBy], rows[j][q.orderBy]
+			if q.desc {
+				a, b = b, a
+			}
+			return less(a, b)
+		})
+	}
+	result := &Result{Entity: q.entity.name, Total: len(rows), Rows: rows}
+	if result.Rows == nil {
+		result.Rows = []Row{}
+	}
+	if q.limit > 0 && len(result.Rows) > q.limit {
+		result.Rows = result.Rows[:q.limit]
+	}
+	return result
+}
+
+func (q *Query) appendMatching(rows []Row, row Row) []Row {
+	if q.where == nil || q.where.eval(row) {
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// matchesRef reports whether the function id is ref, or is declared in the package
+// ref or in a package whose import path ends in "/" + ref.
+func matchesRef(id, ref string) bool {
+	if id == ref {
+		return true
+	}
+	pkg := utils.PackageOfFuncDesc(id)
+	return pkg == ref || strings.HasSuffix(pkg, "/"+ref)
+}
+
+func less(a, b any) bool {
+	switch a := a.(type) {
+	case int:
+		return a < b.(int)
+	case bool:
+		return !a && b.(bool)
+	case string:
+		return a < b.(string)
+	}
+	return false
+}
+
+// Run parses src and evaluates it against g.
+func Run(g *memstore.Graph, src string) (*Result, error) {
+	q, err := Parse(src)
+	if err != nil {
+		return nil, err
+	}
+	return q.Eval(g), nil
+}
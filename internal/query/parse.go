@@ -0,0 +1,305 @@
+// query/parse.go
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Query is a parsed query:
+//
+//	<entity> [from <ref>] [to <ref>] [where <condition>] [order by <field> [asc|desc]] [limit <n>]
+//
+// where entity is one of packages, interfaces, methods, implementations, functions or
+// calls, and from/to are only allowed for calls. A condition combines comparisons
+// "<field> <op> <value>" with and, or, not and parentheses; op is one of ==, !=, <,
+// <=, >, >=, contains or matches (a regular expression).
+type Query struct {
+	entity  *entity
+	from    string
+	to      string
+	where   condition // nil matches everything
+	orderBy string
+	desc    bool
+	limit   int // 0 means no limit
+}
+
+// Parse parses src, checking that the fields it uses exist for its entity and that
+// the values compared with them have the right type. Keywords are case-insensitive.
+func Parse(src string) (*Query, error) {
+	tokens, err := tokenize(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	return p.query()
+}
+
+type tokenKind int
+
+const (
+	tokWord   tokenKind = iota // Bare word: keyword, field, number or unquoted value
+	tokString                  // Quoted string, unquoted
+	tokOp                      // Comparison operator
+	tokLParen
+	tokRParen
+	tokEOF
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func tokenize(src string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, token{tokLParen, "(", i})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{tokRParen, ")", i})
+			i++
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(src[i+1:], c)
+			if end < 0 {
+				return nil, fmt.Errorf("at offset %d: unterminated string", i)
+			}
+			tokens = append(tokens, token{tokString, src[i+1 : i+1+end], i})
+			i += end + 2
+		case strings.IndexByte("=!<>", c) >= 0:
+			op := src[i : i+1]
+			if i+1 < len(src) && src[i+1] == '=' {
+				op = src[i : i+2]
+			}
+			switch op {
+			case "!":
+				return nil, fmt.Errorf("at offset %d: unexpected '!'", i)
+			case "=":
+				tokens = append(tokens, token{tokOp, "==", i})
+			default:
+				tokens = append(tokens, token{tokOp, op, i})
+			}
+			i += len(op)
+		default:
+			start := i
+			for i < len(src) && strings.IndexByte(" \t\n\r()\"'=!<>", src[i]) < 0 {
+				i++
+			}
+			tokens = append(tokens, token{tokWord, src[start:i], start})
+		}
+	}
+	return append(tokens, token{tokEOF, "", len(src)}), nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+	entity *entity // Queried entity, once parsed
+}
+
+func (p *parser) peek() token { return p.tokens[p.pos] }
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+// keyword consumes the next token if it is the bare word kw.
+func (p *parser) keyword(kw string) bool {
+	if t := p.peek(); t.kind == tokWord && strings.EqualFold(t.text, kw) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) errorf(t token, format string, args ...any) error {
+	found := fmt.Sprintf("%q", t.text)
+	if t.kind == tokEOF {
+		found = "end of query"
+	}
+	return fmt.Errorf("at offset %d (%s): %s", t.pos, found, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) query() (*Query, error) {
+	t := p.next()
+	if t.kind != tokWord || entities[strings.ToLower(t.text)] == nil {
+		return nil, p.errorf(t, "expected one of: %s", strings.Join(entityNames(), ", "))
+	}
+	p.entity = entities[strings.ToLower(t.text)]
+	q := &Query{entity: p.entity}
+
+	for _, clause := range []struct {
+		kw  string
+		ref *string
+	}{{"from", &q.from}, {"to", &q.to}} {
+		if !p.keyword(clause.kw) {
+			continue
+		}
+		if q.entity.name != "calls" {
+			return nil, p.errorf(p.tokens[p.pos-1], "%s is only allowed in calls queries", clause.kw)
+		}
+		ref := p.next()
+		if ref.kind != tokWord && ref.kind != tokString {
+			return nil, p.errorf(ref, "expected a package or function after %s", clause.kw)
+		}
+		*clause.ref = ref.text
+	}
+	if p.keyword("where") {
+		cond, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		q.where = cond
+	}
+	if p.keyword("order") {
+		if !p.keyword("by") {
+			return nil, p.errorf(p.peek(), "expected by")
+		}
+		t := p.next()
+		if _, ok := q.entity.fields[t.text]; t.kind != tokWord || !ok {
+			return nil, p.errorf(t, "expected a field of %s: %s", q.entity.name, strings.Join(q.entity.fieldNames(), ", "))
+		}
+		q.orderBy = t.text
+		if p.keyword("desc") {
+			q.desc = true
+		} else {
+			p.keyword("asc")
+		}
+	}
+	if p.keyword("limit") {
+		t := p.next()
+		n, err := strconv.Atoi(t.text)
+		if t.kind != tokWord || err != nil || n <= 0 {
+			return nil, p.errorf(t, "expected a positive limit")
+		}
+		q.limit = n
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, p.errorf(t, "unexpected input")
+	}
+	return q, nil
+}
+
+func (p *parser) or() (condition, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("or") {
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		left = orCond{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) and() (condition, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("and") {
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		left = andCond{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) unary() (condition, error) {
+	if p.keyword("not") {
+		inner, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return notCond{inner}, nil
+	}
+	if p.peek().kind == tokLParen {
+		p.next()
+		inner, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if t := p.next(); t.kind != tokRParen {
+			return nil, p.errorf(t, "expected )")
+		}
+		return inner, nil
+	}
+	return p.comparison()
+}
+
+// comparison parses "<field> <op> <value>" and checks it against the field's type.
+func (p *parser) comparison() (condition, error) {
+	t := p.next()
+	if t.kind != tokWord {
+		return nil, p.errorf(t, "expected a field")
+	}
+	ent := p.entity
+	f, ok := ent.fields[t.text]
+	if !ok {
+		return nil, p.errorf(t, "unknown field for %s; fields are: %s", ent.name, strings.Join(ent.fieldNames(), ", "))
+	}
+	opTok := p.next()
+	op := opTok.text
+	if opTok.kind == tokWord {
+		op = strings.ToLower(op)
+	}
+	if opTok.kind != tokOp && op != "contains" && op != "matches" {
+		return nil, p.errorf(opTok, "expected an operator: ==, !=, <, <=, >, >=, contains or matches")
+	}
+	valTok := p.next()
+	if valTok.kind != tokWord && valTok.kind != tokString {
+		return nil, p.errorf(valTok, "expected a value")
+	}
+	cmp := &comparison{field: t.text, op: op, str: valTok.text}
+
+	switch f.kind {
+	case kindNumber:
+		if op == "contains" || op == "matches" {
+			return nil, p.errorf(opTok, "%s is a number; use ==, !=, <, <=, > or >=", t.text)
+		}
+		n, err := strconv.ParseFloat(valTok.text, 64)
+		if valTok.kind != tokWord || err != nil {
+			return nil, p.errorf(valTok, "%s is a number", t.text)
+		}
+		cmp.num = n
+	case kindBool:
+		if op != "==" && op != "!=" {
+			return nil, p.errorf(opTok, "%s is a boolean; use == or !=", t.text)
+		}
+		b, err := strconv.ParseBool(valTok.text)
+		if valTok.kind != tokWord || err != nil {
+			return nil, p.errorf(valTok, "%s is a boolean; compare it with true or false", t.text)
+		}
+		cmp.boolean = b
+	case kindString:
+		switch op {
+		case "==", "!=", "contains":
+		case "matches":
+			re, err := regexp.Compile(valTok.text)
+			if err != nil {
+				return nil, p.errorf(valTok, "invalid regular expression: %v", err)
+			}
+			cmp.re = re
+		default:
+			return nil, p.errorf(opTok, "%s is a string; use ==, !=, contains or matches", t.text)
+		}
+	}
+	return cmp, nil
+}
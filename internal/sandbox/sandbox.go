@@ -0,0 +1,153 @@
+// sandbox/sandbox.go
+package sandbox
+
+import (
+	"fmt"
+	"go/build"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// Policy restricts the directories the analyzer may be pointed at. A policy without
+// roots allows every directory.
+type Policy struct {
+	Roots []string // Absolute, symlink-resolved directories
+}
+
+// NewPolicy creates a policy allowing roots and everything below them.
+func NewPolicy(roots []string) (*Policy, error) {
+	p := &Policy{}
+	for _, root := range roots {
+		resolved, err := resolve(root)
+		if err != nil {
+			return nil, fmt.Errorf("allowed root %s: %w", root, err)
+		}
+		p.Roots = append(p.Roots, resolved)
+	}
+	return p, nil
+}
+
+// Check returns an error unless dir lies within one of the allowed roots. Symlinks
+// are resolved first, so a link inside a root cannot point the analyzer elsewhere.
+func (p *Policy) Check(dir string) error {
+	if p == nil || len(p.Roots) == 0 {
+		return nil
+	}
+	resolved, err := resolve(dir)
+	if err != nil {
+		return err
+	}
+	for _, root := range p.Roots {
+		if within(root, resolved) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s is outside the allowed roots", dir)
+}
+
+func resolve(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	return filepath.EvalSymlinks(abs)
+}
+
+// within reports whether path equals root or lies below it.
+func within(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) && !filepath.IsAbs(rel)
+}
+
+// Redactor removes absolute paths from analysis results, so output shared with other
+// users does not reveal the server's directory layout.
+type Redactor struct {
+	prefixes []prefix // Longest first
+}
+
+type prefix struct {
+	dir         string
+	replacement string
+}
+
+// NewRedactor creates a redactor for results of the module rooted at moduleDir.
+// Paths inside the module become relative; paths in GOROOT and the module cache are
+// rewritten to start with $GOROOT and $GOMODCACHE; any other absolute path is reduced
+// to its base name.
+func NewRedactor(moduleDir string) *Redactor {
+	r := &Redactor{}
+	r.add(moduleDir, "")
+	r.add(moduleCacheDir(), "$GOMODCACHE")
+	r.add(build.Default.GOROOT, "$GOROOT")
+	return r
+}
+
+func (r *Redactor) add(dir, replacement string) {
+	if dir == "" {
+		return
+	}
+	p := prefix{dir: filepath.Clean(dir), replacement: replacement}
+	i := 0
+	for i < len(r.prefixes) && len(r.prefixes[i].dir) >= len(p.dir) {
+		i++
+	}
+	r.prefixes = append(r.prefixes[:i], append([]prefix{p}, r.prefixes[i:]...)...)
+}
+
+// Path redacts a single path. Relative paths are returned unchanged.
+func (r *Redactor) Path(path string) string {
+	if path == "" || !filepath.IsAbs(path) {
+		return path
+	}
+	for _, p := range r.prefixes {
+		if !within(p.dir, path) {
+			continue
+		}
+		rel, _ := filepath.Rel(p.dir, path)
+		if p.replacement == "" {
+			return rel
+		}
+		return filepath.Join(p.replacement, rel)
+	}
+	return filepath.Base(path)
+}
+
+// Redact rewrites ModuleDir, every Location filename and the local directories of
+// module replacements in analysis in place.
+func (r *Redactor) Redact(analysis *datamodel.ProjectAnalysis) {
+	if analysis == nil {
+		return
+	}
+	analysis.ModuleDir = r.Path(analysis.ModuleDir)
+	if analysis.ModuleDir == "" {
+		analysis.ModuleDir = "."
+	}
+	datamodel.RewriteLocations(analysis, r.Path)
+	if analysis.ModuleGraph != nil {
+		for i := range analysis.ModuleGraph.Modules {
+			node := &analysis.ModuleGraph.Modules[i]
+			node.Replace = r.Path(node.Replace) // Only absolute directories are rewritten
+		}
+	}
+}
+
+// RedactLocations rewrites every Location filename reachable from v, which must be a
+// pointer for the changes to be visible to the caller.
+func (r *Redactor) RedactLocations(v any) {
+	datamodel.RewriteLocations(v, r.Path)
+}
+
+// moduleCacheDir mirrors the go command's default for GOMODCACHE.
+func moduleCacheDir() string {
+	if dir := os.Getenv("GOMODCACHE"); dir != "" {
+		return dir
+	}
+	gopath := filepath.SplitList(build.Default.GOPATH)
+	if len(gopath) == 0 || gopath[0] == "" {
+		return ""
+	}
+	return filepath.Join(gopath[0], "pkg", "mod")
+}
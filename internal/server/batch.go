@@ -0,0 +1,139 @@
+// server/batch.go
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/namikmesic/go-mcp/internal/memstore"
+	"github.com/namikmesic/go-mcp/internal/symbols"
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// maxBatchQueries bounds the number of queries accepted in one batch request.
+const maxBatchQueries = 100
+
+// batchable lists the read-only endpoints that may be used inside a batch.
+var batchable = map[string]bool{
+	"/packages":        true,
+	"/interfaces":      true,
+	"/implementations": true,
+	"/calls":           true,
+	"/explain":         true,
+	"/references":      true,
+	"/symbols":         true,
+	"/entities":        true,
+	"/graph/nodes":     true,
+	"/graph/neighbors": true,
+	"/graph/path":      true,
+	"/callpath":        true,
+	"/tests":           true,
+	"/tests/report":    true,
+	"/extract":         true,
+	"/query":           true,
+	"/graphql":         true,
+}
+
+// BatchQuery is one query in a batch: an endpoint path and its query parameters.
+type BatchQuery struct {
+	ID     string            `json:"ID,omitempty"` // Echoed in the result to correlate answers
+	Path   string            `json:"Path"`         // e.g. "/implementations"
+	Params map[string]string `json:"Params,omitempty"`
+}
+
+// BatchRequest is the body of POST /batch.
+type BatchRequest struct {
+	Queries []BatchQuery `json:"Queries"`
+}
+
+// BatchResult is the answer to one query: the status and body the endpoint would
+// have returned on its own.
+type BatchResult struct {
+	ID     string          `json:"ID,omitempty"`
+	Status int             `json:"Status"`
+	Body   json.RawMessage `json:"Body"`
+}
+
+// BatchResponse is returned by /batch. All results are computed against the same
+// analysis Version, even if the analysis is updated while the batch runs.
+type BatchResponse struct {
+	Version int           `json:"Version"`
+	Results []BatchResult `json:"Results"`
+}
+
+// pinnedKey is the context key under which a batch pins its analysis.
+type pinnedKey struct{}
+
+// pinnedState is the analysis shared by all queries of a batch.
+type pinnedState struct {
+	analysis *datamodel.ProjectAnalysis
+	graph    *memstore.Graph
+	symbols  *symbols.Index
+}
+
+// handleBatch answers several queries in one round trip. Each query is dispatched to
+// its regular handler, so results are identical to individual requests; a failing
+// query reports its own status without failing the batch.
+func (s *Server) handleBatch(w http.ResponseWriter, r *http.Request) {
+	var req BatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid batch request: "+err.Error())
+		return
+	}
+	if len(req.Queries) > maxBatchQueries {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("too many queries: %d (max %d)", len(req.Queries), maxBatchQueries))
+		return
+	}
+
+	s.mu.RLock()
+	pinned := &pinnedState{analysis: s.analysis, graph: s.graph, symbols: s.symbols}
+	version := s.version
+	s.mu.RUnlock()
+	ctx := context.WithValue(r.Context(), pinnedKey{}, pinned)
+
+	resp := BatchResponse{Version: version, Results: make([]BatchResult, 0, len(req.Queries))}
+	for _, q := range req.Queries {
+		resp.Results = append(resp.Results, s.runBatchQuery(ctx, q))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// runBatchQuery dispatches a single query through the mux and captures its response.
+func (s *Server) runBatchQuery(ctx context.Context, q BatchQuery) BatchResult {
+	if !batchable[q.Path] {
+		return batchError(q.ID, http.StatusBadRequest, "path cannot be batched: "+q.Path)
+	}
+	params := url.Values{}
+	for k, v := range q.Params {
+		params.Set(k, v)
+	}
+	sub, err := http.NewRequestWithContext(ctx, http.MethodGet, q.Path+"?"+params.Encode(), nil)
+	if err != nil {
+		return batchError(q.ID, http.StatusBadRequest, err.Error())
+	}
+	rec := &responseRecorder{header: make(http.Header), status: http.StatusOK}
+	s.mux.ServeHTTP(rec, sub)
+	return BatchResult{ID: q.ID, Status: rec.status, Body: bytes.TrimSpace(rec.body.Bytes())}
+}
+
+func batchError(id string, status int, msg string) BatchResult {
+	body, _ := json.Marshal(map[string]string{"error": msg})
+	return BatchResult{ID: id, Status: status, Body: body}
+}
+
+// responseRecorder is an in-memory http.ResponseWriter for batched queries.
+type responseRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) Header() http.Header { return r.header }
+
+func (r *responseRecorder) Write(p []byte) (int, error) { return r.body.Write(p) }
+
+func (r *responseRecorder) WriteHeader(status int) { r.status = status }
@@ -0,0 +1,57 @@
+// server/delta.go
+package server
+
+import (
+	"github.com/namikmesic/go-mcp/internal/diff"
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// Delta describes how the analysis changed between two versions. Interfaces and call
+// edges are compared like the diff command does (see diff.Compare): interfaces change
+// with their method set or implementations, not when they merely move, and call edges
+// are keyed by function IDs rather than SSA descriptions.
+type Delta struct {
+	FromVersion       int                   `json:"FromVersion"`
+	ToVersion         int                   `json:"ToVersion"`
+	AddedInterfaces   []datamodel.Interface `json:"AddedInterfaces"`
+	ChangedInterfaces []datamodel.Interface `json:"ChangedInterfaces"`
+	RemovedInterfaces []string              `json:"RemovedInterfaces"` // packagePath + "." + interfaceName
+	AddedCalls        []datamodel.CallEdge  `json:"AddedCalls"`
+	RemovedCalls      []datamodel.CallEdge  `json:"RemovedCalls"`
+}
+
+// computeDelta compares two analyses, reporting added and changed interfaces in full.
+func computeDelta(from, to *datamodel.ProjectAnalysis) Delta {
+	d := diff.Compare(from, to)
+	delta := Delta{
+		AddedInterfaces:   []datamodel.Interface{},
+		ChangedInterfaces: []datamodel.Interface{},
+		RemovedInterfaces: append([]string{}, d.RemovedInterfaces...),
+		AddedCalls:        append([]datamodel.CallEdge{}, d.AddedCalls...),
+		RemovedCalls:      append([]datamodel.CallEdge{}, d.RemovedCalls...),
+	}
+	ifaces := interfacesByKey(to)
+	for _, key := range d.AddedInterfaces {
+		delta.AddedInterfaces = append(delta.AddedInterfaces, ifaces[key])
+	}
+	for _, change := range d.ChangedInterfaces {
+		delta.ChangedInterfaces = append(delta.ChangedInterfaces, ifaces[change.Interface])
+	}
+	return delta
+}
+
+func interfacesByKey(analysis *datamodel.ProjectAnalysis) map[string]datamodel.Interface {
+	result := make(map[string]datamodel.Interface)
+	if analysis == nil {
+		return result
+	}
+	for _, pkg := range analysis.Packages {
+		if pkg == nil {
+			continue
+		}
+		for _, iface := range pkg.Interfaces {
+			result[iface.PackagePath+"."+iface.Name] = iface
+		}
+	}
+	return result
+}
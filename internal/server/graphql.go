@@ -0,0 +1,56 @@
+// server/graphql.go
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/namikmesic/go-mcp/internal/graphql"
+)
+
+// maxGraphQLBody bounds the size of POST /graphql request bodies.
+const maxGraphQLBody = 1 << 20
+
+var analysisSchema = graphql.NewAnalysisSchema()
+
+// handleGraphQL executes a GraphQL query against the analysis: a JSON request body
+// {"query", "operationName", "variables"} for POST, or the same ?query=,
+// ?operationName= and ?variables= (JSON) parameters for GET. Requests that cannot be
+// executed at all get 400; field errors are reported next to the data with 200.
+func (s *Server) handleGraphQL(w http.ResponseWriter, r *http.Request) {
+	var req graphql.Request
+	if r.Method == http.MethodPost {
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxGraphQLBody)).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+			return
+		}
+	} else {
+		query := r.URL.Query()
+		req.Query = query.Get("query")
+		req.OperationName = query.Get("operationName")
+		if raw := query.Get("variables"); raw != "" {
+			if err := json.Unmarshal([]byte(raw), &req.Variables); err != nil {
+				writeError(w, http.StatusBadRequest, "variables must be a JSON object")
+				return
+			}
+		}
+	}
+	if req.Query == "" {
+		writeError(w, http.StatusBadRequest, "missing query")
+		return
+	}
+	analysis, _ := s.snapshot(r)
+	resp := analysisSchema.Execute(r.Context(), graphql.NewRoot(analysis), req)
+	status := http.StatusOK
+	if resp.Data == nil {
+		status = http.StatusBadRequest
+	}
+	writeJSON(w, status, resp)
+}
+
+// handleGraphQLSchema returns the schema served at /graphql in the schema definition
+// language.
+func (s *Server) handleGraphQLSchema(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Write([]byte(analysisSchema.SDL()))
+}
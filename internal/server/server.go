@@ -0,0 +1,603 @@
+// server/server.go
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/namikmesic/go-mcp/internal/analyzer/reach"
+	"github.com/namikmesic/go-mcp/internal/analyzer/typesystem"
+	"github.com/namikmesic/go-mcp/internal/memstore"
+	"github.com/namikmesic/go-mcp/internal/query"
+	"github.com/namikmesic/go-mcp/internal/symbols"
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// Server exposes a ProjectAnalysis over a read-only HTTP/JSON API. The analysis can
+// be replaced with Update; clients track changes through sessions (see sessions.go).
+type Server struct {
+	mu       sync.RWMutex
+	analysis *datamodel.ProjectAnalysis
+	graph    *memstore.Graph
+	symbols  *symbols.Index
+	version  int                // Incremented on every Update
+	history  []retainedAnalysis // The most recent versions, oldest first, for deltas
+	sessions map[string]*session
+
+	mux        *http.ServeMux
+	httpServer *http.Server // Set by ListenAndServe
+
+	// Explainer answers /explain; the endpoint is unavailable while it is nil.
+	Explainer Explainer
+	// References answers /references; the endpoint is unavailable while it is nil.
+	References ReferenceFinder
+}
+
+// Explainer explains whether a type satisfies an interface, both given as
+// packagePath + "." + name (see typesystem.SatisfactionExplainer).
+type Explainer interface {
+	Explain(ifaceName, typeName string) (*datamodel.SatisfactionExplanation, error)
+}
+
+// ReferenceFinder finds the uses of the symbol name ("Ident" or "Type.Member")
+// declared in the package pkgPath (see typesystem.ReferenceFinder).
+type ReferenceFinder interface {
+	Find(pkgPath, name string) (*datamodel.References, error)
+}
+
+// PackageSummary is the compact package listing returned by /packages.
+type PackageSummary struct {
+	Name           string   `json:"Name"`
+	Path           string   `json:"Path"`
+	Files          []string `json:"Files"`
+	Imports        []string `json:"Imports"`
+	InterfaceCount int      `json:"InterfaceCount"`
+	CallCount      int      `json:"CallCount"`
+}
+
+// ImplementationsResponse is returned by /implementations.
+type ImplementationsResponse struct {
+	Interface       string                     `json:"Interface"` // packagePath + "." + interfaceName
+	Implementations []datamodel.Implementation `json:"Implementations"`
+}
+
+// PathResponse is returned by /graph/path.
+type PathResponse struct {
+	Nodes []*memstore.Node `json:"Nodes"`
+	Edges []*memstore.Edge `json:"Edges"`
+}
+
+// NewServer creates a server for the given analysis results.
+func NewServer(analysis *datamodel.ProjectAnalysis) *Server {
+	if analysis == nil {
+		log.Panicln("Error: Cannot create Server with nil analysis.")
+	}
+	s := &Server{
+		analysis: analysis,
+		graph:    memstore.FromAnalysis(analysis),
+		symbols:  symbols.NewIndex(analysis),
+		version:  1,
+		sessions: make(map[string]*session),
+		mux:      http.NewServeMux(),
+	}
+	s.retain()
+	s.routes()
+	return s
+}
+
+func (s *Server) routes() {
+	s.mux.HandleFunc("GET /packages", s.handlePackages)
+	s.mux.HandleFunc("GET /interfaces", s.handleInterfaces)
+	s.mux.HandleFunc("GET /implementations", s.handleImplementations)
+	s.mux.HandleFunc("GET /calls", s.handleCalls)
+	s.mux.HandleFunc("GET /explain", s.handleExplain)
+	s.mux.HandleFunc("GET /references", s.handleReferences)
+	s.mux.HandleFunc("GET /symbols", s.handleSymbols)
+	s.mux.HandleFunc("GET /entities", s.handleEntities)
+	s.mux.HandleFunc("GET /graph/nodes", s.handleGraphNodes)
+	s.mux.HandleFunc("GET /graph/neighbors", s.handleGraphNeighbors)
+	s.mux.HandleFunc("GET /graph/path", s.handleGraphPath)
+	s.mux.HandleFunc("GET /callpath", s.handleCallPath)
+	s.mux.HandleFunc("GET /tests", s.handleTests)
+	s.mux.HandleFunc("GET /tests/report", s.handleTestReport)
+	s.mux.HandleFunc("GET /extract", s.handleExtract)
+	s.mux.HandleFunc("GET /query", s.handleQuery)
+	s.mux.HandleFunc("GET /graphql", s.handleGraphQL)
+	s.mux.HandleFunc("POST /graphql", s.handleGraphQL)
+	s.mux.HandleFunc("GET /graphql/schema", s.handleGraphQLSchema)
+	s.mux.HandleFunc("POST /batch", s.handleBatch)
+	s.mux.HandleFunc("POST /sessions", s.handleCreateSession)
+	s.mux.HandleFunc("GET /sessions/{id}/delta", s.handleSessionDelta)
+	s.mux.HandleFunc("DELETE /sessions/{id}", s.handleDeleteSession)
+}
+
+// Update replaces the served analysis, e.g. after re-analyzing changed files.
+func (s *Server) Update(analysis *datamodel.ProjectAnalysis) {
+	if analysis == nil {
+		return
+	}
+	graph := memstore.FromAnalysis(analysis)
+	index := symbols.NewIndex(analysis)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.analysis = analysis
+	s.graph = graph
+	s.symbols = index
+	s.version++
+	s.retain()
+	s.reapSessions(time.Now())
+	slog.Info("Serving analysis", "version", s.version)
+}
+
+// snapshot returns the analysis and graph to answer r from: the one pinned by an
+// enclosing batch (see batch.go), or else the current one. Both are immutable once
+// published.
+func (s *Server) snapshot(r *http.Request) (*datamodel.ProjectAnalysis, *memstore.Graph) {
+	if pinned, ok := r.Context().Value(pinnedKey{}).(*pinnedState); ok {
+		return pinned.analysis, pinned.graph
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.analysis, s.graph
+}
+
+// symbolIndex returns the symbol index to answer r from, pinned like snapshot.
+func (s *Server) symbolIndex(r *http.Request) *symbols.Index {
+	if pinned, ok := r.Context().Value(pinnedKey{}).(*pinnedState); ok {
+		return pinned.symbols
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.symbols
+}
+
+// Handler returns the HTTP handler serving the API.
+func (s *Server) Handler() http.Handler {
+	return s.mux
+}
+
+// ListenAndServe serves the API on addr until the listener fails or Shutdown is
+// called, in which case it returns nil.
+func (s *Server) ListenAndServe(addr string) error {
+	httpServer := &http.Server{Addr: addr, Handler: s.mux}
+	s.mu.Lock()
+	s.httpServer = httpServer
+	s.mu.Unlock()
+
+	slog.Info("Serving analysis API", "addr", addr)
+	if err := httpServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Shutdown stops accepting connections and waits for in-flight requests to finish
+// until ctx is done.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.mu.RLock()
+	httpServer := s.httpServer
+	s.mu.RUnlock()
+	if httpServer == nil {
+		return nil
+	}
+	return httpServer.Shutdown(ctx)
+}
+
+// handlePackages lists package summaries, or returns the full package when ?path= is given.
+func (s *Server) handlePackages(w http.ResponseWriter, r *http.Request) {
+	analysis, _ := s.snapshot(r)
+	if path := r.URL.Query().Get("path"); path != "" {
+		for _, pkg := range analysis.Packages {
+			if pkg != nil && pkg.Path == path {
+				writeJSON(w, http.StatusOK, pkg)
+				return
+			}
+		}
+		writeError(w, http.StatusNotFound, "package not found: "+path)
+		return
+	}
+
+	summaries := make([]PackageSummary, 0, len(analysis.Packages))
+	for _, pkg := range analysis.Packages {
+		if pkg == nil {
+			continue
+		}
+		summaries = append(summaries, PackageSummary{
+			Name:           pkg.Name,
+			Path:           pkg.Path,
+			Files:          pkg.Files,
+			Imports:        pkg.Imports,
+			InterfaceCount: len(pkg.Interfaces),
+			CallCount:      len(pkg.Calls),
+		})
+	}
+	writeJSON(w, http.StatusOK, summaries)
+}
+
+// handleInterfaces lists all interfaces, optionally restricted with ?package=<import path>.
+func (s *Server) handleInterfaces(w http.ResponseWriter, r *http.Request) {
+	analysis, _ := s.snapshot(r)
+	pkgFilter := r.URL.Query().Get("package")
+	result := []datamodel.Interface{}
+	for _, pkg := range analysis.Packages {
+		if pkg == nil || (pkgFilter != "" && pkg.Path != pkgFilter) {
+			continue
+		}
+		result = append(result, pkg.Interfaces...)
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// handleImplementations returns the implementations of ?iface=, which may be either
+// the fully qualified name (packagePath + "." + interfaceName) or a bare interface name.
+// Type aliases of interfaces are resolved to the aliased interface.
+func (s *Server) handleImplementations(w http.ResponseWriter, r *http.Request) {
+	analysis, _ := s.snapshot(r)
+	ifaceName := r.URL.Query().Get("iface")
+	if ifaceName == "" {
+		writeError(w, http.StatusBadRequest, "missing required query parameter: iface")
+		return
+	}
+	ifaceName = resolveAlias(analysis, ifaceName)
+
+	result := []ImplementationsResponse{}
+	for _, pkg := range analysis.Packages {
+		if pkg == nil {
+			continue
+		}
+		for _, iface := range pkg.Interfaces {
+			key := iface.PackagePath + "." + iface.Name
+			if key != ifaceName && iface.Name != ifaceName {
+				continue
+			}
+			result = append(result, ImplementationsResponse{
+				Interface:       key,
+				Implementations: iface.Implementations,
+			})
+		}
+	}
+	if len(result) == 0 {
+		writeError(w, http.StatusNotFound, "interface not found: "+ifaceName)
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// handleCalls lists call sites, optionally filtered by substring matches on
+// ?caller= (CallerFuncDesc) and ?callee= (CalleeDesc).
+func (s *Server) handleCalls(w http.ResponseWriter, r *http.Request) {
+	analysis, _ := s.snapshot(r)
+	caller := r.URL.Query().Get("caller")
+	callee := r.URL.Query().Get("callee")
+	result := []datamodel.CallSite{}
+	for _, pkg := range analysis.Packages {
+		if pkg == nil {
+			continue
+		}
+		for _, call := range pkg.Calls {
+			if caller != "" && !strings.Contains(call.CallerFuncDesc, caller) {
+				continue
+			}
+			if callee != "" && !strings.Contains(call.CalleeDesc, callee) {
+				continue
+			}
+			result = append(result, call)
+		}
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// handleExplain explains why ?type= does or does not satisfy ?iface=, both qualified
+// as packagePath.Name.
+func (s *Server) handleExplain(w http.ResponseWriter, r *http.Request) {
+	if s.Explainer == nil {
+		writeError(w, http.StatusNotImplemented, "satisfaction explanations are not available")
+		return
+	}
+	ifaceName, typeName := r.URL.Query().Get("iface"), r.URL.Query().Get("type")
+	if ifaceName == "" || typeName == "" {
+		writeError(w, http.StatusBadRequest, "missing required query parameters: iface, type")
+		return
+	}
+	explanation, err := s.Explainer.Explain(ifaceName, typeName)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, explanation)
+}
+
+// handleReferences lists the uses of ?name= ("Ident" or "Type.Member") declared in the
+// package ?package=.
+func (s *Server) handleReferences(w http.ResponseWriter, r *http.Request) {
+	if s.References == nil {
+		writeError(w, http.StatusNotImplemented, "reference queries are not available")
+		return
+	}
+	pkgPath, name := r.URL.Query().Get("package"), r.URL.Query().Get("name")
+	if pkgPath == "" || name == "" {
+		writeError(w, http.StatusBadRequest, "missing required query parameters: package, name")
+		return
+	}
+	refs, err := s.References.Find(pkgPath, name)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, refs)
+}
+
+// handleSymbols searches the declared functions, methods, types and interfaces for ?q=,
+// optionally restricted to ?kind= (comma-separated) and at most ?limit= matches.
+func (s *Server) handleSymbols(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query().Get("q")
+	if strings.TrimSpace(q) == "" {
+		writeError(w, http.StatusBadRequest, "missing required query parameter: q")
+		return
+	}
+	limit := 0
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n <= 0 {
+			writeError(w, http.StatusBadRequest, "limit must be a positive integer")
+			return
+		}
+		limit = n
+	}
+	var kinds []string
+	if raw := r.URL.Query().Get("kind"); raw != "" {
+		kinds = strings.Split(raw, ",")
+	}
+	writeJSON(w, http.StatusOK, s.symbolIndex(r).Search(q, kinds, limit))
+}
+
+// handleGraphNodes lists graph nodes, optionally filtered by ?label= and a substring ?q= on the ID.
+func (s *Server) handleGraphNodes(w http.ResponseWriter, r *http.Request) {
+	_, graph := s.snapshot(r)
+	q := r.URL.Query().Get("q")
+	nodes := graph.Filter(r.URL.Query().Get("label"), func(n *memstore.Node) bool {
+		return strings.Contains(n.ID, q)
+	})
+	writeJSON(w, http.StatusOK, nodes)
+}
+
+// handleGraphNeighbors returns the nodes adjacent to ?id=, following ?direction=
+// (out, in or both; default out) and optionally only ?edge= labels (comma-separated).
+func (s *Server) handleGraphNeighbors(w http.ResponseWriter, r *http.Request) {
+	_, graph := s.snapshot(r)
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		writeError(w, http.StatusBadRequest, "missing required query parameter: id")
+		return
+	}
+	dir, ok := parseDirection(r.URL.Query().Get("direction"))
+	if !ok {
+		writeError(w, http.StatusBadRequest, "direction must be one of: out, in, both")
+		return
+	}
+	if _, found := graph.Node(id); !found {
+		writeError(w, http.StatusNotFound, "node not found: "+id)
+		return
+	}
+	writeJSON(w, http.StatusOK, graph.Neighbors(id, dir, edgeLabels(r)...))
+}
+
+// handleGraphPath returns a shortest path between ?from= and ?to=, following
+// ?direction= (default out) and optionally only ?edge= labels (comma-separated).
+func (s *Server) handleGraphPath(w http.ResponseWriter, r *http.Request) {
+	_, graph := s.snapshot(r)
+	from, to := r.URL.Query().Get("from"), r.URL.Query().Get("to")
+	if from == "" || to == "" {
+		writeError(w, http.StatusBadRequest, "missing required query parameters: from, to")
+		return
+	}
+	dir, ok := parseDirection(r.URL.Query().Get("direction"))
+	if !ok {
+		writeError(w, http.StatusBadRequest, "direction must be one of: out, in, both")
+		return
+	}
+	edges, found := graph.ShortestPath(from, to, dir, edgeLabels(r)...)
+	if !found {
+		writeError(w, http.StatusNotFound, "no path from "+from+" to "+to)
+		return
+	}
+	start, _ := graph.Node(from)
+	resp := PathResponse{Nodes: []*memstore.Node{start}, Edges: edges}
+	current := from
+	for _, e := range edges {
+		if e.From == current {
+			current = e.To
+		} else {
+			current = e.From
+		}
+		n, _ := graph.Node(current)
+		resp.Nodes = append(resp.Nodes, n)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// handleCallPath returns the call paths from ?from= to ?to=, functions given by ID or
+// unambiguous ID suffix: a shortest one, or with ?all=true up to ?max= paths.
+func (s *Server) handleCallPath(w http.ResponseWriter, r *http.Request) {
+	analysis, _ := s.snapshot(r)
+	query := r.URL.Query()
+	from, to := query.Get("from"), query.Get("to")
+	if from == "" || to == "" {
+		writeError(w, http.StatusBadRequest, "missing required query parameters: from, to")
+		return
+	}
+	all := false
+	if raw := query.Get("all"); raw != "" {
+		var err error
+		if all, err = strconv.ParseBool(raw); err != nil {
+			writeError(w, http.StatusBadRequest, "all must be a boolean")
+			return
+		}
+	}
+	limit := reach.DefaultMaxPaths
+	if raw := query.Get("max"); raw != "" {
+		var err error
+		if limit, err = strconv.Atoi(raw); err != nil || limit <= 0 {
+			writeError(w, http.StatusBadRequest, "max must be a positive integer")
+			return
+		}
+	}
+	paths, err := reach.NewGraph(analysis).Query(from, to, all, limit)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if len(paths.Paths) == 0 {
+		writeError(w, http.StatusNotFound, "no call path from "+paths.From+" to "+paths.To)
+		return
+	}
+	writeJSON(w, http.StatusOK, paths)
+}
+
+// handleTests lists the tests of the analysis, or with ?function= the tests exercising
+// that function (see reach.TestsCovering).
+func (s *Server) handleTests(w http.ResponseWriter, r *http.Request) {
+	analysis, _ := s.snapshot(r)
+	function := r.URL.Query().Get("function")
+	if function == "" {
+		tests := []datamodel.TestFunction{}
+		if analysis != nil && analysis.Tests != nil {
+			tests = analysis.Tests
+		}
+		writeJSON(w, http.StatusOK, tests)
+		return
+	}
+	coverage, err := reach.TestsCovering(analysis, function)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, coverage)
+}
+
+// handleEntities returns the entity named by the canonical URI ?uri= (see
+// datamodel.URIScheme), with its kind.
+func (s *Server) handleEntities(w http.ResponseWriter, r *http.Request) {
+	analysis, _ := s.snapshot(r)
+	uri := r.URL.Query().Get("uri")
+	if uri == "" {
+		writeError(w, http.StatusBadRequest, "missing required query parameter: uri")
+		return
+	}
+	if _, err := datamodel.ParseURI(uri); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	entity, ok := analysis.FindURI(uri)
+	if !ok {
+		writeError(w, http.StatusNotFound, "no entity with URI "+uri)
+		return
+	}
+	writeJSON(w, http.StatusOK, entity)
+}
+
+// handleTestReport reports the benchmarks and fuzz targets with the functions they
+// drive, and the exported functions without tests or fuzzing (see reach.NewTestReport).
+func (s *Server) handleTestReport(w http.ResponseWriter, r *http.Request) {
+	analysis, _ := s.snapshot(r)
+	writeJSON(w, http.StatusOK, reach.NewTestReport(analysis))
+}
+
+// handleExtract suggests an interface for the concrete type ?type= from the methods
+// its callers use (see typesystem.InterfaceExtractor), optionally only counting calls
+// from the package ?from= and naming the interface ?name=.
+func (s *Server) handleExtract(w http.ResponseWriter, r *http.Request) {
+	analysis, _ := s.snapshot(r)
+	query := r.URL.Query()
+	typeName := query.Get("type")
+	if typeName == "" {
+		writeError(w, http.StatusBadRequest, "missing required query parameter: type")
+		return
+	}
+	suggestion, err := typesystem.NewInterfaceExtractor(analysis).Extract(typeName, query.Get("from"), query.Get("name"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, suggestion)
+}
+
+// handleQuery evaluates the query language expression ?q= (see query.Query) against
+// the graph.
+func (s *Server) handleQuery(w http.ResponseWriter, r *http.Request) {
+	_, graph := s.snapshot(r)
+	q := r.URL.Query().Get("q")
+	if strings.TrimSpace(q) == "" {
+		writeError(w, http.StatusBadRequest, "missing required query parameter: q")
+		return
+	}
+	result, err := query.Run(graph, q)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid query: "+err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// resolveAlias returns the target of the type alias named name (qualified or bare), or
+// name itself if it is not an alias. Chains of aliases are followed.
+func resolveAlias(analysis *datamodel.ProjectAnalysis, name string) string {
+	for seen := map[string]bool{}; !seen[name]; {
+		seen[name] = true
+		target := ""
+		for _, pkg := range analysis.Packages {
+			if pkg == nil {
+				continue
+			}
+			for _, t := range pkg.Types {
+				if t.Kind == datamodel.TypeAlias && (t.PackagePath+"."+t.Name == name || t.Name == name) {
+					target = t.Target
+				}
+			}
+		}
+		if target == "" {
+			break
+		}
+		name = target
+	}
+	return name
+}
+
+func parseDirection(s string) (memstore.Direction, bool) {
+	switch s {
+	case "", "out":
+		return memstore.Outgoing, true
+	case "in":
+		return memstore.Incoming, true
+	case "both":
+		return memstore.Both, true
+	}
+	return 0, false
+}
+
+func edgeLabels(r *http.Request) []string {
+	if raw := r.URL.Query().Get("edge"); raw != "" {
+		return strings.Split(raw, ",")
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		slog.Warn("Failed to write JSON response", "error", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
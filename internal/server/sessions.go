@@ -0,0 +1,150 @@
+// server/sessions.go
+package server
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// Limits keeping sessions from growing without bound when clients never delete them.
+const (
+	// sessionIdleTTL ends sessions that have not been used for this long.
+	sessionIdleTTL = 30 * time.Minute
+	// maxSessions bounds the open sessions; creating more fails until some end.
+	maxSessions = 1024
+	// retainedVersions is the number of recent analysis versions kept for deltas.
+	// Sessions that fall further behind must start over.
+	retainedVersions = 8
+)
+
+// session remembers which analysis version a client has seen last.
+type session struct {
+	version  int
+	lastUsed time.Time
+}
+
+// retainedAnalysis is an analysis version kept for computing deltas.
+type retainedAnalysis struct {
+	version  int
+	analysis *datamodel.ProjectAnalysis
+}
+
+// SessionResponse is returned when a session is created.
+type SessionResponse struct {
+	ID      string `json:"ID"`
+	Version int    `json:"Version"`
+}
+
+// retain records the current analysis as the newest version and drops the versions
+// beyond retainedVersions. s.mu must be held for writing.
+func (s *Server) retain() {
+	s.history = append(s.history, retainedAnalysis{version: s.version, analysis: s.analysis})
+	if n := len(s.history) - retainedVersions; n > 0 {
+		clear(s.history[:n])
+		s.history = s.history[n:]
+	}
+}
+
+// retained returns the analysis of version, or nil if it is no longer kept. s.mu must
+// be held.
+func (s *Server) retained(version int) *datamodel.ProjectAnalysis {
+	for _, r := range s.history {
+		if r.version == version {
+			return r.analysis
+		}
+	}
+	return nil
+}
+
+// reapSessions ends the sessions idle for longer than sessionIdleTTL. s.mu must be
+// held for writing.
+func (s *Server) reapSessions(now time.Time) {
+	for id, sess := range s.sessions {
+		if now.Sub(sess.lastUsed) > sessionIdleTTL {
+			delete(s.sessions, id)
+		}
+	}
+}
+
+// handleCreateSession starts a session at the current version. Clients fetch the full
+// results once, then poll /sessions/{id}/delta for incremental changes.
+func (s *Server) handleCreateSession(w http.ResponseWriter, r *http.Request) {
+	var raw [16]byte
+	if _, err := rand.Read(raw[:]); err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to create session id")
+		return
+	}
+	id := hex.EncodeToString(raw[:])
+
+	now := time.Now()
+	s.mu.Lock()
+	if len(s.sessions) >= maxSessions {
+		s.reapSessions(now)
+	}
+	if len(s.sessions) >= maxSessions {
+		s.mu.Unlock()
+		writeError(w, http.StatusServiceUnavailable, fmt.Sprintf("too many sessions (at most %d); delete unused sessions", maxSessions))
+		return
+	}
+	s.sessions[id] = &session{version: s.version, lastUsed: now}
+	version := s.version
+	s.mu.Unlock()
+	writeJSON(w, http.StatusCreated, SessionResponse{ID: id, Version: version})
+}
+
+// handleSessionDelta returns the changes since the version the session saw last
+// and advances the session to the current version. A session whose version is no
+// longer retained is ended with 410 Gone; the client starts a new one and re-fetches
+// the full results.
+func (s *Server) handleSessionDelta(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	now := time.Now()
+	s.mu.Lock()
+	sess, ok := s.sessions[id]
+	if ok && now.Sub(sess.lastUsed) > sessionIdleTTL {
+		delete(s.sessions, id)
+		ok = false
+	}
+	if !ok {
+		s.mu.Unlock()
+		writeError(w, http.StatusNotFound, "session not found: "+id)
+		return
+	}
+	from, to := s.retained(sess.version), s.analysis
+	fromVersion, toVersion := sess.version, s.version
+	if from == nil {
+		delete(s.sessions, id)
+		s.mu.Unlock()
+		writeError(w, http.StatusGone, fmt.Sprintf("session %s is at version %d, which is no longer retained; start a new session", id, fromVersion))
+		return
+	}
+	sess.version, sess.lastUsed = toVersion, now
+	s.mu.Unlock()
+
+	// Analyses are immutable once published, so the comparison can run unlocked
+	delta := computeDelta(nil, nil)
+	if from != to {
+		delta = computeDelta(from, to)
+	}
+	delta.FromVersion, delta.ToVersion = fromVersion, toVersion
+	writeJSON(w, http.StatusOK, delta)
+}
+
+// handleDeleteSession ends a session.
+func (s *Server) handleDeleteSession(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	s.mu.Lock()
+	_, ok := s.sessions[id]
+	delete(s.sessions, id)
+	s.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, "session not found: "+id)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,162 @@
+// server/ui.go
+package server
+
+import (
+	_ "embed"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/namikmesic/go-mcp/internal/memstore"
+)
+
+//go:embed ui/index.html
+var uiIndex []byte
+
+// maxUINodes bounds the graphs sent to the web UI, which cannot lay out much larger
+// ones interactively; larger graphs are cut and marked Truncated.
+const maxUINodes = 1500
+
+// UIElements is a graph returned by /ui/elements, in the format of the cytoscape.js
+// elements option, so other graph tools can read it too.
+type UIElements struct {
+	Nodes     []UIElement `json:"nodes"`
+	Edges     []UIElement `json:"edges"`
+	Truncated bool        `json:"truncated,omitempty"`
+}
+
+// UIElement is a cytoscape.js node or edge.
+type UIElement struct {
+	Data map[string]any `json:"data"`
+}
+
+// EnableUI serves the interactive web UI under /ui/. It renders the interface and
+// implementation graph and the call graph, read from /ui/elements.
+func (s *Server) EnableUI() {
+	s.mux.HandleFunc("GET /ui/{$}", s.handleUI)
+	s.mux.HandleFunc("GET /ui/elements", s.handleUIElements)
+	s.mux.Handle("GET /ui", http.RedirectHandler("/ui/", http.StatusMovedPermanently))
+}
+
+func (s *Server) handleUI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(uiIndex)
+}
+
+// handleUIElements returns ?graph= (interfaces, the default, or calls) as cytoscape.js
+// elements. With ?q=, only edges with an endpoint whose ID contains q are included.
+func (s *Server) handleUIElements(w http.ResponseWriter, r *http.Request) {
+	_, graph := s.snapshot(r)
+	q := r.URL.Query().Get("q")
+	switch r.URL.Query().Get("graph") {
+	case "", "interfaces":
+		writeJSON(w, http.StatusOK, uiElements(graph, q,
+			[]string{memstore.LabelImplementation, memstore.LabelInterface},
+			[]string{memstore.EdgeImplements, memstore.EdgeEmbeds}, memstore.LabelInterface))
+	case "calls":
+		writeJSON(w, http.StatusOK, uiElements(graph, q,
+			[]string{memstore.LabelFunction},
+			[]string{memstore.EdgeCalls}, ""))
+	default:
+		writeError(w, http.StatusBadRequest, "graph must be one of: interfaces, calls")
+	}
+}
+
+// uiElements collects the edgeLabels edges leaving nodes with the given labels, and
+// their endpoints. Parallel edges (e.g. several calls between two functions) are
+// merged into one with a count. Analyzed nodes labeled isolated are included even
+// without edges, so e.g. interfaces without implementations still show.
+func uiElements(graph *memstore.Graph, q string, labels, edgeLabels []string, isolated string) *UIElements {
+	matches := func(id string) bool { return q == "" || strings.Contains(id, q) }
+	type edgeKey struct{ from, to, label string }
+	counts := make(map[edgeKey]int)
+	var keys []edgeKey
+	nodes := make(map[string]*memstore.Node)
+	for _, label := range labels {
+		for _, n := range graph.NodesByLabel(label) {
+			for _, e := range graph.Edges(n.ID, memstore.Outgoing, edgeLabels...) {
+				if !matches(e.From) && !matches(e.To) {
+					continue
+				}
+				key := edgeKey{e.From, e.To, e.Label}
+				if counts[key] == 0 {
+					keys = append(keys, key)
+				}
+				counts[key]++
+			}
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].from != keys[j].from {
+			return keys[i].from < keys[j].from
+		}
+		if keys[i].to != keys[j].to {
+			return keys[i].to < keys[j].to
+		}
+		return keys[i].label < keys[j].label
+	})
+
+	result := &UIElements{Nodes: []UIElement{}, Edges: []UIElement{}}
+	addNode := func(id string) bool {
+		if nodes[id] != nil {
+			return true
+		}
+		if len(nodes) >= maxUINodes {
+			result.Truncated = true
+			return false
+		}
+		n, _ := graph.Node(id)
+		nodes[id] = n
+		return true
+	}
+	for _, key := range keys {
+		if !addNode(key.from) || !addNode(key.to) {
+			continue
+		}
+		result.Edges = append(result.Edges, UIElement{Data: map[string]any{
+			"id":     key.label + ":" + key.from + "->" + key.to,
+			"source": key.from,
+			"target": key.to,
+			"label":  key.label,
+			"count":  counts[key],
+		}})
+	}
+	if isolated != "" {
+		for _, n := range graph.NodesByLabel(isolated) {
+			if len(n.Props) > 0 && matches(n.ID) {
+				addNode(n.ID)
+			}
+		}
+	}
+
+	ids := make([]string, 0, len(nodes))
+	for id := range nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		n := nodes[id]
+		data := map[string]any{"id": n.ID, "kind": n.Label, "name": uiNodeName(n)}
+		for k, v := range n.Props {
+			if _, reserved := data[k]; !reserved {
+				data[k] = v
+			}
+		}
+		result.Nodes = append(result.Nodes, UIElement{Data: data})
+	}
+	return result
+}
+
+// uiNodeName is the short display name of a node: its declared name, or the last
+// element of its ID.
+func uiNodeName(n *memstore.Node) string {
+	for _, prop := range []string{"name", "typeName"} {
+		if name, ok := n.Props[prop].(string); ok && name != "" {
+			if recv, ok := n.Props["receiver"].(string); ok && recv != "" {
+				return "(" + recv + ")." + name
+			}
+			return name
+		}
+	}
+	return n.ID[strings.LastIndex(n.ID, "/")+1:]
+}
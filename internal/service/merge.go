@@ -0,0 +1,231 @@
+// service/merge.go
+package service
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// AnalyzeProjects analyzes each path separately and merges the results into one
+// ProjectAnalysis, for checkouts spanning several modules. A single path is analyzed
+// exactly like AnalyzeProject. Project-wide passes (stability, layers, findings) run
+// per root, so they do not see relationships between roots. Paths in the result use
+// forward slashes on every platform.
+func (s *AnalysisService) AnalyzeProjects(ctx context.Context, paths []string) (*datamodel.ProjectAnalysis, error) {
+	if len(paths) == 1 {
+		analysis, err := s.AnalyzeProject(ctx, paths[0])
+		datamodel.SlashPaths(analysis)
+		return analysis, err
+	}
+	analyses := make([]*datamodel.ProjectAnalysis, 0, len(paths))
+	for _, path := range paths {
+		analysis, err := s.AnalyzeProject(ctx, path)
+		if err != nil {
+			return nil, fmt.Errorf("analyzing %s: %w", path, err)
+		}
+		analyses = append(analyses, analysis)
+	}
+	merged := MergeAnalyses(analyses)
+	datamodel.SlashPaths(merged)
+	s.log().Info("Merged roots", "roots", len(analyses), "packages", len(merged.Packages), "dir", merged.ModuleDir)
+	return merged, nil
+}
+
+// MergeAnalyses combines the results of several roots. ModuleDir becomes the deepest
+// directory containing every root, and module-relative file paths are rebased onto it
+// so they stay unique. Packages and dependencies present in several roots are kept once,
+// from the first root.
+func MergeAnalyses(analyses []*datamodel.ProjectAnalysis) *datamodel.ProjectAnalysis {
+	dirs := make([]string, 0, len(analyses))
+	for _, a := range analyses {
+		if a.ModuleDir != "" {
+			dirs = append(dirs, a.ModuleDir)
+		}
+	}
+	merged := &datamodel.ProjectAnalysis{
+		SchemaVersion: datamodel.SchemaVersion,
+		ModuleDir:     commonDir(dirs),
+		Packages:      []*datamodel.PackageAnalysis{},
+	}
+
+	seenPkgs := make(map[string]bool)
+	seenDeps := make(map[string]bool)
+	seenInit := make(map[string]bool)
+	stdlib := make(map[string]int) // Interface key -> index in merged.StdlibInterfaces
+	for _, a := range analyses {
+		root := datamodel.Root{ModulePath: a.ModulePath, Dir: "."}
+		if a.ModuleDir != "" && merged.ModuleDir != "" {
+			if rel, err := filepath.Rel(merged.ModuleDir, a.ModuleDir); err == nil {
+				root.Dir = rel
+			}
+		}
+		merged.Roots = append(merged.Roots, root)
+		rebase := func(filename string) string {
+			if filename == "" || filepath.IsAbs(filename) || root.Dir == "." {
+				return filename
+			}
+			return filepath.Join(root.Dir, filename)
+		}
+		datamodel.RewriteFiles(a, rebase)
+
+		for _, pkg := range a.Packages {
+			if pkg == nil || seenPkgs[pkg.Path] {
+				continue
+			}
+			seenPkgs[pkg.Path] = true
+			merged.Packages = append(merged.Packages, pkg)
+		}
+		for _, dep := range a.Dependencies {
+			if !seenDeps[dep.Path] {
+				seenDeps[dep.Path] = true
+				merged.Dependencies = append(merged.Dependencies, dep)
+			}
+		}
+		for _, iface := range a.StdlibInterfaces {
+			key := iface.PackagePath + "." + iface.Name
+			if i, ok := stdlib[key]; ok {
+				existing := &merged.StdlibInterfaces[i]
+				existing.Implementations = append(existing.Implementations, iface.Implementations...)
+				continue
+			}
+			stdlib[key] = len(merged.StdlibInterfaces)
+			merged.StdlibInterfaces = append(merged.StdlibInterfaces, iface)
+		}
+		merged.CrossModuleImplementations = append(merged.CrossModuleImplementations, a.CrossModuleImplementations...)
+		if merged.ReachabilityRoots == nil {
+			merged.ReachabilityRoots = a.ReachabilityRoots // The same for every root
+		}
+		mergeModuleGraph(merged, a.ModuleGraph)
+		merged.Tests = append(merged.Tests, a.Tests...)
+		for _, path := range a.InitOrder {
+			if !seenInit[path] {
+				seenInit[path] = true
+				merged.InitOrder = append(merged.InitOrder, path)
+			}
+		}
+		mergeFindings(merged, a.Findings)
+	}
+
+	// Call site URIs include the rebased file paths
+	datamodel.AssignURIs(merged)
+
+	// Keep a common module path when all roots belong to the same module
+	merged.ModulePath = analyses[0].ModulePath
+	for _, a := range analyses[1:] {
+		if a.ModulePath != merged.ModulePath {
+			merged.ModulePath = ""
+			break
+		}
+	}
+	return merged
+}
+
+func mergeFindings(merged *datamodel.ProjectAnalysis, f *datamodel.Findings) {
+	if f == nil {
+		return
+	}
+	if merged.Findings == nil {
+		merged.Findings = &datamodel.Findings{}
+	}
+	m := merged.Findings
+	m.Clones = append(m.Clones, f.Clones...)
+	m.RuleViolations = append(m.RuleViolations, f.RuleViolations...)
+	m.NearMisses = append(m.NearMisses, f.NearMisses...)
+	m.ImportCycles = append(m.ImportCycles, f.ImportCycles...)
+	m.FatInterfaces = append(m.FatInterfaces, f.FatInterfaces...)
+	m.DuplicateInterfaces = append(m.DuplicateInterfaces, f.DuplicateInterfaces...)
+	m.UnrecoveredPanics = append(m.UnrecoveredPanics, f.UnrecoveredPanics...)
+	m.UntestedFunctions = append(m.UntestedFunctions, f.UntestedFunctions...)
+	if f.AdapterGaps != nil {
+		if m.AdapterGaps == nil {
+			m.AdapterGaps = &datamodel.AdapterGaps{}
+		}
+		m.AdapterGaps.UnimplementedInterfaces = append(m.AdapterGaps.UnimplementedInterfaces, f.AdapterGaps.UnimplementedInterfaces...)
+		m.AdapterGaps.ExternalImplementations = append(m.AdapterGaps.ExternalImplementations, f.AdapterGaps.ExternalImplementations...)
+	}
+}
+
+// mergeModuleGraph adds the modules of g to the merged graph, combining the modules
+// present in several roots.
+func mergeModuleGraph(merged *datamodel.ProjectAnalysis, g *datamodel.ModuleGraph) {
+	if g == nil {
+		return
+	}
+	if merged.ModuleGraph == nil {
+		merged.ModuleGraph = &datamodel.ModuleGraph{}
+	}
+	m := merged.ModuleGraph
+	index := make(map[string]int, len(m.Modules))
+	for i, node := range m.Modules {
+		index[node.Path] = i
+	}
+	for _, node := range g.Modules {
+		i, ok := index[node.Path]
+		if !ok {
+			m.Modules = append(m.Modules, node)
+			continue
+		}
+		existing := &m.Modules[i]
+		existing.Main = existing.Main || node.Main
+		// Indirect only if every go.mod requiring it says so
+		switch {
+		case len(existing.RequiredBy) == 0:
+			existing.Indirect = node.Indirect
+		case len(node.RequiredBy) > 0:
+			existing.Indirect = existing.Indirect && node.Indirect
+		}
+		existing.RequiredBy = unionSorted(existing.RequiredBy, node.RequiredBy)
+		existing.Imports = unionSorted(existing.Imports, node.Imports)
+		existing.Importers = unionSorted(existing.Importers, node.Importers)
+	}
+	sort.Slice(m.Modules, func(i, j int) bool {
+		a, b := m.Modules[i], m.Modules[j]
+		if a.Main != b.Main {
+			return a.Main
+		}
+		return a.Path < b.Path
+	})
+}
+
+// unionSorted returns the sorted union of two sorted lists.
+func unionSorted(a, b []string) []string {
+	if len(b) == 0 {
+		return a
+	}
+	seen := make(map[string]bool, len(a)+len(b))
+	var union []string
+	for _, list := range [][]string{a, b} {
+		for _, s := range list {
+			if !seen[s] {
+				seen[s] = true
+				union = append(union, s)
+			}
+		}
+	}
+	sort.Strings(union)
+	return union
+}
+
+// commonDir returns the deepest directory containing all dirs, or "" if there is none.
+func commonDir(dirs []string) string {
+	if len(dirs) == 0 {
+		return ""
+	}
+	common := filepath.Clean(dirs[0])
+	for _, dir := range dirs[1:] {
+		dir = filepath.Clean(dir)
+		for common != dir && !strings.HasPrefix(dir, common+string(filepath.Separator)) {
+			parent := filepath.Dir(common)
+			if parent == common {
+				return common // Reached the filesystem root
+			}
+			common = parent
+		}
+	}
+	return common
+}
@@ -2,15 +2,21 @@
 package service
 
 import (
+	"context"
 	"fmt"
 	"go/token" // Import token needed by ImplementationFinder
 	"log"
+	"log/slog"
+	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
-	"github.com/namikmesic/go-mcp/internal/analyzer"  // Adjusted import path
-	"github.com/namikmesic/go-mcp/internal/datamodel" // Adjusted import path
-	"github.com/namikmesic/go-mcp/internal/loader"    // Adjusted import path
-	"golang.org/x/tools/go/packages"                  // Import needed for map key type
+	"github.com/namikmesic/go-mcp/internal/analyzer" // Adjusted import path
+	"github.com/namikmesic/go-mcp/internal/analyzer/filter"
+	"github.com/namikmesic/go-mcp/internal/loader" // Adjusted import path
+	"github.com/namikmesic/go-mcp/pkg/datamodel"   // Adjusted import path
+	"golang.org/x/tools/go/packages"               // Import needed for map key type
 )
 
 // AnalysisService orchestrates the loading and analysis of Go projects.
@@ -19,6 +25,14 @@ type AnalysisService struct {
 	interfaceAnalyzer    analyzer.InterfaceAnalyzer
 	implementationFinder analyzer.ImplementationFinder
 	callGraphAnalyzer    analyzer.CallGraphAnalyzer
+	filterPolicy         analyzer.FilterPolicy
+	progress             analyzer.ProgressReporter
+	logger               *slog.Logger // nil logs to slog.Default()
+	skipCallGraph        bool
+	skipInterfaces       bool
+	lowMemory            bool
+	packageAnalyzers     []analyzer.PackageAnalyzer
+	projectAnalyzers     []analyzer.ProjectAnalyzer
 }
 
 // NewAnalysisService creates a new service with the required components.
@@ -38,13 +52,124 @@ func NewAnalysisService(
 		interfaceAnalyzer:    ia,
 		implementationFinder: idf,
 		callGraphAnalyzer:    cga,
+		filterPolicy:         filter.AllowAll(),
 	}
 }
 
-// AnalyzeProject loads and analyzes the Go project at the given path.
-func (s *AnalysisService) AnalyzeProject(path string) (*datamodel.ProjectAnalysis, error) {
-	log.Printf("Loading packages from directory: %s", path)
-	pkgs, err := s.loader.Load(path)
+// AddPackageAnalyzer registers an additional pass run for every assembled package.
+func (s *AnalysisService) AddPackageAnalyzer(pa analyzer.PackageAnalyzer) {
+	if f, ok := pa.(analyzer.Filterable); ok {
+		f.SetFilterPolicy(s.filterPolicy)
+	}
+	if p, ok := pa.(analyzer.ProgressAware); ok && s.progress != nil {
+		p.SetProgressReporter(s.progress)
+	}
+	if l, ok := pa.(analyzer.LoggerAware); ok && s.logger != nil {
+		l.SetLogger(s.logger)
+	}
+	s.packageAnalyzers = append(s.packageAnalyzers, pa)
+}
+
+// AddProjectAnalyzer registers an additional pass run once over the assembled project.
+// Project analyzers run in registration order.
+func (s *AnalysisService) AddProjectAnalyzer(pa analyzer.ProjectAnalyzer) {
+	if f, ok := pa.(analyzer.Filterable); ok {
+		f.SetFilterPolicy(s.filterPolicy)
+	}
+	if p, ok := pa.(analyzer.ProgressAware); ok && s.progress != nil {
+		p.SetProgressReporter(s.progress)
+	}
+	if l, ok := pa.(analyzer.LoggerAware); ok && s.logger != nil {
+		l.SetLogger(s.logger)
+	}
+	s.projectAnalyzers = append(s.projectAnalyzers, pa)
+}
+
+// SetFilterPolicy applies the policy to the service and to every component
+// implementing analyzer.Filterable, so all analyzers filter uniformly.
+func (s *AnalysisService) SetFilterPolicy(policy analyzer.FilterPolicy) {
+	if policy == nil {
+		policy = filter.AllowAll()
+	}
+	s.filterPolicy = policy
+	for _, component := range s.components() {
+		if f, ok := component.(analyzer.Filterable); ok {
+			f.SetFilterPolicy(policy)
+		}
+	}
+}
+
+// SetProgressReporter sends progress updates of subsequent analyses to reporter, both
+// from the service's own stages and from every component implementing
+// analyzer.ProgressAware. A nil reporter disables progress reporting.
+func (s *AnalysisService) SetProgressReporter(reporter analyzer.ProgressReporter) {
+	s.progress = reporter
+	for _, component := range s.components() {
+		if p, ok := component.(analyzer.ProgressAware); ok {
+			p.SetProgressReporter(reporter)
+		}
+	}
+}
+
+// SetLogger sends the log output of subsequent analyses to logger, both from the service
+// and from every component implementing analyzer.LoggerAware. A nil logger logs to
+// slog.Default().
+func (s *AnalysisService) SetLogger(logger *slog.Logger) {
+	s.logger = logger
+	for _, component := range s.components() {
+		if l, ok := component.(analyzer.LoggerAware); ok {
+			l.SetLogger(logger)
+		}
+	}
+}
+
+// SetSkipCallGraph controls whether subsequent analyses skip the call graph analyzer.
+// Skipping it avoids building SSA, which dominates runtime and memory, at the cost of
+// reporting no call sites.
+func (s *AnalysisService) SetSkipCallGraph(skip bool) {
+	s.skipCallGraph = skip
+}
+
+// SetSkipInterfaces controls whether subsequent analyses skip the interface analyzer and
+// the implementation finder, reporting no interfaces or implementations.
+func (s *AnalysisService) SetSkipInterfaces(skip bool) {
+	s.skipInterfaces = skip
+}
+
+// SetLowMemory controls whether subsequent analyses release the syntax trees and type
+// information of each package once its package analyzers have run, so memory use does
+// not grow with the number of assembled packages. Project analyzers only see the
+// assembled results, so they are unaffected.
+func (s *AnalysisService) SetLowMemory(lowMemory bool) {
+	s.lowMemory = lowMemory
+}
+
+func (s *AnalysisService) log() *slog.Logger {
+	return analyzer.LoggerOrDefault(s.logger)
+}
+
+// components returns all registered analysis components.
+func (s *AnalysisService) components() []interface{} {
+	components := []interface{}{s.loader, s.interfaceAnalyzer, s.implementationFinder, s.callGraphAnalyzer}
+	for _, pa := range s.packageAnalyzers {
+		components = append(components, pa)
+	}
+	for _, pa := range s.projectAnalyzers {
+		components = append(components, pa)
+	}
+	return components
+}
+
+// AnalyzeProject loads and analyzes the Go project at the given path. When ctx is
+// cancelled or its deadline expires, the analysis stops at the next stage boundary
+// (or earlier, in components that check ctx) and returns ctx.Err().
+func (s *AnalysisService) AnalyzeProject(ctx context.Context, path string) (*datamodel.ProjectAnalysis, error) {
+	s.log().Info("Loading packages", "path", path)
+	analyzer.ReportProgress(s.progress, analyzer.StageLoad, 0, 0)
+	pkgs, err := s.loader.Load(ctx, path)
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to load packages: %w", err)
 	}
@@ -53,13 +178,14 @@ func (s *AnalysisService) AnalyzeProject(path string) (*datamodel.ProjectAnalysi
 		// If not, it means Load succeeded but found nothing valid.
 		return nil, fmt.Errorf("no valid Go packages found or loaded from %s", path)
 	}
-	log.Printf("Successfully loaded %d package(s) for analysis.", len(pkgs))
+	s.log().Info("Loaded packages for analysis", "count", len(pkgs))
+	analyzer.ReportProgress(s.progress, analyzer.StageLoad, len(pkgs), len(pkgs))
 
-	// Determine module information - use the first package with a non-nil module
+	// Determine module information - use the main module, else the first package with a non-nil module
 	var moduleInfo *datamodel.ModuleInfo
 	var moduleDir string
 	var modulePath string
-	for _, pkg := range pkgs {
+	for _, pkg := range mainModuleFirst(pkgs) {
 		if pkg != nil && pkg.Module != nil {
 			moduleInfo = &datamodel.ModuleInfo{
 				Path:    pkg.Module.Path,
@@ -74,50 +200,58 @@ func (s *AnalysisService) AnalyzeProject(path string) (*datamodel.ProjectAnalysi
 		}
 	}
 	if moduleInfo == nil {
-		log.Println("Warning: No module information found for any package.")
+		// GOPATH-mode packages have no module; paths are made relative to the loaded directory
+		moduleDir, modulePath = gopathRoot(path, pkgs)
+		if moduleDir == "" {
+			s.log().Warn("No module information found for any package")
+		} else {
+			s.log().Info("No module information found; using GOPATH root", "path", modulePath, "dir", moduleDir)
+		}
 	} else {
-		log.Printf("Using module: path=%s, dir=%s", modulePath, moduleDir)
+		s.log().Info("Using module", "path", modulePath, "dir", moduleDir)
+	}
+	// Package patterns also match relative to the module, as in architecture rules
+	if policy, ok := s.filterPolicy.(*filter.RulePolicy); ok && policy.ModulePath != modulePath {
+		withModule := *policy
+		withModule.ModulePath = modulePath
+		s.SetFilterPolicy(&withModule)
 	}
 
-	log.Println("Analyzing interfaces...")
 	// interfacesMap key: packagePath + "." + interfaceName
-	interfacesMap, err := s.interfaceAnalyzer.AnalyzeInterfaces(pkgs)
+	interfacesMap := make(map[string]*datamodel.Interface)
+	if s.skipInterfaces {
+		s.log().Info("Skipping interface analysis")
+	} else {
+		s.log().Info("Analyzing interfaces")
+		interfacesMap, err = s.interfaceAnalyzer.AnalyzeInterfaces(ctx, pkgs)
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
 	if err != nil {
 		// Depending on severity, might log and continue or return error
-		log.Printf("Warning: Interface analysis failed: %v. Proceeding without interface data.", err)
+		s.log().Warn("Interface analysis failed; proceeding without interface data", "error", err)
 		interfacesMap = make(map[string]*datamodel.Interface) // Ensure map is non-nil
 	} else {
-		log.Printf("Found %d unique interface definitions.", len(interfacesMap))
+		s.log().Info("Found interface definitions", "count", len(interfacesMap))
 	}
 
-	log.Println("Analyzing calls (building SSA)...")
-	// callsByPackage key: *packages.Package
-	var callsByPackage map[*packages.Package][]datamodel.CallSite
-	var ssaFset *token.FileSet // FileSet from SSA is crucial for consistent positions
-
-	callsByPackage, _, ssaFset, err = s.callGraphAnalyzer.AnalyzeCalls(pkgs)
+	callsByPackage, fset, err := s.analyzeCalls(ctx, pkgs)
 	if err != nil {
-		// Call graph analysis is often critical. Log details and fail.
-		log.Printf("Error: Call graph analysis failed: %v", err)
-		return nil, fmt.Errorf("failed during call graph analysis: %w", err)
+		return nil, err
 	}
-	callCount := 0
-	for _, calls := range callsByPackage {
-		callCount += len(calls)
+
+	if !s.skipInterfaces {
+		s.log().Info("Finding implementations")
+		// Pass the FileSet used for the call sites to the implementation finder
+		err = s.implementationFinder.FindImplementations(ctx, pkgs, interfacesMap, fset)
 	}
-	log.Printf("Found %d call sites across %d packages.", callCount, len(callsByPackage))
-	if ssaFset == nil {
-		// This should ideally be caught by AnalyzeCalls, but double-check
-		log.Println("Error: Call graph analysis succeeded but returned a nil FileSet. Location data will be inconsistent.")
-		return nil, fmt.Errorf("call graph analysis returned nil FileSet")
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
 	}
-
-	log.Println("Finding implementations...")
-	// Pass the FileSet obtained from SSA to the implementation finder
-	err = s.implementationFinder.FindImplementations(pkgs, interfacesMap, ssaFset)
 	if err != nil {
 		// Implementation finding might be less critical than calls for some use cases.
-		log.Printf("Warning: Implementation finding failed: %v. Proceeding without implementation data.", err)
+		s.log().Warn("Implementation finding failed; proceeding without implementation data", "error", err)
 		// If continuing, ensure Implementations slices are empty, not nil
 		for _, iface := range interfacesMap {
 			if iface.Implementations == nil {
@@ -129,24 +263,30 @@ func (s *AnalysisService) AnalyzeProject(path string) (*datamodel.ProjectAnalysi
 		for _, iface := range interfacesMap {
 			implCount += len(iface.Implementations)
 		}
-		log.Printf("Found %d implementation relationships.", implCount)
+		s.log().Info("Found implementation relationships", "count", implCount)
 	}
 
+	// Shared with the additional package and project analyzers
+	env := &analyzer.Env{Fset: fset, ModuleDir: moduleDir}
+
 	// --- Assemble the final result ---
-	log.Println("Assembling final analysis results...")
+	s.log().Info("Assembling final analysis results")
 	projectAnalysis := &datamodel.ProjectAnalysis{
-		ModulePath: modulePath,
-		ModuleDir:  moduleDir,
-		Packages:   make([]*datamodel.PackageAnalysis, 0, len(pkgs)),
+		SchemaVersion: datamodel.SchemaVersion,
+		ModulePath:    modulePath,
+		ModuleDir:     moduleDir,
+		Packages:      make([]*datamodel.PackageAnalysis, 0, len(pkgs)),
 	}
 
 	// Create a map for quick lookup of interfaces belonging to a package path
 	interfacesByPkgPath := make(map[string][]datamodel.Interface)
+	var stdlibInterfaces []datamodel.Interface
 	for _, iface := range interfacesMap {
-		// Make location filenames relative to module directory
+		// Make location filenames relative to module directory; standard library
+		// interfaces keep their absolute GOROOT paths
 		if moduleDir != "" && filepath.IsAbs(iface.Location.Filename) {
 			relPath, err := filepath.Rel(moduleDir, iface.Location.Filename)
-			if err == nil {
+			if err == nil && !strings.HasPrefix(relPath, "..") {
 				iface.Location.Filename = relPath
 			}
 		}
@@ -155,12 +295,22 @@ func (s *AnalysisService) AnalyzeProject(path string) (*datamodel.ProjectAnalysi
 		for i := range iface.Methods {
 			if moduleDir != "" && filepath.IsAbs(iface.Methods[i].Location.Filename) {
 				relPath, err := filepath.Rel(moduleDir, iface.Methods[i].Location.Filename)
-				if err == nil {
+				if err == nil && !strings.HasPrefix(relPath, "..") {
 					iface.Methods[i].Location.Filename = relPath
 				}
 			}
 		}
 
+		// Embedded methods may come from other modules or GOROOT; those keep absolute paths
+		for i := range iface.MethodSet {
+			if moduleDir != "" && filepath.IsAbs(iface.MethodSet[i].Location.Filename) {
+				relPath, err := filepath.Rel(moduleDir, iface.MethodSet[i].Location.Filename)
+				if err == nil && !strings.HasPrefix(relPath, "..") {
+					iface.MethodSet[i].Location.Filename = relPath
+				}
+			}
+		}
+
 		// Make implementation location filenames relative
 		for i := range iface.Implementations {
 			if moduleDir != "" && filepath.IsAbs(iface.Implementations[i].Location.Filename) {
@@ -169,6 +319,32 @@ func (s *AnalysisService) AnalyzeProject(path string) (*datamodel.ProjectAnalysi
 					iface.Implementations[i].Location.Filename = relPath
 				}
 			}
+			// Promoted methods may come from embedded types in other modules
+			for j := range iface.Implementations[i].Methods {
+				loc := &iface.Implementations[i].Methods[j].Location
+				if moduleDir != "" && filepath.IsAbs(loc.Filename) {
+					relPath, err := filepath.Rel(moduleDir, loc.Filename)
+					if err == nil && !strings.HasPrefix(relPath, "..") {
+						loc.Filename = relPath
+					}
+				}
+			}
+		}
+
+		sort.SliceStable(iface.Implementations, func(i, j int) bool {
+			a, b := iface.Implementations[i], iface.Implementations[j]
+			if a.PackagePath != b.PackagePath {
+				return a.PackagePath < b.PackagePath
+			}
+			if a.TypeName != b.TypeName {
+				return a.TypeName < b.TypeName
+			}
+			return !a.IsPointer && b.IsPointer
+		})
+
+		if iface.Stdlib {
+			stdlibInterfaces = append(stdlibInterfaces, *iface)
+			continue
 		}
 
 		// Ensure the slice exists before appending
@@ -191,11 +367,23 @@ func (s *AnalysisService) AnalyzeProject(path string) (*datamodel.ProjectAnalysi
 		callsByPackage[pkg] = calls
 	}
 
-	// Populate PackageAnalysis for each loaded package
-	for _, pkg := range pkgs {
+	// Last chance to stop before the package analyzers start gathering state
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Populate PackageAnalysis for each loaded package, reporting packages with
+	// in-package tests once, through their test variant
+	superseded := loader.SupersededVariants(pkgs)
+	for i, pkg := range pkgs {
+		analyzer.ReportProgress(s.progress, analyzer.StagePackages, i, len(pkgs))
 		// Basic check if pkg is valid
 		if pkg == nil || pkg.PkgPath == "" {
-			log.Printf("Warning: Skipping assembly for a nil or invalid package.")
+			s.log().Warn("Skipping assembly for a nil or invalid package")
+			continue
+		}
+		if superseded[pkg] || !s.filterPolicy.IncludePackage(pkg) {
+			s.release(pkg)
 			continue
 		}
 
@@ -225,6 +413,7 @@ func (s *AnalysisService) AnalyzeProject(path string) (*datamodel.ProjectAnalysi
 			EmbedPatterns: pkg.EmbedPatterns,                // Relative to package dir
 			Interfaces:    interfacesByPkgPath[pkg.PkgPath], // Get interfaces for this package path
 			Calls:         callsByPackage[pkg],              // Get calls for this package (*packages.Package key)
+			Vendored:      filter.IsVendored(pkg),
 		}
 
 		// Ensure slices are non-nil for JSON marshalling
@@ -252,10 +441,165 @@ func (s *AnalysisService) AnalyzeProject(path string) (*datamodel.ProjectAnalysi
 			pkgAnalysis.Imports = append(pkgAnalysis.Imports, path)
 		}
 
+		// Map iteration and parallel workers leave these in varying order; sort them so
+		// repeated runs over the same sources produce identical output
+		sort.Strings(pkgAnalysis.Imports)
+		sort.SliceStable(pkgAnalysis.Interfaces, func(i, j int) bool {
+			a, b := pkgAnalysis.Interfaces[i], pkgAnalysis.Interfaces[j]
+			if a.Name != b.Name {
+				return a.Name < b.Name
+			}
+			return locationLess(a.Location, b.Location)
+		})
+		sort.SliceStable(pkgAnalysis.Calls, func(i, j int) bool {
+			a, b := pkgAnalysis.Calls[i], pkgAnalysis.Calls[j]
+			if a.Location != b.Location {
+				return locationLess(a.Location, b.Location)
+			}
+			return a.CalleeDesc < b.CalleeDesc
+		})
+
+		// Run the additional per-package passes
+		for _, pa := range s.packageAnalyzers {
+			if err := pa.AnalyzePackage(ctx, env, pkg, pkgAnalysis); err != nil {
+				s.log().Warn("Package analyzer failed", "analyzer", fmt.Sprintf("%T", pa), "package", pkg.ID, "error", err)
+			}
+		}
+		s.release(pkg)
+
 		projectAnalysis.Packages = append(projectAnalysis.Packages, pkgAnalysis)
 	}
-	log.Printf("Assembled results for %d packages.", len(projectAnalysis.Packages))
-	log.Println("Analysis complete.")
+	analyzer.ReportProgress(s.progress, analyzer.StagePackages, len(pkgs), len(pkgs))
+	s.log().Info("Assembled package results", "count", len(projectAnalysis.Packages))
+
+	sort.Slice(stdlibInterfaces, func(i, j int) bool {
+		if stdlibInterfaces[i].PackagePath != stdlibInterfaces[j].PackagePath {
+			return stdlibInterfaces[i].PackagePath < stdlibInterfaces[j].PackagePath
+		}
+		return stdlibInterfaces[i].Name < stdlibInterfaces[j].Name
+	})
+	projectAnalysis.StdlibInterfaces = stdlibInterfaces
+	datamodel.AssignURIs(projectAnalysis)
+
+	// Run the project-wide passes over the assembled result. They run even once ctx is
+	// cancelled, since they reset the state their package passes have gathered.
+	for i, pa := range s.projectAnalyzers {
+		analyzer.ReportProgress(s.progress, analyzer.StageProject, i, len(s.projectAnalyzers))
+		if err := pa.AnalyzeProject(ctx, env, projectAnalysis); err != nil && ctx.Err() == nil {
+			s.log().Warn("Project analyzer failed", "analyzer", fmt.Sprintf("%T", pa), "error", err)
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	analyzer.ReportProgress(s.progress, analyzer.StageProject, len(s.projectAnalyzers), len(s.projectAnalyzers))
+	s.log().Info("Analysis complete")
 
 	return projectAnalysis, nil
 }
+
+// release drops the syntax trees and type information of pkg in low-memory mode. Its
+// types are kept: they are shared with every package importing it.
+func (s *AnalysisService) release(pkg *packages.Package) {
+	if !s.lowMemory {
+		return
+	}
+	pkg.Syntax = nil
+	pkg.TypesInfo = nil
+}
+
+// analyzeCalls runs the call graph analyzer and returns the call sites per package along
+// with the FileSet their positions refer to (the one SSA was built with, which is crucial
+// for consistent positions). With the call graph disabled it returns no call sites and
+// the FileSet shared by the loaded packages.
+func (s *AnalysisService) analyzeCalls(ctx context.Context, pkgs []*packages.Package) (map[*packages.Package][]datamodel.CallSite, *token.FileSet, error) {
+	if s.skipCallGraph {
+		s.log().Info("Skipping call graph analysis")
+		for _, pkg := range pkgs {
+			if pkg.Fset != nil {
+				return nil, pkg.Fset, nil
+			}
+		}
+		return nil, nil, fmt.Errorf("no FileSet found in loaded packages")
+	}
+
+	s.log().Info("Analyzing calls (building SSA)")
+	analyzer.ReportProgress(s.progress, analyzer.StageSSA, 0, len(pkgs))
+	// callsByPackage key: *packages.Package
+	callsByPackage, _, ssaFset, err := s.callGraphAnalyzer.AnalyzeCalls(ctx, pkgs)
+	if ctx.Err() != nil {
+		return nil, nil, ctx.Err()
+	}
+	if err != nil {
+		// Call graph analysis is often critical. Log details and fail.
+		s.log().Error("Call graph analysis failed", "error", err)
+		return nil, nil, fmt.Errorf("failed during call graph analysis: %w", err)
+	}
+	callCount := 0
+	for _, calls := range callsByPackage {
+		callCount += len(calls)
+	}
+	s.log().Info("Found call sites", "count", callCount, "packages", len(callsByPackage))
+	analyzer.ReportProgress(s.progress, analyzer.StageSSA, len(pkgs), len(pkgs))
+	if ssaFset == nil {
+		// This should ideally be caught by AnalyzeCalls, but double-check
+		s.log().Error("Call graph analysis succeeded but returned a nil FileSet; location data will be inconsistent")
+		return nil, nil, fmt.Errorf("call graph analysis returned nil FileSet")
+	}
+	return callsByPackage, ssaFset, nil
+}
+
+// locationLess orders locations by file, then by position within the file.
+func locationLess(a, b datamodel.Location) bool {
+	if a.Filename != b.Filename {
+		return a.Filename < b.Filename
+	}
+	if a.Line != b.Line {
+		return a.Line < b.Line
+	}
+	return a.Column < b.Column
+}
+
+// mainModuleFirst returns pkgs with the packages of the main module moved to the front,
+// so vendored packages loaded as roots do not determine the module.
+func mainModuleFirst(pkgs []*packages.Package) []*packages.Package {
+	sorted := append([]*packages.Package(nil), pkgs...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return isMainModule(sorted[i]) && !isMainModule(sorted[j])
+	})
+	return sorted
+}
+
+func isMainModule(pkg *packages.Package) bool {
+	return pkg != nil && pkg.Module != nil && pkg.Module.Main
+}
+
+// gopathRoot returns the directory named by the load pattern path and the import path
+// of the package in it (or the longest import path prefix shared by all packages). It
+// returns "" when path is not a directory.
+func gopathRoot(path string, pkgs []*packages.Package) (dir, importPath string) {
+	dir, _ = loader.SplitPattern(path)
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return "", ""
+	}
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	var common []string
+	for i, pkg := range pkgs {
+		if len(pkg.GoFiles) > 0 && filepath.Dir(pkg.GoFiles[0]) == dir {
+			return dir, pkg.PkgPath
+		}
+		parts := strings.Split(pkg.PkgPath, "/")
+		if i == 0 {
+			common = parts
+			continue
+		}
+		n := 0
+		for n < len(common) && n < len(parts) && common[n] == parts[n] {
+			n++
+		}
+		common = common[:n]
+	}
+	return dir, strings.Join(common, "/")
+}
@@ -0,0 +1,168 @@
+// sink/sink.go
+package sink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/namikmesic/go-mcp/internal/grpcapi"
+	"github.com/namikmesic/go-mcp/internal/output"
+	"github.com/namikmesic/go-mcp/internal/server"
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// shutdownTimeout bounds how long serving sinks wait for in-flight requests on stop.
+const shutdownTimeout = 10 * time.Second
+
+// Sink receives the results of an analysis run: a file, a database, a server, ...
+type Sink interface {
+	// Name identifies the sink in logs and errors.
+	Name() string
+	// Write delivers the analysis. Serving sinks block until ctx is done.
+	Write(ctx context.Context, analysis *datamodel.ProjectAnalysis) error
+}
+
+// Run writes the analysis to all sinks concurrently and waits for them to finish.
+// Failures are logged as they happen and returned together; one failing sink does not
+// stop the others.
+func Run(ctx context.Context, sinks []Sink, analysis *datamodel.ProjectAnalysis) error {
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	for _, s := range sinks {
+		wg.Add(1)
+		go func(s Sink) {
+			defer wg.Done()
+			if err := s.Write(ctx, analysis); err != nil {
+				slog.Warn("Output failed", "output", s.Name(), "error", err)
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("%s: %w", s.Name(), err))
+				mu.Unlock()
+			}
+		}(s)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// RendererSink renders the analysis into a file, standard output or a writer.
+type RendererSink struct {
+	Renderer output.Renderer
+	Path     string    // "-" for standard output; empty to use Out
+	Out      io.Writer // Used when Path is empty
+	Header   string    // Optional line printed before the rendered output
+}
+
+// NewRendererSink creates a sink rendering into the file at path ("-" for standard output).
+func NewRendererSink(renderer output.Renderer, path string) *RendererSink {
+	return &RendererSink{Renderer: renderer, Path: path}
+}
+
+func (s *RendererSink) Name() string {
+	switch s.Path {
+	case "-":
+		return "stdout"
+	case "":
+		return fmt.Sprintf("%T", s.Renderer)
+	}
+	return s.Path
+}
+
+func (s *RendererSink) Write(ctx context.Context, analysis *datamodel.ProjectAnalysis) error {
+	switch s.Path {
+	case "-":
+		return s.render(os.Stdout, analysis)
+	case "":
+		return s.render(s.Out, analysis)
+	}
+	f, err := os.Create(s.Path)
+	if err != nil {
+		return fmt.Errorf("creating %s: %w", s.Path, err)
+	}
+	if err := s.render(f, analysis); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	slog.Info("Wrote output", "path", s.Path)
+	return nil
+}
+
+func (s *RendererSink) render(w io.Writer, analysis *datamodel.ProjectAnalysis) error {
+	if s.Header != "" {
+		fmt.Fprintln(w, s.Header)
+	}
+	return s.Renderer.Render(w, analysis)
+}
+
+// FuncSink adapts a function, e.g. a database store, to the Sink interface.
+type FuncSink struct {
+	Label string
+	Fn    func(ctx context.Context, analysis *datamodel.ProjectAnalysis) error
+}
+
+func (s *FuncSink) Name() string { return s.Label }
+
+func (s *FuncSink) Write(ctx context.Context, analysis *datamodel.ProjectAnalysis) error {
+	return s.Fn(ctx, analysis)
+}
+
+// HTTPSink serves the analysis over the HTTP API until ctx is done.
+type HTTPSink struct {
+	Addr      string
+	Explainer server.Explainer // Optional; enables /explain
+}
+
+func (s *HTTPSink) Name() string { return "http " + s.Addr }
+
+func (s *HTTPSink) Write(ctx context.Context, analysis *datamodel.ProjectAnalysis) error {
+	srv := server.NewServer(analysis)
+	srv.Explainer = s.Explainer
+	errCh := make(chan error, 1)
+	go func() { errCh <- srv.ListenAndServe(s.Addr) }()
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return srv.Shutdown(shutdownCtx)
+}
+
+// GRPCSink serves the analysis over the gRPC API until ctx is done.
+type GRPCSink struct {
+	Addr string
+}
+
+func (s *GRPCSink) Name() string { return "grpc " + s.Addr }
+
+func (s *GRPCSink) Write(ctx context.Context, analysis *datamodel.ProjectAnalysis) error {
+	srv := grpcapi.NewServer(analysis)
+	errCh := make(chan error, 1)
+	go func() { errCh <- srv.ListenAndServe(s.Addr) }()
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+	srv.Stop()
+	return nil
+}
+
+var (
+	_ Sink = (*RendererSink)(nil)
+	_ Sink = (*FuncSink)(nil)
+	_ Sink = (*HTTPSink)(nil)
+	_ Sink = (*GRPCSink)(nil)
+)
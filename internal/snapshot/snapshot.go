@@ -0,0 +1,86 @@
+// snapshot/snapshot.go
+package snapshot
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// Snapshot is a persisted analysis together with what is needed to decide whether
+// it still matches the project on disk.
+type Snapshot struct {
+	Pattern     string                     `json:"Pattern"`     // Load pattern that was analyzed
+	Options     string                     `json:"Options"`     // Analysis options the results depend on
+	Fingerprint string                     `json:"Fingerprint"` // State of the sources when analyzed
+	Analysis    *datamodel.ProjectAnalysis `json:"Analysis"`
+}
+
+// Matches reports whether the snapshot was produced for the same pattern, options
+// and sources, and in the current schema version, so its analysis can be served
+// without re-analyzing.
+func (s *Snapshot) Matches(pattern, options, fingerprint string) bool {
+	return s != nil && s.Analysis != nil && s.Analysis.SchemaVersion == datamodel.SchemaVersion &&
+		s.Pattern == pattern && s.Options == options && s.Fingerprint == fingerprint
+}
+
+// DefaultPath returns the state file for pattern in go-mcp/state under os.UserCacheDir,
+// or under dir if it is not empty.
+func DefaultPath(dir, pattern string) (string, error) {
+	if dir == "" {
+		userDir, err := os.UserCacheDir()
+		if err != nil {
+			return "", fmt.Errorf("locating user cache directory: %w", err)
+		}
+		dir = filepath.Join(userDir, "go-mcp", "state")
+	}
+	sum := sha256.Sum256([]byte(pattern))
+	return filepath.Join(dir, fmt.Sprintf("%x.json", sum[:8])), nil
+}
+
+// Load reads a snapshot. It returns nil without error if the file does not exist.
+func Load(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading snapshot %s: %w", path, err)
+	}
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("decoding snapshot %s: %w", path, err)
+	}
+	return &snap, nil
+}
+
+// Save writes the snapshot atomically, replacing any existing file.
+func Save(path string, snap *Snapshot) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating snapshot directory: %w", err)
+	}
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf("encoding snapshot: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("creating snapshot: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("writing snapshot: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("writing snapshot: %w", err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,229 @@
+// symbols/index.go
+package symbols
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// DefaultLimit and MaxLimit bound the number of matches returned by Search.
+const (
+	DefaultLimit = 20
+	MaxLimit     = 200
+)
+
+// Scoring of a matched query character.
+const (
+	scoreMatch       = 1
+	bonusBoundary    = 8 // Start of the name or of a word in it ("Payment" in "NewPaymentProcessor")
+	bonusConsecutive = 4
+	penaltyGapStart  = 2 // Plus 1 per character skipped between matched characters
+	bonusPrefix      = 20
+	bonusExact       = 100
+)
+
+// Index is a search index over the functions, methods, types and interfaces declared
+// in an analysis. It is immutable once built.
+type Index struct {
+	entries []entry
+}
+
+type entry struct {
+	match     datamodel.SymbolMatch
+	name      string // Name as declared, for word boundaries
+	qualified string // Package name + "." + name
+}
+
+// NewIndex indexes the declarations of the analyzed packages.
+func NewIndex(analysis *datamodel.ProjectAnalysis) *Index {
+	ix := &Index{}
+	if analysis == nil {
+		return ix
+	}
+	for _, pkg := range analysis.Packages {
+		if pkg == nil {
+			continue
+		}
+		add := func(pkgPath, name, kind string, loc datamodel.Location) {
+			ix.entries = append(ix.entries, entry{
+				match:     datamodel.SymbolMatch{ID: pkgPath + "." + name, Name: name, Kind: kind, Package: pkgPath, Location: loc},
+				name:      name,
+				qualified: pkg.Name + "." + name,
+			})
+		}
+		for _, iface := range pkg.Interfaces {
+			add(pkg.Path, iface.Name, datamodel.SymbolInterface, iface.Location)
+			for _, m := range iface.Methods {
+				add(pkg.Path, iface.Name+"."+m.Name, datamodel.SymbolMethod, m.Location)
+			}
+		}
+		for _, s := range pkg.Structs {
+			add(pkg.Path, s.Name, datamodel.SymbolType, s.Location)
+		}
+		for _, t := range pkg.Types {
+			add(pkg.Path, t.Name, datamodel.SymbolType, t.Location)
+		}
+		for _, fn := range pkg.Functions {
+			if fn.Receiver == "" {
+				if fn.Name != "init" {
+					add(pkg.Path, fn.Name, datamodel.SymbolFunc, fn.Location)
+				}
+				continue
+			}
+			recv := strings.TrimPrefix(fn.Receiver, "*")
+			if i := strings.IndexByte(recv, '['); i >= 0 {
+				recv = recv[:i]
+			}
+			add(pkg.Path, recv+"."+fn.Name, datamodel.SymbolMethod, fn.Location)
+		}
+	}
+	return ix
+}
+
+// Search returns the symbols matching query, best first, optionally restricted to the
+// given kinds. A symbol matches when the characters of the query, ignoring case and
+// whitespace, appear in order in its name, or failing that in "pkgname.Name"; so
+// "paymnt processor" finds PaymentProcessor and "store get" finds store.Get. Matches
+// at the start of words, runs of consecutive characters, prefixes and exact names
+// score higher; skipped characters and longer names score lower, and poor matches
+// with a negative score are dropped. limit <= 0 means DefaultLimit.
+func (ix *Index) Search(query string, kinds []string, limit int) []datamodel.SymbolMatch {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	limit = min(limit, MaxLimit)
+	pattern := []rune(strings.ToLower(strings.Join(strings.Fields(query), "")))
+	if len(pattern) == 0 {
+		return []datamodel.SymbolMatch{}
+	}
+	wanted := make(map[string]bool, len(kinds))
+	for _, kind := range kinds {
+		wanted[kind] = true
+	}
+
+	matches := []datamodel.SymbolMatch{}
+	for _, e := range ix.entries {
+		if len(wanted) > 0 && !wanted[e.match.Kind] {
+			continue
+		}
+		score, ok := fuzzyScore(pattern, e.name)
+		if ok {
+			lower := strings.ToLower(e.name)
+			if lower == string(pattern) {
+				score += bonusExact
+			} else if strings.HasPrefix(lower, string(pattern)) {
+				score += bonusPrefix
+			}
+		} else if score, ok = fuzzyScore(pattern, e.qualified); ok {
+			score /= 2
+		}
+		if !ok || score < 0 {
+			continue
+		}
+		m := e.match
+		m.Score = score
+		matches = append(matches, m)
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if len(a.Name) != len(b.Name) {
+			return len(a.Name) < len(b.Name)
+		}
+		return a.ID < b.ID
+	})
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+// fuzzyScore finds the best placement of pattern (lower case) as a subsequence of
+// text, by dynamic programming over the positions of each pattern character. Every
+// character of text left unmatched costs half a point.
+func fuzzyScore(pattern []rune, text string) (int, bool) {
+	runes := []rune(text)
+	n := len(runes)
+	if n < len(pattern) {
+		return 0, false
+	}
+	lower := make([]rune, n)
+	bonus := make([]int, n)
+	for j, r := range runes {
+		lower[j] = unicode.ToLower(r)
+		if isBoundary(runes, j) {
+			bonus[j] = bonusBoundary
+		}
+	}
+
+	const none = -1 << 30
+	// prev[j]: best score with the previous pattern character at j; prevGap[j]: best
+	// over k <= j of prev[k] + k, from which a gap from k to a later j' costs j'-k-1
+	prev := make([]int, n)
+	prevGap := make([]int, n)
+	cur := make([]int, n)
+	for i, pr := range pattern {
+		for j := range cur {
+			cur[j] = none
+			if lower[j] != pr {
+				continue
+			}
+			charScore := scoreMatch + bonus[j]
+			switch {
+			case i == 0:
+				cur[j] = charScore
+			case j > 0:
+				best := none
+				if prev[j-1] != none {
+					best = prev[j-1] + bonusConsecutive
+				}
+				if j > 1 && prevGap[j-2] != none {
+					best = max(best, prevGap[j-2]-(j-1)-penaltyGapStart)
+				}
+				if best != none {
+					cur[j] = best + charScore
+				}
+			}
+		}
+		running := none
+		for j := range cur {
+			if cur[j] != none {
+				running = max(running, cur[j]+j)
+			}
+			prevGap[j] = running
+		}
+		prev, cur = cur, prev
+	}
+	best := none
+	for _, score := range prev {
+		best = max(best, score)
+	}
+	if best == none {
+		return 0, false
+	}
+	return best - (n-len(pattern))/2, true
+}
+
+// isBoundary reports whether runes[j] starts a word: the first character, one after
+// a separator, or an upper case letter after a lower case one or before one
+// ("HTTPServer" has words at H and S).
+func isBoundary(runes []rune, j int) bool {
+	if j == 0 {
+		return true
+	}
+	prev, r := runes[j-1], runes[j]
+	switch {
+	case !unicode.IsLetter(prev) && !unicode.IsDigit(prev):
+		return true
+	case unicode.IsUpper(r) && unicode.IsLower(prev):
+		return true
+	case unicode.IsUpper(r) && unicode.IsUpper(prev) && j+1 < len(runes) && unicode.IsLower(runes[j+1]):
+		return true
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+// watch/poller.go
+package watch
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Poller detects changes to the Go sources under Root by periodically fingerprinting
+// file names, sizes and modification times. Polling avoids platform-specific
+// notification APIs and works on network and container filesystems.
+type Poller struct {
+	Root     string
+	Interval time.Duration
+}
+
+func NewPoller(root string, interval time.Duration) *Poller {
+	return &Poller{Root: root, Interval: interval}
+}
+
+// Run calls onChange after each poll that observed a change, until ctx is done.
+// onChange runs on the polling goroutine, so polls never overlap with it.
+func (p *Poller) Run(ctx context.Context, onChange func()) error {
+	last, err := p.Fingerprint()
+	if err != nil {
+		return err
+	}
+	ticker := time.NewTicker(p.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			current, err := p.Fingerprint()
+			if err != nil {
+				slog.Warn("Failed to scan for changes", "dir", p.Root, "error", err)
+				continue
+			}
+			if current != last {
+				last = current
+				onChange()
+			}
+		}
+	}
+}
+
+// Fingerprint summarizes the state of all files relevant to the build under Root.
+func (p *Poller) Fingerprint() (string, error) {
+	h := sha256.New()
+	err := filepath.WalkDir(p.Root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name := d.Name()
+		if d.IsDir() {
+			// Directories the go command ignores, plus vendored code
+			if path != p.Root && (strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || name == "testdata" || name == "vendor") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(name, ".go") && name != "go.mod" && name != "go.sum" && name != "go.work" {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(h, "%s\x00%d\x00%d\n", path, info.Size(), info.ModTime().UnixNano())
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("scanning %s: %w", p.Root, err)
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
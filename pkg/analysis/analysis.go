@@ -0,0 +1,381 @@
+// analysis/analysis.go
+
+// Package analysis is the supported library API of go-mcp: it analyzes Go projects and
+// returns the same results the command line tool renders, as the types of package
+// github.com/namikmesic/go-mcp/pkg/datamodel.
+//
+// The exported identifiers of this package and of pkg/datamodel follow semantic
+// versioning: within a major version, fields and options are only ever added, and the
+// zero value of a new option keeps the previous behavior. Everything under internal/ may
+// change at any time.
+package analysis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/namikmesic/go-mcp/internal/analyzer"
+	"github.com/namikmesic/go-mcp/internal/analyzer/ast"
+	"github.com/namikmesic/go-mcp/internal/analyzer/filter"
+	"github.com/namikmesic/go-mcp/internal/analyzer/reach"
+	"github.com/namikmesic/go-mcp/internal/analyzer/rules"
+	"github.com/namikmesic/go-mcp/internal/analyzer/ssa"
+	"github.com/namikmesic/go-mcp/internal/analyzer/typesystem"
+	"github.com/namikmesic/go-mcp/internal/analyzer/utils"
+	"github.com/namikmesic/go-mcp/internal/depcache"
+	"github.com/namikmesic/go-mcp/internal/loader"
+	"github.com/namikmesic/go-mcp/internal/service"
+	"github.com/namikmesic/go-mcp/pkg/datamodel"
+)
+
+// Result is the analysis of one project, or of several roots merged into one.
+type Result = datamodel.ProjectAnalysis
+
+// DocCommentOptions controls how doc comments are rendered.
+type DocCommentOptions struct {
+	// StripMarkers removes the comment markers (//, /* */). When false, the raw
+	// comment lines are kept verbatim.
+	StripMarkers bool
+	// NormalizeWhitespace collapses all runs of whitespace, including newlines, into
+	// single spaces.
+	NormalizeWhitespace bool
+	// MaxLength truncates comments longer than this many characters. Zero disables
+	// truncation.
+	MaxLength int
+	// Ellipsis is appended to truncated comments.
+	Ellipsis string
+}
+
+// DefaultDocCommentOptions returns the options used when Options.DocComments is nil:
+// markers stripped, whitespace preserved, no truncation.
+func DefaultDocCommentOptions() DocCommentOptions {
+	d := utils.DefaultDocCommentOptions()
+	return DocCommentOptions{
+		StripMarkers:        d.StripMarkers,
+		NormalizeWhitespace: d.NormalizeWhitespace,
+		MaxLength:           d.MaxLength,
+		Ellipsis:            d.Ellipsis,
+	}
+}
+
+// SSAOptions controls how SSA is built for the call graph.
+type SSAOptions struct {
+	// SanityCheckFunctions adds extra checks during SSA construction. They catch
+	// builder bugs but slow the build down considerably.
+	SanityCheckFunctions bool
+	// BuildSerially builds packages one at a time instead of in parallel.
+	BuildSerially bool
+	// InstantiateGenerics builds a function body for every instantiation of a generic
+	// function, so calls inside generic code are reported per instantiation.
+	InstantiateGenerics bool
+}
+
+// DefaultSSAOptions returns the conservative options used when Options.SSA is nil:
+// sanity checks on, serial building, generics instantiated.
+func DefaultSSAOptions() SSAOptions {
+	d := ssa.DefaultBuildOptions()
+	return SSAOptions{
+		SanityCheckFunctions: d.SanityCheckFunctions,
+		BuildSerially:        d.BuildSerially,
+		InstantiateGenerics:  d.InstantiateGenerics,
+	}
+}
+
+// Analysis stages passed to a ProgressReporter, in the order they run. Progress is
+// counted in packages, except for StageProject, which counts project passes.
+const (
+	StageLoad            = analyzer.StageLoad            // Loading and type-checking packages
+	StageInterfaces      = analyzer.StageInterfaces      // Extracting interface definitions
+	StageSSA             = analyzer.StageSSA             // Building SSA and extracting call sites
+	StageImplementations = analyzer.StageImplementations // Checking types against interfaces
+	StagePackages        = analyzer.StagePackages        // Assembling packages and running package passes
+	StageProject         = analyzer.StageProject         // Running project passes
+)
+
+// ProgressReporter receives the progress of each analysis stage. done counts the
+// completed units of stage out of total; total is 0 while it is not yet known.
+// Implementations must be safe for concurrent use.
+type ProgressReporter interface {
+	Progress(stage string, done, total int)
+}
+
+// Values of Options.Vendor.
+const (
+	VendorInclude = "include" // Analyze vendored packages like the project's own
+	VendorSkip    = "skip"    // Leave vendored packages out of all results
+)
+
+// Options configures an analysis. The zero value analyzes the current directory with
+// the command line tool's defaults.
+type Options struct {
+	// Paths are the project directories or package patterns to analyze. Several paths
+	// are analyzed separately and merged into one Result. Empty means ".". A directory
+	// followed by "/..." (or "\..." on Windows) also analyzes the packages below it.
+	Paths []string
+
+	// SkipTests leaves _test.go files out of the analysis.
+	SkipTests bool
+	// GOPATH loads the project in GOPATH mode with this GOPATH. Empty means automatic
+	// for directories outside any module.
+	GOPATH string
+	// Vendor is VendorInclude, VendorSkip or empty to treat vendored packages as
+	// dependencies only.
+	Vendor string
+	// Workers bounds the packages analyzed concurrently. Zero means the number of CPUs.
+	Workers int
+
+	// NoCallGraph skips building SSA and the call graph.
+	NoCallGraph bool
+	// LowMemory builds SSA one package at a time and releases each package's syntax
+	// and type information once it is analyzed.
+	LowMemory bool
+	// CallGraph names the algorithm resolving dynamic and interface calls (see
+	// CallGraphAlgorithms). Empty resolves none.
+	CallGraph string
+	// SSA overrides the SSA build options. Nil uses the conservative defaults.
+	SSA *SSAOptions
+	// DocComments overrides how doc comments are rendered. Nil strips comment markers
+	// and keeps everything else.
+	DocComments *DocCommentOptions
+
+	// ReachabilityRoots marks the functions reachable from these kinds of roots (see
+	// ReachabilityRootKinds).
+	ReachabilityRoots []string
+	// CentralityMeasures are computed for each function besides in- and out-degree
+	// (see CentralityMeasures).
+	CentralityMeasures []string
+
+	// ExcludeGenerated skips symbols declared in generated files.
+	ExcludeGenerated bool
+	// ExportedOnly only reports exported symbols and calls made from exported functions.
+	ExportedOnly bool
+	// IncludePackages and ExcludePackages select packages by import path pattern,
+	// with the syntax of the --include and --exclude flags.
+	IncludePackages []string
+	ExcludePackages []string
+
+	// RulesFile checks dependencies against the architecture rules in this JSON file.
+	RulesFile string
+	// EmbedMetadata records the size, hash and content type of embedded files.
+	EmbedMetadata bool
+	// NearMisses reports types missing at most this many methods of an interface.
+	NearMisses int
+	// StdInterfaces also finds implementations of these standard library interfaces,
+	// e.g. "io.Reader" or "net/http.Handler".
+	StdInterfaces []string
+	// CrossModule also matches interfaces and types across the analyzed module and
+	// its dependency modules.
+	CrossModule bool
+
+	// Dependencies also reports the exported interfaces of imported dependency packages.
+	Dependencies bool
+	// Dependency results are cached per module@version in DependencyCacheDir, or in
+	// go-mcp/deps under the user cache directory. NoDependencyCache turns this off.
+	NoDependencyCache  bool
+	DependencyCacheDir string
+
+	// Analyzers runs only these passes (see Passes) and the passes they require, e.g.
+	// "interfaces" and "structs". Empty runs every pass enabled by the other options.
+	Analyzers []string
+	// Disable turns off registered passes by name, e.g. "clones", and the passes
+	// requiring them that are not named in Analyzers.
+	Disable []string
+
+	// Logger receives the log output. Nil logs to slog.Default().
+	Logger *slog.Logger
+	// Progress receives the progress of each stage. Nil reports nothing.
+	Progress ProgressReporter
+}
+
+// Accepted values of the Options fields naming algorithms, roots and measures.
+var (
+	CallGraphAlgorithms   = ssa.Algorithms
+	ReachabilityRootKinds = reach.RootKinds
+	CentralityMeasures    = reach.CentralityMeasures
+)
+
+// Analyzer runs analyses with fixed Options. It can be reused to analyze a project
+// again, e.g. after it changed.
+type Analyzer struct {
+	service *service.AnalysisService
+	paths   []string
+}
+
+// Analyze analyzes the projects in opts.Paths.
+func Analyze(ctx context.Context, opts Options) (*Result, error) {
+	a, err := New(opts)
+	if err != nil {
+		return nil, err
+	}
+	return a.Analyze(ctx)
+}
+
+// New validates opts and prepares an Analyzer.
+func New(opts Options) (*Analyzer, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	var archRules []rules.Rule
+	if opts.RulesFile != "" {
+		loaded, err := rules.LoadRules(opts.RulesFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading architecture rules: %w", err)
+		}
+		archRules = loaded
+	}
+	passes, err := selectPasses(&opts)
+	if err != nil {
+		return nil, err
+	}
+	paths := opts.Paths
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	return &Analyzer{service: newService(opts, passes, archRules), paths: paths}, nil
+}
+
+// Analyze analyzes paths, or the Options' Paths when none are given.
+func (a *Analyzer) Analyze(ctx context.Context, paths ...string) (*Result, error) {
+	if len(paths) == 0 {
+		paths = a.paths
+	}
+	return a.service.AnalyzeProjects(ctx, paths)
+}
+
+func (o *Options) validate() error {
+	if o.Vendor != "" && o.Vendor != VendorInclude && o.Vendor != VendorSkip {
+		return fmt.Errorf("invalid Vendor %q: must be %q or %q", o.Vendor, VendorInclude, VendorSkip)
+	}
+	if o.CallGraph != "" && !contains(ssa.Algorithms, o.CallGraph) {
+		return fmt.Errorf("invalid CallGraph %q: must be one of %s", o.CallGraph, strings.Join(ssa.Algorithms, ", "))
+	}
+	for _, kind := range o.ReachabilityRoots {
+		if !reach.IsRootKind(kind) {
+			return fmt.Errorf("unknown reachability root %q: must be one of %s", kind, strings.Join(reach.RootKinds, ", "))
+		}
+	}
+	for _, measure := range o.CentralityMeasures {
+		if !reach.IsCentralityMeasure(measure) {
+			return fmt.Errorf("unknown centrality measure %q: must be one of %s", measure, strings.Join(reach.CentralityMeasures, ", "))
+		}
+	}
+	var errs []error
+	for _, pattern := range append(append([]string(nil), o.IncludePackages...), o.ExcludePackages...) {
+		if _, err := filter.CompilePattern(pattern); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (o *Options) docCommentOptions() utils.DocCommentOptions {
+	if o.DocComments == nil {
+		return utils.DefaultDocCommentOptions()
+	}
+	return utils.DocCommentOptions{
+		StripMarkers:        o.DocComments.StripMarkers,
+		NormalizeWhitespace: o.DocComments.NormalizeWhitespace,
+		MaxLength:           o.DocComments.MaxLength,
+		Ellipsis:            o.DocComments.Ellipsis,
+	}
+}
+
+func (o *Options) ssaOptions() ssa.BuildOptions {
+	if o.SSA == nil {
+		return ssa.DefaultBuildOptions()
+	}
+	return ssa.BuildOptions{
+		SanityCheckFunctions: o.SSA.SanityCheckFunctions,
+		BuildSerially:        o.SSA.BuildSerially,
+		InstantiateGenerics:  o.SSA.InstantiateGenerics,
+	}
+}
+
+func (o *Options) logger() *slog.Logger {
+	if o.Logger != nil {
+		return o.Logger
+	}
+	return slog.Default()
+}
+
+// dependencyCache opens the dependency cache, or returns nil if caching is disabled or
+// the cache directory cannot be used.
+func (o *Options) dependencyCache() *depcache.Cache {
+	if o.NoDependencyCache {
+		return nil
+	}
+	cache, err := depcache.NewCache(o.DependencyCacheDir)
+	if err != nil {
+		o.logger().Warn("Dependency cache disabled", "error", err)
+		return nil
+	}
+	return cache
+}
+
+// newService wires the concrete analysis components together, configured by opts, and
+// adds the selected passes in order.
+func newService(opts Options, passes []registration, archRules []rules.Rule) *service.AnalysisService {
+	// --- Dependency Injection ---
+	// Create concrete instances of our components
+	pkgLoader := loader.NewGoPackagesLoader()
+	pkgLoader.Config.Tests = !opts.SkipTests
+	pkgLoader.GOPATH = opts.GOPATH
+	pkgLoader.IncludeVendor = opts.Vendor == VendorInclude
+	ifAnalyzer := ast.NewASTInterfaceAnalyzer()
+	ifAnalyzer.DocOptions = opts.docCommentOptions()
+	ifAnalyzer.Workers = opts.Workers
+	implFinder := typesystem.NewTypeBasedImplementationFinder()
+	implFinder.StdInterfaces = opts.StdInterfaces
+	implFinder.Workers = opts.Workers
+	callAnalyzer := ssa.NewSSACallGraphAnalyzer(opts.ssaOptions())
+	callAnalyzer.LowMemory = opts.LowMemory
+	callAnalyzer.Algorithm = opts.CallGraph
+
+	// Create the analysis service, injecting the components
+	analysisService := service.NewAnalysisService(
+		pkgLoader,
+		ifAnalyzer,
+		implFinder,
+		callAnalyzer,
+	)
+	if opts.Logger != nil {
+		analysisService.SetLogger(opts.Logger)
+	}
+	b := &builder{callAnalyzer: callAnalyzer, archRules: archRules}
+	for _, pass := range passes {
+		pkgAnalyzer, projectAnalyzer := pass.build(&opts, b)
+		if pkgAnalyzer != nil {
+			analysisService.AddPackageAnalyzer(pkgAnalyzer)
+		}
+		if projectAnalyzer != nil {
+			analysisService.AddProjectAnalyzer(projectAnalyzer)
+		}
+	}
+	policy := filter.AllowAll()
+	policy.ExcludeGenerated = opts.ExcludeGenerated
+	policy.ExportedOnly = opts.ExportedOnly
+	policy.IncludePackages = opts.IncludePackages
+	policy.ExcludePackages = opts.ExcludePackages
+	policy.ExcludeVendored = opts.Vendor == VendorSkip
+	analysisService.SetFilterPolicy(policy)
+	analysisService.SetSkipInterfaces(!isSelected(passes, "interfaces"))
+	analysisService.SetSkipCallGraph(!isSelected(passes, "calls"))
+	analysisService.SetLowMemory(opts.LowMemory)
+	if opts.Progress != nil {
+		analysisService.SetProgressReporter(opts.Progress)
+	}
+	// --- End Dependency Injection ---
+	return analysisService
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,422 @@
+// analysis/registry.go
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/namikmesic/go-mcp/internal/analyzer"
+	"github.com/namikmesic/go-mcp/internal/analyzer/ast"
+	"github.com/namikmesic/go-mcp/internal/analyzer/deps"
+	"github.com/namikmesic/go-mcp/internal/analyzer/layers"
+	"github.com/namikmesic/go-mcp/internal/analyzer/metrics"
+	"github.com/namikmesic/go-mcp/internal/analyzer/reach"
+	"github.com/namikmesic/go-mcp/internal/analyzer/rules"
+	"github.com/namikmesic/go-mcp/internal/analyzer/ssa"
+	"github.com/namikmesic/go-mcp/internal/analyzer/stability"
+	"github.com/namikmesic/go-mcp/internal/analyzer/typesystem"
+)
+
+// Pass is an additional analysis registered with Register. It runs once per analyzed
+// root, after the built-in passes, and may add to the result.
+type Pass interface {
+	// Name identifies the pass in Options.Disable and the --disable flag.
+	Name() string
+	// Requires names the passes whose results this pass reads; they run first.
+	Requires() []string
+	// Analyze inspects and extends result.
+	Analyze(ctx context.Context, result *Result) error
+}
+
+// registration is one entry of the pass registry. build returns the package-level
+// and project-level halves of the pass, either of which may be nil.
+type registration struct {
+	name     string
+	requires []string
+	// enabled reports whether the options turn the pass on; nil means always.
+	enabled func(opts *Options) bool
+	build   func(opts *Options, b *builder) (analyzer.PackageAnalyzer, analyzer.ProjectAnalyzer)
+}
+
+// builder holds the state shared by the passes of one service while it is wired.
+type builder struct {
+	callAnalyzer *ssa.SSACallGraphAnalyzer
+	archRules    []rules.Rule
+}
+
+var (
+	registryMu sync.Mutex
+	registry   = builtinPasses()
+)
+
+// Register adds a pass to every analysis run afterwards, unless disabled by name. Like
+// database/sql drivers, passes are usually registered from an init function. Register
+// panics if the name is empty or already taken.
+func Register(p Pass) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	name := p.Name()
+	if name == "" {
+		panic("analysis: Register of a pass without a name")
+	}
+	for _, r := range registry {
+		if r.name == name {
+			panic("analysis: Register called twice for pass " + name)
+		}
+	}
+	registry = append(registry, registration{
+		name:     name,
+		requires: p.Requires(),
+		build: func(*Options, *builder) (analyzer.PackageAnalyzer, analyzer.ProjectAnalyzer) {
+			return nil, &registeredPass{pass: p}
+		},
+	})
+}
+
+// Passes returns the names of the registered passes, built-in ones included, sorted.
+func Passes() []string {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	names := make([]string, len(registry))
+	for i, r := range registry {
+		names[i] = r.name
+	}
+	sort.Strings(names)
+	return names
+}
+
+// registeredPass adapts a Pass to the project analyzer interface of the service.
+type registeredPass struct {
+	pass Pass
+}
+
+var _ analyzer.ProjectAnalyzer = (*registeredPass)(nil)
+
+// AnalyzeProject implements analyzer.ProjectAnalyzer.
+func (r *registeredPass) AnalyzeProject(ctx context.Context, _ *analyzer.Env, analysis *Result) error {
+	if err := r.pass.Analyze(ctx, analysis); err != nil {
+		return fmt.Errorf("pass %s: %w", r.pass.Name(), err)
+	}
+	return nil
+}
+
+// selectPasses returns the registered passes to run for opts, ordered so that each pass
+// follows the passes it requires and otherwise keeps its registration order. Passes
+// named in opts.Analyzers pull in the passes they require; a requirement turned off
+// by the options, like calls with NoCallGraph, leaves the pass to run on empty data.
+// Disabling a pass also turns off the passes requiring it, directly or not, unless
+// they are named in opts.Analyzers.
+func selectPasses(opts *Options) ([]registration, error) {
+	registryMu.Lock()
+	all := append([]registration(nil), registry...)
+	registryMu.Unlock()
+
+	byName := make(map[string]registration, len(all))
+	for _, r := range all {
+		byName[r.name] = r
+	}
+	for _, r := range all {
+		for _, req := range r.requires {
+			if _, ok := byName[req]; !ok {
+				return nil, fmt.Errorf("pass %s requires unknown pass %q", r.name, req)
+			}
+		}
+	}
+	disabled := make(map[string]bool, len(opts.Disable))
+	for _, name := range opts.Disable {
+		if _, ok := byName[name]; !ok {
+			return nil, fmt.Errorf("unknown pass %q in Disable: must be one of %s", name, strings.Join(Passes(), ", "))
+		}
+		disabled[name] = true
+	}
+
+	wanted := make(map[string]bool, len(all))
+	if len(opts.Analyzers) == 0 {
+		for _, r := range all {
+			wanted[r.name] = true
+		}
+	} else {
+		var add func(name string) error
+		add = func(name string) error {
+			if wanted[name] {
+				return nil
+			}
+			r, ok := byName[name]
+			if !ok {
+				return fmt.Errorf("unknown pass %q in Analyzers: must be one of %s", name, strings.Join(Passes(), ", "))
+			}
+			wanted[name] = true
+			for _, req := range r.requires {
+				if err := add(req); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		for _, name := range opts.Analyzers {
+			if disabled[name] {
+				return nil, fmt.Errorf("pass %s is both selected and disabled", name)
+			}
+			if err := add(name); err != nil {
+				return nil, err
+			}
+			if r := byName[name]; r.enabled != nil && !r.enabled(opts) {
+				return nil, fmt.Errorf("pass %s is selected but turned off by its options", name)
+			}
+		}
+	}
+
+	// Passes requiring a disabled pass are dropped with it, unless they were named
+	named := make(map[string]bool, len(opts.Analyzers))
+	for _, name := range opts.Analyzers {
+		named[name] = true
+	}
+	off := make(map[string]bool, len(disabled))
+	for name := range disabled {
+		off[name] = true
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, r := range all {
+			if off[r.name] || named[r.name] {
+				continue
+			}
+			for _, req := range r.requires {
+				if off[req] {
+					off[r.name] = true
+					changed = true
+					break
+				}
+			}
+		}
+	}
+
+	var enabled []registration
+	var dropped []string
+	for _, r := range all {
+		if !wanted[r.name] || disabled[r.name] || (r.enabled != nil && !r.enabled(opts)) {
+			continue
+		}
+		if off[r.name] {
+			dropped = append(dropped, r.name)
+			continue
+		}
+		enabled = append(enabled, r)
+	}
+	if len(dropped) > 0 {
+		opts.logger().Info("Turned off passes requiring a disabled pass", "passes", strings.Join(dropped, ","))
+	}
+
+	// Stable topological sort: repeatedly take the first pass whose enabled
+	// requirements ran
+	selected := make(map[string]bool, len(enabled))
+	for _, r := range enabled {
+		selected[r.name] = true
+	}
+	ordered := make([]registration, 0, len(enabled))
+	done := make(map[string]bool, len(enabled))
+	for len(ordered) < len(enabled) {
+		progressed := false
+		for _, r := range enabled {
+			if done[r.name] || !requirementsDone(r.requires, selected, done) {
+				continue
+			}
+			done[r.name] = true
+			ordered = append(ordered, r)
+			progressed = true
+			break
+		}
+		if !progressed {
+			return nil, fmt.Errorf("passes have cyclic requirements")
+		}
+	}
+	return ordered, nil
+}
+
+// isSelected reports whether the pass called name is among passes.
+func isSelected(passes []registration, name string) bool {
+	for _, r := range passes {
+		if r.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func requirementsDone(names []string, selected, done map[string]bool) bool {
+	for _, name := range names {
+		if selected[name] && !done[name] {
+			return false
+		}
+	}
+	return true
+}
+
+// builtinPasses lists the passes shipped with go-mcp in the order they run.
+func builtinPasses() []registration {
+	both := func(a interface {
+		analyzer.PackageAnalyzer
+		analyzer.ProjectAnalyzer
+	}) (analyzer.PackageAnalyzer, analyzer.ProjectAnalyzer) {
+		return a, a
+	}
+	packageOnly := func(a analyzer.PackageAnalyzer) (analyzer.PackageAnalyzer, analyzer.ProjectAnalyzer) {
+		return a, nil
+	}
+	projectOnly := func(a analyzer.ProjectAnalyzer) (analyzer.PackageAnalyzer, analyzer.ProjectAnalyzer) {
+		return nil, a
+	}
+	core := func(*Options, *builder) (analyzer.PackageAnalyzer, analyzer.ProjectAnalyzer) {
+		return nil, nil
+	}
+	return []registration{
+		// Interface definitions and their implementations, extracted by the service itself
+		{name: "interfaces", build: core},
+		// The call graph: SSA and the call sites of every function
+		{name: "calls", enabled: func(opts *Options) bool { return !opts.NoCallGraph }, build: core},
+		// The go statements, channel operations, locking and panic handling found while
+		// extracting call sites
+		{name: "call-facts", requires: []string{"calls"}, build: func(_ *Options, b *builder) (analyzer.PackageAnalyzer, analyzer.ProjectAnalyzer) {
+			return packageOnly(b.callAnalyzer)
+		}},
+		{name: "external-functions", build: func(*Options, *builder) (analyzer.PackageAnalyzer, analyzer.ProjectAnalyzer) {
+			return packageOnly(ast.NewExternalFunctionAnalyzer())
+		}},
+		{name: "unsafe", build: func(*Options, *builder) (analyzer.PackageAnalyzer, analyzer.ProjectAnalyzer) {
+			return packageOnly(ast.NewUnsafeAnalyzer())
+		}},
+		{name: "cgo", build: func(*Options, *builder) (analyzer.PackageAnalyzer, analyzer.ProjectAnalyzer) {
+			return packageOnly(ast.NewCgoAnalyzer())
+		}},
+		{name: "package-docs", build: func(opts *Options, _ *builder) (analyzer.PackageAnalyzer, analyzer.ProjectAnalyzer) {
+			a := ast.NewPackageDocAnalyzer()
+			a.DocOptions = opts.docCommentOptions()
+			return packageOnly(a)
+		}},
+		{name: "structs", build: func(opts *Options, _ *builder) (analyzer.PackageAnalyzer, analyzer.ProjectAnalyzer) {
+			a := ast.NewStructAnalyzer()
+			a.DocOptions = opts.docCommentOptions()
+			return packageOnly(a)
+		}},
+		{name: "types", build: func(opts *Options, _ *builder) (analyzer.PackageAnalyzer, analyzer.ProjectAnalyzer) {
+			a := ast.NewTypeAnalyzer()
+			a.DocOptions = opts.docCommentOptions()
+			return packageOnly(a)
+		}},
+		{name: "functions", build: func(opts *Options, _ *builder) (analyzer.PackageAnalyzer, analyzer.ProjectAnalyzer) {
+			a := ast.NewFunctionAnalyzer()
+			a.DocOptions = opts.docCommentOptions()
+			return packageOnly(a)
+		}},
+		{name: "values", build: func(opts *Options, _ *builder) (analyzer.PackageAnalyzer, analyzer.ProjectAnalyzer) {
+			a := ast.NewValueAnalyzer()
+			a.DocOptions = opts.docCommentOptions()
+			return packageOnly(a)
+		}},
+		{name: "adapter-gaps", requires: []string{"interfaces"}, build: func(*Options, *builder) (analyzer.PackageAnalyzer, analyzer.ProjectAnalyzer) {
+			return both(typesystem.NewAdapterGapAnalyzer())
+		}},
+		{name: "clones", build: func(*Options, *builder) (analyzer.PackageAnalyzer, analyzer.ProjectAnalyzer) {
+			return both(ast.NewCloneDetector())
+		}},
+		{name: "metrics", build: func(*Options, *builder) (analyzer.PackageAnalyzer, analyzer.ProjectAnalyzer) {
+			return both(metrics.NewPackageMetricsAnalyzer())
+		}},
+		{name: "sizes", build: func(*Options, *builder) (analyzer.PackageAnalyzer, analyzer.ProjectAnalyzer) {
+			return packageOnly(metrics.NewSizeAnalyzer())
+		}},
+		{name: "stability", requires: []string{"interfaces", "calls"}, build: func(*Options, *builder) (analyzer.PackageAnalyzer, analyzer.ProjectAnalyzer) {
+			return projectOnly(stability.NewClassifier())
+		}},
+		{name: "interface-usage", requires: []string{"interfaces", "calls"}, build: func(*Options, *builder) (analyzer.PackageAnalyzer, analyzer.ProjectAnalyzer) {
+			return projectOnly(typesystem.NewInterfaceUsageAnalyzer())
+		}},
+		{name: "duplicate-interfaces", requires: []string{"interfaces"}, build: func(*Options, *builder) (analyzer.PackageAnalyzer, analyzer.ProjectAnalyzer) {
+			return projectOnly(typesystem.NewDuplicateInterfaceAnalyzer())
+		}},
+		{name: "interface-calls", requires: []string{"interfaces", "calls"}, build: func(*Options, *builder) (analyzer.PackageAnalyzer, analyzer.ProjectAnalyzer) {
+			return projectOnly(typesystem.NewInterfaceCallResolver())
+		}},
+		{
+			// After the resolver, whose interface call targets it follows
+			name:     "reachability",
+			requires: []string{"functions", "interface-calls"},
+			enabled:  func(opts *Options) bool { return len(opts.ReachabilityRoots) > 0 },
+			build: func(opts *Options, _ *builder) (analyzer.PackageAnalyzer, analyzer.ProjectAnalyzer) {
+				return projectOnly(reach.NewAnalyzer(opts.ReachabilityRoots))
+			},
+		},
+		{name: "centrality", requires: []string{"functions", "calls"}, build: func(opts *Options, _ *builder) (analyzer.PackageAnalyzer, analyzer.ProjectAnalyzer) {
+			return projectOnly(reach.NewCentralityAnalyzer(opts.CentralityMeasures))
+		}},
+		{name: "panics", requires: []string{"call-facts", "functions"}, build: func(*Options, *builder) (analyzer.PackageAnalyzer, analyzer.ProjectAnalyzer) {
+			return projectOnly(reach.NewPanicAnalyzer())
+		}},
+		{name: "tests", requires: []string{"functions", "calls"}, build: func(*Options, *builder) (analyzer.PackageAnalyzer, analyzer.ProjectAnalyzer) {
+			return projectOnly(reach.NewTestAnalyzer())
+		}},
+		{name: "layers", build: func(*Options, *builder) (analyzer.PackageAnalyzer, analyzer.ProjectAnalyzer) {
+			return projectOnly(layers.NewInferrer())
+		}},
+		{name: "module-graph", build: func(*Options, *builder) (analyzer.PackageAnalyzer, analyzer.ProjectAnalyzer) {
+			return both(deps.NewModuleGraphAnalyzer())
+		}},
+		{name: "import-edges", build: func(*Options, *builder) (analyzer.PackageAnalyzer, analyzer.ProjectAnalyzer) {
+			return packageOnly(layers.NewImportEdgeAnalyzer())
+		}},
+		{name: "import-cycles", requires: []string{"import-edges"}, build: func(*Options, *builder) (analyzer.PackageAnalyzer, analyzer.ProjectAnalyzer) {
+			return projectOnly(layers.NewCycleDetector())
+		}},
+		{name: "init-order", build: func(*Options, *builder) (analyzer.PackageAnalyzer, analyzer.ProjectAnalyzer) {
+			return both(layers.NewInitAnalyzer())
+		}},
+		{
+			name:    "dependencies",
+			enabled: func(opts *Options) bool { return opts.Dependencies },
+			build: func(opts *Options, _ *builder) (analyzer.PackageAnalyzer, analyzer.ProjectAnalyzer) {
+				depInterfaces := ast.NewASTInterfaceAnalyzer()
+				depInterfaces.DocOptions = opts.docCommentOptions()
+				a := deps.NewDependencyAnalyzer(depInterfaces, opts.dependencyCache())
+				a.SkipVendored = opts.Vendor == VendorSkip
+				return both(a)
+			},
+		},
+		{
+			name:    "cross-module",
+			enabled: func(opts *Options) bool { return opts.CrossModule },
+			build: func(opts *Options, _ *builder) (analyzer.PackageAnalyzer, analyzer.ProjectAnalyzer) {
+				a := typesystem.NewCrossModuleAnalyzer()
+				a.SkipVendored = opts.Vendor == VendorSkip
+				return both(a)
+			},
+		},
+		{
+			name:    "embed-metadata",
+			enabled: func(opts *Options) bool { return opts.EmbedMetadata },
+			build: func(*Options, *builder) (analyzer.PackageAnalyzer, analyzer.ProjectAnalyzer) {
+				return packageOnly(metrics.NewEmbedFileAnalyzer())
+			},
+		},
+		{
+			name:    "near-misses",
+			enabled: func(opts *Options) bool { return opts.NearMisses > 0 },
+			build: func(opts *Options, _ *builder) (analyzer.PackageAnalyzer, analyzer.ProjectAnalyzer) {
+				return both(typesystem.NewNearMissAnalyzer(opts.NearMisses))
+			},
+		},
+		{
+			name:     "rules",
+			requires: []string{"calls"},
+			enabled:  func(opts *Options) bool { return opts.RulesFile != "" },
+			build: func(_ *Options, b *builder) (analyzer.PackageAnalyzer, analyzer.ProjectAnalyzer) {
+				if len(b.archRules) == 0 {
+					return nil, nil
+				}
+				return both(rules.NewChecker(b.archRules))
+			},
+		},
+	}
+}
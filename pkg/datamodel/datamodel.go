@@ -0,0 +1,982 @@
+// datamodel/datamodel.go
+package datamodel
+
+import (
+	"encoding/json"
+	"go/token"
+	"go/types"
+)
+
+// Location represents a file:line:column position.
+type Location struct {
+	Filename  string `json:"Filename"`
+	Line      int    `json:"Line"`
+	Column    int    `json:"Column"`
+	EndLine   int    `json:"EndLine,omitempty"`   // Last line of the declaration, for spans
+	EndColumn int    `json:"EndColumn,omitempty"` // Column just past the end of the declaration
+	Offset    int    `json:"Offset,omitempty"`    // Byte offset of the position in the file
+	EndOffset int    `json:"EndOffset,omitempty"` // Byte offset just past the end of the declaration
+}
+
+// Parameter represents information about a function/method parameter.
+type Parameter struct {
+	Name      string `json:"Name"`
+	Type      string `json:"Type"`
+	IsPointer bool   `json:"IsPointer"`
+	// Could add Location here if needed
+}
+
+// TypeParam represents a type parameter and its constraint, e.g. "K comparable".
+type TypeParam struct {
+	Name       string `json:"Name"`
+	Constraint string `json:"Constraint"`
+}
+
+// Method represents detailed information about an interface method.
+type Method struct {
+	Name        string      `json:"Name"`
+	URI         string      `json:"URI,omitempty"` // Canonical URI; see URIScheme
+	Signature   string      `json:"Signature"`
+	Parameters  []Parameter `json:"Parameters"`
+	ReturnTypes []string    `json:"ReturnTypes"`
+	TypeParams  []TypeParam `json:"TypeParams,omitempty"` // Type parameters of the generic interface, in scope for the method
+	DocComment  string      `json:"DocComment"`
+	Location    Location    `json:"Location"`
+	DeclaredBy  string      `json:"DeclaredBy,omitempty"` // Qualified name of the declaring interface; set when taken from a resolved method set
+}
+
+// Implementation represents a concrete type that implements an interface.
+type Implementation struct {
+	TypeName    string   `json:"TypeName"`
+	PackagePath string   `json:"PackagePath"`
+	PackageName string   `json:"PackageName"`
+	URI         string   `json:"URI,omitempty"` // Canonical URI; see URIScheme
+	IsPointer   bool     `json:"IsPointer"`
+	Location    Location `json:"Location"` // Location of the type definition
+	// Methods holds the concrete method satisfying each interface method, in the
+	// interface's method order.
+	Methods []MethodMatch `json:"Methods,omitempty"`
+}
+
+// Stability levels assigned to interfaces.
+const (
+	StabilityInternal = "Internal" // Unexported or under an /internal/ path; free to change
+	StabilityPublic   = "Public"   // Exported API with few consumers in the analyzed project
+	StabilityCore     = "Core"     // Exported API consumed by many packages; changes are costly
+)
+
+// Interface represents information about a found interface.
+type Interface struct {
+	Name            string           `json:"Name"`
+	PackageName     string           `json:"PackageName"`   // Package where the interface is defined
+	PackagePath     string           `json:"PackagePath"`   // Import path of the defining package
+	URI             string           `json:"URI,omitempty"` // Canonical URI; see URIScheme
+	TypeParams      []TypeParam      `json:"TypeParams,omitempty"`
+	Location        Location         `json:"Location"`
+	DocComment      string           `json:"DocComment"`
+	Methods         []Method         `json:"Methods"`
+	Embeds          []string         `json:"Embeds"`              // Fully qualified names of embedded interfaces
+	MethodSet       []Method         `json:"MethodSet,omitempty"` // Complete method set including embedded methods; only set when Embeds is non-empty
+	Implementations []Implementation `json:"Implementations"`
+	Stability       string           `json:"Stability"`     // One of the Stability* constants
+	ConsumerCount   int              `json:"ConsumerCount"` // Number of other packages implementing or calling the interface
+	Usage           *InterfaceUsage  `json:"Usage,omitempty"`
+	// Stdlib marks standard library interfaces added by the implementation finder;
+	// they are reported under ProjectAnalysis.StdlibInterfaces
+	Stdlib bool `json:"-"`
+	// Keep underlying type info if needed for advanced analysis downstream
+	UnderlyingType *types.Interface `json:"-"` // Exclude from direct JSON marshaling, we'll handle it in MarshalJSON
+}
+
+// MarshalJSON implements json.Marshaler for Interface to handle conditional inclusion of UnderlyingType
+func (i Interface) MarshalJSON() ([]byte, error) {
+	type InterfaceAlias Interface // Avoid recursion in MarshalJSON
+
+	// Create a map representation of the struct without UnderlyingType
+	m := map[string]interface{}{
+		"Name":            i.Name,
+		"PackageName":     i.PackageName,
+		"PackagePath":     i.PackagePath,
+		"Location":        i.Location,
+		"DocComment":      i.DocComment,
+		"Methods":         i.Methods,
+		"Embeds":          i.Embeds,
+		"Implementations": i.Implementations,
+		"Stability":       i.Stability,
+		"ConsumerCount":   i.ConsumerCount,
+	}
+	if len(i.TypeParams) > 0 {
+		m["TypeParams"] = i.TypeParams
+	}
+	if len(i.MethodSet) > 0 {
+		m["MethodSet"] = i.MethodSet
+	}
+	if i.Usage != nil {
+		m["Usage"] = i.Usage
+	}
+	if i.URI != "" {
+		m["URI"] = i.URI
+	}
+
+	// We're omitting UnderlyingType completely as it's only used for internal analysis
+
+	return json.Marshal(m)
+}
+
+// InterfaceUsage reports how the methods of an interface are called through it, to
+// find fat interfaces whose consumers each need only a few of its methods.
+type InterfaceUsage struct {
+	MethodCount     int           `json:"MethodCount"`     // Size of the method set
+	Implementations int           `json:"Implementations"` // Number of implementing concrete types
+	CallSites       int           `json:"CallSites"`       // Interface calls through this interface
+	Consumers       int           `json:"Consumers"`       // Packages making those calls
+	MaxMethodsUsed  int           `json:"MaxMethodsUsed"`  // Most distinct methods called by a single consumer
+	Methods         []MethodUsage `json:"Methods"`         // In method set order
+	// Fat marks an interface of at least FatMinMethods methods whose every consumer
+	// calls at most half of them: a candidate for splitting into smaller interfaces
+	Fat bool `json:"Fat,omitempty"`
+}
+
+// MethodUsage counts the interface calls of one method of an interface.
+type MethodUsage struct {
+	Name      string `json:"Name"`
+	CallSites int    `json:"CallSites"`
+	Consumers int    `json:"Consumers"`
+}
+
+// CallSite represents information about a single call site.
+type CallSite struct {
+	CallerFuncDesc string   `json:"CallerFuncDesc"` // Description of the function/method containing the call
+	CalleeDesc     string   `json:"CalleeDesc"`     // Description of the called function/method/interface method
+	CallType       string   `json:"CallType"`       // Static, Interface, Go, Defer
+	URI            string   `json:"URI,omitempty"`  // Canonical URI; see URIScheme
+	Location       Location `json:"Location"`       // File:line:column of the call site
+	// CallerID and CalleeID are the stable IDs of Function.ID. Closures extend the ID of
+	// their enclosing function ("pkgpath.Func$1"), interface calls use the interface
+	// method ("pkgpath.Iface.Method") and calls through function values have no CalleeID.
+	CallerID string `json:"CallerID,omitempty"`
+	CalleeID string `json:"CalleeID,omitempty"`
+	// CalleeOpaque is set when the callee has no Go body visible to the analysis
+	// (assembly, linkname or cgo stub), so call edges beyond it are unknown.
+	CalleeOpaque bool `json:"CalleeOpaque,omitempty"`
+	// Callees lists the candidate targets of a dynamic or interface call, sorted, as
+	// resolved by the selected call graph algorithm (--callgraph).
+	Callees []string `json:"Callees,omitempty"`
+	// Algorithm names the call graph algorithm that resolved Callees (cha, rta or vta).
+	Algorithm string `json:"Algorithm,omitempty"`
+	// Targets lists the IDs of the concrete methods an interface call may dispatch to,
+	// sorted, as found among the implementations of the called interface method.
+	Targets []string `json:"Targets,omitempty"`
+}
+
+// Goroutine is a go statement: the spawn site of a goroutine.
+type Goroutine struct {
+	SpawnerID string `json:"SpawnerID"` // Function.ID of the function containing the go statement
+	// Target describes the function run by the goroutine, like CallSite.CalleeDesc, and
+	// TargetID is its ID when it is known statically
+	Target   string   `json:"Target"`
+	TargetID string   `json:"TargetID,omitempty"`
+	Closure  bool     `json:"Closure,omitempty"` // The target is a function literal
+	Location Location `json:"Location"`
+}
+
+// Channel operation kinds.
+const (
+	ChannelMake    = "Make"
+	ChannelSend    = "Send"
+	ChannelReceive = "Receive" // Including for range loops over a channel
+	ChannelClose   = "Close"
+	ChannelSelect  = "Select"
+)
+
+// ChannelOp is an operation on a channel inside a function.
+type ChannelOp struct {
+	Op         string `json:"Op"`         // One of the Channel* constants
+	FunctionID string `json:"FunctionID"` // Function.ID of the function performing it
+	Function   string `json:"Function"`   // As in CallSite.CallerFuncDesc
+	// Channel identifies the channel where the analysis can tell: "pkg/path.Type.field"
+	// for struct fields, "pkg/path.name" for package variables, "FunctionID(name)" for
+	// parameters and "FunctionID@line" for channels made in a function and not stored
+	// in a field or variable, followed into the closures capturing them. Unset for
+	// Select, whose channels are listed in Cases.
+	Channel  string       `json:"Channel,omitempty"`
+	ChanType string       `json:"ChanType,omitempty"` // e.g. "chan int", "<-chan error"
+	Cases    []SelectCase `json:"Cases,omitempty"`    // Select only, in source order
+	// Blocking is set for a select without a default case
+	Blocking bool     `json:"Blocking,omitempty"`
+	Location Location `json:"Location"`
+}
+
+// SelectCase is a communication case of a select statement.
+type SelectCase struct {
+	Op       string `json:"Op"` // ChannelSend or ChannelReceive
+	Channel  string `json:"Channel,omitempty"`
+	ChanType string `json:"ChanType"`
+}
+
+// Kinds of synchronization values tracked by LockUsage and Field.Sync.
+const (
+	SyncMutex   = "Mutex"   // sync.Mutex
+	SyncRWMutex = "RWMutex" // sync.RWMutex
+	SyncMap     = "Map"     // sync.Map
+)
+
+// LockUsage is the use of one mutex or sync.Map by a function.
+type LockUsage struct {
+	FunctionID string `json:"FunctionID"`
+	Function   string `json:"Function"` // As in CallSite.CallerFuncDesc
+	// Lock identifies the value like ChannelOp.Channel identifies channels:
+	// "pkg/path.Type.field", "pkg/path.name", "FunctionID(name)" for parameters or
+	// "FunctionID@line" for local variables. Unset when unknown.
+	Lock string   `json:"Lock,omitempty"`
+	Kind string   `json:"Kind"` // One of the Sync* constants
+	Ops  []LockOp `json:"Ops"`  // In source order
+	// Unpaired lists the mutex methods called without their counterpart in the
+	// function: "Lock" (or "TryLock") without "Unlock", "Unlock" without "Lock", and
+	// likewise for RLock and RUnlock. Deferred calls count.
+	Unpaired []string `json:"Unpaired,omitempty"`
+}
+
+// LockOp is a method call on a mutex or sync.Map.
+type LockOp struct {
+	Method   string   `json:"Method"` // e.g. "Lock", "RUnlock", "LoadOrStore"
+	Deferred bool     `json:"Deferred,omitempty"`
+	Location Location `json:"Location"`
+}
+
+// PanicHandling lists the defer statements, panics and recover calls of a function.
+// Only explicit calls to panic are listed, not runtime panics such as nil dereferences.
+type PanicHandling struct {
+	FunctionID string      `json:"FunctionID"`
+	Function   string      `json:"Function"` // As in CallSite.CallerFuncDesc
+	Defers     []DeferStmt `json:"Defers,omitempty"`
+	Panics     []PanicSite `json:"Panics,omitempty"`
+	Recovers   []Location  `json:"Recovers,omitempty"` // Calls to recover in the function itself
+}
+
+// DeferStmt is a defer statement.
+type DeferStmt struct {
+	// Callee describes the deferred function like CallSite.CalleeDesc, and CalleeID is
+	// its ID when it is known statically
+	Callee   string `json:"Callee"`
+	CalleeID string `json:"CalleeID,omitempty"`
+	Closure  bool   `json:"Closure,omitempty"` // The deferred function is a function literal
+	// The deferred function calls recover, stopping panics of the function
+	Recovers bool     `json:"Recovers,omitempty"`
+	Location Location `json:"Location"`
+}
+
+// PanicSite is a call to panic.
+type PanicSite struct {
+	Value    string   `json:"Value"` // Type of the value passed to panic, e.g. "string" or "error"
+	Location Location `json:"Location"`
+}
+
+// UnrecoveredPanic is an exported function that can panic without recovering: it
+// calls panic, or calls a function that can, and defers no function calling recover.
+type UnrecoveredPanic struct {
+	FunctionID string `json:"FunctionID"`
+	// Steps leading from the function to the one calling panic; empty if it calls
+	// panic itself
+	Steps []CallStep `json:"Steps,omitempty"`
+	Panic PanicSite  `json:"Panic"`
+}
+
+// UnsafeUse is a reference to a member of package unsafe.
+type UnsafeUse struct {
+	Name string `json:"Name"` // Member of package unsafe: "Pointer", "Sizeof", "Add", ...
+	// Function.ID of the function containing the use; empty at package level
+	FunctionID string `json:"FunctionID,omitempty"`
+	// From is the type converted by a conversion to unsafe.Pointer, e.g. "uintptr"
+	From string `json:"From,omitempty"`
+	// Arithmetic marks pointer arithmetic: unsafe.Add, or a conversion to unsafe.Pointer
+	// of a computed uintptr
+	Arithmetic bool     `json:"Arithmetic,omitempty"`
+	Location   Location `json:"Location"`
+}
+
+// Cgo describes the cgo usage of a package, which needs a C toolchain to build and
+// whose C code is invisible to the analysis.
+type Cgo struct {
+	GoFiles []string `json:"GoFiles"` // Go files importing "C", relative like PackageAnalysis.Files
+	// C, C++, Objective-C and Fortran sources and headers in the package directory
+	CFiles []string `json:"CFiles,omitempty"`
+	// #cgo directives of the preambles, e.g. "#cgo LDFLAGS: -lm"
+	Directives []string    `json:"Directives,omitempty"`
+	Exports    []CgoExport `json:"Exports,omitempty"` // Go functions exported to C with //export
+}
+
+// CgoExport is a Go function exported to C with an //export directive.
+type CgoExport struct {
+	Name       string   `json:"Name"`
+	FunctionID string   `json:"FunctionID"`
+	Location   Location `json:"Location"`
+}
+
+// PackageInit lists what runs when a package is initialized, in order: the initializers
+// of its package-level variables, then its init functions.
+type PackageInit struct {
+	Variables []VarInit `json:"Variables,omitempty"` // In initialization order
+	Funcs     []string  `json:"Funcs,omitempty"`     // Function.IDs of the init functions, in order
+}
+
+// VarInit is the initializer of package-level variables.
+type VarInit struct {
+	Names []string `json:"Names"` // Several for "a, b = f()"
+	Expr  string   `json:"Expr"`  // Abbreviated, e.g. "T{…}"
+	// IDs of the functions the initializer calls directly, outside function literals
+	Calls    []string `json:"Calls,omitempty"`
+	Location Location `json:"Location"`
+}
+
+// EmbedFile describes a file embedded with //go:embed.
+type EmbedFile struct {
+	Path        string `json:"Path"` // As in PackageAnalysis.EmbedFiles
+	Size        int64  `json:"Size"` // In bytes
+	SHA256      string `json:"SHA256"`
+	ContentType string `json:"ContentType"` // MIME type, e.g. "text/html; charset=utf-8"
+}
+
+// External function kinds.
+const (
+	ExternalAssembly = "Assembly" // Bodyless declaration in a package with .s files
+	ExternalLinkname = "Linkname" // Bodyless declaration bound with //go:linkname
+	ExternalCgo      = "Cgo"      // Stub generated by cmd/cgo for a C function
+	ExternalUnknown  = "Unknown"  // Bodyless declaration with no visible implementation source
+)
+
+// ExternalFunction is a function whose implementation is not Go code visible to
+// the analysis. Calls into it are a known gap in the call graph.
+type ExternalFunction struct {
+	Name      string   `json:"Name"`
+	FullName  string   `json:"FullName"`           // Matches CallSite.CalleeDesc for static calls
+	Receiver  string   `json:"Receiver,omitempty"` // Receiver type for methods
+	Signature string   `json:"Signature"`
+	Kind      string   `json:"Kind"` // One of the External* constants
+	Location  Location `json:"Location"`
+}
+
+// Function represents a package-level function or a method declared in Go source.
+type Function struct {
+	// ID is the stable ID "pkgpath.Func" or "pkgpath.Type.Method" (init functions are
+	// numbered: "pkgpath.init#1"). It matches CallSite.CallerID and CalleeID.
+	ID         string      `json:"ID"`
+	URI        string      `json:"URI,omitempty"` // Canonical URI; see URIScheme
+	Name       string      `json:"Name"`
+	FullName   string      `json:"FullName"`           // Matches CallSite.CallerFuncDesc and CalleeDesc
+	Receiver   string      `json:"Receiver,omitempty"` // Receiver type for methods, e.g. "*Server"
+	Signature  string      `json:"Signature"`
+	TypeParams []TypeParam `json:"TypeParams,omitempty"` // Own type parameters, or the receiver's for methods of generic types
+	DocComment string      `json:"DocComment,omitempty"`
+	Exported   bool        `json:"Exported"`
+	Location   Location    `json:"Location"`
+	// Reachable is set for functions reachable from ProjectAnalysis.ReachabilityRoots
+	// (--reachability); unset means unreachable when those roots are set.
+	Reachable bool `json:"Reachable,omitempty"`
+	// Centrality locates the function in the call graph; unset without calls
+	Centrality *FunctionCentrality `json:"Centrality,omitempty"`
+}
+
+// FunctionCentrality holds centrality measures of a function in the call graph, with
+// calls made by its closures counted as its own.
+type FunctionCentrality struct {
+	InDegree    int     `json:"InDegree"`              // Distinct functions calling it
+	OutDegree   int     `json:"OutDegree"`             // Distinct functions it calls
+	PageRank    float64 `json:"PageRank,omitempty"`    // With --centrality=pagerank; 1 is the average over all functions
+	Betweenness float64 `json:"Betweenness,omitempty"` // With --centrality=betweenness; shortest call paths through it
+}
+
+// Value represents a package-level constant or variable. Names declared together
+// ("a, b = 1, 2") are reported separately.
+type Value struct {
+	Name       string   `json:"Name"`
+	URI        string   `json:"URI,omitempty"`      // Canonical URI; see URIScheme
+	Type       string   `json:"Type"`               // e.g. "untyped string" for untyped constants
+	Value      string   `json:"Value,omitempty"`    // Initializer expression as written, truncated
+	Constant   string   `json:"Constant,omitempty"` // Evaluated value of constants, e.g. for iota
+	DocComment string   `json:"DocComment,omitempty"`
+	Exported   bool     `json:"Exported"`
+	Location   Location `json:"Location"`
+}
+
+// Named type kinds.
+const (
+	TypeAlias   = "Alias"   // type A = B
+	TypeDefined = "Defined" // type A B, for underlying types other than struct and interface
+)
+
+// NamedType represents a type alias or a defined type that is neither a struct nor an
+// interface (e.g. "type Kind string" or "type Handler func()").
+type NamedType struct {
+	Name        string      `json:"Name"`
+	PackageName string      `json:"PackageName"`
+	PackagePath string      `json:"PackagePath"`
+	URI         string      `json:"URI,omitempty"`    // Canonical URI; see URIScheme
+	Kind        string      `json:"Kind"`             // One of the Type* constants
+	Underlying  string      `json:"Underlying"`       // Underlying type, e.g. "string"
+	Target      string      `json:"Target,omitempty"` // Aliased type; packagePath + "." + name for named types
+	TypeParams  []TypeParam `json:"TypeParams,omitempty"`
+	DocComment  string      `json:"DocComment,omitempty"`
+	Exported    bool        `json:"Exported"`
+	Location    Location    `json:"Location"`
+}
+
+// Field represents one field of a struct type. Fields declared together
+// ("a, b int") are reported separately.
+type Field struct {
+	Name       string `json:"Name"` // Type name for embedded fields
+	Type       string `json:"Type"`
+	Tag        string `json:"Tag,omitempty"` // Unquoted struct tag
+	Embedded   bool   `json:"Embedded"`
+	Exported   bool   `json:"Exported"`
+	DocComment string `json:"DocComment,omitempty"`
+	// Sync is set for fields of type sync.Mutex, sync.RWMutex or sync.Map, or pointers
+	// to them: one of the Sync* constants
+	Sync     string   `json:"Sync,omitempty"`
+	Location Location `json:"Location"`
+}
+
+// Struct represents a package-level struct type declaration.
+type Struct struct {
+	Name        string      `json:"Name"`
+	PackageName string      `json:"PackageName"`
+	PackagePath string      `json:"PackagePath"`
+	URI         string      `json:"URI,omitempty"` // Canonical URI; see URIScheme
+	TypeParams  []TypeParam `json:"TypeParams,omitempty"`
+	DocComment  string      `json:"DocComment"`
+	Fields      []Field     `json:"Fields"`
+	Location    Location    `json:"Location"`
+}
+
+// ModuleInfo holds information about the Go module.
+type ModuleInfo struct {
+	Path    string `json:"Path"`
+	Version string `json:"Version"`
+	Dir     string `json:"Dir"`
+	GoMod   string `json:"GoMod"`
+	IsMain  bool   `json:"IsMain"`
+}
+
+// PackageMetrics holds architecture metrics for a package, in the style of
+// Robert C. Martin's package metrics. Coupling only counts analyzed packages.
+type PackageMetrics struct {
+	AfferentCoupling int     `json:"AfferentCoupling"` // Ca: analyzed packages importing this one
+	EfferentCoupling int     `json:"EfferentCoupling"` // Ce: analyzed packages this one imports
+	Instability      float64 `json:"Instability"`      // Ce / (Ca + Ce); 0 = stable, 1 = unstable
+	AbstractTypes    int     `json:"AbstractTypes"`    // Package-level interface types
+	ConcreteTypes    int     `json:"ConcreteTypes"`    // Package-level non-interface named types
+	Abstractness     float64 `json:"Abstractness"`     // AbstractTypes / (AbstractTypes + ConcreteTypes)
+	Distance         float64 `json:"Distance"`         // |Abstractness + Instability - 1|, distance from the main sequence
+	LCOM             int     `json:"LCOM"`             // LCOM4: connected groups of related declarations; 1 = fully cohesive
+	Cohesion         float64 `json:"Cohesion"`         // Relational cohesion (R + 1) / N over declarations
+	Lines            int     `json:"Lines"`            // Physical lines of the package's files
+	CodeLines        int     `json:"CodeLines"`        // Lines holding code, excluding blank and comment-only lines
+	Functions        int     `json:"Functions"`        // Function and method declarations
+	Statements       int     `json:"Statements"`       // Statements, not counting blocks, case clauses and labels
+	// Size of each parsed file, sorted by name
+	Files []FileMetrics `json:"Files,omitempty"`
+}
+
+// FileMetrics holds the size of one source file of a package.
+type FileMetrics struct {
+	File       string `json:"File"`
+	Lines      int    `json:"Lines"`
+	CodeLines  int    `json:"CodeLines"`
+	Functions  int    `json:"Functions"`
+	Statements int    `json:"Statements"`
+}
+
+// PackageAnalysis holds all analyzed information for a single Go package.
+type PackageAnalysis struct {
+	Name          string   `json:"Name"`
+	Path          string   `json:"Path"`
+	URI           string   `json:"URI,omitempty"`      // Canonical URI; see URIScheme
+	Doc           string   `json:"Doc,omitempty"`      // Package doc comment
+	Synopsis      string   `json:"Synopsis,omitempty"` // First sentence of Doc
+	Files         []string `json:"Files"`
+	Imports       []string `json:"Imports"` // Import paths
+	EmbedFiles    []string `json:"EmbedFiles,omitempty"`
+	EmbedPatterns []string `json:"EmbedPatterns,omitempty"`
+	// Size, hash and content type of each of EmbedFiles, with --embed-metadata
+	EmbedMetadata []EmbedFile `json:"EmbedMetadata,omitempty"`
+	Interfaces    []Interface `json:"Interfaces"`
+	Structs       []Struct    `json:"Structs,omitempty"`
+	Types         []NamedType `json:"Types,omitempty"`
+	Functions     []Function  `json:"Functions,omitempty"`
+	Constants     []Value     `json:"Constants,omitempty"`
+	Variables     []Value     `json:"Variables,omitempty"`
+	Calls         []CallSite  `json:"Calls,omitempty"`
+	// Each import declared by the package's files, including imports the go tool drops
+	// from Imports because they close an import cycle
+	ImportEdges []ImportEdge `json:"ImportEdges,omitempty"`
+	// Functions implemented outside Go (assembly, linkname, cgo)
+	ExternalFunctions []ExternalFunction `json:"ExternalFunctions,omitempty"`
+	// Uses of package unsafe in source order
+	Unsafe []UnsafeUse `json:"Unsafe,omitempty"`
+	Cgo    *Cgo        `json:"Cgo,omitempty"` // Set for packages using cgo
+	// Variable initializers and init functions; unset if the package has neither
+	Init *PackageInit `json:"Init,omitempty"`
+	// Go statements of the package's functions, from the call graph analysis
+	Goroutines []Goroutine `json:"Goroutines,omitempty"`
+	// Channel operations of the package's functions, from the call graph analysis
+	ChannelOps []ChannelOp `json:"ChannelOps,omitempty"`
+	// Uses of sync.Mutex, sync.RWMutex and sync.Map by the package's functions, from the
+	// call graph analysis
+	Locking []LockUsage `json:"Locking,omitempty"`
+	// Functions of the package with defer statements, panics or recover calls, from the
+	// call graph analysis
+	PanicHandling []PanicHandling `json:"PanicHandling,omitempty"`
+	Metrics       *PackageMetrics `json:"Metrics,omitempty"`
+	// Architectural layer inferred from imports; 0 = imports no other analyzed package
+	Layer int `json:"Layer"`
+	// Resolved from a vendor directory rather than the module cache or module sources
+	Vendored bool `json:"Vendored,omitempty"`
+	// Store original package and SSA for potential advanced use? Optional.
+	// OriginalPackage *packages.Package
+	// SsaPackage      *ssa.Package
+}
+
+// CloneMember is one function participating in a clone group.
+type CloneMember struct {
+	Function    string   `json:"Function"` // Fully qualified function or method name
+	PackagePath string   `json:"PackagePath"`
+	Location    Location `json:"Location"`
+}
+
+// CloneGroup is a set of functions whose bodies are structurally identical
+// after normalizing identifiers and literal values.
+type CloneGroup struct {
+	Fingerprint string        `json:"Fingerprint"`
+	NodeCount   int           `json:"NodeCount"` // Size of each body in AST nodes
+	Functions   []CloneMember `json:"Functions"`
+}
+
+// Rule violation kinds.
+const (
+	ViolationImport = "Import" // The package imports a denied package
+	ViolationCall   = "Call"   // The package calls into a denied package
+)
+
+// RuleViolation is a dependency that breaks a declared architecture rule.
+type RuleViolation struct {
+	Rule     string   `json:"Rule"`
+	Kind     string   `json:"Kind"` // One of the Violation* constants
+	From     string   `json:"From"` // Depending package
+	To       string   `json:"To"`   // Denied package
+	Detail   string   `json:"Detail,omitempty"`
+	Location Location `json:"Location"`
+}
+
+// UnimplementedInterface is an interface without implementations in any loaded module.
+type UnimplementedInterface struct {
+	Interface string   `json:"Interface"` // packagePath + "." + interfaceName
+	Module    string   `json:"Module"`
+	Location  Location `json:"Location"`
+}
+
+// ExternalImplementation is a type implementing an interface declared in another
+// module. In AdapterGaps the interface's module is not loaded, so the pair appears
+// under no Interface.Implementations; in CrossModuleImplementations either side may
+// belong to a dependency module.
+type ExternalImplementation struct {
+	TypeName        string   `json:"TypeName"`
+	PackagePath     string   `json:"PackagePath"`
+	Module          string   `json:"Module"`
+	Interface       string   `json:"Interface"` // packagePath + "." + interfaceName
+	InterfaceModule string   `json:"InterfaceModule"`
+	IsPointer       bool     `json:"IsPointer"`
+	Location        Location `json:"Location"`
+}
+
+// AdapterGaps lists interface/implementation pairs split across module boundaries.
+type AdapterGaps struct {
+	UnimplementedInterfaces []UnimplementedInterface `json:"UnimplementedInterfaces,omitempty"`
+	ExternalImplementations []ExternalImplementation `json:"ExternalImplementations,omitempty"`
+}
+
+// NearMiss is a type that lacks only a few methods of an interface.
+type NearMiss struct {
+	Interface   string           `json:"Interface"` // packagePath + "." + interfaceName
+	TypeName    string           `json:"TypeName"`
+	PackagePath string           `json:"PackagePath"`
+	Missing     []MethodMismatch `json:"Missing"` // Methods *T lacks or declares with the wrong signature
+	Location    Location         `json:"Location"`
+}
+
+// ImportEdge is an import of one package by another.
+type ImportEdge struct {
+	Importer string   `json:"Importer"`
+	Imported string   `json:"Imported"`
+	TestOnly bool     `json:"TestOnly,omitempty"` // Only imported by _test.go files
+	Location Location `json:"Location"`           // First import spec, preferring non-test files
+}
+
+// ImportCycle is a set of packages that import each other, directly or indirectly.
+type ImportCycle struct {
+	Packages []string      `json:"Packages"` // Sorted import paths
+	Imports  []CycleImport `json:"Imports"`  // One shortest cycle through the packages
+	// TestOnly is set when only imports of _test.go files close the cycle, so the
+	// packages build but their tests do not ("import cycle not allowed in test")
+	TestOnly bool `json:"TestOnly,omitempty"`
+}
+
+// CycleImport is one import of an ImportCycle.
+type CycleImport struct {
+	From     string   `json:"From"`
+	To       string   `json:"To"`
+	Test     bool     `json:"Test,omitempty"` // Declared in _test.go files only
+	Location Location `json:"Location"`       // Import spec
+}
+
+// Findings holds project-wide observations meant to guide refactoring.
+type Findings struct {
+	Clones         []CloneGroup    `json:"Clones,omitempty"`
+	RuleViolations []RuleViolation `json:"RuleViolations,omitempty"`
+	AdapterGaps    *AdapterGaps    `json:"AdapterGaps,omitempty"`
+	NearMisses     []NearMiss      `json:"NearMisses,omitempty"`
+	ImportCycles   []ImportCycle   `json:"ImportCycles,omitempty"`
+	// Interfaces ("pkg/path.Name") whose Usage is Fat, sorted
+	FatInterfaces []string `json:"FatInterfaces,omitempty"`
+	// Groups of interfaces in different packages with identical or nested method sets
+	DuplicateInterfaces []InterfaceCluster `json:"DuplicateInterfaces,omitempty"`
+	// Exported functions that can panic without recovering, sorted by FunctionID
+	UnrecoveredPanics []UnrecoveredPanic `json:"UnrecoveredPanics,omitempty"`
+	// Exported functions no test or fuzz target exercises, sorted; set when the
+	// analysis lists tests
+	UntestedFunctions []string `json:"UntestedFunctions,omitempty"`
+}
+
+// Relations between the method sets of two interfaces.
+const (
+	InterfaceIdentical = "Identical" // Same method set
+	InterfaceSubset    = "Subset"    // Every method of Interface is a method of Other
+)
+
+// InterfaceCluster is a group of interfaces declared in different packages and linked
+// by identical or nested method sets, as accumulate when packages each declare their
+// own Logger or Store.
+type InterfaceCluster struct {
+	Interfaces []string            `json:"Interfaces"` // "pkg/path.Name", sorted
+	Relations  []InterfaceRelation `json:"Relations"`
+}
+
+// InterfaceRelation links two interfaces of an InterfaceCluster.
+type InterfaceRelation struct {
+	Interface string `json:"Interface"`
+	Other     string `json:"Other"`
+	Relation  string `json:"Relation"` // InterfaceIdentical or InterfaceSubset
+}
+
+// UnsafeFunctions returns the IDs of the functions of the package using package unsafe.
+func (p *PackageAnalysis) UnsafeFunctions() map[string]bool {
+	ids := make(map[string]bool)
+	for _, use := range p.Unsafe {
+		if use.FunctionID != "" {
+			ids[use.FunctionID] = true
+		}
+	}
+	return ids
+}
+
+// ProjectAnalysis holds the analysis results for all packages in the project.
+type ProjectAnalysis struct {
+	SchemaVersion string `json:"SchemaVersion"`       // Version of this format; see SchemaVersion
+	ZeroBased     bool   `json:"ZeroBased,omitempty"` // Lines and columns count from 0; see ZeroBasePositions
+	// New top-level fields for module information
+	ModulePath string             `json:"ModulePath"`
+	ModuleDir  string             `json:"ModuleDir"`
+	Roots      []Root             `json:"Roots,omitempty"` // Set when several roots were merged
+	Packages   []*PackageAnalysis `json:"Packages"`
+	Findings   *Findings          `json:"Findings,omitempty"`
+	// Standard library interfaces checked with --std-interfaces, with the project's implementations
+	StdlibInterfaces []Interface `json:"StdlibInterfaces,omitempty"`
+	// Implementations linking the analyzed modules and their dependencies, with --cross-module
+	CrossModuleImplementations []ExternalImplementation `json:"CrossModuleImplementations,omitempty"`
+	// Exported interfaces of packages imported from versioned dependency modules
+	Dependencies []*DependencyPackage `json:"Dependencies,omitempty"`
+	// Root kinds Function.Reachable was computed from, with --reachability
+	ReachabilityRoots []string `json:"ReachabilityRoots,omitempty"`
+	// Modules of the analyzed packages and the modules they depend on
+	ModuleGraph *ModuleGraph `json:"ModuleGraph,omitempty"`
+	// Analyzed packages in the order a program importing them all initializes them
+	InitOrder []string `json:"InitOrder,omitempty"`
+	// Tests, benchmarks, fuzz targets and examples of the analyzed packages, with --tests
+	Tests []TestFunction `json:"Tests,omitempty"`
+	// Could add cross-package analysis results here later
+	// Could add the *ssa.Program here if needed globally
+}
+
+// ModuleGraph describes the analyzed (main) modules and the modules they depend on.
+type ModuleGraph struct {
+	Modules []ModuleNode `json:"Modules"` // Main modules first, then sorted by path
+}
+
+// ModuleNode is a module of the ModuleGraph.
+type ModuleNode struct {
+	Path      string `json:"Path"`
+	Version   string `json:"Version,omitempty"`   // Selected version; empty for main modules
+	Main      bool   `json:"Main,omitempty"`      // An analyzed module
+	GoVersion string `json:"GoVersion,omitempty"` // go directive of the module's go.mod
+	Replace   string `json:"Replace,omitempty"`   // Replacement: "path@version" or a local directory
+	// Indirect is set when every main module requiring it marks it "// indirect"
+	Indirect   bool     `json:"Indirect,omitempty"`
+	RequiredBy []string `json:"RequiredBy,omitempty"` // Main modules whose go.mod requires it
+	Imports    []string `json:"Imports,omitempty"`    // Modules whose packages its packages import
+	// Importers lists the analyzed packages importing packages of the module directly
+	Importers []string `json:"Importers,omitempty"`
+}
+
+// Root is one of several analyzed directories merged into a ProjectAnalysis. File
+// paths of its packages are relative to the merged ModuleDir, which contains all roots.
+type Root struct {
+	ModulePath string `json:"ModulePath"`
+	Dir        string `json:"Dir"` // Relative to ProjectAnalysis.ModuleDir
+}
+
+// DependencyPackage holds the exported interfaces of a package from a dependency module.
+// Its contents are fixed by Module@Version, which makes it cacheable across projects.
+type DependencyPackage struct {
+	Name       string      `json:"Name"`
+	Path       string      `json:"Path"`
+	Module     string      `json:"Module"`
+	Version    string      `json:"Version"`
+	Vendored   bool        `json:"Vendored,omitempty"` // Resolved from a vendor directory
+	Interfaces []Interface `json:"Interfaces"`
+}
+
+// Reasons why an interface method is not matched by a type.
+const (
+	MismatchMissing   = "Missing"   // No field or method with that name
+	MismatchSignature = "Signature" // A method exists with a different signature
+	MismatchNotMethod = "NotMethod" // The name refers to a field, not a method
+)
+
+// MethodMatch is an interface method satisfied by a type.
+type MethodMatch struct {
+	Name            string   `json:"Name"`
+	ID              string   `json:"ID,omitempty"` // Stable ID of the concrete method, matching Function.ID
+	Signature       string   `json:"Signature"`
+	PointerReceiver bool     `json:"PointerReceiver"` // Only *T has the method
+	Promoted        bool     `json:"Promoted"`        // Provided through an embedded field
+	Location        Location `json:"Location"`
+}
+
+// MethodMismatch is an interface method a type fails to provide.
+type MethodMismatch struct {
+	Name     string   `json:"Name"`
+	Want     string   `json:"Want"`           // Signature required by the interface
+	Have     string   `json:"Have,omitempty"` // Type of the conflicting method or field
+	Reason   string   `json:"Reason"`         // One of the Mismatch* constants
+	Location Location `json:"Location"`
+}
+
+// SatisfactionExplanation explains whether and why a type satisfies an interface.
+type SatisfactionExplanation struct {
+	Interface          string           `json:"Interface"` // packagePath + "." + interfaceName
+	Type               string           `json:"Type"`      // packagePath + "." + typeName
+	Satisfied          bool             `json:"Satisfied"`
+	SatisfiedByPointer bool             `json:"SatisfiedByPointer"`
+	Reason             string           `json:"Reason,omitempty"` // First problem, from types.MissingMethod
+	Matched            []MethodMatch    `json:"Matched"`
+	Unmatched          []MethodMismatch `json:"Unmatched"`
+}
+
+// Symbol kinds of References.
+const (
+	SymbolFunc      = "Func"
+	SymbolMethod    = "Method"
+	SymbolField     = "Field"
+	SymbolVar       = "Var"
+	SymbolConst     = "Const"
+	SymbolType      = "Type"
+	SymbolInterface = "Interface"
+)
+
+// References lists the uses of a declared symbol across the analyzed packages.
+type References struct {
+	Symbol     string      `json:"Symbol"` // packagePath + "." + name, or packagePath + ".Type.Member"
+	Kind       string      `json:"Kind"`   // One of the Symbol* constants
+	Definition Location    `json:"Definition"`
+	References []Reference `json:"References"` // By location
+}
+
+// Reference is one use of a symbol.
+type Reference struct {
+	Package  string   `json:"Package"`            // Import path of the package containing the use
+	Function string   `json:"Function,omitempty"` // ID of the enclosing function (Function.ID), unset outside functions
+	Location Location `json:"Location"`
+}
+
+// SymbolMatch is a declared identifier found by a symbol search.
+type SymbolMatch struct {
+	ID       string   `json:"ID"`   // packagePath + "." + Name, matching Function.ID for functions and methods
+	Name     string   `json:"Name"` // "Name", or "Type.Method" for methods
+	Kind     string   `json:"Kind"` // SymbolFunc, SymbolMethod, SymbolType or SymbolInterface
+	Package  string   `json:"Package"`
+	Score    int      `json:"Score"` // Higher is a better match
+	Location Location `json:"Location"`
+}
+
+// CallStepClosure is the CallType of a step from a function to a closure it creates,
+// which may run without a visible call site (e.g. when passed as a callback).
+const CallStepClosure = "Closure"
+
+// CallStep is one call of a CallPath.
+type CallStep struct {
+	CallerID string   `json:"CallerID"`
+	CalleeID string   `json:"CalleeID"` // The concrete method for interface calls resolved through Targets
+	CallType string   `json:"CallType"` // CallSite.CallType, or CallStepClosure
+	Location Location `json:"Location"` // Call site; empty for CallStepClosure
+}
+
+// CallPath is a chain of calls leading from one function to another.
+type CallPath struct {
+	Steps []CallStep `json:"Steps"`
+}
+
+// CallPaths answers a call path query between two functions, given by their IDs.
+type CallPaths struct {
+	From      string     `json:"From"`
+	To        string     `json:"To"`
+	Paths     []CallPath `json:"Paths"`               // Shortest first
+	Truncated bool       `json:"Truncated,omitempty"` // More paths exist than the limit allowed
+}
+
+// Test function kinds, after their name prefix.
+const (
+	TestKindTest      = "Test"
+	TestKindBenchmark = "Benchmark"
+	TestKindFuzz      = "Fuzz"
+	TestKindExample   = "Example"
+)
+
+// TestFunction is a test, benchmark, fuzz target or example declared in a _test.go file.
+type TestFunction struct {
+	FunctionID  string   `json:"FunctionID"`
+	Kind        string   `json:"Kind"` // One of the TestKind* constants
+	PackagePath string   `json:"PackagePath"`
+	Location    Location `json:"Location"`
+	// IDs of the functions outside _test.go files the test reaches in the call graph,
+	// directly or not, sorted
+	Exercises []string `json:"Exercises,omitempty"`
+}
+
+// TestReport summarizes how the tests of an analysis exercise its exported functions.
+type TestReport struct {
+	Tests             int            `json:"Tests"` // Number of Test functions
+	Examples          int            `json:"Examples"`
+	Benchmarks        []TestFunction `json:"Benchmarks"`
+	FuzzTargets       []TestFunction `json:"FuzzTargets"`
+	ExportedFunctions int            `json:"ExportedFunctions"`
+	// Exported functions no test or fuzz target exercises (Findings.UntestedFunctions)
+	Untested []string `json:"Untested"`
+	// Exported functions exercised by fuzz targets, sorted
+	Fuzzed []string `json:"Fuzzed"`
+}
+
+// TestCoverage answers which tests exercise a function.
+type TestCoverage struct {
+	FunctionID string   `json:"FunctionID"`
+	Tests      []string `json:"Tests"` // IDs of the tests reaching the function, sorted
+}
+
+// InterfaceSuggestion is a minimal interface covering the methods of a concrete type
+// that its callers use.
+type InterfaceSuggestion struct {
+	Type        string            `json:"Type"` // packagePath + "." + Name of the concrete type
+	Name        string            `json:"Name"` // Name of the suggested interface
+	Methods     []SuggestedMethod `json:"Methods"`
+	Unused      []string          `json:"Unused,omitempty"` // Methods of the type no caller uses
+	Declaration string            `json:"Declaration"`      // Go declaration, to add to the type's package
+}
+
+// SuggestedMethod is a method of an InterfaceSuggestion.
+type SuggestedMethod struct {
+	Name      string   `json:"Name"`
+	Signature string   `json:"Signature"`
+	Callers   []string `json:"Callers"` // Function IDs, sorted
+	Calls     int      `json:"Calls"`   // Number of call sites
+}
+
+// AnalysisDiff is the structural difference between two analyses. Interfaces are
+// matched by "pkg/path.Name", implementations by type ("*pkg/path.Type" for pointer
+// receivers), and call edges by caller, callee and call type, so code that only moved
+// does not show up as changed.
+type AnalysisDiff struct {
+	AddedInterfaces   []string          `json:"AddedInterfaces,omitempty"`
+	RemovedInterfaces []string          `json:"RemovedInterfaces,omitempty"`
+	ChangedInterfaces []InterfaceChange `json:"ChangedInterfaces,omitempty"`
+	AddedCalls        []CallEdge        `json:"AddedCalls,omitempty"`
+	RemovedCalls      []CallEdge        `json:"RemovedCalls,omitempty"`
+}
+
+// Empty reports whether the analyses compared equal.
+func (d *AnalysisDiff) Empty() bool {
+	return len(d.AddedInterfaces) == 0 && len(d.RemovedInterfaces) == 0 && len(d.ChangedInterfaces) == 0 &&
+		len(d.AddedCalls) == 0 && len(d.RemovedCalls) == 0
+}
+
+// InterfaceChange lists the changes to an interface present in both analyses.
+type InterfaceChange struct {
+	Interface              string         `json:"Interface"`
+	AddedMethods           []string       `json:"AddedMethods,omitempty"`
+	RemovedMethods         []string       `json:"RemovedMethods,omitempty"`
+	ChangedMethods         []MethodChange `json:"ChangedMethods,omitempty"`
+	AddedImplementations   []string       `json:"AddedImplementations,omitempty"`
+	RemovedImplementations []string       `json:"RemovedImplementations,omitempty"`
+}
+
+// MethodChange is a method of an interface's method set whose signature changed.
+type MethodChange struct {
+	Name         string `json:"Name"`
+	OldSignature string `json:"OldSignature"`
+	NewSignature string `json:"NewSignature"`
+}
+
+// CallEdge is a call relationship without its source position. Caller and Callee are
+// function IDs, or call descriptions where a call site has no ID.
+type CallEdge struct {
+	Caller   string `json:"Caller"`
+	Callee   string `json:"Callee"`
+	CallType string `json:"CallType"`
+}
+
+// APIDiff lists the changes to the exported API of the analyzed packages between two
+// analyses, split like golang.org/x/exp/apidiff into changes that can break code using
+// the API and changes that cannot.
+type APIDiff struct {
+	Incompatible []APIChange `json:"Incompatible,omitempty"`
+	Compatible   []APIChange `json:"Compatible,omitempty"`
+}
+
+// Empty reports whether the exported API is unchanged.
+func (d *APIDiff) Empty() bool {
+	return len(d.Incompatible) == 0 && len(d.Compatible) == 0
+}
+
+// APIChange is one change to an exported symbol.
+type APIChange struct {
+	Package string `json:"Package"`
+	Symbol  string `json:"Symbol,omitempty"` // "Name", "Type.Method" or "Type.Field"
+	Change  string `json:"Change"`           // e.g. "removed", "added", "changed from func() to func(int)"
+}
+
+// Helper to create Location from token.Position
+func NewLocation(pos token.Position) Location {
+	return Location{
+		Filename: pos.Filename,
+		Line:     pos.Line,
+		Column:   pos.Column,
+		Offset:   pos.Offset,
+	}
+}
+
+// NewSpan creates a Location starting at pos and ending at end, the position just past
+// the declaration as returned by ast.Node.End.
+func NewSpan(pos, end token.Position) Location {
+	loc := NewLocation(pos)
+	loc.EndLine = end.Line
+	loc.EndColumn = end.Column
+	loc.EndOffset = end.Offset
+	return loc
+}
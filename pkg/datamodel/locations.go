@@ -0,0 +1,125 @@
+// datamodel/locations.go
+package datamodel
+
+import (
+	"path/filepath"
+	"reflect"
+)
+
+var locationType = reflect.TypeOf(Location{})
+
+// RewriteLocations replaces the filename of every Location reachable from v with
+// rewrite(filename). v must be a pointer for the changes to be visible to the caller.
+// Walking by reflection keeps rewrites complete as the datamodel grows; fields
+// tagged json:"-" (such as Interface.UnderlyingType) are skipped.
+func RewriteLocations(v any, rewrite func(string) string) {
+	walkLocations(reflect.ValueOf(v), func(loc *Location) {
+		loc.Filename = rewrite(loc.Filename)
+	})
+}
+
+// RewriteFiles replaces every file path in a with rewrite(path): the filename of each
+// Location and the files listed by packages, their cgo usage and their metrics.
+func RewriteFiles(a *ProjectAnalysis, rewrite func(string) string) {
+	if a == nil {
+		return
+	}
+	RewriteLocations(a, rewrite)
+	for _, pkg := range a.Packages {
+		if pkg == nil {
+			continue
+		}
+		rewriteAll(pkg.Files, rewrite)
+		if pkg.Cgo != nil {
+			rewriteAll(pkg.Cgo.GoFiles, rewrite)
+			rewriteAll(pkg.Cgo.CFiles, rewrite)
+		}
+		if pkg.Metrics != nil {
+			for i := range pkg.Metrics.Files {
+				pkg.Metrics.Files[i].File = rewrite(pkg.Metrics.Files[i].File)
+			}
+		}
+	}
+}
+
+// SlashPaths converts the file and directory paths in a to forward slashes, so results
+// are the same whichever platform produced them. It is a no-op on Unix.
+func SlashPaths(a *ProjectAnalysis) {
+	if a == nil || filepath.Separator == '/' {
+		return
+	}
+	a.ModuleDir = filepath.ToSlash(a.ModuleDir)
+	for i := range a.Roots {
+		a.Roots[i].Dir = filepath.ToSlash(a.Roots[i].Dir)
+	}
+	if a.ModuleGraph != nil {
+		for i := range a.ModuleGraph.Modules {
+			node := &a.ModuleGraph.Modules[i]
+			node.Replace = filepath.ToSlash(node.Replace)
+		}
+	}
+	RewriteFiles(a, filepath.ToSlash)
+}
+
+func rewriteAll(files []string, rewrite func(string) string) {
+	for i, file := range files {
+		files[i] = rewrite(file)
+	}
+}
+
+// ZeroBasePositions converts the 1-based lines and columns of every Location reachable
+// from v to 0-based ones, as used by the Language Server Protocol. Locations without a
+// position are left alone, and byte offsets are 0-based already. v must be a pointer.
+func ZeroBasePositions(v any) {
+	walkLocations(reflect.ValueOf(v), func(loc *Location) {
+		if loc.Line == 0 {
+			return
+		}
+		loc.Line--
+		if loc.Column > 0 {
+			loc.Column--
+		}
+		if loc.EndLine > 0 {
+			loc.EndLine--
+		}
+		if loc.EndColumn > 0 {
+			loc.EndColumn--
+		}
+	})
+}
+
+// walkLocations calls fn with every settable Location reachable from v.
+func walkLocations(v reflect.Value, fn func(*Location)) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			walkLocations(v.Elem(), fn)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			walkLocations(v.Index(i), fn)
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			// Map values are not addressable; copy, rewrite and store back
+			elem := reflect.New(iter.Value().Type()).Elem()
+			elem.Set(iter.Value())
+			walkLocations(elem, fn)
+			v.SetMapIndex(iter.Key(), elem)
+		}
+	case reflect.Struct:
+		if v.Type() == locationType {
+			if v.CanAddr() {
+				fn(v.Addr().Interface().(*Location))
+			}
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if field.IsExported() && field.Tag.Get("json") != "-" {
+				walkLocations(v.Field(i), fn)
+			}
+		}
+	}
+}
@@ -0,0 +1,124 @@
+// datamodel/schema.go
+package datamodel
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// SchemaVersion is the version of the JSON output, recorded in
+// ProjectAnalysis.SchemaVersion. The minor version grows when fields are added, the
+// major version when fields are removed or change meaning.
+const SchemaVersion = "1.4.0"
+
+// SchemaCompatible reports whether results of the given schema version can be read as
+// the current version: they share the major version, or predate versioning.
+func SchemaCompatible(version string) bool {
+	if version == "" {
+		return true
+	}
+	major, _, _ := strings.Cut(version, ".")
+	current, _, _ := strings.Cut(SchemaVersion, ".")
+	return major == current
+}
+
+// JSONSchema returns the JSON Schema (draft 2020-12) of a ProjectAnalysis as written by
+// the JSON renderer. It is derived from the struct definitions, so it always matches
+// the current output: fields without omitempty are required, and slices and pointers
+// that are not omitted may be null.
+func JSONSchema() ([]byte, error) {
+	g := &schemaGenerator{defs: make(map[string]any)}
+	root := g.structSchema(reflect.TypeOf(ProjectAnalysis{}))
+	root["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	root["title"] = "go-mcp ProjectAnalysis " + SchemaVersion
+	root["properties"].(map[string]any)["SchemaVersion"] = map[string]any{"type": "string", "const": SchemaVersion}
+	root["$defs"] = g.defs
+	return json.MarshalIndent(root, "", "  ")
+}
+
+// schemaGenerator collects the named struct types referenced from the root as $defs.
+type schemaGenerator struct {
+	defs map[string]any
+}
+
+// typeSchema returns the schema of values of type t.
+func (g *schemaGenerator) typeSchema(t reflect.Type) map[string]any {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return g.typeSchema(t.Elem())
+	case reflect.Struct:
+		name := t.Name()
+		if _, ok := g.defs[name]; !ok {
+			g.defs[name] = nil // Reserve the name for recursive types
+			g.defs[name] = g.structSchema(t)
+		}
+		return map[string]any{"$ref": "#/$defs/" + name}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": g.typeSchema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": g.typeSchema(t.Elem())}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	}
+	return map[string]any{}
+}
+
+// structSchema returns the object schema of struct type t, following encoding/json:
+// unexported and json:"-" fields are skipped and embedded structs are inlined.
+func (g *schemaGenerator) structSchema(t reflect.Type) map[string]any {
+	properties := make(map[string]any)
+	required := []string{}
+	g.addFields(t, properties, &required)
+	return map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"required":             required,
+		"additionalProperties": false,
+	}
+}
+
+func (g *schemaGenerator) addFields(t reflect.Type, properties map[string]any, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				g.addFields(ft, properties, required)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		schema := g.typeSchema(field.Type)
+		omitEmpty := strings.Contains(","+opts+",", ",omitempty,")
+		if !omitEmpty {
+			*required = append(*required, name)
+			switch field.Type.Kind() {
+			case reflect.Pointer, reflect.Slice, reflect.Map:
+				// nil values are written as null
+				schema = map[string]any{"anyOf": []any{schema, map[string]any{"type": "null"}}}
+			}
+		}
+		properties[name] = schema
+	}
+}
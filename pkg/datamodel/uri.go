@@ -0,0 +1,254 @@
+// datamodel/uri.go
+package datamodel
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// URIScheme starts the canonical URIs of analyzed entities. A URI names a package by
+// its import path, optionally followed by a symbol in it and, for call sites, the
+// module-relative position of the call:
+//
+//	go://example.com/mod/pkg
+//	go://example.com/mod/pkg#Server.Handle
+//	go://example.com/mod/pkg#Server.Handle@server.go:42:9
+//
+// URIs depend only on the code, so they stay the same across runs and can be used to
+// join the results of different analyses.
+const URIScheme = "go://"
+
+// PackageURI returns the URI of the package with this import path.
+func PackageURI(pkgPath string) string {
+	return URIScheme + pkgPath
+}
+
+// SymbolURI returns the URI of a symbol declared in package pkgPath. symbol is a
+// package-level name or a dotted member path such as "Type.Method". Predeclared
+// identifiers, which have no package path, belong to "builtin".
+func SymbolURI(pkgPath, symbol string) string {
+	if pkgPath == "" {
+		pkgPath = "builtin"
+	}
+	return PackageURI(pkgPath) + "#" + symbol
+}
+
+// CallSiteURI returns the URI of a call made by the function with URI callerURI at loc.
+// The filename is written with forward slashes on every platform.
+func CallSiteURI(callerURI string, loc Location) string {
+	site := callerURI + "@" + filepath.ToSlash(loc.Filename) + ":" + strconv.Itoa(loc.Line)
+	if loc.Column > 0 {
+		site += ":" + strconv.Itoa(loc.Column)
+	}
+	return site
+}
+
+// URI is a parsed canonical URI.
+type URI struct {
+	Package string   // Import path
+	Symbol  string   // Symbol within the package, empty for packages
+	Site    Location // Call site position, empty unless the URI names a call site
+}
+
+// ParseURI splits a canonical URI into its parts.
+func ParseURI(uri string) (URI, error) {
+	rest, ok := strings.CutPrefix(uri, URIScheme)
+	if !ok || rest == "" {
+		return URI{}, fmt.Errorf("invalid URI %q: must start with %s and an import path", uri, URIScheme)
+	}
+	var u URI
+	u.Package, rest, _ = strings.Cut(rest, "#")
+	u.Symbol, rest, ok = strings.Cut(rest, "@")
+	if !ok {
+		return u, nil
+	}
+	// The filename may contain colons; line and column are the last elements
+	parts := strings.Split(rest, ":")
+	numbers := 0
+	for i := len(parts) - 1; i > 0 && numbers < 2; i-- {
+		if _, err := strconv.Atoi(parts[i]); err != nil {
+			break
+		}
+		numbers++
+	}
+	if numbers == 0 || u.Symbol == "" {
+		return URI{}, fmt.Errorf("invalid call site in URI %q: expected symbol@file:line[:column]", uri)
+	}
+	file := strings.Join(parts[:len(parts)-numbers], ":")
+	line, _ := strconv.Atoi(parts[len(parts)-numbers])
+	u.Site = Location{Filename: file, Line: line}
+	if numbers == 2 {
+		u.Site.Column, _ = strconv.Atoi(parts[len(parts)-1])
+	}
+	return u, nil
+}
+
+// AssignURIs sets the URI of every package, interface, method, implementation, struct,
+// named type, function, value and call site in the analysis. It must run again after
+// file paths change, since call site URIs include them.
+func AssignURIs(a *ProjectAnalysis) {
+	if a == nil {
+		return
+	}
+	for _, pkg := range a.Packages {
+		if pkg == nil {
+			continue
+		}
+		pkg.URI = PackageURI(pkg.Path)
+		assignInterfaceURIs(pkg.Interfaces)
+		for i := range pkg.Structs {
+			s := &pkg.Structs[i]
+			s.URI = SymbolURI(s.PackagePath, s.Name)
+		}
+		for i := range pkg.Types {
+			t := &pkg.Types[i]
+			t.URI = SymbolURI(t.PackagePath, t.Name)
+		}
+		for i := range pkg.Functions {
+			fn := &pkg.Functions[i]
+			fn.URI = funcURI(pkg.Path, fn.ID, fn.FullName)
+		}
+		for _, values := range [][]Value{pkg.Constants, pkg.Variables} {
+			for i := range values {
+				values[i].URI = SymbolURI(pkg.Path, values[i].Name)
+			}
+		}
+		for i := range pkg.Calls {
+			call := &pkg.Calls[i]
+			call.URI = CallSiteURI(funcURI(pkg.Path, call.CallerID, call.CallerFuncDesc), call.Location)
+		}
+	}
+	assignInterfaceURIs(a.StdlibInterfaces)
+	for _, dep := range a.Dependencies {
+		if dep != nil {
+			assignInterfaceURIs(dep.Interfaces)
+		}
+	}
+}
+
+func assignInterfaceURIs(ifaces []Interface) {
+	for i := range ifaces {
+		iface := &ifaces[i]
+		iface.URI = SymbolURI(iface.PackagePath, iface.Name)
+		for _, methods := range [][]Method{iface.Methods, iface.MethodSet} {
+			for j := range methods {
+				methods[j].URI = iface.URI + "." + methods[j].Name
+			}
+		}
+		for j := range iface.Implementations {
+			impl := &iface.Implementations[j]
+			impl.URI = SymbolURI(impl.PackagePath, impl.TypeName)
+		}
+	}
+}
+
+// funcURI returns the URI of the function with this ID declared in package pkgPath,
+// falling back to its description for functions without a package-qualified ID.
+func funcURI(pkgPath, id, desc string) string {
+	if symbol, ok := strings.CutPrefix(id, pkgPath+"."); ok && symbol != "" {
+		return SymbolURI(pkgPath, symbol)
+	}
+	return SymbolURI(pkgPath, desc)
+}
+
+// Entity is the entity a URI names, as found by FindURI.
+type Entity struct {
+	Kind   string `json:"Kind"` // Package, Interface, Method, Implementation, Struct, Type, Function, Constant, Variable or CallSite
+	URI    string `json:"URI"`
+	Entity any    `json:"Entity"`
+}
+
+// FindURI returns the entity with this URI, as assigned by AssignURIs, or false if the
+// analysis has none. Implementations are returned from the first interface they
+// implement.
+func (a *ProjectAnalysis) FindURI(uri string) (Entity, bool) {
+	if a == nil {
+		return Entity{}, false
+	}
+	found := func(kind string, entity any) (Entity, bool) {
+		return Entity{Kind: kind, URI: uri, Entity: entity}, true
+	}
+	for _, pkg := range a.Packages {
+		if pkg == nil || (pkg.URI != uri && !strings.HasPrefix(uri, pkg.URI+"#")) {
+			continue
+		}
+		if pkg.URI == uri {
+			return found("Package", pkg)
+		}
+		if e, ok := findInterfaceURI(pkg.Interfaces, uri); ok {
+			return e, true
+		}
+		for i := range pkg.Structs {
+			if pkg.Structs[i].URI == uri {
+				return found("Struct", &pkg.Structs[i])
+			}
+		}
+		for i := range pkg.Types {
+			if pkg.Types[i].URI == uri {
+				return found("Type", &pkg.Types[i])
+			}
+		}
+		for i := range pkg.Functions {
+			if pkg.Functions[i].URI == uri {
+				return found("Function", &pkg.Functions[i])
+			}
+		}
+		for i := range pkg.Constants {
+			if pkg.Constants[i].URI == uri {
+				return found("Constant", &pkg.Constants[i])
+			}
+		}
+		for i := range pkg.Variables {
+			if pkg.Variables[i].URI == uri {
+				return found("Variable", &pkg.Variables[i])
+			}
+		}
+		for i := range pkg.Calls {
+			if pkg.Calls[i].URI == uri {
+				return found("CallSite", &pkg.Calls[i])
+			}
+		}
+	}
+	// Implementations may be declared outside the package of the interface
+	for _, pkg := range a.Packages {
+		if pkg == nil {
+			continue
+		}
+		for i := range pkg.Interfaces {
+			for j := range pkg.Interfaces[i].Implementations {
+				if impl := &pkg.Interfaces[i].Implementations[j]; impl.URI == uri {
+					return found("Implementation", impl)
+				}
+			}
+		}
+	}
+	if e, ok := findInterfaceURI(a.StdlibInterfaces, uri); ok {
+		return e, true
+	}
+	for _, dep := range a.Dependencies {
+		if dep == nil {
+			continue
+		}
+		if e, ok := findInterfaceURI(dep.Interfaces, uri); ok {
+			return e, true
+		}
+	}
+	return Entity{}, false
+}
+
+func findInterfaceURI(ifaces []Interface, uri string) (Entity, bool) {
+	for i := range ifaces {
+		iface := &ifaces[i]
+		if iface.URI == uri {
+			return Entity{Kind: "Interface", URI: uri, Entity: iface}, true
+		}
+		for j := range iface.Methods {
+			if iface.Methods[j].URI == uri {
+				return Entity{Kind: "Method", URI: uri, Entity: &iface.Methods[j]}, true
+			}
+		}
+	}
+	return Entity{}, false
+}